@@ -253,4 +253,43 @@ Görev: Makine Öğrenmesi Eğitimi    Öncelik: 2
 * **ring** → görevlerin çekirdekler arasında round-robin ile dağıtılmasını sağladı.
 
 ---
-*/
\ No newline at end of file
+*/
+/*
+---
+
+## TaskHeap'ten Gerçek Bir İş Zamanlayıcısına
+
+Yukarıdaki iki örnekte heap **bir kere doldurulup** döngüde boşaltılıyor. Çalışan bir serviste ise:
+
+* İşler **sürekli** gelir, birden çok worker kuyruğu **aynı anda** boşaltır
+* Kuyrukta bekleyen bir iş **iptal** edilebilir, yani heap'in **ortasından** silinmesi gerekir
+* Aynı öncelikteki işler arasında **deadline'ı yakın olan** önce çalışmalıdır
+
+Bunun için `TaskHeap`'e iki şey eklenir:
+
+```go
+// 1) Her eleman heap içindeki yerini bilir (Swap ve Push günceller)
+func (q jobQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+// ... böylece iptal edilen iş O(log n)'de çıkarılır
+heap.Remove(&s.queue, e.index)
+
+// 2) Eşit öncelikte: önce yakın deadline (EDF), sonra gelme sırası (FIFO)
+func (q jobQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.job.Priority != b.job.Priority {
+		return a.job.Priority < b.job.Priority
+	}
+	...
+	return a.seq < b.seq
+}
+```
+
+Bu kuyruğun worker havuzu, iş başına context (timeout, deadline, grup iptali), backoff ile tekrar deneme ve durum API'si ile birleşmiş tam hâli `context/context_uygulama.go` dosyasındaki **"Context ile Çalışan Öncelikli İş Zamanlayıcısı"** örneğindedir.
+
+---
+*/
@@ -317,4 +317,919 @@ Düğüm 1'den en kısa yollar:
 * Bu sayede Dijkstra algoritması klasik **O(E log V)** karmaşıklığıyla çalıştı.
 
 ---
-*/
\ No newline at end of file
+*/
+
+/*
+---
+
+# 🚀 Generic Graf Paketi: Dijkstra'dan A*, Bellman-Ford, Topolojik Sıralama, SCC ve MST'ye
+
+Yukarıdaki `Dijkstra(graph Graph, start int) map[int]int`:
+
+* Sadece `map[int][]Edge` ile ve sadece `int` düğümlerle çalışıyor
+* Sadece **mesafeleri** döndürüyor; **yolun kendisi** kayboluyor
+* Negatif ağırlıkta sessizce yanlış sonuç veriyor
+
+Rota hesaplayan ve bağımlılık çözen araçlarda hep aynı algoritmalar yeniden yazılmasın diye bunları tek bir **generic** pakette topluyoruz:
+
+| Fonksiyon     | Ne yapar                                      | Karmaşıklık    | Graftan istediği          |
+| ------------- | --------------------------------------------- | -------------- | ------------------------- |
+| `Dijkstra`    | Tek kaynaklı en kısa yollar + yol geri kurma  | O((V+E) log V) | Sadece komşular           |
+| `AStar`       | İki nokta arası en kısa yol, sezgisel ile     | ≤ Dijkstra     | Sadece komşular           |
+| `BellmanFord` | Negatif ağırlıklar + negatif döngü tespiti    | O(V·E)         | Tüm düğümler              |
+| `TopoSort`    | Bağımlılık sırası + döngüyü gösterme          | O(V+E)         | Tüm düğümler              |
+| `SCC`         | Güçlü bağlı bileşenler (Tarjan)               | O(V+E)         | Tüm düğümler              |
+| `Prim`        | Minimum yayılan ağaç (`container/heap` ile)   | O(E log V)     | Tüm düğümler (yönsüz)     |
+| `Kruskal`     | Minimum yayılan ağaç (union-find ile)         | O(E log E)     | Tüm düğümler (yönsüz)     |
+
+Anahtar fikir: **komşuluk `iter.Seq2[N, W]` ile verilir.** Düğüm tipi `N` herhangi bir `comparable` tip olabilir (`int`, `string`, `struct{x, y int}`).
+Komşular bir listeden okunabilir ya da (ızgara, oyun durumu gibi) **anında üretilebilir**.
+
+---
+
+## 📂 Proje Yapısı
+
+graphdemo/
+│── go.mod           (module graphdemo)
+│── main.go          → 5 örnek senaryo
+└── graph/
+    ├── graph.go     → Weight, NeighborFunc, Graph, Edge, AdjList
+    ├── heap.go      → container/heap ile generic min-heap
+    ├── paths.go     → Dijkstra, AStar, BellmanFord, Paths
+    ├── order.go     → TopoSort, SCC
+    └── mst.go       → UnionFind, Kruskal, Prim
+
+---
+
+## 📄 `graph/graph.go`
+*/
+// Package graph, düğüm tipinden bağımsız (generic) graf algoritmaları sağlar.
+// Komşuluk iter.Seq2 ile verilir; böylece aynı algoritmalar bellekteki bir
+// listeyle de, ızgara gibi anında üretilen (implicit) graflarla da çalışır.
+package graph
+
+import "iter"
+
+// Weight, kenar ağırlığı olabilecek sayı tipleridir.
+type Weight interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// NeighborFunc, bir düğümün komşularını ve kenar ağırlıklarını verir.
+// Dijkstra ve AStar sadece buna ihtiyaç duyar.
+type NeighborFunc[N comparable, W Weight] func(N) iter.Seq2[N, W]
+
+// Graph, tüm düğümleri gezilebilen bir graftır. Bellman-Ford, topolojik
+// sıralama, SCC ve MST algoritmaları bunu ister.
+type Graph[N comparable, W Weight] interface {
+	Nodes() iter.Seq[N]
+	Neighbors(N) iter.Seq2[N, W]
+}
+
+// Edge, yönlü bir kenardır.
+type Edge[N comparable, W Weight] struct {
+	From, To N
+	Weight   W
+}
+
+// Edges, grafın tüm kenarlarını düğüm sırasıyla gezer.
+func Edges[N comparable, W Weight](g Graph[N, W]) iter.Seq[Edge[N, W]] {
+	return func(yield func(Edge[N, W]) bool) {
+		for u := range g.Nodes() {
+			for v, w := range g.Neighbors(u) {
+				if !yield(Edge[N, W]{u, v, w}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// AdjList, eklenme sırasını koruyan bir komşuluk listesidir. Sıra korunduğu
+// için algoritmaların çıktısı her çalıştırmada aynıdır (map'ten farklı olarak).
+type AdjList[N comparable, W Weight] struct {
+	index map[N]int
+	nodes []N
+	adj   [][]Edge[N, W]
+}
+
+// NewAdjList, boş bir graf oluşturur.
+func NewAdjList[N comparable, W Weight]() *AdjList[N, W] {
+	return &AdjList[N, W]{index: make(map[N]int)}
+}
+
+// AddNode, düğümü ekler (yoksa) ve iç indeksini döndürür.
+func (g *AdjList[N, W]) AddNode(n N) int {
+	if i, ok := g.index[n]; ok {
+		return i
+	}
+	g.index[n] = len(g.nodes)
+	g.nodes = append(g.nodes, n)
+	g.adj = append(g.adj, nil)
+	return len(g.nodes) - 1
+}
+
+// AddEdge, from → to yönlü kenarını ekler.
+func (g *AdjList[N, W]) AddEdge(from, to N, w W) {
+	i := g.AddNode(from)
+	g.AddNode(to)
+	g.adj[i] = append(g.adj[i], Edge[N, W]{from, to, w})
+}
+
+// AddBoth, yönsüz kenar ekler (iki yönlü iki kenar).
+func (g *AdjList[N, W]) AddBoth(a, b N, w W) {
+	g.AddEdge(a, b, w)
+	g.AddEdge(b, a, w)
+}
+
+// Len, düğüm sayısıdır.
+func (g *AdjList[N, W]) Len() int { return len(g.nodes) }
+
+// Nodes, düğümleri eklenme sırasıyla gezer.
+func (g *AdjList[N, W]) Nodes() iter.Seq[N] {
+	return func(yield func(N) bool) {
+		for _, n := range g.nodes {
+			if !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors, n'nin komşularını gezer; n grafta yoksa hiçbir şey vermez.
+func (g *AdjList[N, W]) Neighbors(n N) iter.Seq2[N, W] {
+	return func(yield func(N, W) bool) {
+		i, ok := g.index[n]
+		if !ok {
+			return
+		}
+		for _, e := range g.adj[i] {
+			if !yield(e.To, e.Weight) {
+				return
+			}
+		}
+	}
+}
+
+// Reverse, tüm kenarları ters çevrilmiş yeni bir graf döndürür.
+func (g *AdjList[N, W]) Reverse() *AdjList[N, W] {
+	r := NewAdjList[N, W]()
+	for _, n := range g.nodes {
+		r.AddNode(n)
+	}
+	for e := range Edges[N, W](g) {
+		r.AddEdge(e.To, e.From, e.Weight)
+	}
+	return r
+}
+
+var _ Graph[string, int] = (*AdjList[string, int])(nil)
+
+/*
+---
+
+## 📄 `graph/heap.go`
+
+Yukarıdaki `PriorityQueue`'nun generic hâli. Dijkstra, A* ve Prim aynı kuyruğu kullanır.
+*/
+package graph
+
+import "container/heap"
+
+// item, öncelik kuyruğundaki bir elemandır: düğüm ve öncelik (mesafe, f skoru
+// ya da kenar ağırlığı). A* için gerçek maliyet (cost), Prim için kenarın
+// nereden geldiği (from) de tutulur.
+type item[N comparable, W Weight] struct {
+	node     N
+	from     N
+	cost     W
+	priority W
+}
+
+// minHeap, container/heap ile min-öncelik kuyruğudur. Dijkstra örneğindeki
+// PriorityQueue'nun generic hâlidir.
+type minHeap[N comparable, W Weight] []item[N, W]
+
+func (h minHeap[N, W]) Len() int           { return len(h) }
+func (h minHeap[N, W]) Less(i, j int) bool { return h[i].priority < h[j].priority }
+func (h minHeap[N, W]) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *minHeap[N, W]) Push(x any)        { *h = append(*h, x.(item[N, W])) }
+func (h *minHeap[N, W]) Pop() any {
+	old := *h
+	n := len(old)
+	it := old[n-1]
+	*h = old[:n-1]
+	return it
+}
+
+func (h *minHeap[N, W]) push(it item[N, W]) { heap.Push(h, it) }
+func (h *minHeap[N, W]) pop() item[N, W]    { return heap.Pop(h).(item[N, W]) }
+
+/*
+---
+
+## 📄 `graph/paths.go`
+*/
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+var (
+	ErrNegativeWeight = errors.New("graph: negatif ağırlıklı kenar (Bellman-Ford kullanın)")
+	ErrNoPath         = errors.New("graph: yol yok")
+)
+
+// NegativeCycleError, Bellman-Ford'un bulduğu negatif döngüdür. Böyle bir
+// döngüden geçen yolların en kısası tanımsızdır (her turda biraz daha kısalır).
+type NegativeCycleError[N comparable] struct {
+	Cycle []N // döngüdeki düğümler, kenar yönünde
+}
+
+func (e *NegativeCycleError[N]) Error() string {
+	return fmt.Sprintf("graph: negatif döngü: %v", e.Cycle)
+}
+
+// Paths, tek kaynaklı en kısa yol sonucudur.
+type Paths[N comparable, W Weight] struct {
+	Source N
+	dist   map[N]W
+	prev   map[N]N
+	order  []N // ulaşılan düğümler: Dijkstra'da kesinleşme, Bellman-Ford'da graf sırası
+}
+
+func newPaths[N comparable, W Weight](source N) *Paths[N, W] {
+	var zero W
+	return &Paths[N, W]{
+		Source: source,
+		dist:   map[N]W{source: zero},
+		prev:   map[N]N{},
+	}
+}
+
+// Dist, kaynaktan n'ye en kısa mesafedir; n'ye ulaşılamıyorsa ok false olur.
+func (p *Paths[N, W]) Dist(n N) (d W, ok bool) {
+	d, ok = p.dist[n]
+	return d, ok
+}
+
+// PathTo, kaynaktan n'ye giden yolu (iki uç dahil) döndürür. Ulaşılamıyorsa nil.
+func (p *Paths[N, W]) PathTo(n N) []N {
+	if _, ok := p.dist[n]; !ok {
+		return nil
+	}
+	path := []N{n}
+	for n != p.Source {
+		n = p.prev[n]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+// All, ulaşılan her düğümü mesafesiyle gezer.
+func (p *Paths[N, W]) All() iter.Seq2[N, W] {
+	return func(yield func(N, W) bool) {
+		for _, n := range p.order {
+			if !yield(n, p.dist[n]) {
+				return
+			}
+		}
+	}
+}
+
+// Dijkstra, negatif olmayan ağırlıklı bir grafta source'tan ulaşılabilen tüm
+// düğümlere en kısa yolları bulur. O((V+E) log V).
+func Dijkstra[N comparable, W Weight](neighbors NeighborFunc[N, W], source N) (*Paths[N, W], error) {
+	p := newPaths[N, W](source)
+	done := map[N]bool{}
+	pq := &minHeap[N, W]{}
+	pq.push(item[N, W]{node: source})
+
+	for pq.Len() > 0 {
+		cur := pq.pop()
+		// Aynı düğüm kuyrukta birden çok kez olabilir (lazy deletion);
+		// ilk çıkan en kısasıdır, sonrakiler atlanır.
+		if done[cur.node] {
+			continue
+		}
+		done[cur.node] = true
+		p.order = append(p.order, cur.node)
+
+		for v, w := range neighbors(cur.node) {
+			if w < 0 {
+				return nil, fmt.Errorf("%w: %v → %v", ErrNegativeWeight, cur.node, v)
+			}
+			nd := cur.priority + w
+			if d, seen := p.dist[v]; !seen || nd < d {
+				p.dist[v] = nd
+				p.prev[v] = cur.node
+				pq.push(item[N, W]{node: v, priority: nd})
+			}
+		}
+	}
+	return p, nil
+}
+
+// AStar, start'tan goal'e en kısa yolu bulur. h(n), n'den goal'e kalan
+// mesafenin bir tahminidir; gerçek mesafeyi hiçbir zaman aşmıyorsa
+// (admissible) bulunan yol en kısadır. h = 0 ile A*, Dijkstra'ya dönüşür.
+func AStar[N comparable, W Weight](neighbors NeighborFunc[N, W], start, goal N, h func(N) W) ([]N, W, error) {
+	g := map[N]W{start: 0}
+	prev := map[N]N{}
+	pq := &minHeap[N, W]{}
+	pq.push(item[N, W]{node: start, priority: h(start)})
+
+	for pq.Len() > 0 {
+		cur := pq.pop()
+		if cur.node == goal {
+			path := []N{goal}
+			for n := goal; n != start; {
+				n = prev[n]
+				path = append(path, n)
+			}
+			slices.Reverse(path)
+			return path, cur.cost, nil
+		}
+		// Bu düğüme sonradan daha ucuz bir yol bulunduysa bu kayıt eskidir
+		if cur.cost > g[cur.node] {
+			continue
+		}
+		for v, w := range neighbors(cur.node) {
+			if w < 0 {
+				return nil, 0, fmt.Errorf("%w: %v → %v", ErrNegativeWeight, cur.node, v)
+			}
+			ng := cur.cost + w
+			if old, seen := g[v]; !seen || ng < old {
+				g[v] = ng
+				prev[v] = cur.node
+				pq.push(item[N, W]{node: v, cost: ng, priority: ng + h(v)})
+			}
+		}
+	}
+	return nil, 0, ErrNoPath
+}
+
+// BellmanFord, negatif ağırlıklı kenarlara izin veren en kısa yol
+// algoritmasıdır. source'tan ulaşılabilen bir negatif döngü varsa
+// *NegativeCycleError döner. O(V·E).
+func BellmanFord[N comparable, W Weight](g Graph[N, W], source N) (*Paths[N, W], error) {
+	p := newPaths[N, W](source)
+	edges := slices.Collect(Edges(g))
+	nodes := slices.Collect(g.Nodes())
+
+	// relax, tüm kenarları bir kez gevşetir; son değişen düğümü döndürür.
+	relax := func() (changed N, ok bool) {
+		for _, e := range edges {
+			du, reached := p.dist[e.From]
+			if !reached {
+				continue
+			}
+			if dv, seen := p.dist[e.To]; !seen || du+e.Weight < dv {
+				p.dist[e.To] = du + e.Weight
+				p.prev[e.To] = e.From
+				changed, ok = e.To, true
+			}
+		}
+		return changed, ok
+	}
+
+	// En kısa yol en fazla V-1 kenar içerir
+	for range len(nodes) - 1 {
+		if _, changed := relax(); !changed {
+			break
+		}
+	}
+	// V. turda hâlâ kısalan varsa negatif döngü vardır
+	if v, changed := relax(); changed {
+		// v döngünün kendisinde olmayabilir ama döngüden ulaşılır; V adım
+		// geri gitmek kesinlikle döngünün içine düşürür.
+		for range len(nodes) {
+			v = p.prev[v]
+		}
+		cycle := []N{v}
+		for u := p.prev[v]; u != v; u = p.prev[u] {
+			cycle = append(cycle, u)
+		}
+		slices.Reverse(cycle)
+		return nil, &NegativeCycleError[N]{Cycle: cycle}
+	}
+
+	for _, n := range nodes {
+		if _, ok := p.dist[n]; ok {
+			p.order = append(p.order, n)
+		}
+	}
+	return p, nil
+}
+
+/*
+---
+
+### 🔎 Notlar
+
+* **Yol geri kurma** → Her düğüm için "buraya kimden geldim" (`prev`) tutulur. `PathTo`, hedeften kaynağa doğru `prev` zincirini izler ve ters çevirir.
+* **Lazy deletion** → `container/heap`'te bir elemanın önceliğini düşürmek yerine düğüm yeni mesafesiyle **tekrar** eklenir. Eski kayıt çıktığında atlanır (Dijkstra'da `done`, A*'da `cur.cost > g[...]`).
+* **A\* sezgiseli** → `h(n)` kalan mesafeyi hiç **fazla tahmin etmemeli** (admissible). Izgarada 4 yönlü hareket için Manhattan mesafesi böyledir. `h = 0` verilirse A* tam olarak Dijkstra gibi davranır.
+* **Bellman-Ford'da döngü bulma** → V. turda hâlâ kısalan bir düğüm varsa negatif döngü vardır. O düğümden `prev` ile V adım geri gidince kesinlikle döngünün içine düşülür; oradan döngü okunur.
+
+---
+
+## 📄 `graph/order.go`
+*/
+package graph
+
+import (
+	"fmt"
+	"slices"
+)
+
+// CycleError, topolojik sıralamayı imkânsız kılan döngüdür.
+type CycleError[N comparable] struct {
+	Cycle []N // ilk düğüm sonda tekrar eder: a → b → c → a
+}
+
+func (e *CycleError[N]) Error() string {
+	return fmt.Sprintf("graph: döngü var: %v", e.Cycle)
+}
+
+// TopoSort, her u → v kenarı için u'yu v'den önce koyan bir sıra döndürür
+// (ör: "a, b'ye bağımlı" değil; "a, b'den önce derlenmeli"). Graf döngü
+// içeriyorsa *CycleError döner. Derinlik öncelikli arama, O(V+E).
+func TopoSort[N comparable, W Weight](g Graph[N, W]) ([]N, error) {
+	const (
+		white = iota // hiç ziyaret edilmedi
+		gray         // şu an yığında
+		black        // tüm torunlarıyla bitti
+	)
+	color := map[N]int{}
+	var stack []N // gri düğümler: döngü bulunursa buradan okunur
+	var order []N // postorder
+
+	var visit func(u N) error
+	visit = func(u N) error {
+		color[u] = gray
+		stack = append(stack, u)
+		for v := range g.Neighbors(u) {
+			switch color[v] {
+			case gray:
+				i := slices.Index(stack, v)
+				cycle := append(slices.Clone(stack[i:]), v)
+				return &CycleError[N]{Cycle: cycle}
+			case white:
+				if err := visit(v); err != nil {
+					return err
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		color[u] = black
+		order = append(order, u)
+		return nil
+	}
+
+	for n := range g.Nodes() {
+		if color[n] == white {
+			if err := visit(n); err != nil {
+				return nil, err
+			}
+		}
+	}
+	slices.Reverse(order) // ters postorder = topolojik sıra
+	return order, nil
+}
+
+// SCC, Tarjan algoritmasıyla güçlü bağlı bileşenleri bulur: her bileşen
+// içinde her düğümden her düğüme yol vardır. Bileşenler ters topolojik sırayla
+// döner (bir bileşenden çıkan kenarlar hep önceki bileşenlere gider). O(V+E).
+func SCC[N comparable, W Weight](g Graph[N, W]) [][]N {
+	index := map[N]int{} // keşif sırası
+	low := map[N]int{}   // ulaşılabilen en küçük keşif sırası
+	onStack := map[N]bool{}
+	var stack []N
+	var comps [][]N
+
+	var strong func(u N)
+	strong = func(u N) {
+		index[u] = len(index)
+		low[u] = index[u]
+		stack = append(stack, u)
+		onStack[u] = true
+
+		for v := range g.Neighbors(u) {
+			if _, seen := index[v]; !seen {
+				strong(v)
+				low[u] = min(low[u], low[v])
+			} else if onStack[v] {
+				low[u] = min(low[u], index[v])
+			}
+		}
+
+		// u bir bileşenin kökü: yığında u'ya kadar olanlar bir bileşendir
+		if low[u] == index[u] {
+			var comp []N
+			for {
+				v := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[v] = false
+				comp = append(comp, v)
+				if v == u {
+					break
+				}
+			}
+			slices.Reverse(comp)
+			comps = append(comps, comp)
+		}
+	}
+
+	for n := range g.Nodes() {
+		if _, seen := index[n]; !seen {
+			strong(n)
+		}
+	}
+	return comps
+}
+
+/*
+---
+
+## 📄 `graph/mst.go`
+*/
+package graph
+
+import (
+	"cmp"
+	"slices"
+)
+
+// UnionFind, ayrık kümeler (disjoint set) yapısıdır: Kruskal'da iki düğümün
+// aynı ağaçta olup olmadığını neredeyse O(1)'de söyler.
+type UnionFind[N comparable] struct {
+	parent map[N]N
+	size   map[N]int
+}
+
+// NewUnionFind, boş bir yapı oluşturur; düğümler ilk görüldüklerinde eklenir.
+func NewUnionFind[N comparable]() *UnionFind[N] {
+	return &UnionFind[N]{parent: map[N]N{}, size: map[N]int{}}
+}
+
+// Find, n'nin kümesinin temsilcisini döndürür (path halving ile).
+func (u *UnionFind[N]) Find(n N) N {
+	if _, ok := u.parent[n]; !ok {
+		u.parent[n], u.size[n] = n, 1
+		return n
+	}
+	for u.parent[n] != n {
+		u.parent[n] = u.parent[u.parent[n]] // yolu yarıya indir
+		n = u.parent[n]
+	}
+	return n
+}
+
+// Union, a ve b'nin kümelerini birleştirir; zaten aynı kümedeyse false döner.
+func (u *UnionFind[N]) Union(a, b N) bool {
+	ra, rb := u.Find(a), u.Find(b)
+	if ra == rb {
+		return false
+	}
+	if u.size[ra] < u.size[rb] { // küçük ağaç büyüğün altına
+		ra, rb = rb, ra
+	}
+	u.parent[rb] = ra
+	u.size[ra] += u.size[rb]
+	return true
+}
+
+// Connected, a ve b aynı kümedeyse true döner.
+func (u *UnionFind[N]) Connected(a, b N) bool { return u.Find(a) == u.Find(b) }
+
+// Kruskal, yönsüz bir grafın minimum yayılan ağacını (bağlı değilse ormanını)
+// ve toplam ağırlığını döndürür. Kenarlar ağırlığa göre sıralanır, döngü
+// oluşturmayanlar alınır. O(E log E).
+func Kruskal[N comparable, W Weight](g Graph[N, W]) ([]Edge[N, W], W) {
+	edges := slices.Collect(Edges(g))
+	slices.SortStableFunc(edges, func(a, b Edge[N, W]) int { return cmp.Compare(a.Weight, b.Weight) })
+
+	uf := NewUnionFind[N]()
+	var tree []Edge[N, W]
+	var total W
+	for _, e := range edges {
+		if uf.Union(e.From, e.To) {
+			tree = append(tree, e)
+			total += e.Weight
+		}
+	}
+	return tree, total
+}
+
+// Prim, Kruskal ile aynı sonucu ağacı tek bir düğümden büyüterek bulur: her
+// adımda ağaca en ucuz kenarla bağlanan düğüm container/heap'ten alınır.
+// Yoğun graflarda Kruskal'dan hızlıdır. O(E log V).
+func Prim[N comparable, W Weight](g Graph[N, W]) ([]Edge[N, W], W) {
+	inTree := map[N]bool{}
+	var tree []Edge[N, W]
+	var total W
+
+	// Graf bağlı değilse her bileşen için ayrı bir ağaç büyütülür
+	for root := range g.Nodes() {
+		if inTree[root] {
+			continue
+		}
+		pq := &minHeap[N, W]{}
+		pq.push(item[N, W]{node: root, from: root})
+		for pq.Len() > 0 {
+			cur := pq.pop()
+			if inTree[cur.node] {
+				continue
+			}
+			inTree[cur.node] = true
+			if cur.node != root {
+				tree = append(tree, Edge[N, W]{cur.from, cur.node, cur.priority})
+				total += cur.priority
+			}
+			for v, w := range g.Neighbors(cur.node) {
+				if !inTree[v] {
+					pq.push(item[N, W]{node: v, from: cur.node, priority: w})
+				}
+			}
+		}
+	}
+	return tree, total
+}
+
+/*
+---
+
+## 📄 `main.go`
+
+İlk senaryo yukarıdaki **aynı grafı** kullanıyor. Eski `map[int][]Edge` tipi bir metotla `iter.Seq2`'ye çevriliyor; veri kopyalanmıyor.
+*/
+package main
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"log"
+
+	"graphdemo/graph"
+)
+
+// Önceki örnekteki graf tipi: map[int][]Edge
+type Edge struct {
+	to, weight int
+}
+type Graph map[int][]Edge
+
+// neighbors, eski map tabanlı grafı paketin beklediği iter.Seq2 biçimine
+// çevirir; veri kopyalanmaz.
+func (g Graph) neighbors(n int) iter.Seq2[int, int] {
+	return func(yield func(int, int) bool) {
+		for _, e := range g[n] {
+			if !yield(e.to, e.weight) {
+				return
+			}
+		}
+	}
+}
+
+func main() {
+	dijkstraDemo()
+	astarDemo()
+	bellmanFordDemo()
+	buildOrderDemo()
+	mstDemo()
+}
+
+// 1) Önceki Dijkstra örneği, artık yollarıyla birlikte
+func dijkstraDemo() {
+	g := Graph{
+		1: {{2, 2}, {3, 4}},
+		2: {{3, 1}, {4, 7}},
+		3: {{5, 3}},
+		4: {{6, 1}},
+		5: {{4, 2}, {6, 5}},
+		6: {},
+	}
+	paths, err := graph.Dijkstra(g.neighbors, 1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("=== Dijkstra (düğüm 1'den) ===")
+	for n, d := range paths.All() {
+		fmt.Printf("→ %d : %-2d yol: %v\n", n, d, paths.PathTo(n))
+	}
+}
+
+// 2) A*: düğümler anında üretilen bir ızgara (implicit graf). '#' duvar.
+type point struct{ x, y int }
+
+func astarDemo() {
+	grid := []string{
+		"S....#....",
+		".###.#.##.",
+		"...#...#..",
+		".#.#####.#",
+		".#.......G",
+	}
+	var start, goal point
+	for y, row := range grid {
+		for x, c := range row {
+			switch c {
+			case 'S':
+				start = point{x, y}
+			case 'G':
+				goal = point{x, y}
+			}
+		}
+	}
+
+	// Komşular bellekte tutulmaz, her çağrıda üretilir
+	expanded := 0
+	neighbors := func(p point) iter.Seq2[point, int] {
+		expanded++
+		return func(yield func(point, int) bool) {
+			for _, d := range []point{{1, 0}, {-1, 0}, {0, 1}, {0, -1}} {
+				q := point{p.x + d.x, p.y + d.y}
+				if q.y < 0 || q.y >= len(grid) || q.x < 0 || q.x >= len(grid[q.y]) || grid[q.y][q.x] == '#' {
+					continue
+				}
+				if !yield(q, 1) {
+					return
+				}
+			}
+		}
+	}
+	// Manhattan mesafesi: 4 yönlü harekette gerçek mesafeyi hiç aşmaz
+	manhattan := func(p point) int { return abs(p.x-goal.x) + abs(p.y-goal.y) }
+
+	path, cost, err := graph.AStar(neighbors, start, goal, manhattan)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("\n=== A* (ızgara) === maliyet %d, açılan düğüm %d\n", cost, expanded)
+
+	expanded = 0
+	graph.AStar(neighbors, start, goal, func(point) int { return 0 })
+	fmt.Printf("(sezgisel olmadan, yani Dijkstra: açılan düğüm %d)\n", expanded)
+
+	canvas := make([][]byte, len(grid))
+	for y := range grid {
+		canvas[y] = []byte(grid[y])
+	}
+	for _, p := range path[1 : len(path)-1] {
+		canvas[p.y][p.x] = '*'
+	}
+	for _, row := range canvas {
+		fmt.Println(string(row))
+	}
+}
+
+// 3) Bellman-Ford: negatif ağırlıklar (ör: iade/indirim) ve negatif döngü
+func bellmanFordDemo() {
+	g := graph.NewAdjList[string, int]()
+	g.AddEdge("depo", "A", 4)
+	g.AddEdge("depo", "B", 5)
+	g.AddEdge("A", "C", 3)
+	g.AddEdge("B", "A", -3) // B üzerinden gitmek indirimli
+	g.AddEdge("C", "müşteri", 2)
+
+	fmt.Println("\n=== Bellman-Ford ===")
+	paths, err := graph.BellmanFord(g, "depo")
+	if err != nil {
+		log.Fatal(err)
+	}
+	d, _ := paths.Dist("müşteri")
+	fmt.Println("depo → müşteri:", d, paths.PathTo("müşteri"))
+
+	// Dijkstra negatif kenarı reddeder
+	if _, err := graph.Dijkstra(g.Neighbors, "depo"); err != nil {
+		fmt.Println("Dijkstra:", err)
+	}
+
+	g.AddEdge("C", "B", 1)  // B → A → C → B toplamı: -3 + 3 + 1 = 1 (döngü ama pozitif)
+	g.AddEdge("A", "B", -1) // A → B → A: -1 + -3 = -4 → negatif döngü
+	_, err = graph.BellmanFord(g, "depo")
+	var nc *graph.NegativeCycleError[string]
+	if errors.As(err, &nc) {
+		fmt.Println("negatif döngü:", nc.Cycle)
+	}
+}
+
+// 4) Bağımlılık aracı: derleme sırası ve döngülü modüller
+func buildOrderDemo() {
+	// u → v: "u, v'den önce derlenmeli"
+	deps := graph.NewAdjList[string, int]()
+	deps.AddEdge("log", "db", 0)
+	deps.AddEdge("config", "db", 0)
+	deps.AddEdge("config", "http", 0)
+	deps.AddEdge("db", "api", 0)
+	deps.AddEdge("http", "api", 0)
+	deps.AddEdge("api", "cmd", 0)
+
+	fmt.Println("\n=== Topolojik sıralama ===")
+	order, err := graph.TopoSort(deps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("derleme sırası:", order)
+
+	// Birisi api'yi config'e bağımlı yaptı: döngü
+	deps.AddEdge("api", "config", 0)
+	if _, err := graph.TopoSort(deps); err != nil {
+		fmt.Println(err)
+	}
+	// Hangi modüller birbirine kilitli?
+	for _, comp := range graph.SCC(deps) {
+		if len(comp) > 1 {
+			fmt.Println("döngüsel bileşen:", comp)
+		}
+	}
+}
+
+// 5) Minimum yayılan ağaç: şehirleri en az kabloyla bağla
+func mstDemo() {
+	g := graph.NewAdjList[string, float64]()
+	g.AddBoth("İstanbul", "Ankara", 450)
+	g.AddBoth("İstanbul", "İzmir", 480)
+	g.AddBoth("Ankara", "İzmir", 590)
+	g.AddBoth("Ankara", "Konya", 260)
+	g.AddBoth("İzmir", "Konya", 550)
+	g.AddBoth("Konya", "Antalya", 300)
+	g.AddBoth("İzmir", "Antalya", 420)
+
+	fmt.Println("\n=== Minimum yayılan ağaç ===")
+	kTree, kTotal := graph.Kruskal(g)
+	pTree, pTotal := graph.Prim(g)
+	for _, e := range kTree {
+		fmt.Printf("%s — %s (%.0f km)\n", e.From, e.To, e.Weight)
+	}
+	fmt.Printf("Kruskal: %.0f km, Prim: %.0f km (%d kenar)\n", kTotal, pTotal, len(pTree))
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+/*
+---
+
+## 📌 Çıktı
+
+```
+=== Dijkstra (düğüm 1'den) ===
+→ 1 : 0  yol: [1]
+→ 2 : 2  yol: [1 2]
+→ 3 : 3  yol: [1 2 3]
+→ 5 : 6  yol: [1 2 3 5]
+→ 4 : 8  yol: [1 2 3 5 4]
+→ 6 : 9  yol: [1 2 3 5 4 6]
+
+=== A* (ızgara) === maliyet 13, açılan düğüm 23
+(sezgisel olmadan, yani Dijkstra: açılan düğüm 29)
+S....#....
+*###.#.##.
+***#...#..
+.#*#####.#
+.#*******G
+
+=== Bellman-Ford ===
+depo → müşteri: 7 [depo B A C müşteri]
+Dijkstra: graph: negatif ağırlıklı kenar (Bellman-Ford kullanın): B → A
+negatif döngü: [A B]
+
+=== Topolojik sıralama ===
+derleme sırası: [config http log db api cmd]
+graph: döngü var: [db api config db]
+döngüsel bileşen: [db api config http]
+
+=== Minimum yayılan ağaç ===
+Ankara — Konya (260 km)
+Konya — Antalya (300 km)
+İzmir — Antalya (420 km)
+İstanbul — Ankara (450 km)
+Kruskal: 1430 km, Prim: 1430 km (4 kenar)
+```
+
+---
+
+## ✅ Açıklama
+
+* Mesafeler ilk örnekle **aynı**, ama artık her düğüme giden **yol** da var. `All()` düğümleri kesinleşme sırasıyla (artan mesafe) verir; map'teki gibi rastgele değil.
+* A*, Manhattan sezgiseli sayesinde hedefe doğru yönelerek **daha az düğüm açtı** (23'e karşı 29). Izgara büyüdükçe bu fark katlanarak artar.
+* Bellman-Ford, negatif kenarlı grafta doğru sonucu verdi. Dijkstra aynı grafı **reddetti**; sessizce yanlış sonuç vermedi. Negatif döngü eklenince **döngünün kendisi** raporlandı.
+* `TopoSort` döngüyü `db → api → config → db` olarak **gösterdi**. `SCC` ise `http`'nin de bu kilitlenmeye dahil olduğunu buldu (`config → http → api → config`).
+* Kruskal ve Prim farklı yollardan **aynı toplamı** (1430 km) buldu. `AddBoth` her yönsüz kenarı iki yönlü iki kenar olarak ekler.
+
+---
+*/
@@ -317,4 +317,655 @@ Döngü tamamlandı, tekrar başa dönüldü: Shape of You
 * `Next()` ve `Prev()` sayesinde kolayca şarkılar arasında dolaşıldı.
 * Son şarkıdan sonra **tekrar başa dönüldü** (circular structure avantajı).
 
-*/
\ No newline at end of file
+*/
+
+/*
+---
+
+# 🔹 Gerçek Kullanım 2: Ring ile CPU Zamanlayıcı Simülatörü
+
+Yukarıdaki round-robin örneği ve `Playlist`, **sabit** bir listede dönüp duruyor. Gerçek bir işletim sistemi zamanlayıcısında ise:
+
+* Görevler **çalışırken gelir ve gider** (yeni süreç, `kill`)
+* Görev I/O beklerken **bloklanır**, I/O bitince **uyanır** ve kuyruğa geri döner
+* Her görev en fazla bir **zaman dilimi (quantum)** çalışır, sonra kesilir (preemption)
+* Bazı görevler daha **ağırlıklıdır** (weighted round-robin: daha uzun dilim)
+* Her değişimin bir **maliyeti** vardır (bağlam değişimi)
+
+Bu örnekte tüm bunları **tick tabanlı** bir simülatörle modelliyoruz. Hazır görevler bir `ring`'de durur. Ring'in işaretçisi (`cur`) CPU'nun sıradaki görevidir:
+
+* **Kesme (preemption)** → `cur = cur.Next()`. Görev halkada kalır, sadece sıra ilerler.
+* **Yeni / uyanan görev** → `cur.Prev().Link(elem)`. Halkanın "sonuna", yani `cur`'dan hemen önceye eklenir.
+* **Bloklanan / biten görev** → `elem.Prev().Unlink(1)`. Görev halkadan tek elemanlı bir halka olarak ayrılır, sonra aynı düğümle geri eklenebilir.
+
+---
+
+## 📂 Proje Yapısı
+
+ringsched/
+│── go.mod           (module ringsched)
+│── main.go          → senaryo + politika karşılaştırması
+└── rr/
+    ├── sim.go       → Task, Config, Sim (ring üzerinde zamanlayıcı)
+    └── report.go    → Stat, Summary, Gantt çizelgesi, tablo
+
+---
+
+## 📄 `rr/sim.go`
+*/
+// Package rr, container/ring üzerinde çalışan, tick tabanlı bir round-robin
+// CPU zamanlayıcı simülatörüdür. Hazır görevler bir halkada (ring) durur;
+// halka üzerindeki işaretçi, CPU'nun sıradaki görevidir.
+package rr
+
+import (
+	"container/ring"
+	"fmt"
+)
+
+// Task, simülasyona verilen bir görevdir.
+type Task struct {
+	Name    string
+	Weight  int // ağırlıklı RR'de dilim = Quantum * Weight (0 → 1)
+	Arrival int // hazır kuyruğuna gireceği tick
+
+	// Bursts, sırasıyla CPU ve I/O sürelerdir: {5, 3, 2} → 5 tick CPU,
+	// 3 tick I/O bekleme, 2 tick CPU. Tek sayıda eleman olmalı (CPU ile biter)
+	// ve her süre en az 1 tick olmalı.
+	Bursts []int
+}
+
+// Config, zamanlama politikasıdır.
+type Config struct {
+	Quantum  int  // zaman dilimi (tick); 0 → kesme yok (FCFS)
+	Weighted bool // dilimi görevin ağırlığıyla çarp
+	Switch   int  // bağlam değişimi maliyeti (tick)
+}
+
+// State, bir görevin o anki durumudur.
+type State int
+
+const (
+	NotArrived State = iota
+	Ready
+	Running
+	Blocked
+	Done
+	Killed
+)
+
+// task, simülasyon içindeki görevdir.
+type task struct {
+	Task
+	id     int
+	state  State
+	burst  int        // Bursts içindeki konum (çift: CPU, tek: I/O)
+	left   int        // mevcut burst'ten kalan
+	wakeAt int        // Blocked iken uyanacağı tick (-1: Wake bekler)
+	elem   *ring.Ring // bu görevin halka düğümü (Value = *task)
+	row    []byte     // Gantt satırı, tick başına bir durum
+	stat   Stat
+}
+
+// Sim, simülatördür.
+type Sim struct {
+	cfg    Config
+	now    int
+	tasks  []*task
+	byName map[string]*task
+	events map[int][]func(*Sim)
+
+	cur       *ring.Ring // halkada sıradaki / çalışan görev; halka boşsa nil
+	ready     int        // halkadaki görev sayısı
+	sliceUsed int        // cur'un bu turda kullandığı tick
+	last      *task      // en son CPU'yu kullanan görev
+	switching int        // kalan bağlam değişimi tick'i
+	cpuRow    []byte     // CPU satırı: çalışan görevin etiketi, '.' boşta, 'x' bağlam değişimi
+	busy      int        // CPU'nun görev çalıştırdığı tick sayısı
+	switches  int        // bağlam değişimi sayısı
+}
+
+// New, boş bir simülatör oluşturur.
+func New(cfg Config) *Sim {
+	return &Sim{cfg: cfg, byName: map[string]*task{}, events: map[int][]func(*Sim){}}
+}
+
+// Now, şu anki tick'tir.
+func (s *Sim) Now() int { return s.now }
+
+// Add, görevi ekler; Arrival geçmişteyse görev hemen hazır olur.
+// Simülasyon sürerken (At içinden) de çağrılabilir.
+func (s *Sim) Add(t Task) error {
+	if _, dup := s.byName[t.Name]; dup {
+		return fmt.Errorf("rr: %q zaten var", t.Name)
+	}
+	if len(t.Bursts)%2 == 0 {
+		return fmt.Errorf("rr: %q: Bursts CPU ile bitmeli (tek sayıda eleman)", t.Name)
+	}
+	for i, b := range t.Bursts {
+		if b <= 0 {
+			// 0'lık bir CPU burst'ünde left hemen negatife düşer ve görev hiç bitmez.
+			return fmt.Errorf("rr: %q: Bursts[%d] = %d, süreler pozitif olmalı", t.Name, i, b)
+		}
+	}
+	tk := &task{Task: t, id: len(s.tasks), wakeAt: -1, row: make([]byte, s.now)}
+	for i := range tk.row {
+		tk.row[i] = ' '
+	}
+	tk.elem = ring.New(1)
+	tk.elem.Value = tk
+	tk.stat = Stat{Name: t.Name, Weight: max(t.Weight, 1), Arrival: max(t.Arrival, s.now), FirstRun: -1}
+	s.tasks = append(s.tasks, tk)
+	s.byName[t.Name] = tk
+
+	arrive := func(s *Sim) {
+		tk.left = tk.Bursts[0]
+		s.enqueue(tk)
+	}
+	if tk.stat.Arrival == s.now {
+		arrive(s)
+	} else {
+		s.At(tk.stat.Arrival, arrive)
+	}
+	return nil
+}
+
+// At, fn'yi verilen tick'in başında çalıştırır (görev ekleme, öldürme, bloklama).
+func (s *Sim) At(tick int, fn func(*Sim)) {
+	tick = max(tick, s.now)
+	s.events[tick] = append(s.events[tick], fn)
+}
+
+// Kill, görevi sonlandırır; hangi durumda olursa olsun sistemden çıkar.
+func (s *Sim) Kill(name string) {
+	tk, ok := s.byName[name]
+	if !ok || tk.state == Done || tk.state == Killed {
+		return
+	}
+	if tk.state == Ready || tk.state == Running {
+		s.dequeue(tk)
+	}
+	tk.state = Killed
+	tk.stat.Finish = s.now
+	tk.stat.Killed = true
+}
+
+// Block, görevi d tick boyunca bloklar (ör: kilit bekliyor). d <= 0 ise
+// görev Wake çağrılana kadar bloklu kalır.
+func (s *Sim) Block(name string, d int) {
+	tk, ok := s.byName[name]
+	if !ok || (tk.state != Ready && tk.state != Running) {
+		return
+	}
+	s.dequeue(tk)
+	tk.state = Blocked
+	tk.wakeAt = -1
+	if d > 0 {
+		tk.wakeAt = s.now + d
+	}
+}
+
+// Wake, bloklu görevi hazır kuyruğunun sonuna geri koyar.
+func (s *Sim) Wake(name string) {
+	if tk, ok := s.byName[name]; ok && tk.state == Blocked {
+		s.enqueue(tk)
+	}
+}
+
+// enqueue, görevi halkanın "sonuna" (cur'dan hemen önceye) ekler: tur
+// sırası ona gelene kadar halkadaki herkes bir kez çalışır.
+func (s *Sim) enqueue(tk *task) {
+	tk.state = Ready
+	tk.wakeAt = -1
+	if s.cur == nil {
+		s.cur = tk.elem
+		s.sliceUsed = 0
+	} else {
+		s.cur.Prev().Link(tk.elem)
+	}
+	s.ready++
+}
+
+// dequeue, görevi halkadan çıkarır. Çıkan görev cur ise sıra bir sonrakine geçer.
+func (s *Sim) dequeue(tk *task) {
+	s.ready--
+	if s.ready == 0 {
+		s.cur = nil
+		return
+	}
+	if tk.elem == s.cur {
+		s.cur = s.cur.Next()
+		s.sliceUsed = 0
+	}
+	tk.elem.Prev().Unlink(1) // tk.elem tek elemanlı bir halka olarak ayrılır
+}
+
+// slice, görevin bir turda kesintisiz çalışabileceği süredir (0 = sınırsız).
+func (s *Sim) slice(tk *task) int {
+	if s.cfg.Weighted {
+		return s.cfg.Quantum * max(tk.Weight, 1)
+	}
+	return s.cfg.Quantum
+}
+
+// Step, bir tick ilerletir.
+func (s *Sim) Step() {
+	// 1) Olaylar: varışlar, I/O bitişleri, senaryo olayları
+	for _, tk := range s.tasks {
+		if tk.state == Blocked && tk.wakeAt == s.now {
+			s.enqueue(tk)
+		}
+	}
+	// Olay içinden At ile aynı tick'e eklenen olaylar da çalışsın diye
+	// uzunluk her turda yeniden okunur.
+	for i := 0; i < len(s.events[s.now]); i++ {
+		s.events[s.now][i](s)
+	}
+	delete(s.events, s.now)
+
+	// 2) CPU bu tick'te ne yapıyor?
+	var run *task
+	cpu := byte('.')
+	if s.cur != nil {
+		next := s.cur.Value.(*task)
+		if next != s.last && s.last != nil && s.switching == 0 {
+			s.switches++
+			s.switching = s.cfg.Switch
+		}
+		if s.switching > 0 {
+			s.switching--
+			cpu = 'x'
+			if s.switching == 0 {
+				s.last = next // değişim bitti, bir sonraki tick next çalışır
+			}
+		} else {
+			run = next
+			cpu = label(run)
+		}
+	}
+	s.cpuRow = append(s.cpuRow, cpu)
+
+	// 3) Durumları kaydet ve istatistikleri güncelle
+	for _, tk := range s.tasks {
+		c := byte(' ')
+		switch {
+		case tk == run:
+			c = '#'
+			tk.stat.CPU++
+		case tk.state == Ready || tk.state == Running:
+			c = '-'
+			tk.stat.Wait++
+		case tk.state == Blocked:
+			c = '.'
+			tk.stat.IO++
+		}
+		tk.row = append(tk.row, c)
+	}
+	s.now++
+	if run == nil {
+		return
+	}
+
+	// 4) Çalışan görevi ilerlet
+	s.busy++
+	if run.stat.FirstRun < 0 {
+		run.stat.FirstRun = s.now - 1
+	}
+	if s.sliceUsed == 0 {
+		run.stat.Turns++
+	}
+	s.last = run
+	run.state = Running
+	run.left--
+	s.sliceUsed++
+
+	switch {
+	case run.left == 0 && run.burst == len(run.Bursts)-1:
+		// Son CPU burst'ü bitti
+		s.dequeue(run)
+		run.state = Done
+		run.stat.Finish = s.now
+	case run.left == 0:
+		// CPU burst'ü bitti, I/O'ya gidiyor
+		s.dequeue(run)
+		run.burst++
+		run.state = Blocked
+		run.wakeAt = s.now + run.Bursts[run.burst]
+		run.burst++
+		run.left = run.Bursts[run.burst]
+	case s.cfg.Quantum > 0 && s.sliceUsed >= s.slice(run):
+		// Dilim doldu: kesilir, halkada sıra bir sonrakine geçer (preemption)
+		run.state = Ready
+		s.cur = s.cur.Next()
+		s.sliceUsed = 0
+	}
+}
+
+// Run, tüm görevler bitene ya da limit tick'e ulaşılana kadar çalıştırır.
+func (s *Sim) Run(limit int) {
+	for s.now < limit && !s.finished() {
+		s.Step()
+	}
+}
+
+func (s *Sim) finished() bool {
+	if len(s.events) > 0 {
+		return false
+	}
+	for _, tk := range s.tasks {
+		if tk.state != Done && tk.state != Killed {
+			return false
+		}
+	}
+	return true
+}
+
+// label, görevin Gantt'taki tek harfli etiketidir (A, B, C...).
+func label(tk *task) byte { return byte('A' + tk.id%26) }
+
+/*
+---
+
+### 🔎 Bir Tick'te Ne Oluyor? (`Step`)
+
+1. **Olaylar** → I/O'su biten görevler uyanır, `At` ile planlanan olaylar çalışır (`Add`, `Kill`, `Block`, `Wake`).
+2. **CPU seçimi** → `cur`'daki görev, en son çalışan görevden farklıysa önce `Switch` tick kadar bağlam değişimi yapılır (`x`).
+3. **Kayıt** → Çalışan görevin `CPU`'su, hazır bekleyenlerin `Wait`'i, bloklu olanların `IO`'su artar. Gantt satırlarına birer karakter eklenir.
+4. **İlerletme** → Çalışan görevin burst'ü bir azalır. Burst bittiyse görev halkadan çıkar (I/O'ya gider ya da biter). Dilim dolduysa `cur = cur.Next()`.
+
+---
+
+## 📄 `rr/report.go`
+*/
+package rr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// Stat, bir görevin simülasyon sonundaki istatistikleridir (birim: tick).
+type Stat struct {
+	Name     string
+	Weight   int
+	Arrival  int
+	FirstRun int // ilk kez CPU aldığı tick (-1: hiç çalışmadı)
+	Finish   int
+	CPU      int // çalıştığı süre
+	IO       int // bloklu geçen süre
+	Wait     int // hazır kuyruğunda bekleyerek geçen süre
+	Turns    int // CPU'yu kaç ayrı turda aldı
+	Killed   bool
+}
+
+// Turnaround, varıştan bitişe geçen süredir.
+func (st Stat) Turnaround() int { return st.Finish - st.Arrival }
+
+// Response, varıştan ilk çalışmaya kadar geçen süredir (etkileşimli
+// görevlerde kullanıcının hissettiği gecikme).
+func (st Stat) Response() int { return st.FirstRun - st.Arrival }
+
+// Summary, bir politikanın tüm görevler üzerinden özetidir.
+type Summary struct {
+	AvgWait       float64
+	AvgTurnaround float64
+	AvgResponse   float64
+	Switches      int
+	Utilization   float64 // CPU'nun görev çalıştırdığı zaman oranı
+	Ticks         int
+}
+
+// Stats, görevlerin istatistiklerini eklenme sırasıyla döndürür.
+func (s *Sim) Stats() []Stat {
+	out := make([]Stat, len(s.tasks))
+	for i, tk := range s.tasks {
+		out[i] = tk.stat
+	}
+	return out
+}
+
+// Summary, bitmiş (öldürülmemiş) görevlerin ortalamalarını hesaplar.
+func (s *Sim) Summary() Summary {
+	sum := Summary{Switches: s.switches, Ticks: s.now}
+	n := 0
+	for _, tk := range s.tasks {
+		if tk.state != Done {
+			continue
+		}
+		n++
+		sum.AvgWait += float64(tk.stat.Wait)
+		sum.AvgTurnaround += float64(tk.stat.Turnaround())
+		sum.AvgResponse += float64(tk.stat.Response())
+	}
+	if n > 0 {
+		sum.AvgWait /= float64(n)
+		sum.AvgTurnaround /= float64(n)
+		sum.AvgResponse /= float64(n)
+	}
+	if s.now > 0 {
+		sum.Utilization = float64(s.busy) / float64(s.now)
+	}
+	return sum
+}
+
+// WriteGantt, her görev için tick başına bir karakterlik zaman çizelgesi yazar:
+//
+//	█ çalışıyor   ░ hazır, CPU bekliyor   · I/O / bloklu
+//
+// En alttaki CPU satırı o tick'te çalışan görevin etiketini gösterir
+// ('.' boşta, 'x' bağlam değişimi).
+func (s *Sim) WriteGantt(w io.Writer) {
+	const pad = 14
+	// Zaman ekseni: her 5 tick'te bir işaret
+	var axis, ticks strings.Builder
+	for t := 0; t < s.now; t++ {
+		if t%5 == 0 {
+			mark := fmt.Sprint(t)
+			axis.WriteString(mark)
+			t += len(mark) - 1
+			ticks.WriteByte('|')
+			for range len(mark) - 1 {
+				ticks.WriteByte(' ')
+			}
+			continue
+		}
+		axis.WriteByte(' ')
+		ticks.WriteByte(' ')
+	}
+	fmt.Fprintf(w, "%*s %s\n", pad, "", axis.String())
+	fmt.Fprintf(w, "%*s %s\n", pad, "", ticks.String())
+
+	for _, tk := range s.tasks {
+		var row strings.Builder
+		for _, c := range tk.row {
+			switch c {
+			case '#':
+				row.WriteString("█")
+			case '-':
+				row.WriteString("░")
+			case '.':
+				row.WriteString("·")
+			default:
+				row.WriteByte(' ')
+			}
+		}
+		name := fmt.Sprintf("%c %s", label(tk), tk.Name)
+		if tk.Weight > 1 {
+			name += fmt.Sprintf(" ×%d", tk.Weight)
+		}
+		end := ""
+		if tk.stat.Killed {
+			end = " ✗"
+		}
+		fmt.Fprintf(w, "%-*s %s%s\n", pad, truncate(name, pad), row.String(), end)
+	}
+	fmt.Fprintf(w, "%-*s %s\n", pad, "CPU", s.cpuRow)
+}
+
+// WriteStats, görev başına istatistik tablosunu ve özeti yazar.
+func (s *Sim) WriteStats(w io.Writer) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "görev\tvarış\tilk çalışma\tbitiş\tcpu\tio\tbekleme\tyanıt\tturnaround\ttur\t")
+	for _, st := range s.Stats() {
+		finish, turnaround := fmt.Sprint(st.Finish), fmt.Sprint(st.Turnaround())
+		if st.Killed {
+			finish, turnaround = fmt.Sprintf("✗ %d", st.Finish), "-"
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%d\t%d\t%d\t%d\t%s\t%d\t\n", st.Name, st.Arrival, st.FirstRun,
+			finish, st.CPU, st.IO, st.Wait, st.Response(), turnaround, st.Turns)
+	}
+	tw.Flush()
+	sum := s.Summary()
+	fmt.Fprintf(w, "ort. bekleme %.1f, ort. yanıt %.1f, ort. turnaround %.1f, bağlam değişimi %d, CPU kullanımı %%%.0f\n",
+		sum.AvgWait, sum.AvgResponse, sum.AvgTurnaround, sum.Switches, sum.Utilization*100)
+}
+
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
+
+/*
+---
+
+## 📄 `main.go`
+*/
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"ringsched/rr"
+)
+
+// workload, her politikada aynı olan görev seti ve senaryodur.
+func workload(s *rr.Sim) {
+	s.Add(rr.Task{Name: "editör", Bursts: []int{1, 3, 1, 3, 1, 3, 1}}) // etkileşimli: kısa CPU, sık I/O
+	s.Add(rr.Task{Name: "derleyici", Bursts: []int{10}})               // CPU yoğun
+	s.Add(rr.Task{Name: "video", Weight: 2, Bursts: []int{4, 2, 4}})   // önemli: çift dilim
+	s.Add(rr.Task{Name: "yedek", Arrival: 4, Bursts: []int{8}})        // sonradan gelir
+
+	// Çalışırken gelen olaylar
+	s.At(8, func(s *rr.Sim) {
+		s.Add(rr.Task{Name: "acil", Bursts: []int{2}}) // anında eklenen görev
+	})
+	s.At(11, func(s *rr.Sim) { s.Block("derleyici", 4) }) // disk kilidini bekliyor
+	s.At(20, func(s *rr.Sim) { s.Kill("yedek") })         // kullanıcı iptal etti
+}
+
+func main() {
+	// 1) Ayrıntılı çalıştırma: ağırlıklı RR, dilim 2, bağlam değişimi 0
+	s := rr.New(rr.Config{Quantum: 2, Weighted: true})
+	workload(s)
+	s.Run(200)
+	fmt.Println("=== Ağırlıklı Round-Robin (q=2) ===")
+	s.WriteGantt(os.Stdout)
+	fmt.Println()
+	s.WriteStats(os.Stdout)
+
+	// 2) Aynı senaryo, farklı politikalar
+	policies := []struct {
+		name string
+		cfg  rr.Config
+	}{
+		{"FCFS (kesmesiz)", rr.Config{Quantum: 0}},
+		{"RR q=1", rr.Config{Quantum: 1}},
+		{"RR q=4", rr.Config{Quantum: 4}},
+		{"Ağırlıklı RR q=2", rr.Config{Quantum: 2, Weighted: true}},
+		{"RR q=1, değişim=1", rr.Config{Quantum: 1, Switch: 1}},
+	}
+	fmt.Println("\n=== Politika karşılaştırması ===")
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "politika\tort. bekleme\tort. yanıt\tort. turnaround\tdeğişim\tCPU\tsüre")
+	for _, p := range policies {
+		s := rr.New(p.cfg)
+		workload(s)
+		s.Run(200)
+		sum := s.Summary()
+		fmt.Fprintf(tw, "%s\t%.1f\t%.1f\t%.1f\t%d\t%%%.0f\t%d\n", p.name,
+			sum.AvgWait, sum.AvgResponse, sum.AvgTurnaround, sum.Switches, sum.Utilization*100, sum.Ticks)
+	}
+	tw.Flush()
+
+	// 3) Bağlam değişimi maliyetinin Gantt üzerindeki etkisi
+	s = rr.New(rr.Config{Quantum: 1, Switch: 1})
+	workload(s)
+	s.Run(200)
+	fmt.Println("\n=== RR q=1, bağlam değişimi 1 tick ===")
+	s.WriteGantt(os.Stdout)
+}
+
+/*
+### 📌 Çıktı
+
+```
+=== Ağırlıklı Round-Robin (q=2) ===
+               0    5    10   15   20   25 
+               |    |    |    |    |    |  
+A editör       █···░░░░░█···░░░░░░░█···░█  
+B derleyici    ░██░░░░██░░····░░░░░░████░██
+C video ×2     ░░░████··░░░░░████          
+D yedek            ░░░░░░██░░░░░░██         ✗
+E acil                 ░░░░██              
+CPU            ABBCCCCBBADDEECCCCDDABBBBABB
+
+      görev  varış  ilk çalışma  bitiş  cpu  io  bekleme  yanıt  turnaround  tur
+     editör      0            0     26    4   9       13      0          26    4
+  derleyici      0            1     28   10   4       14      1          28    5
+      video      0            3     18    8   2        8      3          18    2
+      yedek      4           10   ✗ 20    4   0       12      6           -    2
+       acil      8           12     14    2   0        4      4           6    1
+ort. bekleme 9.8, ort. yanıt 2.0, ort. turnaround 19.5, bağlam değişimi 12, CPU kullanımı %100
+
+=== Politika karşılaştırması ===
+politika           ort. bekleme  ort. yanıt  ort. turnaround  değişim  CPU   süre
+FCFS (kesmesiz)    11.8          6.0         20.5             7        %90   31
+RR q=1             11.5          1.5         21.2             27       %100  29
+RR q=4             10.8          3.0         20.5             10       %100  28
+Ağırlıklı RR q=2   9.8           2.0         19.5             12       %100  28
+RR q=1, değişim=1  24.8          3.0         34.5             23       %53   49
+
+=== RR q=1, bağlam değişimi 1 tick ===
+               0    5    10   15   20   25   30   35   40   45  
+               |    |    |    |    |    |    |    |    |    |   
+A editör       █···░░░░█···░░░░░░█···░░░░░░█                    
+B derleyici    ░░█░░░█░░░░····░░░░░░░░░█░░░░░█░░░█░░░█░░░█░░░███
+C video ×2     ░░░░█░░░░░░░█░░░░░░░█░░░░░█··░░░█░░░█░░░█░░░█    
+D yedek            ░░░░░░█░░░░░█░░░                              ✗
+E acil                 ░░░░░░█░░░░░░░█                          
+CPU            AxBxCxBxAxDxCxExDxAxCxExBxCxAxBxCxBxCxBxCxBxCxBBB
+```
+
+---
+
+### 🔎 Çıktıyı Okumak
+
+| Metrik         | Tanım                   | Kim için önemli                         |
+| -------------- | ----------------------- | --------------------------------------- |
+| **bekleme**    | Hazır ama CPU alamadı   | Genel verim                             |
+| **yanıt**      | İlk çalışma − varış     | Etkileşimli görevler (editör, terminal) |
+| **turnaround** | Bitiş − varış           | Batch işler (derleyici, yedek)          |
+| **değişim**    | Bağlam değişimi sayısı  | Gerçek sistemde kayıp CPU zamanı        |
+
+* **FCFS** en az değişimi yapar ama yanıt süresi kötüdür. Uzun bir görev CPU'yu bırakmadıkça kısa görevler bekler. CPU kullanımı da %100 değildir, çünkü herkes I/O beklerken CPU boşta kalır.
+* **RR q=1** en iyi yanıt süresini verir (`editör` hemen tepki alır) ama en çok değişimi yapar.
+* **Bağlam değişimi 1 tick** olunca aynı q=1 politikasının toplam süresi 29'dan 49'a çıkar, CPU'nun neredeyse yarısı değişime gider. Çok küçük quantum'un gerçek maliyeti budur.
+* **Ağırlıklı RR** `video`'ya çift dilim verir. Bu senaryoda hem ortalama bekleme hem turnaround en iyi sonucu verdi.
+* Senaryo olayları Gantt'ta görünüyor: `acil` 8. tick'te eklendi, `derleyici` 11–14 arası disk kilidi bekledi (`····`), `yedek` 20. tick'te öldürüldü (`✗`).
+
+---
+
+✅ Burada `container/ring`:
+
+* Hazır kuyruğunu **dairesel** tuttu. Round-robin'in "sıradakine geç" adımı tek bir `Next()` oldu.
+* `Link` ve `Unlink` ile görevler halkaya **çalışırken** eklenip çıkarıldı, ayrı bir kopya ya da yeniden sıralama gerekmedi.
+* Her görevin halka düğümü bir kez oluşturuldu. Bloklanıp uyanan görev **aynı düğümle** halkaya geri döndü.
+
+*/
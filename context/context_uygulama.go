@@ -232,4 +232,1082 @@ Başladı, Request ID: REQ-999
 ---
 
 Böylece `context` paketinin **tamamını örneklerle** özetlemiş olduk 🚀
-*/
\ No newline at end of file
+*/
+
+/*
+---
+
+# ⚡ Kapsamlı Örnek: Context ile Çalışan Öncelikli İş Zamanlayıcısı
+
+Yukarıdaki `doWork(ctx)` tek bir goroutine'in context ile nasıl durdurulduğunu gösteriyor.
+`container/container_uygulama.go`'daki `TaskHeap` ise görevleri önceliğe göre sıralıyor ama sadece bir döngüde boşaltıyor.
+Gerçek batch servislerinde ikisi **birlikte** lazım:
+
+* İşler **öncelik** sırasıyla çalışsın (`container/heap`)
+* Aynı anda en fazla **N worker** çalışsın
+* Her denemenin bir **timeout**'u, her işin bir **deadline**'ı olsun
+* Geçici hatalarda **backoff** ile tekrar denensin, kalıcı hatalarda denenmesin
+* Bir **grup** iş, ortak parent context'in `cancel()`'ı ile birlikte iptal edilsin
+* Context'teki değerler (ör: batch ID) işe **taşınsın**
+* İşlerin durumu bir **API** ile sorgulanabilsin
+
+---
+
+## 📂 Proje Yapısı
+
+jobdemo/
+│── go.mod              (module jobdemo)
+│── main.go             → örnek batch
+└── sched/
+    ├── job.go            → Job, State, Status, Backoff, Permanent
+    ├── queue.go          → container/heap ile öncelik kuyruğu
+    ├── scheduler.go      → worker havuzu, iptal, retry, durum API'si
+    └── scheduler_test.go → sahte saatle öncelik, iptal, deadline, backoff testleri
+
+---
+
+## 🔁 Bir İşin Yaşam Döngüsü
+
+Submit → pending ──(worker alır)──▶ running ──▶ succeeded
+            ▲                          │
+            │                          ├──(geçici hata)──▶ retrying ──(backoff)──▶ pending
+            │                          ├──(kalıcı hata / deneme bitti)──▶ failed
+            │                          └──(context iptal)──▶ canceled / expired
+            └── pending veya retrying iken iptal → hemen canceled / expired
+
+---
+
+## 📄 `sched/job.go`
+*/
+package sched
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// Job, zamanlayıcıya verilen bir iştir.
+type Job struct {
+	ID       string // boşsa otomatik verilir
+	Group    string // sadece durum listesinde filtrelemek için etiket
+	Priority int    // küçük sayı = önce çalışır (TaskHeap ile aynı)
+
+	// Timeout, her denemenin süresidir; Deadline ise işin tamamının (tüm
+	// denemeler + bekleme) bitmesi gereken andır. Sıfır değer = sınır yok.
+	Timeout  time.Duration
+	Deadline time.Time
+
+	MaxRetries int     // ilk denemeden sonra en fazla kaç kez daha denensin
+	Backoff    Backoff // denemeler arası bekleme
+
+	Run func(ctx context.Context) error
+}
+
+// State, bir işin yaşam döngüsündeki yeridir.
+type State int
+
+const (
+	Pending  State = iota // kuyrukta sıra bekliyor
+	Running               // bir worker çalıştırıyor
+	Retrying              // başarısız oldu, backoff süresini bekliyor
+	Succeeded
+	Failed
+	Canceled // context iptal edildi (parent, Cancel veya Close)
+	Expired  // Deadline doldu
+)
+
+var stateNames = [...]string{"pending", "running", "retrying", "succeeded", "failed", "canceled", "expired"}
+
+func (s State) String() string { return stateNames[s] }
+
+// MarshalText, durumun JSON'da isim olarak görünmesini sağlar.
+func (s State) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// Done, işin son durumuna ulaşıp ulaşmadığını söyler.
+func (s State) Done() bool { return s >= Succeeded }
+
+// Status, bir işin o anki durumunun kopyasıdır.
+type Status struct {
+	ID        string    `json:"id"`
+	Group     string    `json:"group,omitempty"`
+	Priority  int       `json:"priority"`
+	State     State     `json:"state"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	Submitted time.Time `json:"submitted"`
+	Started   time.Time `json:"started,omitzero"`
+	Finished  time.Time `json:"finished,omitzero"`
+	NextRetry time.Time `json:"next_retry,omitzero"`
+}
+
+// Backoff, üstel bekleme süresini tanımlar: Initial * Multiplier^(deneme-1),
+// en fazla Max. Jitter (0..1), sürenin rastgele kırpılacak oranıdır; aynı anda
+// düşen işlerin aynı anda tekrar denenmesini önler.
+type Backoff struct {
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+	Jitter     float64
+}
+
+// DefaultBackoff, Job.Backoff boş bırakıldığında kullanılır.
+var DefaultBackoff = Backoff{Initial: 100 * time.Millisecond, Max: 10 * time.Second, Multiplier: 2, Jitter: 0.2}
+
+// Delay, attempt. başarısız denemeden sonra beklenecek süredir (attempt >= 1).
+func (b Backoff) Delay(attempt int) time.Duration {
+	if b == (Backoff{}) {
+		b = DefaultBackoff
+	}
+	d := float64(b.Initial)
+	for range attempt - 1 {
+		d *= max(b.Multiplier, 1)
+		if b.Max > 0 && d >= float64(b.Max) {
+			break
+		}
+	}
+	if b.Max > 0 {
+		d = min(d, float64(b.Max))
+	}
+	d -= d * b.Jitter * rand.Float64()
+	return time.Duration(d)
+}
+
+// permanentError, tekrar denenmemesi gereken hatayı işaretler.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent, err'i tekrar denenmeyecek şekilde sarar (ör: geçersiz girdi).
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+func isPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+/*
+---
+
+## 📄 `sched/queue.go`
+
+`TaskHeap`'in geliştirilmiş hâli. Her eleman heap içindeki **indeksini** tutar; böylece kuyrukta bekleyen bir iş iptal edilince `heap.Remove` ile çıkarılabilir.
+*/
+package sched
+
+import "container/heap"
+
+// jobQueue, container/heap ile öncelik kuyruğudur. TaskHeap'ten farkı:
+//   - eşit öncelikte Deadline'ı yakın olan önce çalışır (EDF), sonra FIFO
+//   - her eleman heap içindeki indeksini bilir; böylece iptal edilen iş
+//     heap.Remove ile O(log n)'de kuyruktan çıkarılır
+type jobQueue []*entry
+
+func (q jobQueue) Len() int { return len(q) }
+
+func (q jobQueue) Less(i, j int) bool {
+	a, b := q[i], q[j]
+	if a.job.Priority != b.job.Priority {
+		return a.job.Priority < b.job.Priority
+	}
+	if !a.job.Deadline.Equal(b.job.Deadline) {
+		switch {
+		case a.job.Deadline.IsZero():
+			return false // deadline'ı olmayan en sona
+		case b.job.Deadline.IsZero():
+			return true
+		}
+		return a.job.Deadline.Before(b.job.Deadline)
+	}
+	return a.seq < b.seq
+}
+
+func (q jobQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *jobQueue) Push(x any) {
+	e := x.(*entry)
+	e.index = len(*q)
+	*q = append(*q, e)
+}
+
+func (q *jobQueue) Pop() any {
+	old := *q
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil // GC için
+	e.index = -1
+	*q = old[:n-1]
+	return e
+}
+
+var _ heap.Interface = (*jobQueue)(nil)
+/*
+---
+
+## 📄 `sched/scheduler.go`
+*/
+// Package sched, öncelikli (container/heap) bir kuyruk, sınırlı sayıda worker
+// ve iş başına context ile çalışan bir iş zamanlayıcısıdır.
+package sched
+
+import (
+	"cmp"
+	"container/heap"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	ErrClosed    = errors.New("sched: zamanlayıcı kapalı")
+	ErrDuplicate = errors.New("sched: bu ID ile aktif bir iş var")
+	ErrNoRun     = errors.New("sched: Job.Run boş")
+
+	// İptal sebepleri; Status.LastError'da görünür.
+	errCanceled = errors.New("iş iptal edildi")
+	errStopped  = errors.New("zamanlayıcı durduruldu")
+)
+
+// entry, bir işin zamanlayıcı içindeki kaydıdır. Alanları s.mu ile korunur.
+type entry struct {
+	job    Job
+	seq    uint64 // eşit öncelikte FIFO için
+	index  int    // jobQueue içindeki yeri, kuyrukta değilse -1
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+	stop   func() bool // iptal izleyicisini kaldırır
+	retry  func() bool // Retrying durumunda bekleme zamanlayıcısını durdurur
+	status Status
+}
+
+// Scheduler, işleri öncelik sırasıyla sabit sayıda worker'da çalıştırır.
+type Scheduler struct {
+	ctx     context.Context
+	mu      sync.Mutex
+	cond    *sync.Cond // kuyruğa iş geldi ya da duruluyor
+	queue   jobQueue
+	jobs    map[string]*entry
+	seq     uint64
+	closed  bool // yeni iş kabul edilmiyor
+	stopped bool // worker'lar çıksın
+	active  sync.WaitGroup
+	workers sync.WaitGroup
+
+	// now ve afterFunc, testlerde sahte saatle değiştirilir.
+	now       func() time.Time
+	afterFunc func(d time.Duration, f func()) (stop func() bool)
+}
+
+// New, workers adet worker başlatır. ctx iptal edilirse bekleyen ve çalışan
+// tüm işler iptal edilir; yine de worker'ları durdurmak için Close çağrılmalıdır.
+func New(ctx context.Context, workers int) *Scheduler {
+	s := &Scheduler{
+		ctx:  ctx,
+		jobs: make(map[string]*entry),
+		now:  time.Now,
+		afterFunc: func(d time.Duration, f func()) func() bool {
+			return time.AfterFunc(d, f).Stop
+		},
+	}
+	s.cond = sync.NewCond(&s.mu)
+	for range max(workers, 1) {
+		s.workers.Add(1)
+		go s.worker()
+	}
+	context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, e := range s.jobs {
+			e.cancel(errStopped)
+		}
+	})
+	return s
+}
+
+// Submit, işi kuyruğa ekler. İşin context'i ctx'ten türetilir: ctx'teki
+// değerler (ör: request ID) işe taşınır, ctx iptal edilince iş de iptal olur.
+// Aynı ctx ile gönderilen işler böylece tek bir cancel ile birlikte durdurulur.
+func (s *Scheduler) Submit(ctx context.Context, job Job) (string, error) {
+	if job.Run == nil {
+		return "", ErrNoRun
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed || s.ctx.Err() != nil {
+		return "", ErrClosed
+	}
+	s.seq++
+	if job.ID == "" {
+		job.ID = "job-" + strconv.FormatUint(s.seq, 10)
+	}
+	if old, ok := s.jobs[job.ID]; ok && !old.status.State.Done() {
+		return "", ErrDuplicate
+	}
+
+	e := &entry{job: job, seq: s.seq, index: -1}
+	e.ctx, e.cancel = context.WithCancelCause(ctx)
+	if !job.Deadline.IsZero() {
+		var cancelDeadline context.CancelFunc
+		e.ctx, cancelDeadline = context.WithDeadline(e.ctx, job.Deadline)
+		cancel := e.cancel
+		e.cancel = func(cause error) { cancel(cause); cancelDeadline() }
+	}
+	e.status = Status{ID: job.ID, Group: job.Group, Priority: job.Priority, State: Pending, Submitted: s.now()}
+
+	s.jobs[job.ID] = e
+	s.active.Add(1)
+	heap.Push(&s.queue, e)
+	s.cond.Signal()
+
+	// Kuyrukta ya da backoff beklerken iptal edilen iş hemen sonlandırılır;
+	// çalışan iş ise Run dönünce sonlandırılır.
+	e.stop = context.AfterFunc(e.ctx, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		switch e.status.State {
+		case Pending:
+			heap.Remove(&s.queue, e.index)
+		case Retrying:
+			e.retry()
+		default:
+			return
+		}
+		s.finish(e, stateOf(e.ctx), context.Cause(e.ctx))
+	})
+	return job.ID, nil
+}
+
+// Cancel, işi iptal eder. İş zaten bitmişse false döner.
+func (s *Scheduler) Cancel(id string) bool {
+	s.mu.Lock()
+	e, ok := s.jobs[id]
+	ok = ok && !e.status.State.Done()
+	s.mu.Unlock()
+	if ok {
+		e.cancel(errCanceled)
+	}
+	return ok
+}
+
+// Status, işin o anki durumunu döndürür.
+func (s *Scheduler) Status(id string) (Status, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.jobs[id]
+	if !ok {
+		return Status{}, false
+	}
+	return e.status, true
+}
+
+// List, tüm işlerin durumunu gönderilme sırasıyla döndürür. group boş değilse
+// sadece o gruptakiler döner.
+func (s *Scheduler) List(group string) []Status {
+	s.mu.Lock()
+	var list []*entry
+	for _, e := range s.jobs {
+		if group == "" || e.job.Group == group {
+			list = append(list, e)
+		}
+	}
+	slices.SortFunc(list, func(a, b *entry) int { return cmp.Compare(a.seq, b.seq) })
+	out := make([]Status, len(list))
+	for i, e := range list {
+		out[i] = e.status
+	}
+	s.mu.Unlock()
+	return out
+}
+
+// ServeHTTP, durum API'sidir:
+//
+//	GET /jobs            → tüm işler
+//	GET /jobs?group=rapor → bir grup
+//	GET /jobs?id=job-3   → tek iş
+//	DELETE /jobs?id=job-3 → işi iptal et
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	var v any
+	switch {
+	case r.Method == http.MethodDelete && id != "":
+		if !s.Cancel(id) {
+			http.Error(w, "iş yok ya da zaten bitti", http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+		return
+	case r.Method != http.MethodGet:
+		http.Error(w, "yalnızca GET ve DELETE", http.StatusMethodNotAllowed)
+		return
+	case id != "":
+		st, ok := s.Status(id)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		v = st
+	default:
+		v = s.List(r.URL.Query().Get("group"))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// Close, yeni iş kabulünü durdurur, kuyruktaki ve çalışan işlerin bitmesini
+// bekler ve worker'ları kapatır. Beklemeden durmak için New'e verilen ctx
+// iptal edilir; işler Canceled olarak biter.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+
+	s.active.Wait()
+
+	s.mu.Lock()
+	s.stopped = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	s.workers.Wait()
+}
+
+func (s *Scheduler) worker() {
+	defer s.workers.Done()
+	for {
+		s.mu.Lock()
+		for s.queue.Len() == 0 && !s.stopped {
+			s.cond.Wait()
+		}
+		if s.stopped {
+			s.mu.Unlock()
+			return
+		}
+		e := heap.Pop(&s.queue).(*entry)
+		e.status.State = Running
+		e.status.Attempts++
+		e.status.NextRetry = time.Time{}
+		if e.status.Started.IsZero() {
+			e.status.Started = s.now()
+		}
+		s.mu.Unlock()
+
+		err := e.attempt()
+
+		s.mu.Lock()
+		s.afterAttempt(e, err)
+		s.mu.Unlock()
+	}
+}
+
+// attempt, işi bir kez çalıştırır. Deneme süresi Job.Timeout ile sınırlıdır;
+// panic, hataya çevrilir ki worker ölmesin.
+func (e *entry) attempt() (err error) {
+	ctx := e.ctx
+	if e.job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.job.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return e.job.Run(ctx)
+}
+
+// afterAttempt, deneme sonucuna göre işi bitirir ya da tekrar planlar.
+// s.mu tutuluyor olmalı.
+func (s *Scheduler) afterAttempt(e *entry, err error) {
+	switch {
+	case err == nil:
+		s.finish(e, Succeeded, nil)
+		return
+	case e.ctx.Err() != nil:
+		// İşin kendisi iptal edildi ya da Deadline doldu (sadece deneme süresi değil)
+		s.finish(e, stateOf(e.ctx), context.Cause(e.ctx))
+		return
+	case isPermanent(err) || e.status.Attempts > e.job.MaxRetries:
+		s.finish(e, Failed, err)
+		return
+	}
+
+	delay := e.job.Backoff.Delay(e.status.Attempts)
+	next := s.now().Add(delay)
+	if dl, ok := e.ctx.Deadline(); ok && next.After(dl) {
+		// Bir sonraki deneme zaten deadline'dan sonra olurdu
+		s.finish(e, Expired, fmt.Errorf("deadline öncesi tekrar denenemez: %w", err))
+		return
+	}
+	e.status.State = Retrying
+	e.status.LastError = err.Error()
+	e.status.NextRetry = next
+	e.retry = s.afterFunc(delay, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if e.status.State == Retrying {
+			e.status.State = Pending
+			heap.Push(&s.queue, e)
+			s.cond.Signal()
+		}
+	})
+}
+
+// finish, işi son durumuna taşır. s.mu tutuluyor olmalı.
+func (s *Scheduler) finish(e *entry, state State, err error) {
+	e.status.State = state
+	e.status.Finished = s.now()
+	e.status.NextRetry = time.Time{}
+	e.status.LastError = ""
+	if err != nil {
+		e.status.LastError = err.Error()
+	}
+	e.stop()
+	e.cancel(nil) // context kaynaklarını bırak
+	s.active.Done()
+}
+
+func stateOf(ctx context.Context) State {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return Expired
+	}
+	return Canceled
+}
+/*
+---
+
+## 🧪 `sched/scheduler_test.go`
+
+Zamanlayıcı saati doğrudan okumaz: `Scheduler.now` ve `Scheduler.afterFunc` alanları `New`'de `time.Now` ve `time.AfterFunc` olur, testte ise `fakeClock` ile değiştirilir. Böylece backoff beklemeleri gerçekten beklenmez; `clock.Advance` ile saat ilerletilince dolar ve testler milisaniyeler içinde, her seferinde aynı sırayla çalışır.
+
+Tek worker'lı zamanlayıcıda ilk iş (`block(gate)`) worker'ı tutar; arkasından gönderilen işler kuyrukta bekler. Böylece öncelik sırası ve kuyruktayken iptal kesin olarak gözlenir.
+*/
+package sched
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock, Scheduler.now ve Scheduler.afterFunc yerine geçer: backoff
+// beklemeleri ancak Advance çağrılınca dolar.
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) func() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, t)
+	return func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		was := !t.stopped
+		t.stopped = true
+		return was
+	}
+}
+
+// Advance, saati d kadar ilerletir ve süresi dolan zamanlayıcıları çalıştırır.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	var due []func()
+	c.timers = slices.DeleteFunc(c.timers, func(t *fakeTimer) bool {
+		if t.stopped || t.at.After(c.now) {
+			return t.stopped
+		}
+		t.stopped = true
+		due = append(due, t.f)
+		return true
+	})
+	c.mu.Unlock()
+	for _, f := range due {
+		f()
+	}
+}
+
+// newTestScheduler, sahte saatli bir Scheduler döndürür. Saat gerçek zamandan
+// başlar; böylece context deadline'ları ile karşılaştırmalar anlamlı kalır.
+func newTestScheduler(t *testing.T, ctx context.Context, workers int) (*Scheduler, *fakeClock) {
+	t.Helper()
+	s := New(ctx, workers)
+	clock := &fakeClock{now: time.Now()}
+	s.now, s.afterFunc = clock.Now, clock.AfterFunc
+	t.Cleanup(s.Close)
+	return s, clock
+}
+
+// waitState, işin durumu want olana kadar bekler.
+func waitState(t *testing.T, s *Scheduler, id string, want State) Status {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		st, _ := s.Status(id)
+		if st.State == want {
+			return st
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s: durum %v, beklenen %v", id, st.State, want)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// block, gate kapanana ya da context iptal edilene kadar worker'ı tutar.
+func block(gate <-chan struct{}) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		select {
+		case <-gate:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func TestPriorityOrder(t *testing.T) {
+	s, _ := newTestScheduler(t, context.Background(), 1)
+	gate := make(chan struct{})
+	s.Submit(context.Background(), Job{ID: "gate", Run: block(gate)})
+	waitState(t, s, "gate", Running)
+
+	var mu sync.Mutex
+	var order []string
+	record := func(id string) func(context.Context) error {
+		return func(context.Context) error {
+			mu.Lock()
+			order = append(order, id)
+			mu.Unlock()
+			return nil
+		}
+	}
+	soon := time.Now().Add(time.Hour)
+	for _, j := range []Job{
+		{ID: "p3", Priority: 3},
+		{ID: "p1-fifo-1", Priority: 1},
+		{ID: "p1-fifo-2", Priority: 1},
+		{ID: "p1-geç", Priority: 1, Deadline: soon.Add(time.Minute)},
+		{ID: "p1-erken", Priority: 1, Deadline: soon},
+		{ID: "p2", Priority: 2},
+	} {
+		j.Run = record(j.ID)
+		if _, err := s.Submit(context.Background(), j); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(gate)
+	s.Close()
+
+	// Önce öncelik, eşit öncelikte yakın deadline (EDF), sonra gönderilme sırası
+	want := []string{"p1-erken", "p1-geç", "p1-fifo-1", "p1-fifo-2", "p2", "p3"}
+	if !slices.Equal(order, want) {
+		t.Errorf("sıra = %v, beklenen %v", order, want)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	s, _ := newTestScheduler(t, context.Background(), 1)
+	group, cancelGroup := context.WithCancel(context.Background())
+	gate := make(chan struct{})
+	ran := make(chan string, 4)
+	run := func(id string) func(context.Context) error {
+		return func(context.Context) error { ran <- id; return nil }
+	}
+	s.Submit(context.Background(), Job{ID: "running", Run: block(gate)})
+	waitState(t, s, "running", Running)
+	s.Submit(context.Background(), Job{ID: "pending", Run: run("pending")})
+	s.Submit(group, Job{ID: "group-1", Run: run("group-1")})
+	s.Submit(group, Job{ID: "group-2", Run: run("group-2")})
+	s.Submit(context.Background(), Job{ID: "other", Run: run("other")})
+
+	// Kuyruktaki iş sırası gelmeden çıkarılır
+	if !s.Cancel("pending") {
+		t.Fatal("Cancel(pending) = false")
+	}
+	if st := waitState(t, s, "pending", Canceled); st.Attempts != 0 || st.LastError != errCanceled.Error() {
+		t.Errorf("pending: %+v", st)
+	}
+
+	// Parent context'in iptali gruptaki tüm işleri iptal eder
+	cancelGroup()
+	for _, id := range []string{"group-1", "group-2"} {
+		if st := waitState(t, s, id, Canceled); st.Attempts != 0 {
+			t.Errorf("%s çalıştı: %+v", id, st)
+		}
+	}
+
+	// Çalışan işin context'i iptal edilir, Run dönünce iş biter
+	s.Cancel("running")
+	if st := waitState(t, s, "running", Canceled); st.Attempts != 1 {
+		t.Errorf("running: %+v", st)
+	}
+	if st := waitState(t, s, "other", Succeeded); st.Attempts != 1 {
+		t.Errorf("other: %+v", st)
+	}
+	if s.Cancel("other") || s.Cancel("yok") {
+		t.Error("biten ya da olmayan iş iptal edildi")
+	}
+	s.Close()
+	close(ran)
+	for id := range ran {
+		if id != "other" {
+			t.Errorf("iptal edilen %s çalıştı", id)
+		}
+	}
+}
+
+func TestDeadline(t *testing.T) {
+	s, clock := newTestScheduler(t, context.Background(), 1)
+	gate := make(chan struct{})
+	s.Submit(context.Background(), Job{ID: "gate", Run: block(gate)})
+	waitState(t, s, "gate", Running)
+
+	// Deadline'ı kuyruktayken dolan iş hiç çalışmaz
+	s.Submit(context.Background(), Job{
+		ID:       "late",
+		Deadline: time.Now().Add(-time.Second),
+		Run:      func(context.Context) error { t.Error("late çalıştı"); return nil },
+	})
+	if st := waitState(t, s, "late", Expired); st.Attempts != 0 {
+		t.Errorf("late: %+v", st)
+	}
+	close(gate)
+
+	// Bir sonraki deneme deadline'dan sonraya düşüyorsa beklemeden expired
+	s.Submit(context.Background(), Job{
+		ID:         "short",
+		Deadline:   clock.Now().Add(time.Minute),
+		MaxRetries: 5,
+		Backoff:    Backoff{Initial: time.Hour},
+		Run:        func(context.Context) error { return errors.New("geçici") },
+	})
+	st := waitState(t, s, "short", Expired)
+	if st.Attempts != 1 || st.LastError != "deadline öncesi tekrar denenemez: geçici" {
+		t.Errorf("short: %+v", st)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	s, clock := newTestScheduler(t, context.Background(), 1)
+	backoff := Backoff{Initial: time.Second, Max: 3 * time.Second, Multiplier: 2}
+	fail := func(n int) func(context.Context) error {
+		calls := 0
+		return func(context.Context) error {
+			if calls++; calls <= n {
+				return errors.New("geçici")
+			}
+			return nil
+		}
+	}
+
+	// İki geçici hata: 1 sn, sonra 2 sn beklenir, 3. denemede başarılı
+	start := clock.Now()
+	s.Submit(context.Background(), Job{ID: "flaky", MaxRetries: 3, Backoff: backoff, Run: fail(2)})
+	st := waitState(t, s, "flaky", Retrying)
+	if st.Attempts != 1 || !st.NextRetry.Equal(start.Add(time.Second)) || st.LastError != "geçici" {
+		t.Fatalf("1. denemeden sonra: %+v", st)
+	}
+	clock.Advance(time.Second - time.Millisecond)
+	if st, _ := s.Status("flaky"); st.State != Retrying {
+		t.Fatalf("backoff dolmadan tekrar denendi: %+v", st)
+	}
+	clock.Advance(time.Millisecond)
+	st = waitState(t, s, "flaky", Retrying) // Advance işi Pending yaptı
+	if want := start.Add(3 * time.Second); !st.NextRetry.Equal(want) {
+		t.Fatalf("2. denemeden sonra NextRetry = %v, beklenen %v", st.NextRetry, want)
+	}
+	clock.Advance(2 * time.Second)
+	st = waitState(t, s, "flaky", Succeeded)
+	if st.Attempts != 3 || !st.Finished.Equal(start.Add(3*time.Second)) || st.LastError != "" {
+		t.Errorf("flaky: %+v", st)
+	}
+
+	// Tekrar hakkı biten iş failed olur
+	s.Submit(context.Background(), Job{ID: "exhausted", MaxRetries: 1, Backoff: backoff, Run: fail(10)})
+	waitState(t, s, "exhausted", Retrying)
+	clock.Advance(time.Second)
+	if st := waitState(t, s, "exhausted", Failed); st.Attempts != 2 {
+		t.Errorf("exhausted: %+v", st)
+	}
+
+	// Permanent hata MaxRetries'a bakılmadan failed olur
+	s.Submit(context.Background(), Job{ID: "permanent", MaxRetries: 5, Run: func(context.Context) error {
+		return Permanent(errors.New("geçersiz girdi"))
+	}})
+	if st := waitState(t, s, "permanent", Failed); st.Attempts != 1 || st.LastError != "geçersiz girdi" {
+		t.Errorf("permanent: %+v", st)
+	}
+
+	// Backoff beklerken iptal edilen iş zamanlayıcısını durdurur, tekrar kuyruğa girmez
+	s.Submit(context.Background(), Job{ID: "canceled", MaxRetries: 3, Backoff: backoff, Run: fail(10)})
+	waitState(t, s, "canceled", Retrying)
+	s.Cancel("canceled")
+	waitState(t, s, "canceled", Canceled)
+	clock.Advance(time.Minute)
+	s.Close()
+	if st, _ := s.Status("canceled"); st.State != Canceled || st.Attempts != 1 {
+		t.Errorf("canceled: %+v", st)
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+	for attempt, want := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second, // Max
+		10: time.Second,
+	} {
+		if got := b.Delay(attempt); got != want {
+			t.Errorf("Delay(%d) = %v, beklenen %v", attempt, got, want)
+		}
+	}
+	if got := (Backoff{}).Delay(1); got > DefaultBackoff.Initial {
+		t.Errorf("boş Backoff: Delay(1) = %v", got)
+	}
+
+	// Jitter süreyi en fazla o oranda kısaltır, hiç uzatmaz
+	b.Jitter = 0.5
+	for range 100 {
+		if d := b.Delay(3); d < 200*time.Millisecond || d > 400*time.Millisecond {
+			t.Fatalf("jitter'lı Delay(3) = %v", d)
+		}
+	}
+}
+/*
+
+📌 Çıktı:
+
+
+$ go test -race -v ./sched/
+=== RUN   TestPriorityOrder
+--- PASS: TestPriorityOrder (0.00s)
+=== RUN   TestCancel
+--- PASS: TestCancel (0.00s)
+=== RUN   TestDeadline
+--- PASS: TestDeadline (0.00s)
+=== RUN   TestRetryBackoff
+--- PASS: TestRetryBackoff (0.01s)
+=== RUN   TestBackoffDelay
+--- PASS: TestBackoffDelay (0.00s)
+PASS
+ok  	jobdemo/sched	1.043s
+
+---
+
+## 🔎 Context Nasıl Akıyor?
+
+context.Background()
+ └── root        (signal.NotifyContext → Ctrl-C)          → New(root, 3): iptal olursa tüm işler durur
+      └── batch  (WithValue "batchID")                      → Submit(batch, ...)
+           ├── iş ctx   (WithCancelCause [+ WithDeadline])  → Cancel(id) / Job.Deadline
+           │    └── deneme ctx (WithTimeout)                → Job.Timeout, her denemede yeniden
+           └── reports (WithCancel)                         → cancelReports(): tüm rapor işleri
+                └── iş ctx ...
+
+1. **`Submit(ctx, job)`** → İşin context'i çağıranın context'inden türetilir. Parent iptal olunca iş de iptal olur, parent'taki değerler (`ctx.Value`) işe ulaşır. Ayrı bir "grup iptal" API'sine gerek kalmaz: aynı parent ile gönderilen işler bir **grup**tur.
+2. **Timeout ile Deadline farkı** → `Timeout` sadece o **denemeyi** keser; iş hâlâ tekrar denenebilir. `Deadline` ise işin context'ine bağlıdır; dolunca iş `expired` olur ve tekrar denenmez.
+3. **`afterAttempt`** → Deneme hatasının sebebine bakmak için `err`'e değil **işin context'ine** (`e.ctx.Err()`) bakılır. Deneme timeout'u geçici hatadır, iş context'inin iptali değildir.
+4. **Kuyruktayken iptal** → `context.AfterFunc`, iş context'i iptal olunca işi kuyruktan (`heap.Remove`) ya da backoff bekleyişinden (`e.retry()` zamanlayıcıyı durdurur) çıkarır. İptal edilen iş, sırası gelmesini beklemez.
+5. **Backoff + jitter** → Aynı anda düşen işler (ör: veritabanı yeniden başladı) aynı anda tekrar denenmesin diye süre rastgele kırpılır. Bir sonraki deneme deadline'dan sonraya düşüyorsa beklemeden `expired` olur.
+6. **`sched.Permanent(err)`** → Tekrar denemenin anlamı olmayan hatalar (geçersiz girdi gibi) işaretlenir ve `MaxRetries`'a bakılmadan `failed` olur.
+7. **Panic** → `attempt` içindeki `recover`, işin panic'ini hataya çevirir; worker ölmez.
+
+⚠️ Zamanlayıcı bir işi **zorla** durduramaz: `Run` fonksiyonu `ctx.Done()`'ı dinlemelidir (yukarıdaki `doWork` gibi). Dinlemeyen bir iş, timeout'tan sonra da worker'ı meşgul eder.
+
+---
+
+## 📄 `main.go`
+*/
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"jobdemo/sched"
+)
+
+type ctxKey string
+
+// work, süresi kadar çalışan ama context iptal edilince hemen duran bir iştir
+// (context_uygulama.go'daki doWork'ün iş hâli).
+func work(d time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		select {
+		case <-time.After(d):
+			log.Printf("bitti: %v (batch %v)", d, ctx.Value(ctxKey("batchID")))
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// flaky, ilk n denemesi geçici hata veren bir iştir.
+func flaky(n int32) func(ctx context.Context) error {
+	var calls atomic.Int32
+	return func(ctx context.Context) error {
+		if calls.Add(1) <= n {
+			return errors.New("geçici hata: bağlantı reddedildi")
+		}
+		return work(50 * time.Millisecond)(ctx)
+	}
+}
+
+func main() {
+	// Ctrl-C: tüm işler iptal edilir
+	root, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	s := sched.New(root, 3)
+
+	// Durum API'si: curl localhost:8080/jobs?group=rapor
+	http.Handle("/jobs", s)
+	go http.ListenAndServe("127.0.0.1:8080", nil)
+
+	// Batch context'i: değer işe taşınır
+	batch := context.WithValue(root, ctxKey("batchID"), "B-42")
+
+	backoff := sched.Backoff{Initial: 50 * time.Millisecond, Max: time.Second, Multiplier: 2, Jitter: 0.2}
+	jobs := []sched.Job{
+		{ID: "yedek", Priority: 2, Run: work(300 * time.Millisecond)},
+		{ID: "guvenlik-yamasi", Priority: 1, Run: work(100 * time.Millisecond)},
+		{ID: "log-temizle", Priority: 5, Run: work(100 * time.Millisecond)},
+		{ID: "odeme-senk", Priority: 3, MaxRetries: 3, Backoff: backoff, Run: flaky(2)},
+		{ID: "yavas-api", Priority: 3, Timeout: 100 * time.Millisecond, MaxRetries: 1, Backoff: backoff, Run: work(time.Second)},
+		{ID: "gecersiz-girdi", Priority: 4, MaxRetries: 5, Run: func(context.Context) error {
+			return sched.Permanent(errors.New("CSV başlığı eksik")) // tekrar denemenin anlamı yok
+		}},
+		{ID: "son-tarih", Priority: 9, Deadline: time.Now().Add(200 * time.Millisecond), Run: work(100 * time.Millisecond)},
+	}
+	for _, j := range jobs {
+		if _, err := s.Submit(batch, j); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// Rapor grubu: kendi parent context'i ile; cancel hepsini birden durdurur
+	reports, cancelReports := context.WithCancel(batch)
+	for i := range 4 {
+		s.Submit(reports, sched.Job{
+			ID:       fmt.Sprintf("rapor-%d", i+1),
+			Group:    "rapor",
+			Priority: 6,
+			Run:      work(time.Duration(200+rand.IntN(200)) * time.Millisecond),
+		})
+	}
+	time.AfterFunc(450*time.Millisecond, func() {
+		log.Println("rapor grubu iptal ediliyor")
+		cancelReports()
+	})
+
+	s.Close() // hepsi bitene kadar bekle
+	printStatus(s.List(""))
+}
+
+func printStatus(list []sched.Status) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "İŞ\tÖNCELİK\tDURUM\tDENEME\tSÜRE\tSON HATA")
+	for _, st := range list {
+		var took time.Duration
+		if !st.Started.IsZero() {
+			took = st.Finished.Sub(st.Started).Round(time.Millisecond)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%d\t%v\t%s\n", st.ID, st.Priority, st.State, st.Attempts, took, st.LastError)
+	}
+	tw.Flush()
+}
+/*
+
+📌 Çıktı:
+
+
+2026/10/18 19:59:12 bitti: 100ms (batch B-42)
+2026/10/18 19:59:13 bitti: 100ms (batch B-42)
+2026/10/18 19:59:13 bitti: 50ms (batch B-42)
+2026/10/18 19:59:13 bitti: 300ms (batch B-42)
+2026/10/18 19:59:13 rapor grubu iptal ediliyor
+İŞ               ÖNCELİK  DURUM      DENEME  SÜRE   SON HATA
+yedek            2        succeeded  1       301ms
+guvenlik-yamasi  1        succeeded  1       101ms
+log-temizle      5        succeeded  1       101ms
+odeme-senk       3        succeeded  3       253ms
+yavas-api        3        failed     2       353ms  context deadline exceeded
+gecersiz-girdi   4        failed     1       0s     CSV başlığı eksik
+son-tarih        9        expired    0       0s     context deadline exceeded
+rapor-1          6        canceled   1       349ms  context canceled
+rapor-2          6        canceled   1       149ms  context canceled
+rapor-3          6        canceled   1       97ms   context canceled
+rapor-4          6        canceled   0       0s     context canceled
+
+
+Çıktıdan okunanlar:
+
+* `guvenlik-yamasi` (öncelik 1) ilk sırada, `log-temizle` (öncelik 5) sonra çalıştı
+* `odeme-senk` iki geçici hatadan sonra **3. denemede** başarılı oldu
+* `yavas-api` her denemede 100 ms timeout'a takıldı; 1 tekrar hakkı bitince `failed`
+* `gecersiz-girdi` `Permanent` hata döndüğü için `MaxRetries: 5` olsa da **bir kez** denendi
+* `son-tarih` (öncelik 9) sırası gelmeden deadline'ı doldu, **hiç çalışmadan** `expired` oldu
+* `cancelReports()` çalışan rapor işlerini durdurdu, kuyrukta bekleyen `rapor-4`'ü ise hiç başlatmadı
+
+Program çalışırken durum API'si:
+
+curl 'localhost:8080/jobs?group=rapor'
+curl 'localhost:8080/jobs?id=odeme-senk'
+curl -X DELETE 'localhost:8080/jobs?id=yedek'     # işi iptal et
+
+
+[{"id":"rapor-1","group":"rapor","priority":6,"state":"running","attempts":1,"submitted":"...","started":"..."}, ...]
+
+
+---
+
+Böylece `context`'in iptal, timeout, deadline ve değer taşıma özelliklerini **öncelikli bir iş kuyruğu** ile birleştirmiş olduk 🚀
+*/
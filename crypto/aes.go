@@ -684,4 +684,34 @@ go build -o aesc aesc.go  # (dosya adın farklıysa uyarlayabilirsin)
 * **parolayı dosyadan/çevre değişkeninden** alma,
 * **argon2id** kullanacak şekilde
   geliştirebilirim. Hangisini istersin?
+EVET
+*/
+
+/*
+Üçünden ikisi (parolayı çevre değişkeninden almak ve KDF'i değiştirilebilir yapmak) `fips140.go`'nun sonundaki **`cryptopolicy`** projesinde **`filecrypt`** aracı olarak yazıldı. Orada tek bir araçla kalmayıp TLS sunucu/istemci örnekleriyle aynı **kripto politikası** paylaşılıyor.
+
+Önce `aesc` hakkında bilinmesi gereken bir şey: **scrypt FIPS 140-3'te onaylı bir KDF değildir.** FIPS gereken bir ortamda `aesc` ile şifrelenmiş dosyalar kullanılamaz. Argon2id de onaylı değil. Onaylı parola tabanlı KDF **PBKDF2**'dir ve Go 1.24'ten beri standart kütüphanede (`crypto/pbkdf2`).
+
+`filecrypt`'in `aesc`'den farkları:
+
+* KDF ve AEAD **profilden** seçilir (`-profile modern|fips|legacy`). `fips` profilinde yalnızca PBKDF2 ve AES-GCM'e izin var.
+* Kullanılan algoritmaların **adı ve parametreleri dosya başlığına yazılır**: `PCRY | sürüm | kdf | iterasyon | salt | aead | nonce`. `aesc`'nin başlığında (`AESG | sürüm | salt | nonce`) bunlar yok; scrypt'in N, r, p değerleri kodda sabit ve değiştirildikleri gün eski dosyalar açılamaz.
+* Başlığın tamamı GCM'e **ek veri (AAD)** olarak verilir; `aesc` AAD olarak `nil` veriyordu. İterasyon sayısını düşürmek gibi bir değişiklik çözmeyi bozar.
+* Çözerken başlıktaki algoritmalar da profile göre kontrol edilir. `fips` profiliyle çalışan bir makine, profil dışı bir algoritmayla şifrelenmiş dosyayı açmaz.
+* Parola `FILECRYPT_PASSWORD` çevre değişkeninden ya da stdin'in ilk satırından okunur; pipe ile kullanılabilir.
+
+*/
+``bash
+$ export GODEBUG=fips140=on
+$ FILECRYPT_PASSWORD=parola123 go run ./examples/filecrypt -profile fips encrypt secret.txt
+secret.txt.enc: pbkdf2-sha256 (i=600000) + AES-256-GCM
+$ echo parola123 | go run ./examples/filecrypt -profile fips decrypt secret.txt.enc out.txt
+Parola:
+``
+/*
+`aesc` ile şifrelenmiş dosyalar `filecrypt` ile açılamaz; biçimler farklı. Taşımak için dosya `aesc decrypt` ile çözülüp `filecrypt encrypt` ile yeniden şifrelenmelidir.
+
+Streaming (STDIN/STDOUT) desteği iki araçta da yok: GCM tek parça şifrelediği için dosyanın tamamı bellekte tutuluyor. Büyük dosyalar için veriyi sabit boyutlu parçalara bölüp her parçayı ayrı nonce ile şifrelemek gerekir.
+
+👉 İstersen bir sonraki adımda `filecrypt`'e bu **parçalı (chunked) şifrelemeyi** ekleyebilirim: her parça kendi sıra numarasıyla doğrulanır, böylece parçaların yeri değiştirilemez ve dosya sonundan kesilemez. Bunu ister misin?
 */
\ No newline at end of file
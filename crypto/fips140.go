@@ -267,4 +267,2447 @@ Hangisini istersin — mTLS mi, Docker + GOFIPS140 CI örneği mi, yoksa `fips14
 [6]: https://csrc.nist.gov/csrc/media/Projects/cryptographic-module-validation-program/documents/fips%20140-3/FIPS%20140-3%20IG.pdf?utm_source=chatgpt.com "Implementation Guidance for FIPS 140-3"
 [7]: https://medium.com/%40moeghifar/go-1-24-the-game-changing-security-and-cryptography-release-6ee495742be6?utm_source=chatgpt.com "Go 1.24: The Game-Changing Security and Cryptography ..."
 [8]: https://github.com/quic-go/quic-go/issues/4894?utm_source=chatgpt.com "FIPS Compliance Issues with Go 1.24 #4894"
+EVET
+*/
+
+/*
+Bu üç seçeneğe de yarayacak bir adım atalım. Yukarıdaki `fips_tls_server.go`'nun asıl sorunu şu: **politika kodun içine dağılmış durumda.**
+
+* `MinVersion`, `CipherSuites` ve `CurvePreferences` sunucunun içinde elle yazılı. İstemcide, `tls.go`'daki örneklerde ve `tls-Go-tls-full-project`'te her biri ayrıca ve farklı şekilde yazılı.
+* Anahtar türü (`ecdsa.GenerateKey(elliptic.P256(), ...)`) de sabit kodlu. Diskten okunan bir sertifikanın anahtarı ise hiç kontrol edilmiyor: RSA 1024 bir sertifika da sorunsuz yükleniyor.
+* `aes.go`'daki `aesc` aracı anahtarı **scrypt** ile türetiyor. scrypt FIPS 140-3'te onaylı değil, ama bunu söyleyen hiçbir şey yok.
+* `fips140.Enabled()` yalnızca ekrana yazdırılıyor. `fips140=on` olmadan çalışan "FIPS sunucusu" da sessizce başlıyor.
+
+Çözüm, bütün bu kararları **tek bir pakette** toplamak. Sunucu, istemci ve dosya şifreleme araçları hepsini oradan alır:
+
+* **Profil (`Profile`)**: izin verilen TLS sürümleri, cipher suite'ler, eğriler, anahtar türleri ve boyutları, hash fonksiyonları, AEAD'ler ve KDF'ler. Üç hazır profil var: `modern`, `fips`, `legacy`.
+* **Uygulama (`Apply`)**: profili bir `tls.Config`'e yazar. TLS 1.3 suite'leri `crypto/tls`'te ayarlanamadığı için onları el sıkışmadan sonra `VerifyConnection` içinde kontrol eder.
+* **Anahtar üretimi (`GenerateKey`)**: yalnızca profilin izin verdiği türde anahtar üretir.
+* **Denetim (`AuditPEM`, `cryptopolicy audit`)**: diskteki PEM anahtar ve sertifikaları tarar ve ihlalleri listeler. CI'da çalıştırılabilir.
+
+Paketin standart kütüphane dışında bağımlılığı yok.
+
+---
+
+# 🔹 Profiller
+
+| | `modern` | `fips` | `legacy` |
+|---|---|---|---|
+| TLS sürümü | yalnızca 1.3 | 1.2 – 1.3 | 1.0 – 1.3 |
+| TLS ≤ 1.2 suite'leri | — | ECDHE + AES-GCM (4 suite) | ECDHE + AES-GCM, ChaCha20, AES-CBC-SHA |
+| TLS 1.3 suite'leri | üçü de | yalnızca AES-GCM | üçü de |
+| Eğriler | X25519MLKEM768, X25519, P-256, P-384 | X25519MLKEM768, P-256, P-384, P-521 | beşi de |
+| RSA | ≥ 2048 | ≥ 2048 | ≥ 2048 |
+| ECDSA | P-256, P-384 | P-256, P-384, P-521 | P-256, P-384, P-521 |
+| Ed25519 | ✅ | ✅ | ✅ |
+| Varsayılan anahtar | ecdsa-p256 | ecdsa-p256 | rsa-2048 |
+| Hash | SHA-256 ve üstü, SHA-3 | SHA-224 ve üstü, SHA-3 | SHA-1 dahil |
+| AEAD | AES-GCM, ChaCha20-Poly1305 | yalnızca AES-GCM | AES-GCM, ChaCha20-Poly1305 |
+| KDF | argon2id, scrypt, pbkdf2, hkdf | pbkdf2-sha256/512, hkdf | scrypt, argon2id, pbkdf2 (SHA-1 dahil), hkdf |
+| FIPS modu gerekir | hayır | **evet** | hayır |
+
+* **`fips`**, SP 800-52r2 ve SP 800-131A'nın izin verdiği kümeyi izler. X25519 tek başına onaylı değildir, ama **X25519MLKEM768** hibrit grubu (ML-KEM, FIPS 203) Go'nun FIPS modunda da kullanılabilir.
+* **`legacy`**, eski istemcilerle konuşmak zorunda kalınan durumlar içindir. TLS 1.0 ve SHA-1 açıktır, ama 2048 bitten küçük RSA anahtarına burada da izin yok.
+* `-profile` verilmezse ve program FIPS modunda çalışıyorsa (`GODEBUG=fips140=on`) `fips` seçilir, değilse `modern`.
+
+---
+
+# 📂 Dizin yapısı
+
+```
+cryptopolicy/
+├── go.mod
+├── policy/
+│   ├── profile.go        // Profile, modern/fips/legacy, Lookup, CheckRuntime
+│   ├── violation.go      // Violation, Violations
+│   ├── keys.go           // KeySpec, GenerateKey, CheckPublicKey, CheckCertificate
+│   ├── tlsconfig.go      // Apply, Check, ServerConfig, ClientConfig
+│   ├── audit.go          // AuditPEM, AuditFiles
+│   └── policy_test.go
+├── cmd/cryptopolicy/main.go   // CLI: show, audit, genkey, probe
+└── examples/
+    ├── server/main.go    // fips_tls_server.go'nun profil kullanan hâli
+    ├── client/main.go
+    └── filecrypt/main.go // aesc'nin profilden KDF/AEAD seçen hâli
+```
+
+---
+
+## 📌 `go.mod`
+*/
+``go
+module cryptopolicy
+
+go 1.24.0
+``
+/*
+---
+
+## 📌 `policy/profile.go`
+
+Profiller paket düzeyinde `var` olarak tanımlı. Listeler tercih sırasındadır. Örneğin `filecrypt`, `KDFs` listesinde uygulayabildiği ilk algoritmayı seçer.
+
+`CheckRuntime`, `fips` profilinin FIPS modu kapalıyken kullanılmasını yakalar. Bu durumda algoritmalar doğru olsa bile doğrulanmış modülden gelmez.
+*/
+``go
+// Package policy, TLS sunucu/istemci örneklerinin ve dosya şifreleme
+// araçlarının paylaştığı kriptografi politikasıdır. Bir Profile; izin
+// verilen TLS sürümlerini, cipher suite'leri, eğrileri, anahtar türlerini
+// ve boyutlarını, hash fonksiyonlarını, AEAD'leri ve KDF'leri listeler.
+// Profil tls.Config'e uygulanır (Apply), anahtar üretimini sınırlar
+// (GenerateKey) ve diskteki PEM anahtar ve sertifikaları denetler (AuditPEM).
+package policy
+
+import (
+	"crypto"
+	"crypto/fips140"
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Profile, tek bir politikanın tamamıdır. Listeler tercih sırasındadır;
+// ilk eleman varsayılandır (ör. dosya şifreleme aracı AEADs[0] ve
+// KDFs[0]'ı kullanır).
+type Profile struct {
+	Name string
+
+	MinVersion, MaxVersion uint16
+
+	// CipherSuites, TLS 1.0–1.2 suite'leridir ve tls.Config.CipherSuites'e
+	// yazılır. TLS 1.3 suite'leri crypto/tls'te ayarlanamaz; CipherSuitesTLS13
+	// yalnızca el sıkışmadan sonra VerifyConnection içinde kontrol edilir.
+	CipherSuites      []uint16
+	CipherSuitesTLS13 []uint16
+
+	// Curves, anahtar değişimi gruplarıdır (tls.Config.CurvePreferences).
+	Curves []tls.CurveID
+
+	// MinRSABits 0 ise RSA anahtarlarına hiç izin verilmez.
+	MinRSABits  int
+	ECDSACurves []string // "P-256", "P-384", "P-521"
+	Ed25519     bool
+
+	// KeyGen, GenerateKey("") çağrısında üretilen anahtar türüdür.
+	KeyGen string
+
+	// Hashes, imza ve HMAC için izin verilen hash'lerdir. Sertifika
+	// imzalarının hash'i de buradan kontrol edilir.
+	Hashes []crypto.Hash
+	AEADs  []string
+	KDFs   []string
+
+	// FIPSModule, profilin yalnızca Go Cryptographic Module FIPS 140-3
+	// modunda (GODEBUG=fips140=on) anlamlı olduğunu belirtir; bkz. CheckRuntime.
+	FIPSModule bool
+}
+
+// AEAD adları.
+const (
+	AES128GCM        = "AES-128-GCM"
+	AES256GCM        = "AES-256-GCM"
+	ChaCha20Poly1305 = "ChaCha20-Poly1305"
+)
+
+// KDF adları. pbkdf2 ve hkdf adları credstore'daki PHC kimlikleriyle aynıdır.
+const (
+	PBKDF2SHA1   = "pbkdf2-sha1"
+	PBKDF2SHA256 = "pbkdf2-sha256"
+	PBKDF2SHA512 = "pbkdf2-sha512"
+	HKDFSHA256   = "hkdf-sha256"
+	HKDFSHA384   = "hkdf-sha384"
+	Scrypt       = "scrypt"
+	Argon2id     = "argon2id"
+)
+
+var (
+	// Modern yalnızca TLS 1.3 konuşur. Mozilla'nın "modern" yapılandırmasına
+	// karşılık gelir; 2018'den eski istemciler bağlanamaz.
+	Modern = &Profile{
+		Name:       "modern",
+		MinVersion: tls.VersionTLS13,
+		MaxVersion: tls.VersionTLS13,
+		CipherSuitesTLS13: []uint16{
+			tls.TLS_AES_128_GCM_SHA256,
+			tls.TLS_AES_256_GCM_SHA384,
+			tls.TLS_CHACHA20_POLY1305_SHA256,
+		},
+		Curves:      []tls.CurveID{tls.X25519MLKEM768, tls.X25519, tls.CurveP256, tls.CurveP384},
+		MinRSABits:  2048,
+		ECDSACurves: []string{"P-256", "P-384"},
+		Ed25519:     true,
+		KeyGen:      "ecdsa-p256",
+		Hashes: []crypto.Hash{
+			crypto.SHA256, crypto.SHA384, crypto.SHA512,
+			crypto.SHA3_256, crypto.SHA3_384, crypto.SHA3_512,
+		},
+		AEADs: []string{AES256GCM, AES128GCM, ChaCha20Poly1305},
+		KDFs:  []string{Argon2id, Scrypt, PBKDF2SHA256, PBKDF2SHA512, HKDFSHA256, HKDFSHA384},
+	}
+
+	// FIPS, Go Cryptographic Module'ün FIPS 140-3 modunda kabul ettiklerinin
+	// alt kümesidir: TLS 1.2–1.3, yalnızca ECDHE + AES-GCM, NIST eğrileri ve
+	// X25519MLKEM768, ChaCha20 yok, scrypt/argon2id yok (SP 800-132 yalnızca
+	// PBKDF2'yi tanır).
+	FIPS = &Profile{
+		Name:       "fips",
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+		},
+		CipherSuitesTLS13: []uint16{
+			tls.TLS_AES_128_GCM_SHA256,
+			tls.TLS_AES_256_GCM_SHA384,
+		},
+		Curves:      []tls.CurveID{tls.X25519MLKEM768, tls.CurveP256, tls.CurveP384, tls.CurveP521},
+		MinRSABits:  2048,
+		ECDSACurves: []string{"P-256", "P-384", "P-521"},
+		Ed25519:     true,
+		KeyGen:      "ecdsa-p256",
+		Hashes: []crypto.Hash{
+			crypto.SHA224, crypto.SHA256, crypto.SHA384, crypto.SHA512,
+			crypto.SHA512_224, crypto.SHA512_256,
+			crypto.SHA3_224, crypto.SHA3_256, crypto.SHA3_384, crypto.SHA3_512,
+		},
+		AEADs:      []string{AES256GCM, AES128GCM},
+		KDFs:       []string{PBKDF2SHA256, PBKDF2SHA512, HKDFSHA256, HKDFSHA384},
+		FIPSModule: true,
+	}
+
+	// Legacy, TLS 1.0'a kadar inen eski istemciler içindir. RC4, 3DES ve
+	// RSA anahtar değişimi burada da yoktur; SHA-1 yalnızca mevcut
+	// sertifikaların denetimden geçmesi için listededir.
+	Legacy = &Profile{
+		Name:       "legacy",
+		MinVersion: tls.VersionTLS10,
+		MaxVersion: tls.VersionTLS13,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+			tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_ECDHE_RSA_WITH_AES_128_CBC_SHA,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_CBC_SHA,
+			tls.TLS_ECDHE_RSA_WITH_AES_256_CBC_SHA,
+		},
+		CipherSuitesTLS13: []uint16{
+			tls.TLS_AES_128_GCM_SHA256,
+			tls.TLS_AES_256_GCM_SHA384,
+			tls.TLS_CHACHA20_POLY1305_SHA256,
+		},
+		Curves:      []tls.CurveID{tls.X25519MLKEM768, tls.X25519, tls.CurveP256, tls.CurveP384, tls.CurveP521},
+		MinRSABits:  2048,
+		ECDSACurves: []string{"P-256", "P-384", "P-521"},
+		Ed25519:     true,
+		KeyGen:      "rsa-2048",
+		Hashes: []crypto.Hash{
+			crypto.SHA1, crypto.SHA224, crypto.SHA256, crypto.SHA384, crypto.SHA512,
+			crypto.SHA3_256, crypto.SHA3_384, crypto.SHA3_512,
+		},
+		AEADs: []string{AES256GCM, AES128GCM, ChaCha20Poly1305},
+		KDFs:  []string{Scrypt, Argon2id, PBKDF2SHA256, PBKDF2SHA512, PBKDF2SHA1, HKDFSHA256, HKDFSHA384},
+	}
+)
+
+// Profiles, Lookup'ın tanıdığı profillerdir.
+var Profiles = []*Profile{Modern, FIPS, Legacy}
+
+// Lookup, adı verilen profili döndürür. Boş ad Default()'tur.
+func Lookup(name string) (*Profile, error) {
+	if name == "" {
+		return Default(), nil
+	}
+	for _, p := range Profiles {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	names := make([]string, len(Profiles))
+	for i, p := range Profiles {
+		names[i] = p.Name
+	}
+	return nil, fmt.Errorf("policy: bilinmeyen profil %q (%s)", name, strings.Join(names, ", "))
+}
+
+// Default, program FIPS 140-3 modunda çalışıyorsa FIPS'i, değilse Modern'i
+// döndürür.
+func Default() *Profile {
+	if fips140.Enabled() {
+		return FIPS
+	}
+	return Modern
+}
+
+// CheckRuntime, FIPSModule profili FIPS modu kapalıyken kullanılıyorsa
+// hata döndürür. Profil yine uygulanır ama algoritmalar doğrulanmış modülden
+// değil, normal Go kodundan gelir; denetçi açısından bu "FIPS" değildir.
+func (p *Profile) CheckRuntime() error {
+	if p.FIPSModule && !fips140.Enabled() {
+		return &Violation{Profile: p.Name, Rule: RuleRuntime,
+			Detail: `FIPS 140-3 modu kapalı (GODEBUG="fips140=on" ile çalıştırın)`}
+	}
+	return nil
+}
+
+// AllowsVersion, TLS sürümünün [MinVersion, MaxVersion] aralığında olup
+// olmadığını söyler.
+func (p *Profile) AllowsVersion(v uint16) bool {
+	return v >= p.MinVersion && v <= p.MaxVersion
+}
+
+// AllowsCipherSuite, suite'in profilde olup olmadığını söyler. TLS 1.3
+// suite'leri ayrı listeden kontrol edilir.
+func (p *Profile) AllowsCipherSuite(id uint16) bool {
+	return slices.Contains(p.CipherSuites, id) || slices.Contains(p.CipherSuitesTLS13, id)
+}
+
+// AllowsCurve, anahtar değişimi grubunun profilde olup olmadığını söyler.
+func (p *Profile) AllowsCurve(id tls.CurveID) bool {
+	return slices.Contains(p.Curves, id)
+}
+
+// CheckHash, h profilde yoksa *Violation döndürür.
+func (p *Profile) CheckHash(h crypto.Hash) error {
+	if !slices.Contains(p.Hashes, h) {
+		return p.violation(RuleHash, "%s izinli değil", hashName(h))
+	}
+	return nil
+}
+
+// CheckAEAD, dosya şifreleme araçları içindir: name (ör. "AES-256-GCM")
+// profilde yoksa *Violation döndürür.
+func (p *Profile) CheckAEAD(name string) error {
+	if !slices.Contains(p.AEADs, name) {
+		return p.violation(RuleAEAD, "%s izinli değil (%s)", name, strings.Join(p.AEADs, ", "))
+	}
+	return nil
+}
+
+// CheckKDF, name (ör. "scrypt", "pbkdf2-sha256") profilde yoksa *Violation
+// döndürür.
+func (p *Profile) CheckKDF(name string) error {
+	if !slices.Contains(p.KDFs, name) {
+		return p.violation(RuleKDF, "%s izinli değil (%s)", name, strings.Join(p.KDFs, ", "))
+	}
+	return nil
+}
+
+func hashName(h crypto.Hash) string {
+	if h == 0 {
+		return "hash yok"
+	}
+	return h.String()
+}
+``
+/*
+---
+
+## 📌 `policy/violation.go`
+
+Her ihlal bir `*Violation`'dır: profil, kural (`key`, `cipher-suite`, `signature`...) ve açıklama. Birden çok ihlal `Violations` olarak döner. `Unwrap() []error` sayesinde `errors.As(err, &v)` ile tek bir ihlal de yakalanabilir; `VerifyConnection`'dan dönen hata `tls` paketinin hatalarının içine sarılmış olsa bile.
+*/
+``go
+package policy
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kural adları; Violation.Rule bunlardan biridir.
+const (
+	RuleRuntime     = "runtime"
+	RuleTLSVersion  = "tls-version"
+	RuleCipherSuite = "cipher-suite"
+	RuleCurve       = "curve"
+	RuleKey         = "key"
+	RuleHash        = "hash"
+	RuleSignature   = "signature"
+	RuleAEAD        = "aead"
+	RuleKDF         = "kdf"
+	RuleValidity    = "validity"
+	RuleVerify      = "verify"
+)
+
+// Violation, tek bir politika ihlalidir. Check* fonksiyonları ihlali
+// *Violation olarak döndürür; errors.As ile kurala ulaşılabilir.
+type Violation struct {
+	Profile string
+	Rule    string
+	Detail  string
+}
+
+func (v *Violation) Error() string {
+	return fmt.Sprintf("policy(%s): %s: %s", v.Profile, v.Rule, v.Detail)
+}
+
+func (p *Profile) violation(rule, format string, args ...any) *Violation {
+	return &Violation{Profile: p.Name, Rule: rule, Detail: fmt.Sprintf(format, args...)}
+}
+
+// Violations, bir denetimde bulunan bütün ihlallerdir.
+type Violations []*Violation
+
+func (vs Violations) Error() string {
+	msgs := make([]string, len(vs))
+	for i, v := range vs {
+		msgs[i] = v.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap, errors.As'in listedeki ilk *Violation'a ulaşmasını sağlar.
+func (vs Violations) Unwrap() []error {
+	errs := make([]error, len(vs))
+	for i, v := range vs {
+		errs[i] = v
+	}
+	return errs
+}
+
+// Err, ihlal yoksa nil, varsa vs'yi döndürür. Boş bir Violations'ı doğrudan
+// error olarak döndürmek nil olmayan bir hata üretirdi.
+func (vs Violations) Err() error {
+	if len(vs) == 0 {
+		return nil
+	}
+	return vs
+}
+
+// add, err bir *Violation ise listeye ekler; nil'i yok sayar.
+func (vs *Violations) add(err error) {
+	if v, ok := err.(*Violation); ok {
+		*vs = append(*vs, v)
+	}
+}
+``
+/*
+---
+
+## 📌 `policy/keys.go`
+
+Anahtar türleri `minica`'daki `-alg` değerleriyle aynı biçimde yazılır: `ecdsa-p256`, `ed25519`, `rsa-3072`... `GenerateKey` önce profili kontrol eder, sonra üretir.
+
+`CheckCertificate` sertifikanın anahtarını, imza algoritmasını ve geçerlilik süresini kontrol eder. **Kendinden imzalı kök sertifikaların imzası kontrol edilmez.** Kök, güven deposunda olduğu için güvenilirdir; imzası hiçbir şey kanıtlamaz.
+*/
+``go
+package policy
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// KeySpec, üretilecek bir anahtarın türüdür. Metin biçimi minica'nın -alg
+// bayrağıyla aynıdır: "ecdsa-p256", "ecdsa-p384", "ecdsa-p521", "ed25519",
+// "rsa-2048", "rsa-3072", "rsa-4096".
+type KeySpec struct {
+	Type  string // "rsa", "ecdsa", "ed25519"
+	Bits  int    // yalnızca RSA
+	Curve string // yalnızca ECDSA: "P-256", "P-384", "P-521"
+}
+
+// ParseKeySpec, "ecdsa-p256" gibi bir metni KeySpec'e çevirir.
+func ParseKeySpec(s string) (KeySpec, error) {
+	typ, arg, _ := strings.Cut(strings.ToLower(s), "-")
+	switch typ {
+	case "ed25519":
+		if arg == "" {
+			return KeySpec{Type: "ed25519"}, nil
+		}
+	case "ecdsa":
+		switch arg {
+		case "p256", "p384", "p521":
+			return KeySpec{Type: "ecdsa", Curve: "P-" + arg[1:]}, nil
+		}
+	case "rsa":
+		// 1024 gibi zayıf boyutlar burada değil, profilde reddedilir; böylece
+		// hata mesajı "bilinmiyor" değil "en az 2048" olur.
+		if bits, err := strconv.Atoi(arg); err == nil && bits >= 512 && bits <= 16384 && bits%8 == 0 {
+			return KeySpec{Type: "rsa", Bits: bits}, nil
+		}
+	}
+	return KeySpec{}, fmt.Errorf("policy: geçersiz anahtar türü %q (ör. ecdsa-p256, ed25519, rsa-3072)", s)
+}
+
+func (k KeySpec) String() string {
+	switch k.Type {
+	case "rsa":
+		return "rsa-" + strconv.Itoa(k.Bits)
+	case "ecdsa":
+		return "ecdsa-" + strings.ToLower(strings.ReplaceAll(k.Curve, "-", ""))
+	}
+	return k.Type
+}
+
+// CheckKeySpec, k profilde üretilebiliyorsa nil döndürür.
+func (p *Profile) CheckKeySpec(k KeySpec) error {
+	switch k.Type {
+	case "rsa":
+		return p.checkRSA(k.Bits)
+	case "ecdsa":
+		return p.checkECDSA(k.Curve)
+	case "ed25519":
+		return p.checkEd25519()
+	}
+	return p.violation(RuleKey, "bilinmeyen anahtar türü %q", k.Type)
+}
+
+func (p *Profile) checkRSA(bits int) error {
+	if p.MinRSABits == 0 {
+		return p.violation(RuleKey, "RSA izinli değil")
+	}
+	if bits < p.MinRSABits {
+		return p.violation(RuleKey, "RSA %d bit (en az %d)", bits, p.MinRSABits)
+	}
+	return nil
+}
+
+func (p *Profile) checkECDSA(curve string) error {
+	if !slices.Contains(p.ECDSACurves, curve) {
+		return p.violation(RuleKey, "ECDSA %s izinli değil (%s)", curve, strings.Join(p.ECDSACurves, ", "))
+	}
+	return nil
+}
+
+func (p *Profile) checkEd25519() error {
+	if !p.Ed25519 {
+		return p.violation(RuleKey, "Ed25519 izinli değil")
+	}
+	return nil
+}
+
+// GenerateKey, spec türünde bir imza anahtarı üretir. spec boşsa profilin
+// KeyGen'i kullanılır. Profilin izin vermediği bir tür için anahtar
+// üretilmez; hata *Violation'dır.
+func (p *Profile) GenerateKey(spec string) (crypto.Signer, error) {
+	if spec == "" {
+		spec = p.KeyGen
+	}
+	k, err := ParseKeySpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.CheckKeySpec(k); err != nil {
+		return nil, err
+	}
+	switch k.Type {
+	case "rsa":
+		return rsa.GenerateKey(rand.Reader, k.Bits)
+	case "ecdsa":
+		return ecdsa.GenerateKey(namedCurve(k.Curve), rand.Reader)
+	default: // ed25519
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	}
+}
+
+func namedCurve(name string) elliptic.Curve {
+	switch name {
+	case "P-384":
+		return elliptic.P384()
+	case "P-521":
+		return elliptic.P521()
+	}
+	return elliptic.P256()
+}
+
+// CheckPublicKey, açık anahtarın türünü ve boyutunu kontrol eder. Özel
+// anahtarlar için Public() sonucu verilmelidir.
+func (p *Profile) CheckPublicKey(pub any) error {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return p.checkRSA(k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return p.checkECDSA(k.Curve.Params().Name)
+	case ed25519.PublicKey:
+		return p.checkEd25519()
+	case *ecdh.PublicKey:
+		// Anahtar değişimi anahtarları (ecdh.go, mlkem.go örnekleri) Curves'e
+		// göre kontrol edilir.
+		var ids []tls.CurveID
+		switch k.Curve() {
+		case ecdh.X25519():
+			ids = []tls.CurveID{tls.X25519, tls.X25519MLKEM768}
+		case ecdh.P256():
+			ids = []tls.CurveID{tls.CurveP256}
+		case ecdh.P384():
+			ids = []tls.CurveID{tls.CurveP384}
+		case ecdh.P521():
+			ids = []tls.CurveID{tls.CurveP521}
+		}
+		if !slices.ContainsFunc(ids, p.AllowsCurve) {
+			return p.violation(RuleCurve, "ECDH %v izinli değil", k.Curve())
+		}
+		return nil
+	}
+	return p.violation(RuleKey, "desteklenmeyen anahtar türü %T", pub)
+}
+
+// signatureHash, sertifika imza algoritmasının kullandığı hash'tir.
+// Ed25519 kendi içinde SHA-512 kullanır ve ayrı bir hash seçilmez; 0 döner.
+func signatureHash(alg x509.SignatureAlgorithm) (crypto.Hash, bool) {
+	switch alg {
+	case x509.MD5WithRSA:
+		return crypto.MD5, true
+	case x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		return crypto.SHA1, true
+	case x509.SHA256WithRSA, x509.SHA256WithRSAPSS, x509.ECDSAWithSHA256, x509.DSAWithSHA256:
+		return crypto.SHA256, true
+	case x509.SHA384WithRSA, x509.SHA384WithRSAPSS, x509.ECDSAWithSHA384:
+		return crypto.SHA384, true
+	case x509.SHA512WithRSA, x509.SHA512WithRSAPSS, x509.ECDSAWithSHA512:
+		return crypto.SHA512, true
+	case x509.PureEd25519:
+		return 0, true
+	}
+	return 0, false
+}
+
+// CheckSignatureAlgorithm, sertifika ya da CRL imzasının algoritmasını
+// kontrol eder.
+func (p *Profile) CheckSignatureAlgorithm(alg x509.SignatureAlgorithm) error {
+	h, ok := signatureHash(alg)
+	switch {
+	case !ok:
+		return p.violation(RuleSignature, "%v desteklenmiyor", alg)
+	case alg == x509.PureEd25519:
+		if !p.Ed25519 {
+			return p.violation(RuleSignature, "Ed25519 izinli değil")
+		}
+	case !slices.Contains(p.Hashes, h):
+		return p.violation(RuleSignature, "%v: %s izinli değil", alg, hashName(h))
+	}
+	return nil
+}
+
+// CheckCertificate, sertifikanın açık anahtarını, imza algoritmasını ve
+// geçerlilik süresini now'a göre kontrol eder. Kendinden imzalı CA
+// sertifikalarının imzası kontrol edilmez: zincir doğrulaması kökün kendi
+// imzasına hiç bakmaz, SHA-1 ile imzalanmış eski bir kök bu yüzden zayıf
+// değildir.
+func (p *Profile) CheckCertificate(c *x509.Certificate, now time.Time) Violations {
+	var vs Violations
+	vs.add(p.CheckPublicKey(c.PublicKey))
+	selfSigned := c.IsCA && bytes.Equal(c.RawIssuer, c.RawSubject)
+	if !selfSigned {
+		vs.add(p.CheckSignatureAlgorithm(c.SignatureAlgorithm))
+	}
+	switch {
+	case now.After(c.NotAfter):
+		vs = append(vs, p.violation(RuleValidity, "süresi %s tarihinde doldu", c.NotAfter.Format(time.DateOnly)))
+	case now.Before(c.NotBefore):
+		vs = append(vs, p.violation(RuleValidity, "%s tarihinden önce geçerli değil", c.NotBefore.Format(time.DateOnly)))
+	}
+	return vs
+}
+``
+/*
+---
+
+## 📌 `policy/tlsconfig.go`
+
+`Apply` mevcut `VerifyConnection`'ı **silmez, sarar**. Önce profil kontrolü, sonra eski hook çalışır. Böylece `tls-Go-tls-full-project`'teki revocation kontrolü ile birlikte kullanılabilir.
+
+`Check` ise `tls.Config`'i değiştirmeden denetler. Elle yazılmış bir yapılandırmanın profile uyup uymadığını test etmek için kullanılır (aşağıdaki testte `fips_tls_server.go`'nun yapılandırması denetleniyor).
+*/
+``go
+package policy
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Apply, profili cfg'ye uygular: sürüm aralığını, TLS 1.2 suite'lerini ve
+// eğri tercihlerini profilinkilerle değiştirir ve cfg.VerifyConnection'ı
+// profil kontrolüyle sarar. cfg'de zaten bir VerifyConnection varsa (ör.
+// revocation.Checker) profil kontrolünden sonra o da çağrılır.
+//
+// cfg.Certificates'teki yerel sertifikalar da kontrol edilir; ihlal varsa
+// cfg yine de değiştirilmiş olarak kalır ve Violations döner. Sunucu
+// genellikle bu hatayla başlamayı reddetmelidir. GetCertificate ile verilen
+// sertifikalar burada görülmez; bkz. CheckTLSCertificate.
+func (p *Profile) Apply(cfg *tls.Config) error {
+	cfg.MinVersion = p.MinVersion
+	cfg.MaxVersion = p.MaxVersion
+	cfg.CipherSuites = slices.Clone(p.CipherSuites)
+	cfg.CurvePreferences = slices.Clone(p.Curves)
+
+	next := cfg.VerifyConnection
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if err := p.CheckConnectionState(cs); err != nil {
+			return err
+		}
+		if next != nil {
+			return next(cs)
+		}
+		return nil
+	}
+
+	var vs Violations
+	for _, c := range cfg.Certificates {
+		vs = append(vs, p.CheckTLSCertificate(c)...)
+	}
+	return vs.Err()
+}
+
+// CheckTLSCertificate, tls.Certificate'in yaprak sertifikasını ve zincirdeki
+// ara sertifikaları kontrol eder. Sertifikayı GetCertificate ile veren
+// sunucular (ör. OCSP stapling) bunu Apply'dan önce kendileri çağırmalıdır.
+func (p *Profile) CheckTLSCertificate(c tls.Certificate) Violations {
+	var vs Violations
+	for i, der := range c.Certificate {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			vs = append(vs, p.violation(RuleKey, "sertifika %d okunamadı: %v", i, err))
+			continue
+		}
+		vs = append(vs, p.CheckCertificate(cert, time.Now())...)
+	}
+	return vs
+}
+
+// CheckConnectionState, tamamlanmış bir el sıkışmayı kontrol eder: sürüm,
+// seçilen suite (TLS 1.3 dahil) ve karşı tarafın gönderdiği sertifikalar.
+// Apply bunu VerifyConnection olarak kurar; hata dönerse el sıkışma
+// başarısız olur.
+//
+// Eğri burada kontrol edilmez: CurvePreferences yalnızca profildeki grupları
+// önerdiği için karşı taraf başka bir grup seçemez.
+func (p *Profile) CheckConnectionState(cs tls.ConnectionState) error {
+	if !p.AllowsVersion(cs.Version) {
+		return p.violation(RuleTLSVersion, "%s izinli değil", tls.VersionName(cs.Version))
+	}
+	suites := p.CipherSuites
+	if cs.Version == tls.VersionTLS13 {
+		suites = p.CipherSuitesTLS13
+	}
+	if !slices.Contains(suites, cs.CipherSuite) {
+		return p.violation(RuleCipherSuite, "%s izinli değil", tls.CipherSuiteName(cs.CipherSuite))
+	}
+	var vs Violations
+	for _, c := range cs.PeerCertificates {
+		vs = append(vs, p.CheckCertificate(c, time.Now())...)
+	}
+	return vs.Err()
+}
+
+// Check, cfg'yi değiştirmeden denetler; elle yazılmış bir tls.Config'in
+// profile uyup uymadığını gösterir. Boş bırakılan CipherSuites ve
+// CurvePreferences, crypto/tls'in sürüme göre değişen varsayılanları
+// kullanıldığı için profili kısıtlayan alanlarda ihlal sayılır.
+func (p *Profile) Check(cfg *tls.Config) Violations {
+	var vs Violations
+
+	// Sıfır değerler crypto/tls'in varsayılanlarıdır: en az TLS 1.2, en çok 1.3.
+	minV, maxV := cfg.MinVersion, cfg.MaxVersion
+	if minV == 0 {
+		minV = tls.VersionTLS12
+	}
+	if maxV == 0 {
+		maxV = tls.VersionTLS13
+	}
+	if minV < p.MinVersion {
+		vs = append(vs, p.violation(RuleTLSVersion, "MinVersion %s (en az %s)", tls.VersionName(minV), tls.VersionName(p.MinVersion)))
+	}
+	if maxV > p.MaxVersion {
+		vs = append(vs, p.violation(RuleTLSVersion, "MaxVersion %s (en çok %s)", tls.VersionName(maxV), tls.VersionName(p.MaxVersion)))
+	}
+
+	// TLS 1.2 suite'leri yalnızca TLS 1.2 ve altı konuşulabiliyorsa önemlidir.
+	if minV <= tls.VersionTLS12 {
+		if cfg.CipherSuites == nil {
+			vs = append(vs, p.violation(RuleCipherSuite, "CipherSuites ayarlanmamış, crypto/tls varsayılanları kullanılıyor"))
+		}
+		var bad []string
+		for _, id := range cfg.CipherSuites {
+			if !slices.Contains(p.CipherSuites, id) {
+				bad = append(bad, tls.CipherSuiteName(id))
+			}
+		}
+		if bad != nil {
+			vs = append(vs, p.violation(RuleCipherSuite, "izinli olmayan suite'ler: %s", strings.Join(bad, ", ")))
+		}
+	}
+
+	if cfg.CurvePreferences == nil {
+		vs = append(vs, p.violation(RuleCurve, "CurvePreferences ayarlanmamış, crypto/tls varsayılanları kullanılıyor"))
+	}
+	var bad []string
+	for _, id := range cfg.CurvePreferences {
+		if !p.AllowsCurve(id) {
+			bad = append(bad, id.String())
+		}
+	}
+	if bad != nil {
+		vs = append(vs, p.violation(RuleCurve, "izinli olmayan eğriler: %s", strings.Join(bad, ", ")))
+	}
+
+	if cfg.InsecureSkipVerify && cfg.VerifyPeerCertificate == nil && cfg.VerifyConnection == nil {
+		vs = append(vs, p.violation(RuleVerify, "InsecureSkipVerify açık ve sertifika hiç doğrulanmıyor"))
+	}
+
+	for _, c := range cfg.Certificates {
+		vs = append(vs, p.CheckTLSCertificate(c)...)
+	}
+	return vs
+}
+
+// ServerConfig, profile uygun bir sunucu yapılandırması döndürür.
+func (p *Profile) ServerConfig(certs ...tls.Certificate) (*tls.Config, error) {
+	cfg := &tls.Config{Certificates: certs}
+	if err := p.Apply(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ClientConfig, roots'a güvenen ve profile uygun bir istemci yapılandırması
+// döndürür. roots nil ise sistem kökleri kullanılır.
+func (p *Profile) ClientConfig(roots *x509.CertPool) *tls.Config {
+	cfg := &tls.Config{RootCAs: roots}
+	p.Apply(cfg) // Certificates boş; Apply hata döndüremez
+	return cfg
+}
+``
+/*
+---
+
+## 📌 `policy/audit.go`
+
+PEM dosyalarındaki her blok ayrı bir `Finding` olur. Şifreli özel anahtarlar (`ENCRYPTED PRIVATE KEY`, `DEK-Info` başlıklı PEM) parola olmadan okunamadığı için **atlanır** ve `SKIP` olarak raporlanır. `minica` CRL'leri DER olarak yazdığı için PEM bloğu olmayan dosyalar DER sertifika ya da CRL olarak da denenir.
+*/
+``go
+package policy
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Finding, PEM dosyasındaki tek bir bloğun denetim sonucudur.
+type Finding struct {
+	File  string
+	Block int    // dosyadaki sıra, 1'den başlar
+	Type  string // PEM blok tipi: "CERTIFICATE", "PRIVATE KEY", ...
+	Desc  string // "CN=localhost ECDSA P-256" gibi kısa açıklama
+
+	Violations Violations
+	// Skipped, blok denetlenemediyse nedenidir (ör. parola ile şifrelenmiş
+	// anahtar). Atlanan bloklar ihlal sayılmaz ama raporda görünür.
+	Skipped string
+}
+
+// OK, blokta ihlal yoksa ve blok atlanmadıysa true döner.
+func (f *Finding) OK() bool { return len(f.Violations) == 0 && f.Skipped == "" }
+
+// AuditPEM, data'daki bütün PEM bloklarını denetler: sertifikalar, CSR'lar,
+// CRL'ler, özel ve açık anahtarlar. name yalnızca rapora yazılır.
+// Tanınmayan blok tipleri atlanmış olarak raporlanır.
+func (p *Profile) AuditPEM(name string, data []byte, now time.Time) []Finding {
+	var out []Finding
+	for i := 1; ; i++ {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		f := Finding{File: name, Block: i, Type: block.Type}
+		p.auditBlock(&f, block, now)
+		out = append(out, f)
+	}
+	return out
+}
+
+func (p *Profile) auditBlock(f *Finding, block *pem.Block, now time.Time) {
+	if _, ok := block.Headers["DEK-Info"]; ok {
+		f.Skipped = "RFC 1423 ile şifrelenmiş anahtar"
+		return
+	}
+	var (
+		pub any
+		err error
+	)
+	switch block.Type {
+	case "CERTIFICATE":
+		var c *x509.Certificate
+		if c, err = x509.ParseCertificate(block.Bytes); err == nil {
+			f.Desc = subject(c) + " " + describeKey(c.PublicKey) + " " + c.SignatureAlgorithm.String()
+			f.Violations = p.CheckCertificate(c, now)
+			return
+		}
+	case "CERTIFICATE REQUEST", "NEW CERTIFICATE REQUEST":
+		var csr *x509.CertificateRequest
+		if csr, err = x509.ParseCertificateRequest(block.Bytes); err == nil {
+			f.Desc = "CN=" + csr.Subject.CommonName + " " + describeKey(csr.PublicKey)
+			f.Violations.add(p.CheckPublicKey(csr.PublicKey))
+			f.Violations.add(p.CheckSignatureAlgorithm(csr.SignatureAlgorithm))
+			return
+		}
+	case "X509 CRL":
+		var crl *x509.RevocationList
+		if crl, err = x509.ParseRevocationList(block.Bytes); err == nil {
+			f.Desc = "issuer " + crl.Issuer.String() + " " + crl.SignatureAlgorithm.String()
+			f.Violations.add(p.CheckSignatureAlgorithm(crl.SignatureAlgorithm))
+			if !crl.NextUpdate.IsZero() && now.After(crl.NextUpdate) {
+				f.Violations = append(f.Violations, p.violation(RuleValidity, "NextUpdate %s geçti", crl.NextUpdate.Format(time.DateTime)))
+			}
+			return
+		}
+	case "ENCRYPTED PRIVATE KEY":
+		f.Skipped = "PKCS#8 ile şifrelenmiş anahtar"
+		return
+	case "PRIVATE KEY":
+		pub, err = publicOf(x509.ParsePKCS8PrivateKey(block.Bytes))
+	case "RSA PRIVATE KEY":
+		pub, err = publicOf(x509.ParsePKCS1PrivateKey(block.Bytes))
+	case "EC PRIVATE KEY":
+		pub, err = publicOf(x509.ParseECPrivateKey(block.Bytes))
+	case "PUBLIC KEY":
+		pub, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		pub, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "EC PARAMETERS":
+		// openssl ecparam -genkey anahtarın önüne bunu yazar; anahtar
+		// sonraki blokta denetlenir.
+		f.Skipped = "eğri parametreleri"
+		return
+	default:
+		f.Skipped = "bilinmeyen blok tipi"
+		return
+	}
+	if err != nil {
+		// Okunamayan bir blok, x509'un desteklemediği bir algoritma da
+		// olabilir (ör. DSA); her iki durumda da kullanılamaz.
+		f.Violations = append(f.Violations, p.violation(RuleKey, "okunamadı: %v", err))
+		return
+	}
+	f.Desc = describeKey(pub)
+	f.Violations.add(p.CheckPublicKey(pub))
+}
+
+// publicOf, Parse*PrivateKey fonksiyonlarının sonucunu açık anahtara
+// çevirir. PKCS#8, X25519 gibi imza atamayan *ecdh.PrivateKey de
+// döndürebilir.
+func publicOf(key any, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case crypto.Signer:
+		return k.Public(), nil
+	case *ecdh.PrivateKey:
+		return k.PublicKey(), nil
+	}
+	return nil, fmt.Errorf("%T açık anahtarı alınamıyor", key)
+}
+
+func subject(c *x509.Certificate) string {
+	if c.Subject.CommonName != "" {
+		return "CN=" + c.Subject.CommonName
+	}
+	return c.Subject.String()
+}
+
+// describeKey, anahtarı "RSA 2048", "ECDSA P-256" gibi kısaca yazar.
+func describeKey(pub any) string {
+	switch k := pub.(type) {
+	case *rsa.PublicKey:
+		return fmt.Sprintf("RSA %d", k.N.BitLen())
+	case *ecdsa.PublicKey:
+		return "ECDSA " + k.Curve.Params().Name
+	case ed25519.PublicKey:
+		return "Ed25519"
+	case *ecdh.PublicKey:
+		return fmt.Sprintf("ECDH %v", k.Curve())
+	}
+	return fmt.Sprintf("%T", pub)
+}
+
+// auditDER, PEM bloğu olmayan bir dosyayı DER sertifika ya da CRL olarak
+// dener; minica CRL'leri DER olarak yazar.
+func (p *Profile) auditDER(name string, data []byte, now time.Time) Finding {
+	f := Finding{File: name, Block: 1}
+	switch {
+	case isDER(x509.ParseCertificate(data)):
+		f.Type = "CERTIFICATE"
+	case isDER(x509.ParseRevocationList(data)):
+		f.Type = "X509 CRL"
+	default:
+		f.Skipped = "PEM bloğu yok"
+		return f
+	}
+	p.auditBlock(&f, &pem.Block{Type: f.Type, Bytes: data}, now)
+	return f
+}
+
+func isDER(_ any, err error) bool { return err == nil }
+
+// AuditFiles, paths'teki dosyaları denetler. Bir dizin verilirse içindeki
+// .pem, .crt, .cer, .key, .csr ve .crl dosyaları alt dizinlerle birlikte
+// taranır. Okunamayan dosya hata olarak döner; PEM ya da DER olarak
+// tanınmayan dosya atlanmış bir Finding üretir.
+func (p *Profile) AuditFiles(now time.Time, paths ...string) ([]Finding, error) {
+	var out []Finding
+	auditFile := func(path string) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		found := p.AuditPEM(path, data, now)
+		if len(found) == 0 {
+			found = []Finding{p.auditDER(path, data, now)}
+		}
+		out = append(out, found...)
+		return nil
+	}
+	for _, root := range paths {
+		info, err := os.Stat(root)
+		if err != nil {
+			return out, err
+		}
+		if !info.IsDir() {
+			if err := auditFile(root); err != nil {
+				return out, err
+			}
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".pem", ".crt", ".cer", ".key", ".csr", ".crl":
+				return auditFile(path)
+			}
+			return nil
+		})
+		if err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
+``
+/*
+---
+
+## 📌 `cmd/cryptopolicy/main.go`
+
+`audit` ve `probe` ihlal bulursa **1 ile çıkar**. CI'da adım olarak kullanmak için bu yeterli.
+*/
+``go
+// cryptopolicy, policy paketinin komut satırı arayüzüdür.
+//
+//	cryptopolicy show -profile fips                # profilin tamamı
+//	cryptopolicy audit -profile fips certs/ pki/   # PEM anahtar ve sertifikaları denetle
+//	cryptopolicy genkey -profile fips -alg ecdsa-p384 -out key.pem
+//	cryptopolicy probe -profile modern -ca certs/ca.pem localhost:8443
+//
+// -profile verilmezse program FIPS modundaysa "fips", değilse "modern"
+// kullanılır. audit ve probe, ihlal bulursa 1 ile çıkar; CI'da bu yeterli.
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"cryptopolicy/policy"
+)
+
+// errViolations, ihlal bulunduğunda döner; ayrıntılar zaten yazılmıştır.
+var errViolations = errors.New("ihlal bulundu")
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "kullanım: cryptopolicy show|audit|genkey|probe [bayraklar]")
+		os.Exit(2)
+	}
+	cmds := map[string]func([]string) error{
+		"show":   show,
+		"audit":  audit,
+		"genkey": genkey,
+		"probe":  probe,
+	}
+	cmd, ok := cmds[os.Args[1]]
+	if !ok {
+		log.Fatalf("bilinmeyen komut %q", os.Args[1])
+	}
+	err := cmd(os.Args[2:])
+	if errors.Is(err, errViolations) {
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse, ortak -profile bayrağını tanımlar, argümanları ayrıştırır ve
+// profili döndürür.
+func parse(fs *flag.FlagSet, args []string) (*policy.Profile, error) {
+	name := fs.String("profile", "", "modern, fips ya da legacy (varsayılan: FIPS modundaysa fips, değilse modern)")
+	fs.Parse(args)
+	return policy.Lookup(*name)
+}
+
+// warnRuntime, fips profiliyle anahtar üretilir ya da bağlantı kurulurken
+// FIPS modu kapalıysa uyarır. show ve audit yalnızca dosya ve tabloya
+// baktığı için uyarmaz.
+func warnRuntime(p *policy.Profile) {
+	if err := p.CheckRuntime(); err != nil {
+		log.Printf("uyarı: %v", err)
+	}
+}
+
+func show(args []string) error {
+	fs := flag.NewFlagSet("show", flag.ExitOnError)
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	row := func(k string, v any) { fmt.Fprintf(w, "%s\t%v\n", k, v) }
+	row("profile", p.Name)
+	row("tls", tls.VersionName(p.MinVersion)+" – "+tls.VersionName(p.MaxVersion))
+	if p.CipherSuites != nil {
+		row("suites (≤1.2)", suiteNames(p.CipherSuites))
+	}
+	row("suites (1.3)", suiteNames(p.CipherSuitesTLS13))
+	curves := make([]string, len(p.Curves))
+	for i, c := range p.Curves {
+		curves[i] = c.String()
+	}
+	row("curves", strings.Join(curves, ", "))
+	row("rsa", fmt.Sprintf("≥ %d bit", p.MinRSABits))
+	row("ecdsa", strings.Join(p.ECDSACurves, ", "))
+	row("ed25519", p.Ed25519)
+	row("keygen", p.KeyGen)
+	hashes := make([]string, len(p.Hashes))
+	for i, h := range p.Hashes {
+		hashes[i] = h.String()
+	}
+	row("hashes", strings.Join(hashes, ", "))
+	row("aead", strings.Join(p.AEADs, ", "))
+	row("kdf", strings.Join(p.KDFs, ", "))
+	row("fips module", p.FIPSModule)
+	return w.Flush()
+}
+
+func suiteNames(ids []uint16) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = tls.CipherSuiteName(id)
+	}
+	return strings.Join(names, "\n\t")
+}
+
+func audit(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	quiet := fs.Bool("q", false, "yalnızca ihlalleri yaz")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		return errors.New("audit: en az bir dosya ya da dizin gerekli")
+	}
+	findings, err := p.AuditFiles(time.Now(), fs.Args()...)
+	if err != nil {
+		return err
+	}
+	bad := 0
+	for _, f := range findings {
+		switch {
+		case len(f.Violations) > 0:
+			bad++
+			fmt.Printf("FAIL  %s#%d %s %s\n", f.File, f.Block, f.Type, f.Desc)
+			for _, v := range f.Violations {
+				fmt.Printf("      %s: %s\n", v.Rule, v.Detail)
+			}
+		case f.Skipped != "":
+			if !*quiet {
+				fmt.Printf("SKIP  %s#%d %s (%s)\n", f.File, f.Block, f.Type, f.Skipped)
+			}
+		default:
+			if !*quiet {
+				fmt.Printf("ok    %s#%d %s %s\n", f.File, f.Block, f.Type, f.Desc)
+			}
+		}
+	}
+	fmt.Printf("%d blok, %d ihlalli (profil %s)\n", len(findings), bad, p.Name)
+	if bad > 0 {
+		return errViolations
+	}
+	return nil
+}
+
+func genkey(args []string) error {
+	fs := flag.NewFlagSet("genkey", flag.ExitOnError)
+	alg := fs.String("alg", "", "ecdsa-p256, ecdsa-p384, ecdsa-p521, ed25519, rsa-2048, rsa-3072, rsa-4096 (varsayılan: profilin keygen'i)")
+	out := fs.String("out", "", "PKCS#8 PEM dosyası (varsayılan: stdout)")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	warnRuntime(p)
+	key, err := p.GenerateKey(*alg)
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if *out == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	// O_EXCL: var olan bir anahtarın üzerine yazmak, ona bağlı sertifikaları
+	// kullanılmaz hale getirirdi.
+	f, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// probe, bir TLS sunucusuna profilin istemci yapılandırmasıyla bağlanır.
+// Sunucu profile uymuyorsa el sıkışma başarısız olur ve neden yazılır.
+func probe(args []string) error {
+	fs := flag.NewFlagSet("probe", flag.ExitOnError)
+	caFile := fs.String("ca", "", "güvenilen CA (varsayılan: sistem kökleri)")
+	insecure := fs.Bool("k", false, "sertifika zincirini doğrulama (profil kontrolleri yine yapılır)")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return errors.New("probe: host:port gerekli")
+	}
+	addr := fs.Arg(0)
+	warnRuntime(p)
+
+	var roots *x509.CertPool
+	if *caFile != "" {
+		data, err := os.ReadFile(*caFile)
+		if err != nil {
+			return err
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(data) {
+			return fmt.Errorf("%s: sertifika yok", *caFile)
+		}
+	}
+	cfg := p.ClientConfig(roots)
+	cfg.InsecureSkipVerify = *insecure
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		cfg.ServerName = host
+	}
+
+	conn, err := tls.DialWithDialer(&net.Dialer{Timeout: 10 * time.Second}, "tcp", addr, cfg)
+	var (
+		vs policy.Violations
+		v  *policy.Violation
+	)
+	switch {
+	case errors.As(err, &vs):
+	case errors.As(err, &v):
+		vs = policy.Violations{v}
+	}
+	if vs != nil {
+		fmt.Printf("FAIL  %s\n", addr)
+		for _, v := range vs {
+			fmt.Printf("      %s: %s\n", v.Rule, v.Detail)
+		}
+		return errViolations
+	}
+	if err != nil {
+		// Profildeki hiçbir sürüm ya da suite sunucuda yoksa el sıkışma
+		// daha VerifyConnection'a gelmeden bir TLS alert'iyle biter.
+		fmt.Printf("FAIL  %s: %v\n", addr, err)
+		return errViolations
+	}
+	defer conn.Close()
+	cs := conn.ConnectionState()
+	fmt.Printf("ok    %s %s %s\n", addr, tls.VersionName(cs.Version), tls.CipherSuiteName(cs.CipherSuite))
+	for _, c := range cs.PeerCertificates {
+		fmt.Printf("      %s (%s, %s'e kadar)\n", c.Subject.CommonName, c.SignatureAlgorithm, c.NotAfter.Format(time.DateOnly))
+	}
+	return nil
+}
+``
+/*
+---
+
+## 📌 `examples/server/main.go`
+
+Yukarıdaki `fips_tls_server.go`'nun yerini alır. Elle yazılmış `tls.Config` yok. `fips` profili FIPS modu kapalıyken seçilirse sunucu **başlamaz**.
+*/
+``go
+// server, fips_tls_server.go'nun policy paketiyle yazılmış hâlidir. TLS
+// ayarları elle değil profilden gelir; sertifika anahtarı da profilin
+// izin verdiği türde üretilir ya da diskten okunup denetlenir.
+//
+//	GODEBUG=fips140=on go run ./examples/server -profile fips
+//	go run ./examples/server -profile modern -cert server.pem -key server.key
+package main
+
+import (
+	"crypto/fips140"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"time"
+
+	"cryptopolicy/policy"
+)
+
+func main() {
+	addr := flag.String("addr", ":8443", "dinlenecek adres")
+	profile := flag.String("profile", "", "modern, fips ya da legacy (varsayılan: FIPS modundaysa fips, değilse modern)")
+	certFile := flag.String("cert", "", "sertifika PEM (boşsa kendinden imzalı üretilir)")
+	keyFile := flag.String("key", "", "anahtar PEM")
+	flag.Parse()
+
+	p, err := policy.Lookup(*profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	// fips profili FIPS modu kapalıyken çalışır ama algoritmalar doğrulanmış
+	// modülden gelmez; bunu sessizce kabul etmek yerine başlamayı reddet.
+	if err := p.CheckRuntime(); err != nil {
+		log.Fatal(err)
+	}
+
+	var cert tls.Certificate
+	if *certFile != "" {
+		cert, err = tls.LoadX509KeyPair(*certFile, *keyFile)
+	} else {
+		cert, err = selfSigned(p)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// ServerConfig sürümleri, suite'leri ve eğrileri ayarlar ve sertifikayı
+	// denetler; ör. modern profilde RSA 1024 ya da P-521 sertifika reddedilir.
+	cfg, err := p.ServerConfig(cert)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	server := &http.Server{
+		Addr:      *addr,
+		TLSConfig: cfg,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, "hello — profile: %s, FIPS mode: %v, %s %s\n", p.Name, fips140.Enabled(),
+				tls.VersionName(r.TLS.Version), tls.CipherSuiteName(r.TLS.CipherSuite))
+		}),
+	}
+
+	log.Printf("Listening on https://localhost%s (profile %s, FIPS mode %v)", *addr, p.Name, fips140.Enabled())
+	log.Fatal(server.ListenAndServeTLS("", ""))
+}
+
+// selfSigned, profilin varsayılan anahtar türüyle kısa ömürlü, kendinden
+// imzalı bir localhost sertifikası üretir.
+func selfSigned(p *policy.Profile) (tls.Certificate, error) {
+	key, err := p.GenerateKey("")
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "localhost", Organization: []string{"Example Org"}},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:     []string{"localhost"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}, nil
+}
+``
+/*
+---
+
+## 📌 `examples/client/main.go`
+*/
+``go
+// client, policy profiliyle yapılandırılmış bir HTTPS istemcisidir. Sunucu
+// profile uymuyorsa (eski sürüm, izinsiz suite, zayıf sertifika anahtarı)
+// istek el sıkışmada başarısız olur.
+//
+//	go run ./examples/client -profile fips -k https://localhost:8443/
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+
+	"cryptopolicy/policy"
+)
+
+func main() {
+	profile := flag.String("profile", "", "modern, fips ya da legacy (varsayılan: FIPS modundaysa fips, değilse modern)")
+	caFile := flag.String("ca", "", "güvenilen CA (varsayılan: sistem kökleri)")
+	insecure := flag.Bool("k", false, "sertifika zincirini doğrulama (profil kontrolleri yine yapılır)")
+	flag.Parse()
+	url := "https://localhost:8443/"
+	if flag.NArg() > 0 {
+		url = flag.Arg(0)
+	}
+
+	p, err := policy.Lookup(*profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := p.CheckRuntime(); err != nil {
+		log.Print(err)
+	}
+
+	var roots *x509.CertPool
+	if *caFile != "" {
+		caPEM, err := os.ReadFile(*caFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(caPEM) {
+			log.Fatalf("%s: sertifika yok", *caFile)
+		}
+	}
+
+	// -k zincir doğrulamasını kapatır ama Apply'ın kurduğu VerifyConnection
+	// yine çalışır: kendinden imzalı bir sunucunun anahtarı da denetlenir.
+	cfg := p.ClientConfig(roots)
+	cfg.InsecureSkipVerify = *insecure
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	resp, err := client.Get(url)
+	if err != nil {
+		log.Fatalf("GET error: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	fmt.Printf("status: %s (%s, %s)\n", resp.Status, tls.VersionName(resp.TLS.Version), tls.CipherSuiteName(resp.TLS.CipherSuite))
+	fmt.Printf("body: %s", body)
+}
+``
+/*
+---
+
+## 📌 `examples/filecrypt/main.go`
+
+`aes.go`'daki `aesc`'nin profile uyan hâli. Kullanılan KDF ve AEAD'in adı dosya başlığına yazılır ve başlık GCM'e ek veri (AAD) olarak verilir. Böylece:
+
+* dosya, hangi algoritmayla şifrelendiğini kendisi söyler; profil değişince eski dosyalar yine açılır,
+* çözerken başlıktaki algoritmalar profile göre kontrol edilir,
+* başlıktaki bir baytı değiştirmek (ör. iterasyon sayısını düşürmek) kimlik doğrulamayı bozar.
+*/
+``go
+// filecrypt, aes.go'daki aesc aracının politikaya uyan sürümüdür. aesc
+// anahtarı scrypt ile türetir; scrypt FIPS 140-3'te onaylı değildir. Burada
+// KDF ve AEAD profilden seçilir ve adları dosya başlığına yazılır. Çözerken
+// başlıktaki algoritmalar da profile göre kontrol edilir; fips profiliyle
+// çalışan bir makine, profil dışı bir algoritmayla şifrelenmiş dosyayı açmaz.
+//
+//	FILECRYPT_PASSWORD=... filecrypt -profile fips encrypt secret.txt
+//	filecrypt -profile fips decrypt secret.txt.enc < parola.txt
+//
+// Yalnızca standart kütüphane kullanılır; bu yüzden desteklenen KDF'ler
+// pbkdf2-sha256 ve pbkdf2-sha512, AEAD'ler AES-256-GCM ve AES-128-GCM'dir.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"flag"
+	"fmt"
+	"hash"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"cryptopolicy/policy"
+)
+
+const (
+	magic     = "PCRY"
+	version   = 1
+	saltSize  = 16
+	nonceSize = 12
+)
+
+// kdfs ve aeads, bu aracın uyguladığı algoritmalardır. Profilin tercih
+// sırasındaki ilk uygulanabilir algoritma seçilir.
+var kdfs = map[string]struct {
+	h    func() hash.Hash
+	iter uint32 // OWASP 2023 değerleri
+}{
+	policy.PBKDF2SHA256: {sha256.New, 600_000},
+	policy.PBKDF2SHA512: {sha512.New, 210_000},
+}
+
+var aeads = map[string]int{ // anahtar uzunluğu
+	policy.AES256GCM: 32,
+	policy.AES128GCM: 16,
+}
+
+// header, dosyanın şifrelenmemiş başlığıdır. Başlığın baytları GCM'e ek
+// veri (AAD) olarak verilir; KDF ya da AEAD adını değiştirmek çözmeyi
+// bozar.
+type header struct {
+	kdf   string
+	iter  uint32
+	salt  []byte
+	aead  string
+	nonce []byte
+}
+
+func (h *header) marshal() []byte {
+	var b bytes.Buffer
+	b.WriteString(magic)
+	b.WriteByte(version)
+	b.WriteByte(byte(len(h.kdf)))
+	b.WriteString(h.kdf)
+	binary.Write(&b, binary.BigEndian, h.iter)
+	b.Write(h.salt)
+	b.WriteByte(byte(len(h.aead)))
+	b.WriteString(h.aead)
+	b.Write(h.nonce)
+	return b.Bytes()
+}
+
+// parseHeader, başlığı ve başlığın uzunluğunu döndürür.
+func parseHeader(data []byte) (*header, int, error) {
+	r := bytes.NewReader(data)
+	var short bool
+	next := func(n int) []byte {
+		b := make([]byte, n)
+		if _, err := io.ReadFull(r, b); err != nil {
+			short = true
+		}
+		return b
+	}
+	str := func() string {
+		n, _ := r.ReadByte()
+		return string(next(int(n)))
+	}
+	if string(next(len(magic))) != magic {
+		return nil, 0, errors.New("geçersiz dosya: magic uyuşmuyor")
+	}
+	if v := next(1)[0]; v != version {
+		return nil, 0, fmt.Errorf("desteklenmeyen sürüm: %d", v)
+	}
+	h := &header{kdf: str()}
+	h.iter = binary.BigEndian.Uint32(next(4))
+	h.salt = next(saltSize)
+	h.aead = str()
+	h.nonce = next(nonceSize)
+	if short || h.kdf == "" || h.aead == "" {
+		return nil, 0, errors.New("geçersiz dosya: kısa")
+	}
+	return h, len(data) - r.Len(), nil
+}
+
+// deriveAEAD, başlıktaki algoritmaları profile göre kontrol eder ve
+// paroladan AEAD'i kurar.
+func deriveAEAD(p *policy.Profile, h *header, password string) (cipher.AEAD, error) {
+	if err := p.CheckKDF(h.kdf); err != nil {
+		return nil, err
+	}
+	if err := p.CheckAEAD(h.aead); err != nil {
+		return nil, err
+	}
+	kdf, ok := kdfs[h.kdf]
+	if !ok {
+		return nil, fmt.Errorf("KDF %s desteklenmiyor", h.kdf)
+	}
+	keyLen, ok := aeads[h.aead]
+	if !ok {
+		return nil, fmt.Errorf("AEAD %s desteklenmiyor", h.aead)
+	}
+	// Başlıktaki iterasyon sayısı saldırganın elinde; çok küçük bir sayı
+	// zayıf bir anahtar, çok büyük bir sayı dakikalarca CPU demektir.
+	if h.iter < 100_000 || h.iter > 10_000_000 {
+		return nil, fmt.Errorf("iterasyon sayısı %d kabul edilmiyor", h.iter)
+	}
+	key, err := pbkdf2.Key(kdf.h, password, h.salt, int(h.iter), keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encrypt(p *policy.Profile, in, out, password string) error {
+	plain, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	h := &header{salt: make([]byte, saltSize), nonce: make([]byte, nonceSize)}
+	for _, name := range p.KDFs {
+		if k, ok := kdfs[name]; ok {
+			h.kdf, h.iter = name, k.iter
+			break
+		}
+	}
+	for _, name := range p.AEADs {
+		if _, ok := aeads[name]; ok {
+			h.aead = name
+			break
+		}
+	}
+	if h.kdf == "" || h.aead == "" {
+		return fmt.Errorf("%s profilinde bu aracın uyguladığı bir KDF ya da AEAD yok", p.Name)
+	}
+	rand.Read(h.salt)
+	rand.Read(h.nonce)
+	aead, err := deriveAEAD(p, h, password)
+	if err != nil {
+		return err
+	}
+	hdr := h.marshal()
+	if out == "" {
+		out = in + ".enc"
+	}
+	log.Printf("%s: %s (i=%d) + %s", out, h.kdf, h.iter, h.aead)
+	return os.WriteFile(out, aead.Seal(hdr, h.nonce, plain, hdr), 0o600)
+}
+
+func decrypt(p *policy.Profile, in, out, password string) error {
+	data, err := os.ReadFile(in)
+	if err != nil {
+		return err
+	}
+	h, n, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	aead, err := deriveAEAD(p, h, password)
+	if err != nil {
+		return err
+	}
+	plain, err := aead.Open(nil, h.nonce, data[n:], data[:n])
+	if err != nil {
+		return errors.New("çözme/kimlik doğrulama başarısız (parola yanlış veya dosya bozuk)")
+	}
+	if out == "" {
+		out = strings.TrimSuffix(in, ".enc")
+		if out == in {
+			out = in + ".dec"
+		}
+	}
+	return os.WriteFile(out, plain, 0o600)
+}
+
+// readPassword, parolayı FILECRYPT_PASSWORD'den ya da stdin'in ilk
+// satırından okur. Gizli giriş için aesc'deki golang.org/x/term kullanılabilir;
+// burada modül dış bağımlılıksız kalsın diye yok.
+func readPassword() (string, error) {
+	if pw := os.Getenv("FILECRYPT_PASSWORD"); pw != "" {
+		return pw, nil
+	}
+	fmt.Fprint(os.Stderr, "Parola: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimRight(line, "\r\n")
+	if line == "" {
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		return "", errors.New("parola boş")
+	}
+	return line, nil
+}
+
+func main() {
+	log.SetFlags(0)
+	profile := flag.String("profile", "", "modern, fips ya da legacy (varsayılan: FIPS modundaysa fips, değilse modern)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "kullanım: filecrypt [-profile fips] encrypt|decrypt <girdi> [çıktı]")
+	}
+	flag.Parse()
+	if flag.NArg() < 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	p, err := policy.Lookup(*profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := p.CheckRuntime(); err != nil {
+		log.Print(err)
+	}
+	run := map[string]func(*policy.Profile, string, string, string) error{"encrypt": encrypt, "decrypt": decrypt}[flag.Arg(0)]
+	if run == nil {
+		flag.Usage()
+		os.Exit(2)
+	}
+	pw, err := readPassword()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := run(p, flag.Arg(1), flag.Arg(2), pw); err != nil {
+		log.Fatal(err)
+	}
+}
+``
+/*
+---
+
+# 🧪 Testler
+
+## 📌 `policy/policy_test.go`
+
+* `TestProfilesConsistent`: her profilin kendi varsayılan anahtarına ve suite'lerine izin verdiğini kontrol eder. Profil tablosundaki bir yazım hatasını yakalar.
+* `TestCheckConfig`: yukarıdaki `fips_tls_server.go`'nun `tls.Config`'i `fips` profiline uyar, `modern`'e uymaz (TLS 1.2 açık).
+* `TestHandshake`: gerçek bir TCP bağlantısı üzerinde el sıkışır. `net.Pipe` burada kullanılamaz: iki taraf aynı anda alert yazmaya çalışınca kilitlenir.
+* `TestAudit`: RSA 1024 anahtar, süresi dolmuş bir sertifika, şifreli ve tanınmayan PEM blokları, bozuk bir açık anahtar ve okunamayan bir CRL içeren bir dizini denetler.
+*/
+``go
+package policy
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/fips140"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLookup(t *testing.T) {
+	for _, p := range Profiles {
+		got, err := Lookup(p.Name)
+		if err != nil || got != p {
+			t.Errorf("Lookup(%q) = %v, %v", p.Name, got, err)
+		}
+	}
+	if p, _ := Lookup(""); p != Default() {
+		t.Errorf(`Lookup("") = %s, Default() = %s`, p.Name, Default().Name)
+	}
+	if _, err := Lookup("paranoid"); err == nil {
+		t.Error("bilinmeyen profil kabul edildi")
+	}
+}
+
+// Profillerin kendi içinde tutarlı olduğunu kontrol eder: keygen türü
+// üretilebilir olmalı, varsayılan AEAD ve KDF listede olmalı.
+func TestProfilesConsistent(t *testing.T) {
+	for _, p := range Profiles {
+		k, err := ParseKeySpec(p.KeyGen)
+		if err != nil {
+			t.Errorf("%s: %v", p.Name, err)
+		} else if err := p.CheckKeySpec(k); err != nil {
+			t.Errorf("%s: keygen %s: %v", p.Name, p.KeyGen, err)
+		}
+		if p.MinVersion > p.MaxVersion {
+			t.Errorf("%s: MinVersion > MaxVersion", p.Name)
+		}
+		if p.MinVersion <= tls.VersionTLS12 && len(p.CipherSuites) == 0 {
+			t.Errorf("%s: TLS 1.2 izinli ama suite yok", p.Name)
+		}
+		if len(p.AEADs) == 0 || len(p.KDFs) == 0 {
+			t.Errorf("%s: AEAD ya da KDF listesi boş", p.Name)
+		}
+	}
+}
+
+func TestParseKeySpec(t *testing.T) {
+	for _, s := range []string{"ecdsa-p256", "ecdsa-p384", "ecdsa-p521", "ed25519", "rsa-2048", "rsa-1024"} {
+		k, err := ParseKeySpec(s)
+		if err != nil {
+			t.Errorf("ParseKeySpec(%q): %v", s, err)
+		} else if k.String() != s {
+			t.Errorf("ParseKeySpec(%q).String() = %q", s, k.String())
+		}
+	}
+	for _, s := range []string{"", "ecdsa", "ecdsa-p224", "rsa", "rsa-abc", "rsa-2047", "ed448", "ed25519-x"} {
+		if _, err := ParseKeySpec(s); err == nil {
+			t.Errorf("ParseKeySpec(%q) hata vermedi", s)
+		}
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	for _, tc := range []struct {
+		p    *Profile
+		spec string
+		rule string // "" ise üretilmeli
+	}{
+		{FIPS, "", ""},
+		{FIPS, "ecdsa-p521", ""},
+		{FIPS, "ed25519", ""},
+		{Modern, "ecdsa-p521", RuleKey},
+		{FIPS, "rsa-1024", RuleKey},
+		{Legacy, "rsa-1536", RuleKey},
+	} {
+		key, err := tc.p.GenerateKey(tc.spec)
+		if tc.rule == "" {
+			if err != nil {
+				t.Errorf("%s %q: %v", tc.p.Name, tc.spec, err)
+			} else if err := tc.p.CheckPublicKey(key.Public()); err != nil {
+				t.Errorf("%s %q: üretilen anahtar: %v", tc.p.Name, tc.spec, err)
+			}
+			continue
+		}
+		var v *Violation
+		if !errors.As(err, &v) || v.Rule != tc.rule {
+			t.Errorf("%s %q: hata = %v, %s ihlali bekleniyordu", tc.p.Name, tc.spec, err, tc.rule)
+		}
+		if key != nil {
+			t.Errorf("%s %q: ihlale rağmen anahtar üretildi", tc.p.Name, tc.spec)
+		}
+	}
+}
+
+func TestChecks(t *testing.T) {
+	if err := FIPS.CheckAEAD(ChaCha20Poly1305); err == nil {
+		t.Error("fips ChaCha20-Poly1305'i kabul etti")
+	}
+	if err := FIPS.CheckKDF(Scrypt); err == nil {
+		t.Error("fips scrypt'i kabul etti")
+	}
+	if err := Modern.CheckKDF(Scrypt); err != nil {
+		t.Error(err)
+	}
+	if err := Modern.CheckHash(crypto.SHA1); err == nil {
+		t.Error("modern SHA-1'i kabul etti")
+	}
+	if err := Legacy.CheckHash(crypto.SHA1); err != nil {
+		t.Error(err)
+	}
+	for _, p := range Profiles {
+		if err := p.CheckSignatureAlgorithm(x509.MD5WithRSA); err == nil {
+			t.Errorf("%s MD5 imzasını kabul etti", p.Name)
+		}
+	}
+	if err := FIPS.CheckRuntime(); (err == nil) != fips140.Enabled() {
+		t.Errorf("CheckRuntime = %v, fips140.Enabled() = %v", err, fips140.Enabled())
+	}
+	if err := Modern.CheckRuntime(); err != nil {
+		t.Error(err)
+	}
+}
+
+// testCA, tek seviyeli bir test PKI'sidir.
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &testCA{cert, key}
+}
+
+// issue, ca'nın imzaladığı bir sunucu sertifikası ve anahtarı döndürür.
+func (ca *testCA) issue(t *testing.T, key crypto.Signer, notAfter time.Time) tls.Certificate {
+	t.Helper()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-2 * time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func mustKey(t *testing.T, spec string) crypto.Signer {
+	t.Helper()
+	key, err := Legacy.GenerateKey(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func rules(vs Violations) string {
+	var rs []string
+	for _, v := range vs {
+		rs = append(rs, v.Rule)
+	}
+	return strings.Join(rs, ",")
+}
+
+func TestCheckCertificate(t *testing.T) {
+	ca := newCA(t)
+	now := time.Now()
+	p521 := ca.issue(t, mustKey(t, "ecdsa-p521"), now.Add(time.Hour))
+	expired := ca.issue(t, mustKey(t, "ecdsa-p256"), now.Add(-time.Hour))
+
+	leaf := func(c tls.Certificate) *x509.Certificate {
+		x, err := x509.ParseCertificate(c.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return x
+	}
+	for _, tc := range []struct {
+		name string
+		p    *Profile
+		cert *x509.Certificate
+		want string
+	}{
+		{"ca", Modern, ca.cert, ""},
+		{"p521 fips", FIPS, leaf(p521), ""},
+		{"p521 modern", Modern, leaf(p521), RuleKey},
+		{"expired", FIPS, leaf(expired), RuleValidity},
+	} {
+		if got := rules(tc.p.CheckCertificate(tc.cert, now)); got != tc.want {
+			t.Errorf("%s: ihlaller %q, beklenen %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// fipsTLSServerConfig, fips_tls_server.go'daki elle yazılmış yapılandırmadır.
+func fipsTLSServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+		CipherSuites: []uint16{
+			tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+			tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+		},
+		CurvePreferences: []tls.CurveID{tls.CurveP256, tls.CurveP384},
+	}
+}
+
+func TestCheckConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		p    *Profile
+		cfg  *tls.Config
+		want string
+	}{
+		{"fips_tls_server fips", FIPS, fipsTLSServerConfig(), ""},
+		{"fips_tls_server modern", Modern, fipsTLSServerConfig(), RuleTLSVersion + "," + RuleCipherSuite},
+		{"zero fips", FIPS, &tls.Config{}, RuleCipherSuite + "," + RuleCurve},
+		{"zero modern", Modern, &tls.Config{MinVersion: tls.VersionTLS13}, RuleCurve},
+		{"chacha fips", FIPS, &tls.Config{
+			CipherSuites:     []uint16{tls.TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256},
+			CurvePreferences: []tls.CurveID{tls.X25519},
+		}, RuleCipherSuite + "," + RuleCurve},
+		{"insecure", Legacy, &tls.Config{
+			CipherSuites:       Legacy.CipherSuites,
+			CurvePreferences:   Legacy.Curves,
+			InsecureSkipVerify: true,
+		}, RuleVerify},
+	} {
+		if got := rules(tc.p.Check(tc.cfg)); got != tc.want {
+			t.Errorf("%s: ihlaller %q, beklenen %q", tc.name, got, tc.want)
+		}
+	}
+
+	// Apply'dan sonra her profil kendi yapılandırmasını temiz bulmalı.
+	for _, p := range Profiles {
+		cfg := &tls.Config{}
+		if err := p.Apply(cfg); err != nil {
+			t.Fatal(err)
+		}
+		if vs := p.Check(cfg); len(vs) > 0 {
+			t.Errorf("%s: Apply sonrası ihlaller: %v", p.Name, vs)
+		}
+	}
+}
+
+// handshake, client ve server arasında yerel bir TCP bağlantısı üzerinde
+// el sıkışma yapar ve istemci tarafının sonucunu döndürür. net.Pipe
+// kullanılmıyor: tamponsuz olduğu için iki taraf aynı anda alert yazınca
+// kilitlenir.
+func handshake(t *testing.T, server, client *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		conn.(*tls.Conn).Handshake()
+		conn.Close()
+	}()
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	if err != nil {
+		return tls.ConnectionState{}, err
+	}
+	defer conn.Close()
+	return conn.ConnectionState(), nil
+}
+
+func TestHandshake(t *testing.T) {
+	ca := newCA(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	good := ca.issue(t, mustKey(t, "ecdsa-p256"), time.Now().Add(time.Hour))
+	p521 := ca.issue(t, mustKey(t, "ecdsa-p521"), time.Now().Add(time.Hour))
+
+	client := func(p *Profile) *tls.Config {
+		cfg := p.ClientConfig(roots)
+		cfg.ServerName = "localhost"
+		return cfg
+	}
+
+	for _, p := range Profiles {
+		server, err := p.ServerConfig(good)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cs, err := handshake(t, server, client(p))
+		if err != nil {
+			t.Errorf("%s: %v", p.Name, err)
+			continue
+		}
+		if !p.AllowsVersion(cs.Version) || !p.AllowsCipherSuite(cs.CipherSuite) {
+			t.Errorf("%s: %s %s", p.Name, tls.VersionName(cs.Version), tls.CipherSuiteName(cs.CipherSuite))
+		}
+	}
+
+	// Sunucu profil dışı bir sertifika kullanıyorsa ServerConfig reddeder,
+	// istemci de VerifyConnection'da reddeder.
+	if _, err := Modern.ServerConfig(p521); err == nil {
+		t.Error("modern ServerConfig P-521 sertifikayı kabul etti")
+	}
+	cfg := &tls.Config{Certificates: []tls.Certificate{p521}}
+	_, err := handshake(t, cfg, client(Modern))
+	var v *Violation
+	if !errors.As(err, &v) || v.Rule != RuleKey {
+		t.Errorf("P-521 sunucu sertifikası: %v", err)
+	}
+
+	// Yalnızca TLS 1.2 + ChaCha20 konuşan bir sunucuyla fips istemcisinin
+	// ortak suite'i yoktur.
+	old := &tls.Config{
+		Certificates: []tls.Certificate{good},
+		MaxVersion:   tls.VersionTLS12,
+		CipherSuites: []uint16{tls.TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256},
+	}
+	if _, err := handshake(t, old, client(FIPS)); err == nil {
+		t.Error("fips istemcisi ChaCha20 sunucusuna bağlandı")
+	}
+	// Aynı sunucuya legacy istemcisi bağlanabilir. FIPS modunda crypto/tls
+	// ChaCha20'yi profilden bağımsız olarak kendisi kapatır.
+	if _, err := handshake(t, old, client(Legacy)); err != nil && !fips140.Enabled() {
+		t.Errorf("legacy: %v", err)
+	}
+
+	// Apply, var olan VerifyConnection'ı profil kontrolünden sonra çağırır.
+	called := false
+	chained := client(FIPS)
+	chained.VerifyConnection = func(tls.ConnectionState) error { called = true; return nil }
+	FIPS.Apply(chained)
+	server, _ := FIPS.ServerConfig(good)
+	if _, err := handshake(t, server, chained); err != nil || !called {
+		t.Errorf("zincirlenen VerifyConnection: err=%v called=%v", err, called)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	ca := newCA(t)
+	dir := t.TempDir()
+	write := func(name string, blocks ...*pem.Block) {
+		var data []byte
+		for _, b := range blocks {
+			data = append(data, pem.EncodeToMemory(b)...)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pkcs8 := func(key crypto.Signer) *pem.Block {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+
+	rsa1024, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ec, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ecDER, _ := x509.MarshalECPrivateKey(ec)
+	expired := ca.issue(t, ec, time.Now().Add(-time.Minute))
+
+	write("ca.pem", &pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw})
+	write("weak.key", &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsa1024)})
+	write("server.pem",
+		&pem.Block{Type: "CERTIFICATE", Bytes: expired.Certificate[0]},
+		&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})
+	write("mixed.pem",
+		pkcs8(ec),
+		&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte{1}},
+		&pem.Block{Type: "OPENSSH PRIVATE KEY", Bytes: []byte{1}},
+		&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("bozuk")})
+	write("notes.txt", &pem.Block{Type: "PRIVATE KEY", Bytes: []byte{1}}) // uzantı yüzünden taranmaz
+	os.WriteFile(filepath.Join(dir, "root.crl"), []byte("PEM değil"), 0o644)
+
+	findings, err := FIPS.AuditFiles(time.Now(), dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range findings {
+		s := filepath.Base(f.File) + "#" + string(rune('0'+f.Block)) + " "
+		switch {
+		case f.Skipped != "":
+			s += "skip"
+		case f.OK():
+			s += "ok"
+		default:
+			s += rules(f.Violations)
+		}
+		got = append(got, s)
+	}
+	want := []string{
+		"ca.pem#1 ok",
+		"mixed.pem#1 ok",
+		"mixed.pem#2 skip",
+		"mixed.pem#3 skip",
+		"mixed.pem#4 key",
+		"root.crl#1 skip",
+		"server.pem#1 validity",
+		"server.pem#2 ok",
+		"weak.key#1 key",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("denetim:\n%s\nbeklenen:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// Tek dosya da verilebilir; olmayan dosya hatadır.
+	if _, err := FIPS.AuditFiles(time.Now(), filepath.Join(dir, "yok.pem")); err == nil {
+		t.Error("olmayan dosya hata vermedi")
+	}
+}
+``
+/*
+Testler `-race` ile ve FIPS modunda da geçiyor. FIPS modunda `crypto/tls` ChaCha20'yi kendisi devre dışı bırakır. Bu yüzden `legacy` profilindeki ChaCha20 el sıkışması o modda başarısız olabilir ve test bunu kabul eder:
+
+*/
+``bash
+$ go test -race ./...
+?   	cryptopolicy/cmd/cryptopolicy	[no test files]
+?   	cryptopolicy/examples/client	[no test files]
+?   	cryptopolicy/examples/filecrypt	[no test files]
+?   	cryptopolicy/examples/server	[no test files]
+ok  	cryptopolicy/policy	1.240s
+$ GODEBUG=fips140=on go test ./policy
+ok  	cryptopolicy/policy	0.112s
+``
+/*
+---
+
+# ▶️ Çalıştırma
+
+## Profili görmek
+
+*/
+``bash
+$ cryptopolicy show -profile fips
+profile        fips
+tls            TLS 1.2 – TLS 1.3
+suites (≤1.2)  TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256
+               TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256
+               TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384
+               TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384
+suites (1.3)   TLS_AES_128_GCM_SHA256
+               TLS_AES_256_GCM_SHA384
+curves         X25519MLKEM768, CurveP256, CurveP384, CurveP521
+rsa            ≥ 2048 bit
+ecdsa          P-256, P-384, P-521
+ed25519        true
+keygen         ecdsa-p256
+hashes         SHA-224, SHA-256, SHA-384, SHA-512, SHA-512/224, SHA-512/256, SHA3-224, SHA3-256, SHA3-384, SHA3-512
+aead           AES-256-GCM, AES-128-GCM
+kdf            pbkdf2-sha256, pbkdf2-sha512, hkdf-sha256, hkdf-sha384
+fips module    true
+``
+/*
+## Diskteki anahtar ve sertifikaları denetlemek
+
+`certs/` dizininde bir RSA 1024 anahtar ve sertifika, SHA-1 ile imzalanmış bir sertifika ve P-521 bir sertifika var:
+
+*/
+``bash
+$ cryptopolicy audit -profile fips certs
+ok    certs/ca.pem#1 CERTIFICATE CN=Legacy CA RSA 2048 SHA256-RSA
+FAIL  certs/old.key#1 PRIVATE KEY RSA 1024
+      key: RSA 1024 bit (en az 2048)
+FAIL  certs/old.pem#1 CERTIFICATE CN=old.example RSA 1024 SHA1-RSA
+      key: RSA 1024 bit (en az 2048)
+ok    certs/p521.key#1 PRIVATE KEY ECDSA P-521
+ok    certs/p521.pem#1 CERTIFICATE CN=localhost ECDSA P-521 ECDSA-SHA256
+ok    certs/server.key#1 PRIVATE KEY ECDSA P-384
+ok    certs/sha1.key#1 PRIVATE KEY RSA 2048
+FAIL  certs/sha1.pem#1 CERTIFICATE CN=sha1.example RSA 2048 SHA1-RSA
+      signature: SHA1-RSA: SHA-1 izinli değil
+8 blok, 3 ihlalli (profil fips)
+$ echo $?
+1
+``
+/*
+`old.pem` kendinden imzalı olduğu için SHA-1 imzası raporlanmıyor, yalnızca anahtarı. Aynı dizin `modern` profiliyle denetlenince P-521 de ihlal olur:
+
+*/
+``bash
+$ cryptopolicy audit -q -profile modern certs
+FAIL  certs/old.key#1 PRIVATE KEY RSA 1024
+      key: RSA 1024 bit (en az 2048)
+FAIL  certs/old.pem#1 CERTIFICATE CN=old.example RSA 1024 SHA1-RSA
+      key: RSA 1024 bit (en az 2048)
+FAIL  certs/p521.key#1 PRIVATE KEY ECDSA P-521
+      key: ECDSA P-521 izinli değil (P-256, P-384)
+FAIL  certs/p521.pem#1 CERTIFICATE CN=localhost ECDSA P-521 ECDSA-SHA256
+      key: ECDSA P-521 izinli değil (P-256, P-384)
+FAIL  certs/sha1.pem#1 CERTIFICATE CN=sha1.example RSA 2048 SHA1-RSA
+      signature: SHA1-RSA: SHA-1 izinli değil
+8 blok, 5 ihlalli (profil modern)
+``
+/*
+## Anahtar üretimi
+
+*/
+``bash
+$ cryptopolicy genkey -profile fips -alg rsa-1024
+uyarı: policy(fips): runtime: FIPS 140-3 modu kapalı (GODEBUG="fips140=on" ile çalıştırın)
+policy(fips): key: RSA 1024 bit (en az 2048)
+$ cryptopolicy genkey -profile modern -alg ecdsa-p521
+policy(modern): key: ECDSA P-521 izinli değil (P-256, P-384)
+$ cryptopolicy genkey -profile modern -out server.key   # profilin varsayılanı: ecdsa-p256
+``
+/*
+## Sunucu ve istemci
+
+`fips` profili FIPS modu kapalıyken sunucuyu başlatmaz:
+
+*/
+``bash
+$ go run ./examples/server -profile fips
+2026/10/18 22:19:59 policy(fips): runtime: FIPS 140-3 modu kapalı (GODEBUG="fips140=on" ile çalıştırın)
+exit status 1
+
+$ GODEBUG=fips140=on go run ./examples/server -profile fips -addr :18443
+2026/10/18 22:19:59 Listening on https://localhost:18443 (profile fips, FIPS mode true)
+``
+``bash
+$ GODEBUG=fips140=on go run ./examples/client -profile fips -k https://localhost:18443/
+status: 200 OK (TLS 1.3, TLS_AES_128_GCM_SHA256)
+body: hello — profile: fips, FIPS mode: true, TLS 1.3 TLS_AES_128_GCM_SHA256
+$ cryptopolicy probe -profile modern -k localhost:18443
+ok    localhost:18443 TLS 1.3 TLS_AES_128_GCM_SHA256
+      localhost (ECDSA-SHA256, 2026-10-19'e kadar)
+``
+/*
+P-521 sertifikalı bir `legacy` sunucu `legacy` istemciye uyar ama `modern` istemci bağlantıyı reddeder. `-k` olmadan da aynı sonuç çıkar: zincir doğrulaması geçse bile profil kontrolü sertifikanın anahtarına bakar:
+
+*/
+``bash
+$ go run ./examples/server -profile legacy -addr :18444 -cert certs/p521.pem -key certs/p521.key &
+$ cryptopolicy probe -profile legacy -ca certs/p521.pem localhost:18444
+ok    localhost:18444 TLS 1.3 TLS_AES_128_GCM_SHA256
+      localhost (ECDSA-SHA256, 2026-11-17'e kadar)
+$ cryptopolicy probe -profile modern -ca certs/p521.pem localhost:18444
+FAIL  localhost:18444
+      key: ECDSA P-521 izinli değil (P-256, P-384)
+$ go run ./examples/client -profile modern -ca certs/p521.pem https://localhost:18444/
+2026/10/18 22:20:01 GET error: Get "https://localhost:18444/": policy(modern): key: ECDSA P-521 izinli değil (P-256, P-384)
+``
+/*
+Sunucu tarafında bu, istemcinin gönderdiği bir alert olarak görünür:
+
+*/
+``bash
+2026/10/18 22:20:01 http: TLS handshake error from 127.0.0.1:36328: remote error: tls: bad certificate
+``
+/*
+## Go dışı sunucuları yoklamak
+
+`probe` herhangi bir TLS sunucusuna bağlanabilir. Aşağıda `openssl s_server` yalnızca ChaCha20 ile çalışıyor.
+
+TLS 1.2'de suite `CipherSuites` listesinde olmadığı için `fips` istemci onu önermez bile; el sıkışma sunucunun alert'iyle biter:
+
+*/
+``bash
+$ openssl s_server -accept 18445 -cert certs/p521.pem -key certs/p521.key \
+    -tls1_2 -cipher ECDHE-ECDSA-CHACHA20-POLY1305 -quiet &
+$ cryptopolicy probe -profile fips -ca certs/p521.pem localhost:18445
+uyarı: policy(fips): runtime: FIPS 140-3 modu kapalı (GODEBUG="fips140=on" ile çalıştırın)
+FAIL  localhost:18445: remote error: tls: handshake failure
+$ cryptopolicy probe -profile legacy -ca certs/p521.pem localhost:18445
+ok    localhost:18445 TLS 1.2 TLS_ECDHE_ECDSA_WITH_CHACHA20_POLY1305_SHA256
+      localhost (ECDSA-SHA256, 2026-11-17'e kadar)
+``
+/*
+TLS 1.3'te suite ayarlanamaz. FIPS modu kapalıyken Go istemcisi ChaCha20'yi önerir; el sıkışma tamamlanır ve **`VerifyConnection` bağlantıyı keser**. FIPS modunda ise Go ChaCha20'yi hiç önermez:
+
+*/
+``bash
+$ openssl s_server -accept 18446 -cert certs/p521.pem -key certs/p521.key \
+    -tls1_3 -ciphersuites TLS_CHACHA20_POLY1305_SHA256 -quiet &
+$ cryptopolicy probe -profile fips -ca certs/p521.pem localhost:18446
+uyarı: policy(fips): runtime: FIPS 140-3 modu kapalı (GODEBUG="fips140=on" ile çalıştırın)
+FAIL  localhost:18446
+      cipher-suite: TLS_CHACHA20_POLY1305_SHA256 izinli değil
+$ GODEBUG=fips140=on cryptopolicy probe -profile fips -ca certs/p521.pem localhost:18446
+FAIL  localhost:18446: remote error: tls: handshake failure
+``
+/*
+## Dosya şifreleme
+
+*/
+``bash
+$ export GODEBUG=fips140=on
+$ FILECRYPT_PASSWORD=parola123 go run ./examples/filecrypt -profile fips encrypt secret.txt
+secret.txt.enc: pbkdf2-sha256 (i=600000) + AES-256-GCM
+$ echo parola123 | go run ./examples/filecrypt -profile fips decrypt secret.txt.enc out.txt
+Parola:
+$ cat out.txt
+gizli veri
+$ echo yanlis | go run ./examples/filecrypt -profile fips decrypt secret.txt.enc out.txt
+Parola: çözme/kimlik doğrulama başarısız (parola yanlış veya dosya bozuk)
+$ xxd secret.txt.enc | head -3
+00000000: 5043 5259 010d 7062 6b64 6632 2d73 6861  PCRY..pbkdf2-sha
+00000010: 3235 3600 0927 c05d d27b 05cf ba67 17dc  256..'.].{...g..
+00000020: 6aae 45da 1ab5 440b 4145 532d 3235 362d  j.E...D.AES-256-
+``
+/*
+---
+
+# ⚠️ Notlar
+
+* **`fips` profili tek başına FIPS uyumluluğu değildir.** Profil yalnızca onaylı algoritmaları seçer. Uyumluluk için algoritmaların doğrulanmış bir modülden gelmesi gerekir (`GODEBUG=fips140=on` ya da `GOFIPS140` ile derleme). Yukarıdaki 3. bölümdeki kurumsal gereksinimler de geçerlidir.
+* **TLS 1.3 suite'leri yalnızca el sıkışmadan sonra kontrol edilir.** `crypto/tls` TLS 1.3 suite listesini ayarlatmaz. İzinsiz bir suite seçilirse bağlantı `VerifyConnection`'da kesilir, ama el sıkışma o ana kadar yapılmış olur.
+* **`GetCertificate` ile verilen sertifikalar `Apply` tarafından görülmez.** OCSP stapling ya da SNI ile sertifika seçen sunucular yüklenen sertifikayı `CheckTLSCertificate` ile kendileri kontrol etmelidir.
+* **Kendinden imzalı köklerin imzası kontrol edilmez.** SHA-1 ile imzalanmış eski bir kök CA `fips` profilinde de geçer; anahtarı yine kontrol edilir. Go'nun `x509` paketi zincirdeki SHA-1 imzalarını zaten reddeder, yani `legacy` profili SHA-1 imzalı ara sertifikaları kabul etse bile doğrulama başarısız olur.
+* **`legacy` profili TLS 1.0 ve 1.1'i açar**, ama Go istemcileri varsayılan olarak TLS 1.2'nin altını önermez. Profil `MinVersion`'ı açıkça ayarladığı için bu profil kullanıldığında eski sürümler gerçekten konuşulur. Yalnızca gerçekten gerekiyorsa kullanın.
+* **`filecrypt` yalnızca PBKDF2 ve AES-GCM uygular.** `modern` profilinde ilk tercih argon2id olsa da araç standart kütüphanede olmadığı için onu atlar ve `pbkdf2-sha256`'yı seçer. `aes.go`'daki scrypt'li `aesc` dosyaları bu araçla açılamaz.
+
+---
+
+👉 İstersen bir sonraki adımda bu paketi **mTLS** ile genişletebilirim: istemci sertifikalarının da profile göre denetlenmesi, `minica` ile profile uygun istemci sertifikası üretimi ve reddedilen istemcilerin günlüğe yazılması. Bunu ister misin?
 */
@@ -179,4 +179,1934 @@ func main() {
 ---
 
 👉 İstersen sana **ML-KEM ile TLS benzeri bir güvenli iletişim örneği** (mesaj şifreleme ve çözme) de gösterebilirim. Görmek ister misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Harika 😄 Önce önemli bir düzeltme: yukarıda "Go'nun standart kütüphanesinde `mlkem` paketi yok" dedik. Bu artık doğru değil. **Go 1.24** ile standart kütüphaneye **`crypto/mlkem`** (FIPS 203, ML-KEM-768 ve ML-KEM-1024) ve **`crypto/hkdf`** geldi. `crypto/tls` de aynı sürümden beri varsayılan olarak hibrit **X25519MLKEM768** anahtar değişimini kullanıyor. Bu yüzden bu örnekte üçüncü parti paket yok, yalnızca standart kütüphane var.
+
+Bu örnekte TLS'in yaptığını küçük ölçekte kendimiz yapıyoruz: `net.Conn` üzerinde çalışan, iki tarafı da doğrulayan bir **el sıkışma** ve ardından şifreli bir **kayıt katmanı**.
+
+---
+
+# 🔹 Neden hibrit?
+
+* **ML-KEM** kuantum bilgisayarlara karşı dayanıklı ama yeni; uygulama hataları ve analizleri daha az zamanla sınandı.
+* **X25519** (ECDH) yıllardır sahada, ama yeterince büyük bir kuantum bilgisayar onu kırabilir.
+* İki sır **HKDF** ile birleştirilir. Saldırganın oturum anahtarını bulması için **ikisini birden** kırması gerekir.
+* Bugün kaydedilen trafik yarın kuantum bilgisayarla çözülmeye çalışılabilir ("şimdi topla, sonra çöz"). ML-KEM bu riske karşı, X25519 ise ML-KEM'de çıkabilecek bir soruna karşı sigortadır.
+
+# 🔹 Neden kimlik doğrulama?
+
+ECDH de ML-KEM de **kiminle** anahtar paylaştığını bilmez. Araya giren biri (MITM) iki tarafla ayrı ayrı anahtar değişimi yapabilir. Bu yüzden her tarafın uzun ömürlü bir **Ed25519** anahtarı var ve karşı tarafın açık anahtarı önceden biliniyor (SSH'teki `known_hosts` / `authorized_keys` gibi). Her taraf el sıkışmanın o ana kadarki halini (**transcript**) imzalar.
+
+---
+
+# 🔹 Protokol
+
+```
+istemci                                                sunucu
+ClientHello   sürüm | X25519 pub | ML-KEM ek      ──►
+                                                 ◄──   ServerHello   sürüm | X25519 pub | ML-KEM ct
+                                                 ◄──   ServerAuth    Ed25519 pub | imza | finished
+ClientAuth    Ed25519 pub | imza | finished       ──►
+                      ◄══ AES-256-GCM kayıtlar ══►
+```
+
+| Mesaj | Boyut (gövde) | İçerik |
+|---|---|---|
+| ClientHello | 1217 bayt | 1 + 32 (X25519) + 1184 (ML-KEM-768 encapsulation key) |
+| ServerHello | 1121 bayt | 1 + 32 (X25519) + 1088 (ML-KEM-768 ciphertext) |
+| ServerAuth / ClientAuth | 128 bayt | 32 (Ed25519 pub) + 64 (imza) + 32 (HMAC-SHA256 finished) |
+
+Her mesaj `tür (1) | uzunluk (2) | gövde` olarak çerçevelenir. Kayıtlar da aynı çerçeveyi kullanır.
+
+## Anahtar takvimi (key schedule)
+
+```
+prk         = HKDF-Extract(salt = "hybrid-x25519-mlkem768-v1", ikm = mlkemSecret || x25519Secret)
+th1         = SHA-256(ClientHello || ServerHello)
+finished    = HKDF-Expand(prk, "... s finished" || th1) ve "... c finished"
+th2         = SHA-256(ClientHello || ServerHello || ServerAuth || ClientAuth)
+trafik      = HKDF-Expand(prk, "... c2s key" || th2), "c2s iv", "s2c key", "s2c iv"
+```
+
+* **İmza**, `bağlam || SHA-256(transcript)` üzerinedir. Transcript iki tarafın geçici anahtarlarını ve ML-KEM ciphertext'ini içerir. Saldırgan bunlardan birini değiştirirse iki tarafın transcript'i farklı olur ve imza doğrulanmaz.
+* **Bağlam** metni rolleri ayırır (`server signature` / `client signature`). Sunucunun imzası istemci imzası olarak geri yansıtılamaz.
+* **Finished**, karşı tarafın ortak sırrı gerçekten türettiğini gösterir (anahtar onayı).
+* **Trafik anahtarları** bütün transcript'e bağlıdır ve her yön için ayrıdır.
+
+## Kayıt katmanı ve tekrar koruması
+
+* Her kayıt `0x17 | uzunluk | AES-256-GCM(veri || içerik türü)` biçimindedir. Başlık AEAD'in ek verisidir.
+* **Nonce = IV XOR sıra numarası.** Sıra numarası kablo üzerinde gönderilmez; iki taraf da sayar. Tekrar gönderilen, atlanan ya da yeri değiştirilen kayıt yanlış nonce ile açılmaya çalışılır ve **reddedilir**.
+* `Close`, şifreli bir **close** kaydı gönderir. Bağlantı bu kayıt gelmeden kapanırsa `Read` `io.EOF` yerine `io.ErrUnexpectedEOF` döner. Saldırganın bağlantıyı keserek veriyi "kısaltması" böylece fark edilir.
+* Her bağlantının geçici anahtarları yenidir. Kaydedilmiş bir el sıkışmanın başka bir bağlantıda tekrar oynatılması, yeni transcript'i imzalayamadığı için başarısız olur.
+
+---
+
+# 📂 Dizin yapısı
+
+```
+hybrid-handshake/
+├── go.mod
+├── hybrid/
+│   ├── config.go          // Config, hatalar, paket dokümanı
+│   ├── keyschedule.go     // HKDF birleştirici ve anahtar türetme
+│   ├── handshake.go       // mesajlar, istemci/sunucu el sıkışması
+│   ├── record.go          // AES-GCM kayıtları, sıra numarası
+│   ├── conn.go            // net.Conn uygulaması: Client, Server, Dial, Listen
+│   ├── hybrid_test.go     // uçtan uca testler
+│   ├── vectors_test.go    // test vektörleri
+│   └── mitm_test.go       // MITM negatif testleri
+└── cmd/hybridecho/main.go // keygen + yankı sunucusu/istemcisi
+```
+
+---
+
+## 📌 `go.mod`
+*/
+``go
+module hybrid-handshake
+
+go 1.24
+``
+/*
+---
+
+## 📌 `hybrid/config.go`
+*/
+``go
+// Package hybrid, net.Conn üzerinde çalışan küçük bir kimlik doğrulamalı
+// anahtar değişimi protokolüdür. Ortak sır, X25519 (klasik) ve ML-KEM-768
+// (kuantuma dayanıklı) sırlarının HKDF ile birleştirilmesinden türetilir;
+// saldırganın bağlantıyı çözmesi için ikisini birden kırması gerekir.
+// Taraflar birbirini önceden bilinen Ed25519 anahtarlarıyla doğrular.
+// El sıkışmadan sonra veri, sıra numaralı AES-256-GCM kayıtlarıyla taşınır.
+package hybrid
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"time"
+)
+
+var (
+	ErrVersion         = errors.New("hybrid: desteklenmeyen protokol sürümü")
+	ErrBadMessage      = errors.New("hybrid: bozuk el sıkışma mesajı")
+	ErrBadSignature    = errors.New("hybrid: karşı tarafın imzası geçersiz")
+	ErrBadFinished     = errors.New("hybrid: finished doğrulaması başarısız")
+	ErrPeerNotTrusted  = errors.New("hybrid: karşı tarafın anahtarı güvenilir listede değil")
+	ErrBadRecord       = errors.New("hybrid: kayıt doğrulanamadı (değiştirilmiş, tekrar edilmiş ya da sırası bozulmuş)")
+	ErrRecordOverflow  = errors.New("hybrid: kayıt boyutu sınırı aşıldı")
+	ErrSequenceOverrun = errors.New("hybrid: sıra numarası tükendi, yeni bağlantı açılmalı")
+)
+
+// Config, bir tarafın kimliğini ve güvendiği karşı taraf anahtarlarını
+// tutar. Aynı Config birden çok bağlantıda kullanılabilir.
+type Config struct {
+	// PrivateKey, bu tarafın uzun ömürlü Ed25519 kimlik anahtarıdır.
+	PrivateKey ed25519.PrivateKey
+
+	// PeerKeys, kabul edilen karşı taraf anahtarlarıdır. İstemci için
+	// sunucunun anahtarı, sunucu için yetkili istemcilerin anahtarları.
+	// Boş liste hiçbir karşı tarafı kabul etmez.
+	PeerKeys []ed25519.PublicKey
+
+	// HandshakeTimeout, el sıkışmanın toplam süresidir. 0 ise 10 saniye.
+	HandshakeTimeout time.Duration
+}
+
+func (c *Config) check() error {
+	if len(c.PrivateKey) != ed25519.PrivateKeySize {
+		return errors.New("hybrid: Config.PrivateKey eksik")
+	}
+	if len(c.PeerKeys) == 0 {
+		return errors.New("hybrid: Config.PeerKeys boş")
+	}
+	return nil
+}
+
+func (c *Config) trusts(pub ed25519.PublicKey) bool {
+	for _, k := range c.PeerKeys {
+		if bytes.Equal(k, pub) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Config) handshakeTimeout() time.Duration {
+	if c.HandshakeTimeout > 0 {
+		return c.HandshakeTimeout
+	}
+	return 10 * time.Second
+}
+``
+/*
+---
+
+## 📌 `hybrid/keyschedule.go`
+*/
+``go
+package hybrid
+
+import (
+	"crypto/hkdf"
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// protocolID, bütün HKDF etiketlerinin ve imza bağlamlarının önekidir.
+// Sürüm ya da algoritma değişirse bu da değişir; böylece bir sürümün
+// anahtarları ya da imzaları başka bir sürümde kullanılamaz.
+const protocolID = "hybrid-x25519-mlkem768-v1"
+
+// Anahtar uzunlukları: AES-256-GCM anahtarı, GCM nonce'u, HMAC-SHA256 anahtarı.
+const (
+	keySize      = 32
+	ivSize       = 12
+	finishedSize = sha256.Size
+)
+
+// combine, iki paylaşılan sırrı tek bir HKDF anahtarına dönüştürür.
+//
+// Girdi ML-KEM sırrı ile X25519 sırrının birleşimidir (TLS'teki
+// X25519MLKEM768 ile aynı sıra). Birinin kırılması diğerinin katkısını
+// ortadan kaldırmaz: HKDF'in çıktısı iki sırrın ikisi de bilinmeden
+// tahmin edilemez. Sabit salt, bu protokolün çıktılarını aynı sırlarla
+// çalışan başka protokollerinkinden ayırır.
+func combine(mlkemSecret, x25519Secret []byte) []byte {
+	ikm := make([]byte, 0, len(mlkemSecret)+len(x25519Secret))
+	ikm = append(ikm, mlkemSecret...)
+	ikm = append(ikm, x25519Secret...)
+	prk, err := hkdf.Extract(sha256.New, ikm, []byte(protocolID))
+	if err != nil {
+		panic(err) // yalnızca geçersiz hash ile olur
+	}
+	return prk
+}
+
+// expand, prk'dan label'a ve transcript hash'ine bağlı bir anahtar türetir.
+// Transcript her iki tarafın geçici anahtarlarını ve ML-KEM ciphertext'ini
+// içerdiği için türetilen anahtar, el sıkışmanın o anki haline bağlanır.
+func expand(prk []byte, label string, th []byte, n int) []byte {
+	out, err := hkdf.Expand(sha256.New, prk, protocolID+" "+label+"\x00"+string(th), n)
+	if err != nil {
+		panic(err) // n en fazla 255*32 olabilir; burada hep küçük
+	}
+	return out
+}
+
+// handshakeKeys, finished MAC'leri için kullanılan anahtarlardır.
+type handshakeKeys struct {
+	client, server []byte
+}
+
+func deriveHandshakeKeys(prk, th []byte) handshakeKeys {
+	return handshakeKeys{
+		client: expand(prk, "c finished", th, finishedSize),
+		server: expand(prk, "s finished", th, finishedSize),
+	}
+}
+
+// trafficKeys, her yön için ayrı AEAD anahtarı ve nonce tabanıdır.
+type trafficKeys struct {
+	clientKey, clientIV []byte
+	serverKey, serverIV []byte
+}
+
+func deriveTrafficKeys(prk, th []byte) trafficKeys {
+	return trafficKeys{
+		clientKey: expand(prk, "c2s key", th, keySize),
+		clientIV:  expand(prk, "c2s iv", th, ivSize),
+		serverKey: expand(prk, "s2c key", th, keySize),
+		serverIV:  expand(prk, "s2c iv", th, ivSize),
+	}
+}
+
+func finishedMAC(key, th []byte) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write(th)
+	return m.Sum(nil)
+}
+``
+/*
+---
+
+## 📌 `hybrid/handshake.go`
+
+Bütün mesajların boyutu sabit olduğu için `readMsg` tam uzunluğu ister. Bu, karşı tarafın büyük bir uzunluk yazıp bellek ayırtmasını da engeller. `mlkem.NewEncapsulationKey768` FIPS 203'ün istediği giriş kontrolünü yapar; `ecdh.X25519().ECDH` de küçük mertebeli noktalarda (sıfır sonuç) hata döner.
+*/
+``go
+package hybrid
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// El sıkışma:
+//
+//	istemci                                            sunucu
+//	ClientHello  sürüm, X25519 pub, ML-KEM ek   ──►
+//	                                            ◄──   ServerHello  sürüm, X25519 pub, ML-KEM ct
+//	                                            ◄──   ServerAuth   Ed25519 pub, imza, finished
+//	ClientAuth   Ed25519 pub, imza, finished    ──►
+//	                    ◄══ şifreli kayıtlar ══►
+//
+// Her mesaj "tür (1 bayt) | uzunluk (2 bayt) | gövde" olarak çerçevelenir.
+// Transcript, çerçeveler dahil bütün mesajların birleşimidir.
+//
+// İmzalar, o ana kadarki transcript'in hash'ini imzalar. Transcript iki
+// tarafın geçici anahtarlarını içerdiği için ortadaki adam (MITM) bir
+// anahtarı değiştirirse iki tarafın transcript'i farklı olur ve imza
+// doğrulanmaz. Finished MAC'i ise karşı tarafın ortak sırrı gerçekten
+// türettiğini gösterir (anahtar onayı).
+
+const (
+	version = 1
+
+	msgClientHello = 1
+	msgServerHello = 2
+	msgServerAuth  = 3
+	msgClientAuth  = 4
+
+	x25519Size      = 32
+	clientHelloSize = 1 + x25519Size + mlkem.EncapsulationKeySize768
+	serverHelloSize = 1 + x25519Size + mlkem.CiphertextSize768
+	authSize        = ed25519.PublicKeySize + ed25519.SignatureSize + finishedSize
+)
+
+// İmza bağlamları rolleri ayırır: sunucunun imzası istemci imzası yerine
+// (ya da tersi) yansıtılamaz.
+const (
+	serverSignatureContext = protocolID + " server signature\x00"
+	clientSignatureContext = protocolID + " client signature\x00"
+)
+
+// transcript, el sıkışmada gönderilen ve alınan çerçevelerin birleşimidir.
+type transcript []byte
+
+func (t transcript) hash(extra ...[]byte) []byte {
+	h := sha256.New()
+	h.Write(t)
+	for _, b := range extra {
+		h.Write(b)
+	}
+	return h.Sum(nil)
+}
+
+func writeMsg(w io.Writer, t *transcript, typ byte, body []byte) error {
+	frame := make([]byte, 3+len(body))
+	frame[0] = typ
+	binary.BigEndian.PutUint16(frame[1:], uint16(len(body)))
+	copy(frame[3:], body)
+	*t = append(*t, frame...)
+	_, err := w.Write(frame)
+	return err
+}
+
+// readMsg, beklenen türde ve tam olarak size uzunluğunda bir mesaj okur.
+// Bütün mesajların boyutu sabit olduğu için fazlası ya da eksiği hatadır;
+// bu aynı zamanda karşı tarafın büyük bir uzunlukla bellek ayırtmasını
+// engeller. El sıkışma ortasında kapanan bağlantı io.ErrUnexpectedEOF'tur.
+func readMsg(r io.Reader, t *transcript, typ byte, size int) ([]byte, error) {
+	var hdr [3]byte
+	if _, err := io.ReadFull(r, hdr[:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if hdr[0] != typ || int(binary.BigEndian.Uint16(hdr[1:])) != size {
+		return nil, fmt.Errorf("%w: tür %d, uzunluk %d (beklenen tür %d, uzunluk %d)",
+			ErrBadMessage, hdr[0], binary.BigEndian.Uint16(hdr[1:]), typ, size)
+	}
+	body := make([]byte, size)
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, err
+	}
+	if body[0] != version && (typ == msgClientHello || typ == msgServerHello) {
+		return nil, fmt.Errorf("%w: %d", ErrVersion, body[0])
+	}
+	*t = append(*t, hdr[:]...)
+	*t = append(*t, body...)
+	return body, nil
+}
+
+// authBody, "Ed25519 pub | imza | finished" gövdesini üretir ve doğrular.
+func authBody(priv ed25519.PrivateKey, context string, t transcript, finKey []byte) []byte {
+	pub := priv.Public().(ed25519.PublicKey)
+	sig := ed25519.Sign(priv, append([]byte(context), t.hash()...))
+	fin := finishedMAC(finKey, t.hash(pub, sig))
+	body := make([]byte, 0, authSize)
+	body = append(body, pub...)
+	body = append(body, sig...)
+	return append(body, fin...)
+}
+
+// verifyAuth, karşı tarafın auth mesajını t (mesaj eklenmeden önceki
+// transcript) üzerinde doğrular ve Ed25519 anahtarını döndürür.
+func verifyAuth(cfg *Config, body []byte, context string, t transcript, finKey []byte) (ed25519.PublicKey, error) {
+	pub := ed25519.PublicKey(body[:ed25519.PublicKeySize])
+	sig := body[ed25519.PublicKeySize : ed25519.PublicKeySize+ed25519.SignatureSize]
+	fin := body[ed25519.PublicKeySize+ed25519.SignatureSize:]
+
+	if !ed25519.Verify(pub, append([]byte(context), t.hash()...), sig) {
+		return nil, ErrBadSignature
+	}
+	if !hmac.Equal(fin, finishedMAC(finKey, t.hash(pub, sig))) {
+		return nil, ErrBadFinished
+	}
+	// Güven kontrolü en sonda: imzası doğru ama tanınmayan bir anahtar
+	// (kendi anahtarıyla araya giren bir MITM) ayrı bir hata alır.
+	if !cfg.trusts(pub) {
+		return nil, fmt.Errorf("%w: %x", ErrPeerNotTrusted, []byte(pub))
+	}
+	return append(ed25519.PublicKey(nil), pub...), nil
+}
+
+// clientHandshake, istemci tarafını çalıştırır ve trafik anahtarlarını döndürür.
+func clientHandshake(rw io.ReadWriter, cfg *Config) (trafficKeys, ed25519.PublicKey, error) {
+	var t transcript
+
+	xPriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	dk, err := mlkem.GenerateKey768()
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	hello := make([]byte, 0, clientHelloSize)
+	hello = append(hello, version)
+	hello = append(hello, xPriv.PublicKey().Bytes()...)
+	hello = append(hello, dk.EncapsulationKey().Bytes()...)
+	if err := writeMsg(rw, &t, msgClientHello, hello); err != nil {
+		return trafficKeys{}, nil, err
+	}
+
+	sh, err := readMsg(rw, &t, msgServerHello, serverHelloSize)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	peerX, err := ecdh.X25519().NewPublicKey(sh[1 : 1+x25519Size])
+	if err != nil {
+		return trafficKeys{}, nil, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+	// X25519, küçük mertebeli noktalar için (sonuç sıfır) hata döner.
+	xSecret, err := xPriv.ECDH(peerX)
+	if err != nil {
+		return trafficKeys{}, nil, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+	kSecret, err := dk.Decapsulate(sh[1+x25519Size:])
+	if err != nil {
+		return trafficKeys{}, nil, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+	prk := combine(kSecret, xSecret)
+	hk := deriveHandshakeKeys(prk, t.hash())
+
+	before := append(transcript(nil), t...)
+	sa, err := readMsg(rw, &t, msgServerAuth, authSize)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	peer, err := verifyAuth(cfg, sa, serverSignatureContext, before, hk.server)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+
+	if err := writeMsg(rw, &t, msgClientAuth, authBody(cfg.PrivateKey, clientSignatureContext, t, hk.client)); err != nil {
+		return trafficKeys{}, nil, err
+	}
+	return deriveTrafficKeys(prk, t.hash()), peer, nil
+}
+
+// serverHandshake, sunucu tarafını çalıştırır ve trafik anahtarlarını döndürür.
+func serverHandshake(rw io.ReadWriter, cfg *Config) (trafficKeys, ed25519.PublicKey, error) {
+	var t transcript
+
+	ch, err := readMsg(rw, &t, msgClientHello, clientHelloSize)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	peerX, err := ecdh.X25519().NewPublicKey(ch[1 : 1+x25519Size])
+	if err != nil {
+		return trafficKeys{}, nil, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+	// NewEncapsulationKey768, FIPS 203'teki modül kontrolünü yapar.
+	ek, err := mlkem.NewEncapsulationKey768(ch[1+x25519Size:])
+	if err != nil {
+		return trafficKeys{}, nil, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+
+	xPriv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	xSecret, err := xPriv.ECDH(peerX)
+	if err != nil {
+		return trafficKeys{}, nil, fmt.Errorf("%w: %v", ErrBadMessage, err)
+	}
+	kSecret, ct := ek.Encapsulate()
+
+	hello := make([]byte, 0, serverHelloSize)
+	hello = append(hello, version)
+	hello = append(hello, xPriv.PublicKey().Bytes()...)
+	hello = append(hello, ct...)
+	if err := writeMsg(rw, &t, msgServerHello, hello); err != nil {
+		return trafficKeys{}, nil, err
+	}
+	prk := combine(kSecret, xSecret)
+	hk := deriveHandshakeKeys(prk, t.hash())
+
+	if err := writeMsg(rw, &t, msgServerAuth, authBody(cfg.PrivateKey, serverSignatureContext, t, hk.server)); err != nil {
+		return trafficKeys{}, nil, err
+	}
+
+	before := append(transcript(nil), t...)
+	ca, err := readMsg(rw, &t, msgClientAuth, authSize)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	peer, err := verifyAuth(cfg, ca, clientSignatureContext, before, hk.client)
+	if err != nil {
+		return trafficKeys{}, nil, err
+	}
+	return deriveTrafficKeys(prk, t.hash()), peer, nil
+}
+``
+/*
+---
+
+## 📌 `hybrid/record.go`
+*/
+``go
+package hybrid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/binary"
+	"math"
+)
+
+// Kayıt biçimi (TLS 1.3'e benzer):
+//
+//	başlık:  recordType (1 bayt) | ciphertext uzunluğu (2 bayt)
+//	gövde:   AES-256-GCM(veri | içerik türü)
+//
+// Başlık AEAD'in ek verisidir, değiştirilirse kayıt açılmaz. Nonce, o yönün
+// IV'si ile 64 bitlik sıra numarasının XOR'udur. Sıra numarası kablo
+// üzerinde gönderilmez; iki taraf da kendi sayacını tutar. Bu yüzden
+// tekrar gönderilen, atlanan ya da yeri değiştirilen her kayıt yanlış
+// nonce ile açılmaya çalışılır ve ErrBadRecord ile reddedilir.
+
+const (
+	recordType = 0x17
+
+	maxPlaintext  = 16 << 10
+	maxCiphertext = maxPlaintext + 1 + 16 // + içerik türü + GCM etiketi
+
+	contentData  = 0
+	contentClose = 1
+)
+
+// halfConn, bağlantının bir yönüdür (okuma ya da yazma).
+type halfConn struct {
+	aead cipher.AEAD
+	iv   [ivSize]byte
+	seq  uint64
+}
+
+func newHalfConn(key, iv []byte) halfConn {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err) // anahtar hep 32 bayt
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	h := halfConn{aead: aead}
+	copy(h.iv[:], iv)
+	return h
+}
+
+func (h *halfConn) nonce() []byte {
+	n := h.iv
+	var seq [8]byte
+	binary.BigEndian.PutUint64(seq[:], h.seq)
+	for i := range seq {
+		n[ivSize-8+i] ^= seq[i]
+	}
+	return n[:]
+}
+
+// seal, tek bir kaydı (başlık + ciphertext) dst'ye ekler.
+func (h *halfConn) seal(dst []byte, content byte, data []byte) ([]byte, error) {
+	if h.seq == math.MaxUint64 {
+		return dst, ErrSequenceOverrun
+	}
+	n := len(data) + 1 + h.aead.Overhead()
+	dst = append(dst, recordType, byte(n>>8), byte(n))
+	hdr := dst[len(dst)-3:]
+	inner := make([]byte, 0, len(data)+1)
+	inner = append(inner, data...)
+	inner = append(inner, content)
+	dst = h.aead.Seal(dst, h.nonce(), inner, hdr)
+	h.seq++
+	return dst, nil
+}
+
+// open, bir kaydın gövdesini çözer; içerik türünü ve veriyi döndürür.
+func (h *halfConn) open(hdr, body []byte) (byte, []byte, error) {
+	if h.seq == math.MaxUint64 {
+		return 0, nil, ErrSequenceOverrun
+	}
+	inner, err := h.aead.Open(body[:0], h.nonce(), body, hdr)
+	if err != nil || len(inner) == 0 {
+		return 0, nil, ErrBadRecord
+	}
+	h.seq++
+	return inner[len(inner)-1], inner[:len(inner)-1], nil
+}
+``
+/*
+---
+
+## 📌 `hybrid/conn.go`
+
+`Conn`, `crypto/tls.Conn` gibi davranır: el sıkışma ilk `Read`/`Write`'ta kendiliğinden yapılır, `Listener.Accept` el sıkışmayı beklemez. Okuma ve yazma ayrı kilitlerle korunur; bir goroutine okurken diğeri yazabilir.
+*/
+``go
+package hybrid
+
+import (
+	"crypto/ed25519"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Conn, hybrid protokolü ile korunan bir net.Conn'dur. crypto/tls'teki gibi
+// el sıkışma ilk Read ya da Write'ta kendiliğinden yapılır; hatayı erken
+// görmek için Handshake doğrudan çağrılabilir.
+type Conn struct {
+	conn     net.Conn
+	config   *Config
+	isClient bool
+
+	handshakeMu   sync.Mutex
+	handshakeDone atomic.Bool
+	handshakeErr  error
+	peerKey       ed25519.PublicKey
+
+	inMu  sync.Mutex
+	in    halfConn
+	input []byte // okunmamış düz metin
+	rerr  error  // kalıcı okuma hatası (io.EOF dahil)
+
+	outMu sync.Mutex
+	out   halfConn
+	werr  error // kalıcı yazma hatası
+}
+
+// Client, conn üzerinde istemci tarafı bir Conn döndürür.
+func Client(conn net.Conn, config *Config) *Conn {
+	return &Conn{conn: conn, config: config, isClient: true}
+}
+
+// Server, conn üzerinde sunucu tarafı bir Conn döndürür.
+func Server(conn net.Conn, config *Config) *Conn {
+	return &Conn{conn: conn, config: config}
+}
+
+// Dial, addr'e bağlanır ve el sıkışmayı tamamlar.
+func Dial(network, addr string, config *Config) (*Conn, error) {
+	raw, err := net.DialTimeout(network, addr, config.handshakeTimeout())
+	if err != nil {
+		return nil, err
+	}
+	c := Client(raw, config)
+	if err := c.Handshake(); err != nil {
+		raw.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+type listener struct {
+	net.Listener
+	config *Config
+}
+
+// Accept, el sıkışması yapılmamış bir *Conn döndürür. El sıkışma,
+// bağlantıyı işleyen goroutine'de ilk Read/Write ile olur; yavaş bir
+// istemci böylece diğer bağlantıları bekletmez.
+func (l *listener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return Server(c, l.config), nil
+}
+
+// NewListener, inner'dan gelen bağlantıları sunucu tarafı Conn'lara sarar.
+func NewListener(inner net.Listener, config *Config) net.Listener {
+	return &listener{Listener: inner, config: config}
+}
+
+// Listen, net.Listen ile dinler ve NewListener ile sarar.
+func Listen(network, addr string, config *Config) (net.Listener, error) {
+	l, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewListener(l, config), nil
+}
+
+// Handshake, el sıkışmayı çalıştırır. Birden çok kez çağrılabilir; ilk
+// sonucu döndürür.
+func (c *Conn) Handshake() error {
+	c.handshakeMu.Lock()
+	defer c.handshakeMu.Unlock()
+	if c.handshakeDone.Load() || c.handshakeErr != nil {
+		return c.handshakeErr
+	}
+	c.handshakeErr = c.handshake()
+	c.handshakeDone.Store(c.handshakeErr == nil)
+	return c.handshakeErr
+}
+
+func (c *Conn) handshake() error {
+	if err := c.config.check(); err != nil {
+		return err
+	}
+	c.conn.SetDeadline(time.Now().Add(c.config.handshakeTimeout()))
+	defer c.conn.SetDeadline(time.Time{})
+
+	var (
+		keys trafficKeys
+		peer ed25519.PublicKey
+		err  error
+	)
+	if c.isClient {
+		keys, peer, err = clientHandshake(c.conn, c.config)
+	} else {
+		keys, peer, err = serverHandshake(c.conn, c.config)
+	}
+	if err != nil {
+		return err
+	}
+
+	client := newHalfConn(keys.clientKey, keys.clientIV)
+	server := newHalfConn(keys.serverKey, keys.serverIV)
+	if c.isClient {
+		c.out, c.in = client, server
+	} else {
+		c.out, c.in = server, client
+	}
+	c.peerKey = peer
+	return nil
+}
+
+// PeerKey, el sıkışmada doğrulanan karşı tarafın Ed25519 anahtarıdır.
+// El sıkışma tamamlanmadıysa nil döner.
+func (c *Conn) PeerKey() ed25519.PublicKey {
+	c.handshakeMu.Lock()
+	defer c.handshakeMu.Unlock()
+	return c.peerKey
+}
+
+func (c *Conn) Read(b []byte) (int, error) {
+	if err := c.Handshake(); err != nil {
+		return 0, err
+	}
+	if len(b) == 0 {
+		return 0, nil
+	}
+	c.inMu.Lock()
+	defer c.inMu.Unlock()
+	for len(c.input) == 0 {
+		if c.rerr != nil {
+			return 0, c.rerr
+		}
+		c.rerr = c.readRecord()
+	}
+	n := copy(b, c.input)
+	c.input = c.input[n:]
+	return n, nil
+}
+
+// readRecord, bir kayıt okur. Veri kaydı c.input'u doldurur; close kaydı
+// io.EOF döndürür. Close kaydı gelmeden bağlantı kapanırsa bu bir kesme
+// (truncation) olabilir, io.ErrUnexpectedEOF döner.
+func (c *Conn) readRecord() error {
+	var hdr [3]byte
+	if _, err := io.ReadFull(c.conn, hdr[:]); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	n := int(binary.BigEndian.Uint16(hdr[1:]))
+	if hdr[0] != recordType {
+		return fmt.Errorf("%w: kayıt türü %#x", ErrBadRecord, hdr[0])
+	}
+	if n > maxCiphertext {
+		return ErrRecordOverflow
+	}
+	body := make([]byte, n)
+	if _, err := io.ReadFull(c.conn, body); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	content, data, err := c.in.open(hdr[:], body)
+	if err != nil {
+		return err
+	}
+	switch content {
+	case contentData:
+		c.input = data
+		return nil
+	case contentClose:
+		return io.EOF
+	default:
+		return fmt.Errorf("%w: içerik türü %d", ErrBadRecord, content)
+	}
+}
+
+func (c *Conn) Write(b []byte) (int, error) {
+	if err := c.Handshake(); err != nil {
+		return 0, err
+	}
+	c.outMu.Lock()
+	defer c.outMu.Unlock()
+	if c.werr != nil {
+		return 0, c.werr
+	}
+	var n int
+	for len(b) > 0 {
+		chunk := b[:min(len(b), maxPlaintext)]
+		if err := c.writeRecord(contentData, chunk); err != nil {
+			c.werr = err
+			return n, err
+		}
+		n += len(chunk)
+		b = b[len(chunk):]
+	}
+	return n, nil
+}
+
+func (c *Conn) writeRecord(content byte, data []byte) error {
+	rec, err := c.out.seal(nil, content, data)
+	if err != nil {
+		return err
+	}
+	_, err = c.conn.Write(rec)
+	return err
+}
+
+var errClosed = errors.New("hybrid: bağlantı kapatıldı")
+
+// Close, el sıkışma tamamlandıysa karşı tarafa close kaydı gönderir ve
+// bağlantıyı kapatır. Karşı taraf bu kayıt sayesinde düzgün kapanmayı
+// (io.EOF) saldırganın bağlantıyı kesmesinden ayırabilir. Devam eden bir
+// el sıkışma, alttaki bağlantı kapandığı için hata ile biter.
+func (c *Conn) Close() error {
+	var alertErr error
+	if c.handshakeDone.Load() {
+		c.outMu.Lock()
+		if c.werr == nil {
+			c.conn.SetWriteDeadline(time.Now().Add(time.Second))
+			alertErr = c.writeRecord(contentClose, nil)
+			c.werr = errClosed
+		}
+		c.outMu.Unlock()
+	}
+	if err := c.conn.Close(); err != nil {
+		return err
+	}
+	return alertErr
+}
+
+// CloseWrite, close kaydını gönderir ve alttaki bağlantı destekliyorsa
+// (*net.TCPConn) yazma yönünü kapatır. Okuma devam edebilir; karşı taraf
+// kalan yanıtlarını gönderip bağlantıyı kapatana kadar beklenebilir.
+func (c *Conn) CloseWrite() error {
+	if !c.handshakeDone.Load() {
+		return errors.New("hybrid: el sıkışma tamamlanmadan CloseWrite çağrıldı")
+	}
+	c.outMu.Lock()
+	defer c.outMu.Unlock()
+	if c.werr != nil {
+		return c.werr
+	}
+	err := c.writeRecord(contentClose, nil)
+	c.werr = errClosed
+	if err != nil {
+		return err
+	}
+	if cw, ok := c.conn.(interface{ CloseWrite() error }); ok {
+		return cw.CloseWrite()
+	}
+	return nil
+}
+
+func (c *Conn) LocalAddr() net.Addr                { return c.conn.LocalAddr() }
+func (c *Conn) RemoteAddr() net.Addr               { return c.conn.RemoteAddr() }
+func (c *Conn) SetDeadline(t time.Time) error      { return c.conn.SetDeadline(t) }
+func (c *Conn) SetReadDeadline(t time.Time) error  { return c.conn.SetReadDeadline(t) }
+func (c *Conn) SetWriteDeadline(t time.Time) error { return c.conn.SetWriteDeadline(t) }
+``
+/*
+---
+
+## 📌 `cmd/hybridecho/main.go`
+
+Anahtarlar standart PEM dosyalarıdır (PKCS#8 / PKIX), `openssl pkey -in server.key -text` ile de okunabilir.
+*/
+``go
+// hybridecho, hybrid protokolü üzerinden çalışan bir yankı sunucusu ve
+// istemcisidir.
+//
+//	hybridecho keygen -name server            # server.key, server.pub
+//	hybridecho keygen -name client
+//	hybridecho server -key server.key -peers client.pub
+//	hybridecho client -key client.key -peer server.pub
+package main
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"hybrid-handshake/hybrid"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, "kullanım: hybridecho keygen|server|client [bayraklar]")
+		os.Exit(2)
+	}
+	var err error
+	switch os.Args[1] {
+	case "keygen":
+		err = keygen(os.Args[2:])
+	case "server":
+		err = server(os.Args[2:])
+	case "client":
+		err = client(os.Args[2:])
+	default:
+		err = fmt.Errorf("bilinmeyen komut %q", os.Args[1])
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func keygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	name := fs.String("name", "", "dosya adı öneki (<name>.key, <name>.pub)")
+	fs.Parse(args)
+	if *name == "" {
+		return errors.New("-name gerekli")
+	}
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*name+".key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return err
+	}
+	der, err = x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(*name+".pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("%s.key, %s.pub yazıldı (parmak izi %s)\n", *name, *name, fingerprint(pub))
+	return nil
+}
+
+func server(args []string) error {
+	fs := flag.NewFlagSet("server", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:9443", "dinlenecek adres")
+	keyFile := fs.String("key", "server.key", "sunucu özel anahtarı")
+	peers := fs.String("peers", "client.pub", "virgülle ayrılmış yetkili istemci anahtarları")
+	fs.Parse(args)
+
+	cfg, err := loadConfig(*keyFile, strings.Split(*peers, ","))
+	if err != nil {
+		return err
+	}
+	l, err := hybrid.Listen("tcp", *addr, cfg)
+	if err != nil {
+		return err
+	}
+	log.Printf("dinleniyor %s (X25519 + ML-KEM-768, %d yetkili istemci)", l.Addr(), len(cfg.PeerKeys))
+	for {
+		c, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go serve(c.(*hybrid.Conn))
+	}
+}
+
+func serve(c *hybrid.Conn) {
+	defer c.Close()
+	if err := c.Handshake(); err != nil {
+		log.Printf("%s: el sıkışma: %v", c.RemoteAddr(), err)
+		return
+	}
+	who := fingerprint(c.PeerKey())
+	log.Printf("%s: istemci %s doğrulandı", c.RemoteAddr(), who)
+	s := bufio.NewScanner(c)
+	for s.Scan() {
+		fmt.Fprintf(c, "yankı: %s\n", s.Text())
+	}
+	if err := s.Err(); err != nil {
+		log.Printf("%s: %v", who, err)
+		return
+	}
+	log.Printf("%s: bağlantı kapandı", who)
+}
+
+func client(args []string) error {
+	fs := flag.NewFlagSet("client", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:9443", "sunucu adresi")
+	keyFile := fs.String("key", "client.key", "istemci özel anahtarı")
+	peer := fs.String("peer", "server.pub", "sunucunun açık anahtarı")
+	fs.Parse(args)
+
+	cfg, err := loadConfig(*keyFile, []string{*peer})
+	if err != nil {
+		return err
+	}
+	c, err := hybrid.Dial("tcp", *addr, cfg)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	log.Printf("bağlandı, sunucu %s", fingerprint(c.PeerKey()))
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := io.Copy(os.Stdout, c)
+		done <- err
+	}()
+	in := bufio.NewScanner(os.Stdin)
+	for in.Scan() {
+		if _, err := fmt.Fprintln(c, in.Text()); err != nil {
+			return err
+		}
+	}
+	if err := in.Err(); err != nil {
+		return err
+	}
+	// Girdi bitti: sunucuya close gönder, kalan yankıları okumaya devam et.
+	if err := c.CloseWrite(); err != nil {
+		return err
+	}
+	return <-done
+}
+
+func loadConfig(keyFile string, peerFiles []string) (*hybrid.Config, error) {
+	data, err := os.ReadFile(keyFile)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: PEM bulunamadı", keyFile)
+	}
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", keyFile, err)
+	}
+	priv, ok := k.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%s: Ed25519 anahtarı değil", keyFile)
+	}
+
+	cfg := &hybrid.Config{PrivateKey: priv}
+	for _, f := range peerFiles {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("%s: PEM bulunamadı", f)
+		}
+		k, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", f, err)
+		}
+		pub, ok := k.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("%s: Ed25519 anahtarı değil", f)
+		}
+		cfg.PeerKeys = append(cfg.PeerKeys, pub)
+	}
+	return cfg, nil
+}
+
+// fingerprint, anahtarın SHA-256 özetinin ilk 8 baytıdır (log için).
+func fingerprint(pub ed25519.PublicKey) string {
+	sum := sha256.Sum256(pub)
+	return fmt.Sprintf("%x", sum[:8])
+}
+``
+/*
+---
+
+# 🧪 Testler
+
+## 📌 `hybrid/hybrid_test.go`
+*/
+``go
+package hybrid
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func newKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return priv
+}
+
+func pub(k ed25519.PrivateKey) ed25519.PublicKey { return k.Public().(ed25519.PublicKey) }
+
+// configs, birbirine güvenen bir istemci ve sunucu Config'i döndürür.
+func configs(t *testing.T) (client, server *Config) {
+	ck, sk := newKey(t), newKey(t)
+	client = &Config{PrivateKey: ck, PeerKeys: []ed25519.PublicKey{pub(sk)}, HandshakeTimeout: 5 * time.Second}
+	server = &Config{PrivateKey: sk, PeerKeys: []ed25519.PublicKey{pub(ck)}, HandshakeTimeout: 5 * time.Second}
+	return client, server
+}
+
+type serverResult struct {
+	peer ed25519.PublicKey
+	data []byte
+	err  error // el sıkışma hatası ya da okumayı bitiren hata
+}
+
+// startServer, tek bir bağlantı kabul eden bir sunucu açar. Sunucu
+// bağlantıdaki her şeyi okur ve sonucu kanala yazar.
+func startServer(t *testing.T, cfg *Config) (string, <-chan serverResult) {
+	t.Helper()
+	l, err := Listen("tcp", "127.0.0.1:0", cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	res := make(chan serverResult, 1)
+	go func() {
+		nc, err := l.Accept()
+		if err != nil {
+			res <- serverResult{err: err}
+			return
+		}
+		c := nc.(*Conn)
+		defer c.Close()
+		if err := c.Handshake(); err != nil {
+			res <- serverResult{err: err}
+			return
+		}
+		data, err := io.ReadAll(c)
+		if err == nil {
+			err = io.EOF // ReadAll io.EOF'u nil'e çevirir; testler ikisini ayırsın diye geri koyuyoruz
+		}
+		res <- serverResult{peer: c.PeerKey(), data: data, err: err}
+	}()
+	return l.Addr().String(), res
+}
+
+func TestHandshakeAndTransfer(t *testing.T) {
+	ccfg, scfg := configs(t)
+	addr, res := startServer(t, scfg)
+
+	c, err := Dial("tcp", addr, ccfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(c.PeerKey(), scfg.PrivateKey.Public().(ed25519.PublicKey)) {
+		t.Fatal("istemci yanlış sunucu anahtarı bildirdi")
+	}
+	// Birden çok kayda bölünmesi gereken bir yük.
+	msg := bytes.Repeat([]byte("0123456789abcdef"), 5000)
+	if _, err := c.Write(msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := <-res
+	if r.err != io.EOF {
+		t.Fatalf("sunucu: %v, io.EOF bekleniyordu", r.err)
+	}
+	if !bytes.Equal(r.data, msg) {
+		t.Fatalf("sunucu %d bayt aldı, %d bekleniyordu", len(r.data), len(msg))
+	}
+	if !bytes.Equal(r.peer, pub(ccfg.PrivateKey)) {
+		t.Fatal("sunucu yanlış istemci anahtarı bildirdi")
+	}
+}
+
+func TestBidirectionalEcho(t *testing.T) {
+	ccfg, scfg := configs(t)
+	a, b := net.Pipe()
+	client, server := Client(a, ccfg), Server(b, scfg)
+
+	go func() {
+		defer server.Close()
+		io.Copy(server, server)
+	}()
+	for _, m := range []string{"merhaba", "post-quantum", ""} {
+		if _, err := client.Write([]byte(m + "\n")); err != nil {
+			t.Fatal(err)
+		}
+		got := make([]byte, len(m)+1)
+		if _, err := io.ReadFull(client, got); err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != m+"\n" {
+			t.Fatalf("yankı %q, beklenen %q", got, m+"\n")
+		}
+	}
+	client.Close()
+}
+
+func TestCloseWrite(t *testing.T) {
+	ccfg, scfg := configs(t)
+	l, err := Listen("tcp", "127.0.0.1:0", scfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		c, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		io.Copy(c, c) // istemcinin close kaydına kadar yankı
+	}()
+
+	c, err := Dial("tcp", l.Addr().String(), ccfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c.Write([]byte("son mesaj"))
+	if err := c.CloseWrite(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Write([]byte("x")); err == nil {
+		t.Fatal("CloseWrite sonrası Write başarılı")
+	}
+	got, err := io.ReadAll(c)
+	if err != nil || string(got) != "son mesaj" {
+		t.Fatalf("ReadAll = %q, %v", got, err)
+	}
+}
+
+func TestUntrustedPeers(t *testing.T) {
+	t.Run("sunucu istemciyi tanımıyor", func(t *testing.T) {
+		ccfg, scfg := configs(t)
+		scfg.PeerKeys = []ed25519.PublicKey{pub(newKey(t))}
+		addr, res := startServer(t, scfg)
+
+		c, err := Dial("tcp", addr, ccfg)
+		if err == nil {
+			// İstemci el sıkışmayı kendi tarafında bitirir; ret, ilk okumada görülür.
+			_, err = c.Read(make([]byte, 1))
+			c.Close()
+		}
+		if err == nil {
+			t.Fatal("istemci reddedilmedi")
+		}
+		if r := <-res; !errors.Is(r.err, ErrPeerNotTrusted) {
+			t.Fatalf("sunucu: %v, ErrPeerNotTrusted bekleniyordu", r.err)
+		}
+	})
+	t.Run("istemci sunucuyu tanımıyor", func(t *testing.T) {
+		ccfg, scfg := configs(t)
+		ccfg.PeerKeys = []ed25519.PublicKey{pub(newKey(t))}
+		addr, res := startServer(t, scfg)
+
+		if _, err := Dial("tcp", addr, ccfg); !errors.Is(err, ErrPeerNotTrusted) {
+			t.Fatalf("istemci: %v, ErrPeerNotTrusted bekleniyordu", err)
+		}
+		if r := <-res; r.err == nil {
+			t.Fatal("sunucu el sıkışmayı tamamladı")
+		}
+	})
+}
+
+func TestConfigErrors(t *testing.T) {
+	a, b := net.Pipe()
+	defer a.Close()
+	defer b.Close()
+	if err := Client(a, &Config{PrivateKey: newKey(t)}).Handshake(); err == nil {
+		t.Fatal("boş PeerKeys kabul edildi")
+	}
+	if err := Server(b, &Config{PeerKeys: []ed25519.PublicKey{pub(newKey(t))}}).Handshake(); err == nil {
+		t.Fatal("eksik PrivateKey kabul edildi")
+	}
+}
+``
+/*
+## 📌 `hybrid/vectors_test.go`
+
+Vektörler sabit girdilerle anahtar takviminin ve kayıt biçiminin çıktısını sabitler. Protokolü başka bir dilde yazan biri çıktısını bunlarla karşılaştırabilir.
+
+* X25519 girdisi **RFC 7748**'deki Alice/Bob örneği.
+* HKDF çıktıları ayrıca Python'un standart kütüphanesiyle (`hmac` + `hashlib`, RFC 5869) bağımsız olarak hesaplanıp karşılaştırıldı.
+* ML-KEM'in kendisi için vektör yok. Encapsulation rastgeledir, `crypto/mlkem` de kendi testlerinde FIPS 203 vektörlerini kullanıyor.
+*/
+``go
+package hybrid
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// Test vektörleri. X25519 sırrı RFC 7748 bölüm 6.1'deki Alice/Bob
+// örneğidir; ML-KEM sırrı 00..1f baytlarıdır (ML-KEM'in kendisi
+// crypto/mlkem'in testlerinde doğrulanıyor). HKDF çıktıları, RFC 5869'un
+// Python standart kütüphanesiyle (hmac + hashlib) yazılmış ayrı bir
+// uygulamasıyla da üretilip karşılaştırıldı.
+const (
+	vecAlicePriv = "77076d0a7318a57d3c16c17251b26645df4c2f87ebc0992ab177fba51db92c2a"
+	vecBobPub    = "de9edb7d7b7dc1b4d35b61c2ece435373f8343c85b78674dadfc7e146f882b4f"
+	vecX25519    = "4a5d9d5ba4ce2de1728e3bf480350f25e07e21c947d19e3376f09b3c1e161742"
+	vecMLKEM     = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+	vecPRK       = "e8b8086799c5b7079f124478964df0acc9cd72d45de4f75f45ba66dfa9b39d3a"
+	vecClientFin = "3bf8ebeba22909a0dcdc32de908252a72bcea28342747b53bc9a0851a414d4b2"
+	vecServerFin = "166940f94d8d9b187c7c9b5d023469b5267db97a6dddad54f2d2535418ac97d6"
+	vecClientKey = "605bfd55c6daebe3e8c5560957e94498b9fb879d7322dd0c91e84b5c5d488963"
+	vecClientIV  = "406c65c3a26393d12c5325a3"
+	vecServerKey = "811d13c860943978b34d5ed7970909546591d07d25129a6f5a7375dd7ad7d531"
+	vecServerIV  = "3742d56cbbd4d14ce5bd1566"
+
+	// c2s anahtarlarıyla sıra 0'da "merhaba" veri kaydı ve sıra 1'de close kaydı.
+	vecRecord0 = "1700185d3cdd7ddecfac3083f949659a67d946ff0a4fd8249dbae0"
+	vecRecord1 = "1700110f41d5b86e52e833e9ffd5ee487cfd79f7"
+)
+
+// Vektörlerdeki transcript hash'leri, sabit iki metnin SHA-256'sıdır.
+var (
+	vecTH1 = sha256.Sum256([]byte("th1"))
+	vecTH2 = sha256.Sum256([]byte("th2"))
+)
+
+func TestX25519Vector(t *testing.T) {
+	priv, err := ecdh.X25519().NewPrivateKey(unhex(t, vecAlicePriv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, err := ecdh.X25519().NewPublicKey(unhex(t, vecBobPub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := priv.ECDH(peer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(got) != vecX25519 {
+		t.Fatalf("X25519 = %x", got)
+	}
+}
+
+func TestKeyScheduleVector(t *testing.T) {
+	prk := combine(unhex(t, vecMLKEM), unhex(t, vecX25519))
+	hk := deriveHandshakeKeys(prk, vecTH1[:])
+	tk := deriveTrafficKeys(prk, vecTH2[:])
+
+	for _, tc := range []struct {
+		name string
+		got  []byte
+		want string
+	}{
+		{"prk", prk, vecPRK},
+		{"c finished", hk.client, vecClientFin},
+		{"s finished", hk.server, vecServerFin},
+		{"c2s key", tk.clientKey, vecClientKey},
+		{"c2s iv", tk.clientIV, vecClientIV},
+		{"s2c key", tk.serverKey, vecServerKey},
+		{"s2c iv", tk.serverIV, vecServerIV},
+	} {
+		if hex.EncodeToString(tc.got) != tc.want {
+			t.Errorf("%s = %x, beklenen %s", tc.name, tc.got, tc.want)
+		}
+	}
+
+	// Sırların sırası ve her biri sonucu değiştirmeli: biri sabit kalsa
+	// bile diğeri değişince bütün anahtarlar değişir.
+	if bytes.Equal(combine(unhex(t, vecX25519), unhex(t, vecMLKEM)), prk) {
+		t.Error("combine sıradan bağımsız")
+	}
+	if bytes.Equal(combine(make([]byte, 32), unhex(t, vecX25519)), prk) {
+		t.Error("ML-KEM sırrı prk'yı etkilemiyor")
+	}
+}
+
+func TestRecordVector(t *testing.T) {
+	key, iv := unhex(t, vecClientKey), unhex(t, vecClientIV)
+
+	out := newHalfConn(key, iv)
+	r0, err := out.seal(nil, contentData, []byte("merhaba"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r1, err := out.seal(nil, contentClose, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hex.EncodeToString(r0) != vecRecord0 || hex.EncodeToString(r1) != vecRecord1 {
+		t.Fatalf("kayıtlar\n%x\n%x", r0, r1)
+	}
+
+	open := func(h *halfConn, rec string) (byte, []byte, error) {
+		b := unhex(t, rec)
+		return h.open(b[:3], b[3:])
+	}
+
+	in := newHalfConn(key, iv)
+	if typ, data, err := open(&in, vecRecord0); err != nil || typ != contentData || string(data) != "merhaba" {
+		t.Fatalf("kayıt 0: %d %q %v", typ, data, err)
+	}
+	if typ, _, err := open(&in, vecRecord1); err != nil || typ != contentClose {
+		t.Fatalf("kayıt 1: %d %v", typ, err)
+	}
+
+	// Aynı kayıtlar ters sırayla ya da ikinci kez açılamaz.
+	in = newHalfConn(key, iv)
+	if _, _, err := open(&in, vecRecord1); err != ErrBadRecord {
+		t.Fatalf("sıra 0'da kayıt 1: %v", err)
+	}
+	in = newHalfConn(key, iv)
+	open(&in, vecRecord0)
+	if _, _, err := open(&in, vecRecord0); err != ErrBadRecord {
+		t.Fatalf("tekrar edilen kayıt: %v", err)
+	}
+
+	// İki yönün anahtarları ayrı: istemcinin kaydı istemciye geri
+	// yansıtılırsa (s2c anahtarlarıyla) açılmaz.
+	back := newHalfConn(unhex(t, vecServerKey), unhex(t, vecServerIV))
+	if _, _, err := open(&back, vecRecord0); err != ErrBadRecord {
+		t.Fatalf("yansıtılan kayıt: %v", err)
+	}
+}
+``
+/*
+## 📌 `hybrid/mitm_test.go`
+
+Saldırgan, iki taraf arasında duran bir TCP aracısıdır. Çerçeveleri okur, değiştirir, çoğaltır, sırasını değiştirir ya da düşürür. En güçlü senaryo `TestMITMKeySubstitution`: saldırgan X25519 ve ML-KEM anahtarlarının hepsini kendininkilerle değiştirir ve iki taraftaki bütün sırları bilir. Sunucunun finished MAC'ini bile istemcinin transcript'ine göre doğru hesaplar. Yapamadığı tek şey **imzayı** yeniden üretmektir.
+*/
+``go
+package hybrid
+
+import (
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/mlkem"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"sync"
+	"testing"
+)
+
+// Bu dosyadaki testlerde saldırgan, istemci ile sunucu arasında duran ve
+// bütün baytları görebilen, değiştirebilen bir TCP aracısıdır (MITM).
+// El sıkışma mesajları ve kayıtlar aynı "tür | uzunluk | gövde"
+// çerçevesini kullandığı için aracı çerçeve çerçeve çalışır.
+
+const (
+	toServer = iota
+	toClient
+)
+
+// tamper, yön ve o yöndeki sıra numarasıyla (0 = hello, 1 = auth, 2+ =
+// kayıtlar) gelen çerçeveyi alır ve yerine iletilecek çerçeveleri
+// döndürür. cut true ise aracı iki bağlantıyı da kapatır.
+type tamper func(dir, n int, frame []byte) (out [][]byte, cut bool)
+
+func readFrame(r io.Reader) ([]byte, error) {
+	hdr := make([]byte, 3)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, err
+	}
+	frame := make([]byte, 3+int(binary.BigEndian.Uint16(hdr[1:])))
+	copy(frame, hdr)
+	_, err := io.ReadFull(r, frame[3:])
+	return frame, err
+}
+
+// startMITM, target'a giden tek bir bağlantıyı f ile değiştiren bir aracı
+// açar ve istemcinin bağlanacağı adresi döndürür.
+func startMITM(t *testing.T, target string, f tamper) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		client, err := l.Accept()
+		if err != nil {
+			return
+		}
+		server, err := net.Dial("tcp", target)
+		if err != nil {
+			client.Close()
+			return
+		}
+		var (
+			mu   sync.Mutex // f iki yönden çağrılır; testlerdeki durum paylaşımı için
+			once sync.Once
+		)
+		closeBoth := func() { once.Do(func() { client.Close(); server.Close() }) }
+		pump := func(dir int, src, dst net.Conn) {
+			defer closeBoth()
+			for n := 0; ; n++ {
+				frame, err := readFrame(src)
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				out, cut := f(dir, n, frame)
+				mu.Unlock()
+				if cut {
+					return
+				}
+				for _, b := range out {
+					if _, err := dst.Write(b); err != nil {
+						return
+					}
+				}
+			}
+		}
+		go pump(toServer, client, server)
+		pump(toClient, server, client)
+	}()
+	return l.Addr().String()
+}
+
+func pass(_, _ int, frame []byte) ([][]byte, bool) { return [][]byte{frame}, false }
+
+func TestMITMTransparentRelay(t *testing.T) {
+	ccfg, scfg := configs(t)
+	addr, res := startServer(t, scfg)
+
+	c, err := Dial("tcp", startMITM(t, addr, pass), ccfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Write([]byte("aracıdan geçti"))
+	c.Close()
+	if r := <-res; r.err != io.EOF || string(r.data) != "aracıdan geçti" {
+		t.Fatalf("sunucu: %q %v", r.data, r.err)
+	}
+}
+
+// Saldırgan kendi Ed25519 anahtarıyla iki tarafa da "öbür taraf" gibi
+// davranır. Bütün kriptografi doğru çalışır; onu durduran, istemcinin
+// sunucu anahtarını önceden bilmesidir.
+func TestMITMWithOwnIdentity(t *testing.T) {
+	ccfg, _ := configs(t)
+	attacker := &Config{PrivateKey: newKey(t), PeerKeys: []ed25519.PublicKey{pub(ccfg.PrivateKey)}}
+	addr, res := startServer(t, attacker)
+
+	_, err := Dial("tcp", addr, ccfg)
+	if !errors.Is(err, ErrPeerNotTrusted) {
+		t.Fatalf("istemci: %v, ErrPeerNotTrusted bekleniyordu", err)
+	}
+	if r := <-res; r.err == nil {
+		t.Fatal("saldırgan el sıkışmayı tamamladı")
+	}
+}
+
+// Saldırgan iki taraf arasındaki anahtar değişimini tamamen ele geçirir:
+// X25519 anahtarlarını ve ML-KEM anahtarını kendininkilerle değiştirir,
+// böylece iki taraftaki bütün sırları bilir. Sunucunun finished MAC'ini
+// istemcinin transcript'ine göre yeniden hesaplar. Yapamadığı tek şey,
+// sunucunun imzasını değişmiş transcript için yeniden üretmektir.
+func TestMITMKeySubstitution(t *testing.T) {
+	ccfg, scfg := configs(t)
+	addr, res := startServer(t, scfg)
+
+	var (
+		xToServer, xToClient *ecdh.PrivateKey // saldırganın iki X25519 anahtarı
+		dk                   *mlkem.DecapsulationKey768
+		clientX              *ecdh.PublicKey
+		clientEK             *mlkem.EncapsulationKey768
+		clientT              transcript // istemcinin gördüğü transcript
+		clientPRK            []byte
+	)
+	xToServer, _ = ecdh.X25519().GenerateKey(rand.Reader)
+	xToClient, _ = ecdh.X25519().GenerateKey(rand.Reader)
+	dk, _ = mlkem.GenerateKey768()
+
+	f := func(dir, n int, frame []byte) ([][]byte, bool) {
+		body := frame[3:]
+		switch {
+		case dir == toServer && n == 0: // ClientHello
+			clientT = append(clientT, frame...)
+			clientX, _ = ecdh.X25519().NewPublicKey(body[1 : 1+x25519Size])
+			clientEK, _ = mlkem.NewEncapsulationKey768(body[1+x25519Size:])
+			forged := append([]byte{frame[0], frame[1], frame[2], version}, xToServer.PublicKey().Bytes()...)
+			forged = append(forged, dk.EncapsulationKey().Bytes()...)
+			return [][]byte{forged}, false
+
+		case dir == toClient && n == 0: // ServerHello
+			// Sunucu tarafı sırları da saldırganda; burada yalnızca istemci
+			// tarafını kuruyoruz.
+			xs, _ := xToClient.ECDH(clientX)
+			ks, ct := clientEK.Encapsulate()
+			forged := append([]byte{frame[0], frame[1], frame[2], version}, xToClient.PublicKey().Bytes()...)
+			forged = append(forged, ct...)
+			clientT = append(clientT, forged...)
+			clientPRK = combine(ks, xs)
+			return [][]byte{forged}, false
+
+		case dir == toClient && n == 1: // ServerAuth: imza olduğu gibi, finished yeniden hesaplanır
+			hk := deriveHandshakeKeys(clientPRK, clientT.hash())
+			signed := body[:ed25519.PublicKeySize+ed25519.SignatureSize]
+			forged := append(append([]byte(nil), frame[:3]...), signed...)
+			forged = append(forged, finishedMAC(hk.server, clientT.hash(signed))...)
+			return [][]byte{forged}, false
+		}
+		return [][]byte{frame}, false
+	}
+
+	_, err := Dial("tcp", startMITM(t, addr, f), ccfg)
+	if !errors.Is(err, ErrBadSignature) {
+		t.Fatalf("istemci: %v, ErrBadSignature bekleniyordu", err)
+	}
+	if r := <-res; r.err == nil {
+		t.Fatal("sunucu el sıkışmayı tamamladı")
+	}
+}
+
+// flip, çerçevenin i. gövde baytını değiştirir.
+func flip(frame []byte, i int) []byte {
+	out := append([]byte(nil), frame...)
+	out[3+i] ^= 0x01
+	return out
+}
+
+func TestMITMHandshakeTampering(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		dir, n, at int
+		client     error // istemcinin alacağı hata (nil: yalnızca sunucu reddeder)
+		server     error
+	}{
+		{"ClientHello sürümü", toServer, 0, 0, nil, ErrVersion},
+		{"ClientHello X25519", toServer, 0, 1, ErrBadSignature, nil},
+		{"ClientHello ML-KEM", toServer, 0, 1 + x25519Size + 100, ErrBadSignature, nil},
+		{"ServerHello X25519", toClient, 0, 1, ErrBadSignature, nil},
+		{"ServerHello ML-KEM ciphertext", toClient, 0, 1 + x25519Size + 100, ErrBadSignature, nil},
+		{"ServerAuth imza", toClient, 1, ed25519.PublicKeySize, ErrBadSignature, nil},
+		{"ServerAuth finished", toClient, 1, authSize - 1, ErrBadFinished, nil},
+		{"ClientAuth imza", toServer, 1, ed25519.PublicKeySize, nil, ErrBadSignature},
+		{"ClientAuth finished", toServer, 1, authSize - 1, nil, ErrBadFinished},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ccfg, scfg := configs(t)
+			addr, res := startServer(t, scfg)
+			f := func(dir, n int, frame []byte) ([][]byte, bool) {
+				if dir == tc.dir && n == tc.n {
+					frame = flip(frame, tc.at)
+				}
+				return [][]byte{frame}, false
+			}
+
+			c, err := Dial("tcp", startMITM(t, addr, f), ccfg)
+			if err == nil {
+				_, err = c.Read(make([]byte, 1))
+				c.Close()
+			}
+			if err == nil || (tc.client != nil && !errors.Is(err, tc.client)) {
+				t.Errorf("istemci: %v, beklenen %v", err, tc.client)
+			}
+			r := <-res
+			if r.err == nil || r.err == io.EOF || (tc.server != nil && !errors.Is(r.err, tc.server)) {
+				t.Errorf("sunucu: %v, beklenen %v", r.err, tc.server)
+			}
+		})
+	}
+}
+
+func TestMITMRecordManipulation(t *testing.T) {
+	var held []byte
+	for _, tc := range []struct {
+		name string
+		f    tamper
+		want error // sunucunun Read'den alacağı hata
+		data string
+	}{
+		{
+			name: "değiştirme",
+			f: func(dir, n int, frame []byte) ([][]byte, bool) {
+				if dir == toServer && n == 3 {
+					frame = flip(frame, 0)
+				}
+				return [][]byte{frame}, false
+			},
+			want: ErrBadRecord, data: "bir",
+		},
+		{
+			name: "tekrar",
+			f: func(dir, n int, frame []byte) ([][]byte, bool) {
+				if dir == toServer && n == 2 {
+					return [][]byte{frame, frame}, false
+				}
+				return [][]byte{frame}, false
+			},
+			want: ErrBadRecord, data: "bir",
+		},
+		{
+			name: "sıra değiştirme",
+			f: func(dir, n int, frame []byte) ([][]byte, bool) {
+				switch {
+				case dir == toServer && n == 2:
+					held = frame
+					return nil, false
+				case dir == toServer && n == 3:
+					return [][]byte{frame, held}, false
+				}
+				return [][]byte{frame}, false
+			},
+			want: ErrBadRecord, data: "",
+		},
+		{
+			name: "kayıt düşürme",
+			f: func(dir, n int, frame []byte) ([][]byte, bool) {
+				if dir == toServer && n == 3 {
+					return nil, false
+				}
+				return [][]byte{frame}, false
+			},
+			want: ErrBadRecord, data: "bir",
+		},
+		{
+			// Bütün veri gelir ama close kaydı (5. çerçeve) yerine bağlantı
+			// kesilir. Sunucu bunu düzgün kapanmadan ayırabilmeli.
+			name: "kesme",
+			f: func(dir, n int, frame []byte) ([][]byte, bool) {
+				if dir == toServer && n == 5 {
+					return nil, true
+				}
+				return [][]byte{frame}, false
+			},
+			want: io.ErrUnexpectedEOF, data: "birikiüç",
+		},
+		{
+			// Anahtarı bilmeyen saldırganın uydurduğu bir kayıt.
+			name: "sahte kayıt",
+			f: func(dir, n int, frame []byte) ([][]byte, bool) {
+				if dir == toServer && n == 2 {
+					return [][]byte{{recordType, 0, 20}, make([]byte, 20)}, false
+				}
+				return [][]byte{frame}, false
+			},
+			want: ErrBadRecord, data: "",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ccfg, scfg := configs(t)
+			addr, res := startServer(t, scfg)
+
+			c, err := Dial("tcp", startMITM(t, addr, tc.f), ccfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Her Write ayrı bir kayıttır: c2s yönünde 2, 3, 4. çerçeveler,
+			// Close'un kaydı 5.
+			for _, m := range []string{"bir", "iki", "üç"} {
+				c.Write([]byte(m))
+			}
+			c.Close()
+
+			r := <-res
+			if !errors.Is(r.err, tc.want) {
+				t.Errorf("sunucu: %v, beklenen %v", r.err, tc.want)
+			}
+			if string(r.data) != tc.data {
+				t.Errorf("sunucu %q okudu, beklenen %q", r.data, tc.data)
+			}
+		})
+	}
+}
+``
+/*
+---
+
+# ▶️ Çalıştırma
+*/
+``bash
+go test -v ./...
+``
+/*
+```
+--- PASS: TestHandshakeAndTransfer (0.00s)
+--- PASS: TestBidirectionalEcho (0.00s)
+--- PASS: TestCloseWrite (0.00s)
+--- PASS: TestUntrustedPeers (0.00s)
+--- PASS: TestConfigErrors (0.00s)
+--- PASS: TestMITMTransparentRelay (0.00s)
+--- PASS: TestMITMWithOwnIdentity (0.00s)
+--- PASS: TestMITMKeySubstitution (0.00s)
+--- PASS: TestMITMHandshakeTampering (0.01s)
+    --- PASS: TestMITMHandshakeTampering/ClientHello_sürümü (0.00s)
+    --- PASS: TestMITMHandshakeTampering/ClientHello_X25519 (0.00s)
+    ...
+--- PASS: TestMITMRecordManipulation (0.01s)
+    --- PASS: TestMITMRecordManipulation/değiştirme (0.00s)
+    --- PASS: TestMITMRecordManipulation/tekrar (0.00s)
+    --- PASS: TestMITMRecordManipulation/sıra_değiştirme (0.00s)
+    --- PASS: TestMITMRecordManipulation/kayıt_düşürme (0.00s)
+    --- PASS: TestMITMRecordManipulation/kesme (0.00s)
+    --- PASS: TestMITMRecordManipulation/sahte_kayıt (0.00s)
+--- PASS: TestX25519Vector (0.00s)
+--- PASS: TestKeyScheduleVector (0.00s)
+--- PASS: TestRecordVector (0.00s)
+ok  	hybrid-handshake/hybrid	0.023s
+```
+
+Yankı sunucusu:
+*/
+``bash
+go build -o hybridecho ./cmd/hybridecho
+./hybridecho keygen -name server
+./hybridecho keygen -name client
+./hybridecho server &
+printf 'merhaba\npost-quantum dünya\n' | ./hybridecho client
+``
+/*
+📌 Çıktı:
+
+```
+server.key, server.pub yazıldı (parmak izi a38ff9469b1d28a7)
+client.key, client.pub yazıldı (parmak izi 2e37263a9c6d851e)
+2026/10/18 21:36:34 dinleniyor 127.0.0.1:9443 (X25519 + ML-KEM-768, 1 yetkili istemci)
+2026/10/18 21:36:35 bağlandı, sunucu a38ff9469b1d28a7
+2026/10/18 21:36:35 127.0.0.1:34440: istemci 2e37263a9c6d851e doğrulandı
+yankı: merhaba
+yankı: post-quantum dünya
+2026/10/18 21:36:35 2e37263a9c6d851e: bağlantı kapandı
+```
+
+Yetkisiz bir istemci anahtarıyla (`./hybridecho keygen -name mallory`):
+
+```
+$ echo x | ./hybridecho client -key mallory.key
+2026/10/18 21:36:35 bağlandı, sunucu a38ff9469b1d28a7
+2026/10/18 21:36:35 write tcp 127.0.0.1:34456->127.0.0.1:9443: write: broken pipe
+
+sunucu:
+2026/10/18 21:36:35 127.0.0.1:34456: el sıkışma: hybrid: karşı tarafın anahtarı güvenilir listede değil: ca1c2311...
+```
+
+İstemci yanlış bir sunucu anahtarına güvenirse (`-peer mallory.pub`), el sıkışma istemci tarafında durur:
+
+```
+$ ./hybridecho client -peer mallory.pub
+2026/10/18 21:36:35 hybrid: karşı tarafın anahtarı güvenilir listede değil: 7c703cae...
+```
+
+---
+
+# 📌 Hangi saldırıyı ne durduruyor?
+
+| Saldırı | Fark eden | Hata |
+|---|---|---|
+| Saldırgan kendi Ed25519 anahtarıyla sunucu gibi davranır | istemci | `ErrPeerNotTrusted` |
+| X25519 / ML-KEM anahtarlarını ya da ciphertext'i değiştirir | karşı tarafın imza kontrolü | `ErrBadSignature` |
+| Sürüm baytını değiştirir (downgrade) | sunucu | `ErrVersion` |
+| Finished MAC'ini değiştirir | karşı taraf | `ErrBadFinished` |
+| Kaydı değiştirir, tekrar eder, sırasını değiştirir, düşürür | alıcı | `ErrBadRecord` |
+| Close kaydından önce bağlantıyı keser | alıcı | `io.ErrUnexpectedEOF` |
+
+---
+
+# ⚠️ Notlar
+
+* Bu bir **öğrenme örneği**dir. Gerçek uygulamalarda `crypto/tls` kullanın. Go 1.24'ten beri TLS 1.3 el sıkışması `X25519MLKEM768` ile zaten hibrit ve kuantuma dayanıklı.
+* **İleri gizlilik (forward secrecy)** var: X25519 ve ML-KEM anahtarları her bağlantıda yeniden üretilir. Ed25519 anahtarı sonradan çalınsa bile eski trafik çözülemez.
+* **Kimlik gizleme yok.** Ed25519 açık anahtarları düz metin olarak gider; ağı izleyen biri kimin kiminle konuştuğunu görebilir. TLS 1.3 sertifikaları şifreli gönderir.
+* Ed25519 kuantum dayanıklı değildir. Kuantum bilgisayarlı bir saldırgan **gelecekte** imzaları taklit edebilir, ama bu yalnızca o andaki bağlantıları etkiler. Bugün kaydedilmiş trafiği çözmek için yine ML-KEM'i kırması gerekir. Tam kuantum dayanıklılık için imzalar da ML-DSA gibi bir algoritmaya geçmelidir (standart kütüphanede henüz yok).
+* İstemci, el sıkışmanın son mesajını gönderen taraftır. Sunucu istemciyi reddederse istemci bunu ilk `Read`/`Write`'ta görür (yukarıdaki `broken pipe`). TLS 1.3'te de durum aynıdır.
+* Oturum yenileme, 0-RTT ve anahtar güncelleme (key update) yok. Her bağlantı tam bir el sıkışma yapar.
+
+---
+
+👉 İstersen bir sonraki adımda bu projeye **ML-KEM-1024** ile güvenlik seviyesi seçimi ve el sıkışmada **algoritma pazarlığı** (downgrade korumalı) ekleyebilirim. Bunu ister misin?
+*/
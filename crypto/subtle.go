@@ -514,4 +514,160 @@ bob: 8b3c1d9a7e5f2b...
 
 İstersen ben bunu bir adım daha ileri götürüp **hash ve salt değerlerini JSON dosyasına kaydeden ve program yeniden başlatıldığında okuyabilen bir sistem** de yapabilirim.
 Bunu ister misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Harika 😄 Yalnızca JSON'a kaydetmekle kalmayalım. Yukarıdaki örnekte iterasyon sayısı (`100000`) ve anahtar uzunluğu (`32`) kodda sabit; dosyaya yalnızca salt ve hash yazılsaydı, bu sayıları artırdığımız gün eski kullanıcılar giriş yapamazdı.
+
+Bunun için `pbkdf2.go` dosyasının sonunda tam bir proje yazdık: **`credstore`**. Özellikleri:
+
+* Hash'ler **PHC string formatı**nda saklanır: `$pbkdf2-sha256$i=100000$<salt>$<hash>`. Parametreler kaydın içindedir.
+* Kullanıcılar bir **JSON dosyasında** tutulur (izin `0600`, geçici dosya + `rename` ile atomik yazma).
+* Politika değişince eski hash'ler kullanıcı giriş yaptığında **yükseltilir**.
+* Art arda hatalı girişte **hesap kilitleme**, IP ve kullanıcı başına **deneme sınırı**.
+* Olmayan kullanıcı için de hash doğrulanır; yanıt süresi kullanıcı adının var olup olmadığını ele vermez. Karşılaştırma yine `subtle.ConstantTimeCompare` ile yapılır.
+
+Burada yalnızca yukarıdaki bellek içi kullanıcıların `credstore`'a nasıl taşınacağını gösteriyoruz.
+
+---
+
+## 📌 Kod: `migrate/main.go`
+
+Eski sistemde salt ve hash ayrı alanlardı. `password.PHC` yapısı ikisini, algoritma ve iterasyon sayısıyla birlikte tek bir string'e yazar. `store.Import` bu string'i doğrular ve kaydeder:
+*/
+``go
+// migrate, multiuser_password_system.go'daki bellek içi kullanıcıları
+// (salt ve hash ayrı ayrı, PBKDF2-SHA256, 100000 iterasyon, 32 bayt)
+// credstore'un JSON dosyasına taşır.
+package main
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"maps"
+	"slices"
+
+	"credstore/password"
+	"credstore/store"
+)
+
+// Eski sistemdeki kullanıcı yapısı.
+type User struct {
+	Salt []byte
+	Hash []byte
+}
+
+// register, eski sistemin yaptığını yapar; burada yalnızca taşınacak
+// veriyi üretmek için var.
+func register(users map[string]User, name, pw string) {
+	salt := make([]byte, 16)
+	rand.Read(salt)
+	hash, _ := pbkdf2.Key(sha256.New, pw, salt, 100000, 32)
+	users[name] = User{Salt: salt, Hash: hash}
+}
+
+func main() {
+	old := make(map[string]User)
+	register(old, "alice", "parola123")
+	register(old, "bob", "gizli456")
+
+	f, err := store.OpenJSON("users.json")
+	if err != nil {
+		log.Fatal(err)
+	}
+	s, err := store.New(f, store.Options{Policy: password.DefaultPolicy(), Logf: log.Printf})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Parametreler eski kodda sabitti; PHC string'ine yazılınca kaydın
+	// kendisiyle birlikte taşınırlar.
+	for _, name := range slices.Sorted(maps.Keys(old)) {
+		u := old[name]
+		phc := password.PHC{
+			ID:     password.PBKDF2SHA256,
+			Params: []password.Param{{Key: "i", Value: "100000"}},
+			Salt:   u.Salt,
+			Hash:   u.Hash,
+		}
+		if err := s.Import(name, phc.String()); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%s taşındı: %s\n", name, password.Describe(phc.String()))
+	}
+
+	for _, tc := range []struct{ name, pw string }{
+		{"alice", "parola123"},
+		{"alice", "yanlis"},
+		{"bob", "gizli456"},
+		{"charlie", "deneme"},
+	} {
+		if _, err := s.Authenticate(tc.name, tc.pw, ""); err != nil {
+			fmt.Printf("❌ %s: %v\n", tc.name, err)
+		} else {
+			fmt.Printf("✅ %s başarılı giriş!\n", tc.name)
+		}
+	}
+}
+``
+/*
+---
+
+## 📌 Çıktı
+
+*/
+``bash
+$ go run ./migrate
+alice taşındı: pbkdf2-sha256 i=100000
+bob taşındı: pbkdf2-sha256 i=100000
+2026/10/18 21:50:43 store: alice: hash yükseltildi: pbkdf2-sha256 i=100000 → pbkdf2-sha256 i=600000
+✅ alice başarılı giriş!
+❌ alice: store: kullanıcı adı ya da parola hatalı
+2026/10/18 21:50:43 store: bob: hash yükseltildi: pbkdf2-sha256 i=100000 → pbkdf2-sha256 i=600000
+✅ bob başarılı giriş!
+❌ charlie: store: kullanıcı adı ya da parola hatalı
+``
+/*
+`users.json`. Hash'ler giriş sırasında 600.000 iterasyona yükseltildi; alice'in yanlış parolası `failed_logins` olarak sayıldı:
+
+*/
+``json
+{
+  "users": {
+    "alice": {
+      "name": "alice",
+      "hash": "$pbkdf2-sha256$i=600000$WVrasdtEZ3OVs4wxwWzp7Q$qKlyuTXG5saokJsPkfPEP9KMnNuuKTWUsqOMndTBEiA",
+      "created": "2026-10-18T21:50:43.133891822Z",
+      "password_changed": "2026-10-18T21:50:43.133891822Z",
+      "last_login": "2026-10-18T21:50:43.152697222Z",
+      "failed_logins": 1
+    },
+    "bob": {
+      "name": "bob",
+      "hash": "$pbkdf2-sha256$i=600000$KiDoIAWwISmzOxZHjf4gkw$flnpc6sO1eEXa7lgmRhvWjE0/r3RUvQwOgq0mEPV6Xk",
+      "created": "2026-10-18T21:50:43.152575244Z",
+      "password_changed": "2026-10-18T21:50:43.152575244Z",
+      "last_login": "2026-10-18T21:50:43.395621459Z"
+    }
+  }
+}
+``
+/*
+Program yeniden başlatıldığında kullanıcılar aynı dosyadan okunur. Dosya `credstore` komutuyla da yönetilebilir (`credstore list`, `credstore reset -user alice` ...).
+
+---
+
+## 📌 Notlar
+
+* Hash'ler artık hex değil **dolgusuz base64**. PHC formatı böyle tanımlıyor; aynı bilgi daha kısa yazılıyor.
+* Yukarıdaki örnekte olmayan kullanıcı (`charlie`) hemen `false` dönüyordu. Bu hız farkı, hangi kullanıcı adlarının var olduğunu ölçerek bulmaya izin verir. `credstore` bu durumda da bir hash doğrular.
+* `subtle.ConstantTimeCompare` yalnızca **karşılaştırmayı** sabit sürede yapar. Asıl yavaşlık (ve kaba kuvvete karşı koruma) PBKDF2 / Argon2id'den gelir. İkisi birlikte gerekir.
+
+---
+
+👉 İstersen bir sonraki adımda `subtle` paketinin diğer fonksiyonlarını (`ConstantTimeSelect`, `ConstantTimeByteEq`, `XORBytes`) kullanarak **sabit zamanlı bir tablo araması** örneği yapabilirim. Bunu ister misin?
+*/
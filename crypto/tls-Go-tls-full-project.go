@@ -410,3 +410,5015 @@ docker-compose logs -f
 ✅ Artık hem **TLS Server** hem de **TLS Client**, `docker-compose` ile aynı anda çalışıyor.
 
 İstersen client ile server arasında **iki yönlü TLS (mutual TLS)** desteğini de ekleyelim. Bunu ister misin?
+EVET
+
+# Go TLS Full Project
+
+**Mutual TLS** aslında ilk sürümden beri projede var: sunucu `MUTUAL=true` ile istemci sertifikası ister, istemci de `--mutual` ile sertifika sunar. Docker kurulumunda ise hiç çalışmıyordu. Bunun üç nedeni vardı:
+
+* Dockerfile var olmayan `/app/server/server.crt` dosyasını kopyalıyordu.
+* İstemci `network_mode: host` ile sunucuya ulaşmaya çalışıyordu.
+* Sertifikalar `openssl` + `bash` gerektiren `scripts/generate_certs.sh` ile üretiliyordu.
+
+Bu güncellemede script'in yerine saf Go ile yazılmış küçük bir CA aracı, **`minica`**, geliyor. Docker imajı artık openssl'e ihtiyaç duymuyor ve mTLS compose içinde varsayılan olarak açık.
+
+`minica` şunları yapar:
+
+* **kök** ve **ara CA** oluşturur (kök anahtarı yalnızca ara CA'yı imzalar),
+* anahtar üretip **SAN'lı sunucu** ve **mTLS istemci** sertifikası verir,
+* dışarıda üretilmiş bir **CSR**'ı imzalar,
+* sertifikayı aynı anahtarla **yeniler** (`-expiring 720h` ile toplu),
+* sertifikayı **iptal eder**,
+* verilen her sertifikayı **`pki/index.json`** içinde izler.
+
+---
+
+## Yeni dizin yapısı
+
+```text
+go-tls-full-project/
+├── go.mod
+├── Makefile
+├── Dockerfile
+├── docker-compose.yml
+├── .gitignore
+├── minica/
+│   ├── main.go          // komutlar ve bayraklar
+│   ├── pki.go           // CA yükleme, imzalama, renew, revoke
+│   ├── index.go         // index.json
+│   ├── keys.go          // anahtar üretimi, PEM okuma/yazma
+│   └── minica_test.go
+├── server/main.go       // yalnızca varsayılan yollar değişti
+├── client/main.go       // yalnızca varsayılan yollar değişti
+├── pki/                 // (üretilir) CA anahtarları ve index — repoya girmez
+└── certs/               // (üretilir) sunucu/istemcinin kullandığı dosyalar
+```
+
+`scripts/generate_certs.sh` silindi.
+
+---
+
+## Dosya: `go.mod`
+
+```go
+module go-tls-full-project
+
+go 1.24
+```
+
+---
+
+## Dosya: `minica/main.go`
+
+```go
+// minica, TLS örnek projesi için küçük bir özel CA aracıdır: kök ve ara CA
+// oluşturur, sunucu/istemci sertifikası verir, CSR imzalar, yeniler ve
+// iptal eder. Verilen her sertifika pki/index.json dosyasında izlenir.
+//
+//	minica init         -cn "Example Root CA" -cert certs/ca.pem
+//	minica intermediate -cn "Example Issuing CA"
+//	minica issue        -type server -dns localhost -ip 127.0.0.1 -cert certs/server_cert.pem -key certs/server_key.pem
+//	minica issue        -type client -cn client.local -cert certs/client_cert.pem -key certs/client_key.pem
+//	minica sign         -type server -csr req.csr -cert api.pem
+//	minica renew        -expiring 720h
+//	minica revoke       -serial 3F2A9C -reason keyCompromise
+//	minica list
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("minica: ")
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
+	}
+}
+
+var commands = map[string]func(fs *flag.FlagSet, args []string, out io.Writer) error{
+	"init":         cmdInit,
+	"intermediate": cmdIntermediate,
+	"issue":        cmdIssue,
+	"sign":         cmdSign,
+	"renew":        cmdRenew,
+	"revoke":       cmdRevoke,
+	"list":         cmdList,
+}
+
+const usage = `kullanım: minica <komut> [bayraklar]
+
+komutlar:
+  init          kök CA oluştur
+  intermediate  kökün imzaladığı ara CA oluştur
+  issue         anahtar üret ve sunucu/istemci sertifikası ver
+  sign          dışarıda üretilmiş bir CSR'ı imzala
+  renew         sertifikayı aynı anahtarla yeniden ver
+  revoke        sertifikayı iptal et
+  list          index'i göster
+
+Her komutun bayrakları için: minica <komut> -h
+CA dizini -dir ile ya da MINICA_DIR ile seçilir (varsayılan ./pki).
+`
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return flag.ErrHelp
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("bilinmeyen komut %q", args[0])
+	}
+	fs := flag.NewFlagSet("minica "+args[0], flag.ContinueOnError)
+	fs.String("dir", envOr("MINICA_DIR", "pki"), "CA durum dizini")
+	return cmd(fs, args[1:], out)
+}
+
+// parse, bayrakları ayrıştırır ve -dir'deki PKI'yı açar. Fazladan konumsal
+// argüman genellikle bayrakların yanlış sırada yazıldığını gösterir.
+func parse(fs *flag.FlagSet, args []string) (*PKI, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("beklenmeyen argüman %q", fs.Arg(0))
+	}
+	return openPKI(fs.Lookup("dir").Value.String())
+}
+
+func cmdInit(fs *flag.FlagSet, args []string, out io.Writer) error {
+	name := fs.String("name", "root", "CA adı (pki altındaki dizin)")
+	cn := fs.String("cn", "minica root CA", "kök CA'nın Common Name'i")
+	alg := fs.String("alg", "ecdsa-p256", "anahtar algoritması")
+	days := fs.Int("days", 3650, "geçerlilik süresi (gün)")
+	certFile := fs.String("cert", "", "kök sertifikanın ayrıca kopyalanacağı dosya (istemcilerin güven dosyası)")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	ca, err := p.InitRoot(*name, *cn, *alg, *days)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "kök CA %q: %s/ca.pem (bitiş %s)\n", ca.Name, p.caDir(ca.Name), ca.Cert.NotAfter.Format(time.DateOnly))
+	if *certFile != "" {
+		if err := writeFile(*certFile, certPEM(ca.Cert), 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "güven dosyası: %s\n", *certFile)
+	}
+	return nil
+}
+
+func cmdIntermediate(fs *flag.FlagSet, args []string, out io.Writer) error {
+	parent := fs.String("parent", "root", "imzalayan CA")
+	name := fs.String("name", "issuing", "ara CA adı")
+	cn := fs.String("cn", "minica issuing CA", "ara CA'nın Common Name'i")
+	alg := fs.String("alg", "ecdsa-p256", "anahtar algoritması")
+	days := fs.Int("days", 1825, "geçerlilik süresi (gün)")
+	pathLen := fs.Int("pathlen", 0, "altında izin verilen ara CA sayısı")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	pca, err := p.LoadCA(*parent)
+	if err != nil {
+		return err
+	}
+	ca, err := p.InitIntermediate(pca, *name, *cn, *alg, *days, *pathLen)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "ara CA %q: %s/ca.pem (seri %s, bitiş %s)\n", ca.Name, p.caDir(ca.Name),
+		serialString(ca.Cert.SerialNumber), ca.Cert.NotAfter.Format(time.DateOnly))
+	return nil
+}
+
+// identityFlags, issue ve sign'ın ortak bayraklarıdır.
+type identityFlags struct {
+	ca, kind, cn   *string
+	dns, ip, email listFlag
+	days           *int
+	certFile       *string
+}
+
+func addIdentityFlags(fs *flag.FlagSet) *identityFlags {
+	f := &identityFlags{
+		ca:       fs.String("ca", "issuing", "imzalayan CA"),
+		kind:     fs.String("type", "server", "server ya da client"),
+		cn:       fs.String("cn", "", "Common Name (sunucuda varsayılan ilk SAN)"),
+		days:     fs.Int("days", 365, "geçerlilik süresi (gün)"),
+		certFile: fs.String("cert", "", "sertifika zincirinin yazılacağı dosya"),
+	}
+	fs.Var(&f.dns, "dns", "DNS SAN (virgülle ayrılmış ya da tekrarlanabilir)")
+	fs.Var(&f.ip, "ip", "IP SAN")
+	fs.Var(&f.email, "email", "e-posta SAN")
+	return f
+}
+
+func (f *identityFlags) request() (Request, error) {
+	r := Request{Kind: *f.kind, CN: *f.cn, DNS: f.dns, Emails: f.email, Days: *f.days}
+	for _, s := range f.ip {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return r, fmt.Errorf("geçersiz IP %q", s)
+		}
+		r.IPs = append(r.IPs, ip)
+	}
+	return r, nil
+}
+
+func cmdIssue(fs *flag.FlagSet, args []string, out io.Writer) error {
+	f := addIdentityFlags(fs)
+	keyFile := fs.String("key", "", "özel anahtarın yazılacağı dosya")
+	alg := fs.String("alg", "ecdsa-p256", "anahtar algoritması")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *f.certFile == "" || *keyFile == "" {
+		return errors.New("-cert ve -key gerekli")
+	}
+	r, err := f.request()
+	if err != nil {
+		return err
+	}
+	if err := r.validate(); err != nil {
+		return err
+	}
+	ca, err := p.LoadCA(*f.ca)
+	if err != nil {
+		return err
+	}
+	key, err := generateKey(*alg)
+	if err != nil {
+		return err
+	}
+	r.PublicKey = key.Public()
+	// Anahtar önce yazılır: sertifika index'e girdiğinde anahtarı da diskte olmalı.
+	if err := writeKey(*keyFile, key); err != nil {
+		return err
+	}
+	cert, err := p.Issue(ca, r, *f.certFile)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "%s sertifikası %q: %s, %s (seri %s, bitiş %s)\n", r.Kind, cert.Subject.CommonName,
+		*f.certFile, *keyFile, serialString(cert.SerialNumber), cert.NotAfter.Format(time.DateOnly))
+	return nil
+}
+
+func cmdSign(fs *flag.FlagSet, args []string, out io.Writer) error {
+	f := addIdentityFlags(fs)
+	csrFile := fs.String("csr", "", "imzalanacak CSR (PEM)")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *csrFile == "" || *f.certFile == "" {
+		return errors.New("-csr ve -cert gerekli")
+	}
+	csr, err := readCSR(*csrFile)
+	if err != nil {
+		return err
+	}
+	r, err := f.request()
+	if err != nil {
+		return err
+	}
+	// CSR'dan yalnızca anahtar ve kimlik alınır. İstenen uzantılar (IsCA,
+	// KeyUsage…) yok sayılır; sertifikanın ne yapabileceğine CA karar verir.
+	r.PublicKey = csr.PublicKey
+	if r.CN == "" {
+		r.CN = csr.Subject.CommonName
+	}
+	r.DNS = append(csr.DNSNames, r.DNS...)
+	r.IPs = append(csr.IPAddresses, r.IPs...)
+	r.Emails = append(csr.EmailAddresses, r.Emails...)
+	ca, err := p.LoadCA(*f.ca)
+	if err != nil {
+		return err
+	}
+	cert, err := p.Issue(ca, r, *f.certFile)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "CSR imzalandı %q: %s (seri %s, bitiş %s)\n", cert.Subject.CommonName,
+		*f.certFile, serialString(cert.SerialNumber), cert.NotAfter.Format(time.DateOnly))
+	return nil
+}
+
+func cmdRenew(fs *flag.FlagSet, args []string, out io.Writer) error {
+	serial := fs.String("serial", "", "yenilenecek sertifikanın serisi (ya da benzersiz öneki)")
+	expiring := fs.Duration("expiring", 0, "bu süre içinde dolacak bütün sertifikaları yenile (ör. 720h)")
+	days := fs.Int("days", 365, "yeni geçerlilik süresi (gün)")
+	certFile := fs.String("cert", "", "yeni zincirin yazılacağı dosya (varsayılan: eskisinin yeri)")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	var todo []*Entry
+	switch {
+	case *serial != "" && *expiring == 0:
+		e, err := p.Index.Find(*serial)
+		if err != nil {
+			return err
+		}
+		todo = append(todo, e)
+	case *serial == "" && *expiring > 0:
+		if *certFile != "" {
+			return errors.New("-cert yalnızca -serial ile kullanılabilir")
+		}
+		now := p.Now()
+		todo = p.Index.Expiring(now, now.Add(*expiring))
+	default:
+		return errors.New("-serial ya da -expiring (yalnızca biri) gerekli")
+	}
+	for _, e := range todo {
+		cert, err := p.Renew(e, *days, *certFile)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "yenilendi %s -> %s %q (bitiş %s)\n", e.Serial, e.RenewedBy,
+			cert.Subject.CommonName, cert.NotAfter.Format(time.DateOnly))
+	}
+	if len(todo) == 0 {
+		fmt.Fprintln(out, "yenilenecek sertifika yok")
+	}
+	return nil
+}
+
+func cmdRevoke(fs *flag.FlagSet, args []string, out io.Writer) error {
+	serial := fs.String("serial", "", "iptal edilecek sertifikanın serisi (ya da benzersiz öneki)")
+	reason := fs.String("reason", "unspecified", "RFC 5280 iptal nedeni")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *serial == "" {
+		return errors.New("-serial gerekli")
+	}
+	e, err := p.Index.Find(*serial)
+	if err != nil {
+		return err
+	}
+	if err := p.Revoke(e, *reason); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "iptal edildi %s %s (%s)\n", e.Serial, e.Subject, e.Reason)
+	return nil
+}
+
+func cmdList(fs *flag.FlagSet, args []string, out io.Writer) error {
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	now := p.Now()
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SERIAL\tCA\tKIND\tSUBJECT\tNOT AFTER\tSTATUS")
+	for _, e := range p.Index.Sorted() {
+		status := e.Status(now)
+		switch {
+		case status == "revoked":
+			status += " (" + e.Reason + ")"
+		case e.RenewedBy != "":
+			status += " -> " + short(e.RenewedBy)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", short(e.Serial), e.CA, e.Kind, e.Subject,
+			e.NotAfter.Format(time.DateOnly), status)
+	}
+	return tw.Flush()
+}
+
+// short, seriyi listede okunabilir tutar; Find bu öneki kabul eder.
+func short(serial string) string {
+	if len(serial) > 12 {
+		return serial[:12]
+	}
+	return serial
+}
+
+// listFlag, "-dns a,b" ve "-dns a -dns b" yazımlarının ikisini de kabul eder.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(v string) error {
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			*l = append(*l, s)
+		}
+	}
+	return nil
+}
+
+func envOr(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+```
+
+---
+
+## Dosya: `minica/pki.go`
+
+```go
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// PKI, tek bir dizinde duran CA durumudur:
+//
+//	pki/
+//	├── index.json            verilen her sertifika
+//	├── root/
+//	│   ├── ca.pem            kök sertifika (istemcilerin güveneceği dosya)
+//	│   └── ca.key
+//	└── issuing/
+//	    ├── ca.pem            ara CA + (varsa) üstündeki ara CA'lar, kök hariç
+//	    ├── ca.key
+//	    └── issued/<seri>.pem verilen sertifikaların kopyası (renew ve CRL için)
+type PKI struct {
+	Dir   string
+	Index *Index
+	Now   func() time.Time
+}
+
+func openPKI(dir string) (*PKI, error) {
+	idx, err := loadIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &PKI{Dir: dir, Index: idx, Now: time.Now}, nil
+}
+
+// CA, imza atabilen bir sertifika ve anahtarıdır. Bundle, yaprak sertifikanın
+// arkasına eklenecek ara sertifikalardır; kök CA için boştur.
+type CA struct {
+	Name   string
+	Cert   *x509.Certificate
+	Key    crypto.Signer
+	Bundle []*x509.Certificate
+}
+
+func (p *PKI) caDir(name string) string { return filepath.Join(p.Dir, name) }
+
+func (p *PKI) LoadCA(name string) (*CA, error) {
+	certs, err := readCerts(filepath.Join(p.caDir(name), "ca.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("CA %q: %w", name, err)
+	}
+	key, err := readKey(filepath.Join(p.caDir(name), "ca.key"))
+	if err != nil {
+		return nil, fmt.Errorf("CA %q: %w", name, err)
+	}
+	ca := &CA{Name: name, Cert: certs[0], Key: key}
+	if !ca.Cert.IsCA {
+		return nil, fmt.Errorf("CA %q: sertifika bir CA değil", name)
+	}
+	if !publicKeysEqual(ca.Cert.PublicKey, key.Public()) {
+		return nil, fmt.Errorf("CA %q: ca.key, ca.pem ile eşleşmiyor", name)
+	}
+	if !isSelfSigned(ca.Cert) {
+		ca.Bundle = certs
+	}
+	return ca, nil
+}
+
+func (p *PKI) saveCA(name string, certs []*x509.Certificate, key crypto.Signer) error {
+	dir := p.caDir(name)
+	if err := createNew(filepath.Join(dir, "ca.pem"), filepath.Join(dir, "ca.key")); err != nil {
+		return fmt.Errorf("CA %q: %w", name, err)
+	}
+	if err := writeKey(filepath.Join(dir, "ca.key"), key); err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, "ca.pem"), certPEM(certs...), 0o644)
+}
+
+// InitRoot, kendinden imzalı bir kök CA oluşturur.
+func (p *PKI) InitRoot(name, cn, alg string, days int) (*CA, error) {
+	key, err := generateKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              now.AddDate(0, 0, days),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	cert, err := sign(tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.saveCA(name, []*x509.Certificate{cert}, key); err != nil {
+		return nil, err
+	}
+	return &CA{Name: name, Cert: cert, Key: key}, nil
+}
+
+// InitIntermediate, parent'ın imzaladığı bir ara CA oluşturur. pathLen 0
+// (varsayılan) ara CA'nın yalnızca yaprak sertifika verebileceği anlamına
+// gelir; kök anahtarı böylece çevrimdışı kalabilir.
+func (p *PKI) InitIntermediate(parent *CA, name, cn, alg string, days, pathLen int) (*CA, error) {
+	if pc := parent.Cert; pc.MaxPathLen == 0 && pc.MaxPathLenZero ||
+		pc.MaxPathLen > 0 && pathLen >= pc.MaxPathLen {
+		return nil, fmt.Errorf("CA %q altında bu derinlikte ara CA'ya izin yok (pathlen %d)", parent.Name, pc.MaxPathLen)
+	}
+	key, err := generateKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              p.clampNotAfter(parent, now.AddDate(0, 0, days)),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		MaxPathLen:            pathLen,
+		MaxPathLenZero:        pathLen == 0,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	cert, err := sign(tmpl, parent.Cert, key.Public(), parent.Key)
+	if err != nil {
+		return nil, err
+	}
+	bundle := append([]*x509.Certificate{cert}, parent.Bundle...)
+	if err := p.saveCA(name, bundle, key); err != nil {
+		return nil, err
+	}
+	if err := p.record(parent, cert, "ca", filepath.Join(p.caDir(name), "ca.pem")); err != nil {
+		return nil, err
+	}
+	return &CA{Name: name, Cert: cert, Key: key, Bundle: bundle}, nil
+}
+
+// Request, bir yaprak sertifikada istenen kimliktir. Anahtar ya minica'nın
+// ürettiği anahtardır ya da bir CSR'dan gelir; CA özel anahtarı hiç görmez.
+type Request struct {
+	Kind      string // server ya da client
+	CN        string
+	DNS       []string
+	IPs       []net.IP
+	Emails    []string
+	Days      int
+	PublicKey crypto.PublicKey
+}
+
+func (r *Request) validate() error {
+	switch r.Kind {
+	case "server":
+		if len(r.DNS) == 0 && len(r.IPs) == 0 {
+			// Go 1.15'ten beri CN'e bakılmıyor; SAN'sız sunucu sertifikası işe yaramaz.
+			return errors.New("sunucu sertifikası en az bir -dns ya da -ip ister")
+		}
+		if r.CN == "" {
+			if len(r.DNS) > 0 {
+				r.CN = r.DNS[0]
+			} else {
+				r.CN = r.IPs[0].String()
+			}
+		}
+	case "client":
+		if r.CN == "" {
+			return errors.New("istemci sertifikası -cn ister (sunucu kimliği buradan okur)")
+		}
+	default:
+		return fmt.Errorf("bilinmeyen sertifika türü %q (server ya da client)", r.Kind)
+	}
+	for _, name := range r.DNS {
+		if name == "" || strings.ContainsAny(name, " /:") {
+			return fmt.Errorf("geçersiz DNS adı %q", name)
+		}
+	}
+	if r.Days <= 0 {
+		return fmt.Errorf("geçersiz süre: %d gün", r.Days)
+	}
+	return nil
+}
+
+// Issue, isteği ca ile imzalar, index'e yazar ve sertifikanın bir kopyasını
+// CA dizininde saklar. certFile, index'e "teslim edilen yer" olarak yazılır.
+func (p *PKI) Issue(ca *CA, r Request, certFile string) (*x509.Certificate, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               pkix.Name{CommonName: r.CN},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              p.clampNotAfter(ca, now.AddDate(0, 0, r.Days)),
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		DNSNames:              r.DNS,
+		IPAddresses:           r.IPs,
+		EmailAddresses:        r.Emails,
+	}
+	if _, ok := r.PublicKey.(*rsa.PublicKey); ok {
+		// Yalnızca RSA anahtar taşımada (TLS 1.2 RSA kex) kullanılabilir.
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	if r.Kind == "server" {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	} else {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+	return p.issueTemplate(ca, tmpl, r.PublicKey, r.Kind, certFile)
+}
+
+// Renew, var olan bir sertifikayı aynı açık anahtar, aynı konu ve aynı
+// SAN'larla, yeni seri ve yeni süreyle yeniden verir. Anahtar değişmediği
+// için sunucuda yalnızca sertifika dosyası değişir. Eski sertifika süresi
+// dolana kadar geçerli kalır; hemen geçersiz olması gerekiyorsa ayrıca
+// revoke edilmelidir.
+func (p *PKI) Renew(old *Entry, days int, certFile string) (*x509.Certificate, error) {
+	if old.Kind == "ca" {
+		return nil, fmt.Errorf("%s bir CA; CA'lar renew ile yenilenmez, yeni bir ara CA oluşturun", old.Serial)
+	}
+	if st := old.Status(p.Now()); st == "revoked" {
+		return nil, fmt.Errorf("%s iptal edilmiş; iptal edilmiş bir anahtarla yeniden sertifika verilmez", old.Serial)
+	}
+	if old.RenewedBy != "" {
+		return nil, fmt.Errorf("%s zaten %s ile yenilendi", old.Serial, old.RenewedBy)
+	}
+	ca, err := p.LoadCA(old.CA)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := readCerts(p.issuedPath(old.CA, old.Serial))
+	if err != nil {
+		return nil, err
+	}
+	c := prev[0]
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               c.Subject,
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              p.clampNotAfter(ca, now.AddDate(0, 0, days)),
+		BasicConstraintsValid: true,
+		KeyUsage:              c.KeyUsage,
+		ExtKeyUsage:           c.ExtKeyUsage,
+		DNSNames:              c.DNSNames,
+		IPAddresses:           c.IPAddresses,
+		EmailAddresses:        c.EmailAddresses,
+	}
+	if certFile == "" {
+		certFile = old.CertFile
+	}
+	cert, err := p.issueTemplate(ca, tmpl, c.PublicKey, old.Kind, certFile)
+	if err != nil {
+		return nil, err
+	}
+	old.RenewedBy = serialString(cert.SerialNumber)
+	return cert, p.Index.Save()
+}
+
+// Revoke, kaydı iptal edilmiş olarak işaretler. Dağıtım (CRL, OCSP) ayrı bir
+// adımdır; burada yalnızca index değişir.
+func (p *PKI) Revoke(e *Entry, reason string) error {
+	if !slices.Contains(revocationReasons, reason) {
+		return fmt.Errorf("bilinmeyen iptal nedeni %q (%v)", reason, revocationReasons)
+	}
+	if e.RevokedAt != nil {
+		return fmt.Errorf("%s zaten %s tarihinde iptal edilmiş", e.Serial, e.RevokedAt.Format(time.DateOnly))
+	}
+	now := p.Now().UTC().Truncate(time.Second)
+	e.RevokedAt = &now
+	e.Reason = reason
+	return p.Index.Save()
+}
+
+// revocationReasons, RFC 5280 5.3.1'deki CRLReason adlarıdır (sırası kod
+// değerleriyle aynı değil; 7 kullanılmıyor).
+var revocationReasons = []string{
+	"unspecified", "keyCompromise", "cACompromise", "affiliationChanged",
+	"superseded", "cessationOfOperation", "certificateHold", "privilegeWithdrawn",
+}
+
+func (p *PKI) issueTemplate(ca *CA, tmpl *x509.Certificate, pub crypto.PublicKey, kind, certFile string) (*x509.Certificate, error) {
+	cert, err := sign(tmpl, ca.Cert, pub, ca.Key)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(p.issuedPath(ca.Name, serialString(cert.SerialNumber)), certPEM(cert), 0o644); err != nil {
+		return nil, err
+	}
+	if certFile != "" {
+		// Sunucu ve istemci, zinciri kendisi göndermeli: yaprak + ara CA'lar.
+		// Kök gönderilmez; karşı taraf onu zaten güven deposunda tutar.
+		bundle := append([]*x509.Certificate{cert}, ca.Bundle...)
+		if err := writeFile(certFile, certPEM(bundle...), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.record(ca, cert, kind, certFile); err != nil {
+		return nil, err
+	}
+	return cert, nil
+}
+
+func (p *PKI) record(ca *CA, cert *x509.Certificate, kind, certFile string) error {
+	e := &Entry{
+		Serial:    serialString(cert.SerialNumber),
+		CA:        ca.Name,
+		Kind:      kind,
+		Subject:   cert.Subject.String(),
+		DNS:       cert.DNSNames,
+		Emails:    cert.EmailAddresses,
+		NotBefore: cert.NotBefore.UTC(),
+		NotAfter:  cert.NotAfter.UTC(),
+		CertFile:  certFile,
+	}
+	for _, ip := range cert.IPAddresses {
+		e.IPs = append(e.IPs, ip.String())
+	}
+	p.Index.Add(e)
+	return p.Index.Save()
+}
+
+func (p *PKI) issuedPath(ca, serial string) string {
+	return filepath.Join(p.caDir(ca), "issued", serial+".pem")
+}
+
+// clampNotAfter, sertifikanın imzalayan CA'dan uzun yaşamasını engeller;
+// aksi halde zincir CA'nın bitişinde zaten kırılır ve bu sürpriz olur.
+func (p *PKI) clampNotAfter(ca *CA, notAfter time.Time) time.Time {
+	if notAfter.After(ca.Cert.NotAfter) {
+		fmt.Fprintf(os.Stderr, "minica: uyarı: süre CA %q bitişine (%s) kısaltıldı\n",
+			ca.Name, ca.Cert.NotAfter.Format(time.DateOnly))
+		return ca.Cert.NotAfter
+	}
+	return notAfter
+}
+
+func sign(tmpl, parent *x509.Certificate, pub crypto.PublicKey, key crypto.Signer) (*x509.Certificate, error) {
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, key)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParseCertificate(der)
+}
+
+// newSerial, 128 bit rastgele pozitif bir seri üretir. RFC 5280 seriyi en
+// fazla 20 bayt ile sınırlar; rastgelelik, ayrı bir "serial" dosyası tutmayı
+// ve iki CA'nın aynı seriyi vermesini gereksiz kılar.
+func newSerial() *big.Int {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	for {
+		n, err := rand.Int(rand.Reader, limit)
+		if err != nil {
+			panic(err) // crypto/rand Go 1.24'ten beri hata döndürmez
+		}
+		if n.Sign() > 0 {
+			return n
+		}
+	}
+}
+
+func serialString(n *big.Int) string { return fmt.Sprintf("%X", n) }
+
+func isSelfSigned(c *x509.Certificate) bool {
+	return c.CheckSignatureFrom(c) == nil && c.Subject.String() == c.Issuer.String()
+}
+
+func publicKeysEqual(a, b crypto.PublicKey) bool {
+	k, ok := a.(interface{ Equal(crypto.PublicKey) bool })
+	return ok && k.Equal(b)
+}
+```
+
+---
+
+## Dosya: `minica/index.go`
+
+```go
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Entry, index dosyasındaki bir satırdır: CA'nın verdiği her sertifika
+// (ara CA'lar dahil) için bir kayıt. CRL ve OCSP yanıtları da ileride bu
+// kayıtlardan üretilir; bu yüzden iptal zamanı ve nedeni burada tutulur.
+type Entry struct {
+	Serial    string    `json:"serial"` // büyük harf hex, openssl ile aynı biçim
+	CA        string    `json:"ca"`     // imzalayan CA'nın adı
+	Kind      string    `json:"kind"`   // ca, server, client
+	Subject   string    `json:"subject"`
+	DNS       []string  `json:"dns,omitempty"`
+	IPs       []string  `json:"ips,omitempty"`
+	Emails    []string  `json:"emails,omitempty"`
+	NotBefore time.Time `json:"not_before"`
+	NotAfter  time.Time `json:"not_after"`
+	CertFile  string    `json:"cert_file,omitempty"` // sertifikanın teslim edildiği yol
+
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	Reason    string     `json:"reason,omitempty"`
+	RenewedBy string     `json:"renewed_by,omitempty"`
+}
+
+// Status, kaydın şu anki durumunu döndürür. "expired" saklanmaz,
+// her seferinde saatten hesaplanır.
+func (e *Entry) Status(now time.Time) string {
+	switch {
+	case e.RevokedAt != nil:
+		return "revoked"
+	case now.After(e.NotAfter):
+		return "expired"
+	}
+	return "valid"
+}
+
+type Index struct {
+	path    string
+	Entries []*Entry `json:"entries"`
+}
+
+func loadIndex(dir string) (*Index, error) {
+	idx := &Index{path: filepath.Join(dir, "index.json")}
+	data, err := os.ReadFile(idx.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return idx, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, fmt.Errorf("%s: %w", idx.path, err)
+	}
+	return idx, nil
+}
+
+func (idx *Index) Save() error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(idx.path, append(data, '\n'), 0o644)
+}
+
+func (idx *Index) Add(e *Entry) {
+	idx.Entries = append(idx.Entries, e)
+}
+
+// Find, seriyi tam ya da benzersiz bir önekle bulur; uzun hex seriyi
+// elle yazmak zorunda kalmamak için "minica revoke -serial 3F2A9C" yeterli.
+func (idx *Index) Find(serial string) (*Entry, error) {
+	serial = strings.ToUpper(strings.ReplaceAll(serial, ":", ""))
+	if len(serial) < 6 {
+		return nil, fmt.Errorf("seri %q çok kısa (en az 6 hex hane)", serial)
+	}
+	var found []*Entry
+	for _, e := range idx.Entries {
+		if e.Serial == serial {
+			return e, nil
+		}
+		if strings.HasPrefix(e.Serial, serial) {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("seri %s index'te yok", serial)
+	case 1:
+		return found[0], nil
+	}
+	return nil, fmt.Errorf("seri öneki %s birden fazla kayıtla eşleşiyor", serial)
+}
+
+// Expiring, until'den önce süresi dolacak geçerli ve henüz yenilenmemiş
+// yaprak sertifikaları döndürür. CA'lar otomatik yenilenmez.
+func (idx *Index) Expiring(now time.Time, until time.Time) []*Entry {
+	var out []*Entry
+	for _, e := range idx.Entries {
+		if e.Kind != "ca" && e.RenewedBy == "" && e.Status(now) == "valid" && e.NotAfter.Before(until) {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+// Sorted, kayıtları önce CA'ya sonra bitiş tarihine göre sıralı döndürür.
+func (idx *Index) Sorted() []*Entry {
+	out := slices.Clone(idx.Entries)
+	slices.SortStableFunc(out, func(a, b *Entry) int {
+		if c := strings.Compare(a.CA, b.CA); c != 0 {
+			return c
+		}
+		return a.NotAfter.Compare(b.NotAfter)
+	})
+	return out
+}
+```
+
+---
+
+## Dosya: `minica/keys.go`
+
+```go
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// keyAlgs, -alg bayrağının kabul ettiği değerlerdir. Varsayılan P-256:
+// RSA'dan çok daha hızlı üretilir, küçüktür ve her TLS istemcisi destekler.
+var keyAlgs = []string{"ecdsa-p256", "ecdsa-p384", "ed25519", "rsa-2048", "rsa-3072", "rsa-4096"}
+
+func generateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case "ecdsa-p256", "":
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "ecdsa-p384":
+		return ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case "ed25519":
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		return k, err
+	case "rsa-2048":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	case "rsa-3072":
+		return rsa.GenerateKey(rand.Reader, 3072)
+	case "rsa-4096":
+		return rsa.GenerateKey(rand.Reader, 4096)
+	}
+	return nil, fmt.Errorf("bilinmeyen anahtar algoritması %q (%v)", alg, keyAlgs)
+}
+
+// writeKey, anahtarı PKCS#8 PEM ("PRIVATE KEY") olarak yalnızca sahibinin
+// okuyabileceği izinlerle yazar. PKCS#8 her algoritma için aynı blok tipini
+// kullanır; tls.LoadX509KeyPair ve openssl üçünü de okur.
+func writeKey(path string, key crypto.Signer) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+}
+
+func readKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: PEM bloğu yok", path)
+	}
+	var key any
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY": // generate_certs.sh'in ürettiği eski anahtarlar
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%s: beklenmeyen PEM tipi %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s: %T imza atamaz", path, key)
+	}
+	return signer, nil
+}
+
+func certPEM(certs ...*x509.Certificate) []byte {
+	var out []byte
+	for _, c := range certs {
+		out = append(out, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})...)
+	}
+	return out
+}
+
+// readCerts, dosyadaki bütün sertifikaları sırayla okur (yaprak + zincir).
+func readCerts(path string) ([]*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		c, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		certs = append(certs, c)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%s: sertifika yok", path)
+	}
+	return certs, nil
+}
+
+func readCSR(path string) (*x509.CertificateRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("%s: CERTIFICATE REQUEST bloğu yok", path)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	// İmza, isteği yapanın özel anahtara sahip olduğunu kanıtlar.
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%s: CSR imzası geçersiz: %w", path, err)
+	}
+	return csr, nil
+}
+
+// writeFile, dosyayı önce geçici bir adla yazıp sonra yerine taşır; yarıda
+// kesilen bir çalışma yarım bir sertifika ya da index bırakmaz.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// createNew, var olan bir dosyanın üzerine yazmayı reddeder. CA anahtarını
+// yanlışlıkla ezmek, o CA'nın verdiği her sertifikayı geçersiz kılar.
+func createNew(paths ...string) error {
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			return fmt.Errorf("%s zaten var", p)
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+```
+
+---
+
+## Dosya: `minica/minica_test.go`
+
+Testler gerçek bir zinciri `x509.Verify` ile doğrular ve `net.Pipe` üzerinde gerçek bir mTLS el sıkışması yapar. Ayrıca şunları kontrol ederler:
+
+* CSR'daki `cA=TRUE` isteğinin sertifikaya geçmemesi,
+* renew'un anahtarı ve SAN'ları koruması,
+* iptal edilmiş sertifikanın yenilenememesi,
+* pathlen 0 olan bir ara CA'nın altına CA açılamaması.
+
+```go
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestPKI, kök + ara CA'sı hazır bir PKI döndürür.
+func newTestPKI(t *testing.T) (*PKI, *CA, *CA) {
+	t.Helper()
+	p, err := openPKI(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := p.InitRoot("root", "Test Root", "ecdsa-p256", 3650)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuing, err := p.InitIntermediate(root, "issuing", "Test Issuing", "ecdsa-p256", 1825, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, root, issuing
+}
+
+func issue(t *testing.T, p *PKI, ca *CA, r Request) (certFile, keyFile string) {
+	t.Helper()
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, r.Kind+".pem"), filepath.Join(dir, r.Kind+"-key.pem")
+	key, err := generateKey("ecdsa-p256")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := writeKey(keyFile, key); err != nil {
+		t.Fatal(err)
+	}
+	r.PublicKey = key.Public()
+	if r.Days == 0 {
+		r.Days = 365
+	}
+	if _, err := p.Issue(ca, r, certFile); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+func TestServerChainVerifies(t *testing.T) {
+	p, root, issuing := newTestPKI(t)
+	certFile, _ := issue(t, p, issuing, Request{Kind: "server", DNS: []string{"localhost"}, IPs: []net.IP{net.ParseIP("127.0.0.1")}})
+
+	chain, err := readCerts(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chain) != 2 {
+		t.Fatalf("zincir %d sertifika, 2 bekleniyordu (yaprak + ara)", len(chain))
+	}
+	roots, inter := x509.NewCertPool(), x509.NewCertPool()
+	roots.AddCert(root.Cert)
+	inter.AddCert(chain[1])
+	for _, name := range []string{"localhost", "127.0.0.1"} {
+		_, err := chain[0].Verify(x509.VerifyOptions{DNSName: name, Roots: roots, Intermediates: inter})
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+	if _, err := chain[0].Verify(x509.VerifyOptions{DNSName: "example.com", Roots: roots, Intermediates: inter}); err == nil {
+		t.Error("SAN'da olmayan ad doğrulandı")
+	}
+	// Sunucu sertifikası istemci kimliği olarak kullanılamamalı.
+	_, err = chain[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: inter,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	if err == nil {
+		t.Error("serverAuth sertifikası clientAuth için kabul edildi")
+	}
+}
+
+func TestMutualTLSHandshake(t *testing.T) {
+	p, root, issuing := newTestPKI(t)
+	srvCert, srvKey := issue(t, p, issuing, Request{Kind: "server", DNS: []string{"localhost"}})
+	cliCert, cliKey := issue(t, p, issuing, Request{Kind: "client", CN: "client.local"})
+
+	pool := x509.NewCertPool()
+	pool.AddCert(root.Cert)
+	sc, err := tls.LoadX509KeyPair(srvCert, srvKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cc, err := tls.LoadX509KeyPair(cliCert, cliKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, b := net.Pipe()
+	srv := tls.Server(a, &tls.Config{Certificates: []tls.Certificate{sc}, ClientCAs: pool, ClientAuth: tls.RequireAndVerifyClientCert})
+	cli := tls.Client(b, &tls.Config{Certificates: []tls.Certificate{cc}, RootCAs: pool, ServerName: "localhost"})
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Handshake() }()
+	if err := cli.Handshake(); err != nil {
+		t.Fatal("istemci:", err)
+	}
+	if err := <-errc; err != nil {
+		t.Fatal("sunucu:", err)
+	}
+	if cn := srv.ConnectionState().PeerCertificates[0].Subject.CommonName; cn != "client.local" {
+		t.Errorf("istemci CN = %q", cn)
+	}
+}
+
+func TestSignCSRIgnoresRequestedExtensions(t *testing.T) {
+	p, _, issuing := newTestPKI(t)
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	// CSR kendini CA ilan etmeye çalışıyor; minica bunu yok saymalı.
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "api.internal"},
+		DNSNames: []string{"api.internal"},
+		ExtraExtensions: []pkix.Extension{{
+			Id: []int{2, 5, 29, 19}, Critical: true, Value: []byte{0x30, 0x03, 0x01, 0x01, 0xff}, // cA=TRUE
+		}},
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrFile := filepath.Join(t.TempDir(), "req.csr")
+	os.WriteFile(csrFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), 0o644)
+
+	csr, err := readCSR(csrFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := p.Issue(issuing, Request{Kind: "server", DNS: csr.DNSNames, Days: 30, PublicKey: csr.PublicKey}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cert.IsCA {
+		t.Fatal("CSR'daki cA=TRUE sertifikaya geçti")
+	}
+	if !key.PublicKey.Equal(cert.PublicKey) {
+		t.Error("sertifika CSR'ın anahtarını taşımıyor")
+	}
+
+	// İmzası bozuk CSR reddedilmeli.
+	der[len(der)-3] ^= 0xff
+	os.WriteFile(csrFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), 0o644)
+	if _, err := readCSR(csrFile); err == nil {
+		t.Error("bozuk CSR kabul edildi")
+	}
+}
+
+func TestRenewKeepsKeyAndNames(t *testing.T) {
+	p, _, issuing := newTestPKI(t)
+	certFile, keyFile := issue(t, p, issuing, Request{Kind: "server", DNS: []string{"localhost", "server"}, Days: 10})
+	old := p.Index.Entries[len(p.Index.Entries)-1]
+
+	p.Now = func() time.Time { return time.Now().Add(5 * 24 * time.Hour) }
+	if got := p.Index.Expiring(p.Now(), p.Now().Add(30*24*time.Hour)); len(got) != 1 || got[0] != old {
+		t.Fatalf("Expiring = %v", got)
+	}
+	cert, err := p.Renew(old, 90, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if old.RenewedBy != serialString(cert.SerialNumber) {
+		t.Errorf("RenewedBy = %q", old.RenewedBy)
+	}
+	if strings.Join(cert.DNSNames, ",") != "localhost,server" {
+		t.Errorf("DNSNames = %v", cert.DNSNames)
+	}
+	// Yeni zincir eski yere yazıldı ve eski anahtarla eşleşiyor.
+	if _, err := tls.LoadX509KeyPair(certFile, keyFile); err != nil {
+		t.Fatal(err)
+	}
+	chain, _ := readCerts(certFile)
+	if chain[0].SerialNumber.Cmp(cert.SerialNumber) != 0 {
+		t.Error("sertifika dosyası güncellenmedi")
+	}
+	if _, err := p.Renew(old, 90, ""); err == nil {
+		t.Error("aynı sertifika iki kez yenilendi")
+	}
+
+	reloaded, err := loadIndex(p.Dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reloaded.Entries) != 3 || reloaded.Entries[1].RenewedBy == "" {
+		t.Errorf("index diske yazılmadı: %+v", reloaded.Entries)
+	}
+}
+
+func TestRevokeAndFind(t *testing.T) {
+	p, _, issuing := newTestPKI(t)
+	issue(t, p, issuing, Request{Kind: "client", CN: "alice"})
+	e := p.Index.Entries[len(p.Index.Entries)-1]
+
+	got, err := p.Index.Find(strings.ToLower(e.Serial[:8]))
+	if err != nil || got != e {
+		t.Fatalf("Find(önek) = %v, %v", got, err)
+	}
+	if _, err := p.Index.Find("12"); err == nil {
+		t.Error("çok kısa önek kabul edildi")
+	}
+	if err := p.Revoke(e, "stolen"); err == nil {
+		t.Error("bilinmeyen neden kabul edildi")
+	}
+	if err := p.Revoke(e, "keyCompromise"); err != nil {
+		t.Fatal(err)
+	}
+	if e.Status(time.Now()) != "revoked" {
+		t.Errorf("Status = %q", e.Status(time.Now()))
+	}
+	if err := p.Revoke(e, "keyCompromise"); err == nil {
+		t.Error("iki kez iptal edildi")
+	}
+	if _, err := p.Renew(e, 30, ""); err == nil {
+		t.Error("iptal edilmiş sertifika yenilendi")
+	}
+}
+
+func TestIntermediateCannotOutliveRootOrSignCAs(t *testing.T) {
+	p, err := openPKI(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := p.InitRoot("root", "Short Root", "ecdsa-p256", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuing, err := p.InitIntermediate(root, "issuing", "Issuing", "ecdsa-p256", 1825, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issuing.Cert.NotAfter.After(root.Cert.NotAfter) {
+		t.Error("ara CA kökten uzun yaşıyor")
+	}
+	if issuing.Cert.MaxPathLen != 0 || !issuing.Cert.MaxPathLenZero {
+		t.Error("pathlen 0 yazılmadı")
+	}
+	// pathlen 0 olan ara CA'nın altına CA koymak, doğrulanamayan bir zincir
+	// üretir; minica bunu baştan reddetmeli.
+	if _, err := p.InitIntermediate(issuing, "sub", "Sub", "ecdsa-p256", 10, 0); err == nil {
+		t.Error("pathlen 0 olan CA altına ara CA oluşturuldu")
+	}
+	if _, err := os.Stat(filepath.Join(p.Dir, "sub")); err == nil {
+		t.Error("reddedilen CA için dizin oluştu")
+	}
+}
+
+func TestCLI(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("MINICA_DIR", filepath.Join(dir, "pki"))
+	var out bytes.Buffer
+	steps := [][]string{
+		{"init", "-cn", "CLI Root", "-cert", filepath.Join(dir, "ca.pem")},
+		{"intermediate"},
+		{"issue", "-type", "server", "-dns", "localhost,server", "-ip", "127.0.0.1",
+			"-cert", filepath.Join(dir, "server.pem"), "-key", filepath.Join(dir, "server-key.pem")},
+		{"list"},
+	}
+	for _, args := range steps {
+		if err := run(args, &out); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+	if err := run([]string{"init"}, &out); err == nil || !strings.Contains(err.Error(), "zaten var") {
+		t.Errorf("ikinci init: %v", err)
+	}
+	keyFile := filepath.Join(dir, "nosan-key.pem")
+	if err := run([]string{"issue", "-type", "server", "-cert", filepath.Join(dir, "nosan.pem"), "-key", keyFile}, &out); err == nil {
+		t.Error("SAN'sız sunucu sertifikası verildi")
+	}
+	if _, err := os.Stat(keyFile); err == nil {
+		t.Error("reddedilen istek için anahtar dosyası yazıldı")
+	}
+	if err := run([]string{"list", "extra"}, &out); err == nil {
+		t.Error("fazladan argüman kabul edildi")
+	}
+	for _, want := range []string{"SERIAL", "root", "issuing", "CN=localhost", "valid"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("çıktıda %q yok:\n%s", want, out.String())
+		}
+	}
+}
+```
+
+```bash
+$ go test ./minica
+ok  	go-tls-full-project/minica	0.017s
+```
+
+---
+
+## Dosya: `server/main.go` ve `client/main.go` (değişen satırlar)
+
+Kodun geri kalanı aynı. Yalnızca varsayılan yollar `../scripts/ca/` yerine `../certs/` oldu:
+
+```go
+// server/main.go
+certFile := flag.String("cert", "../certs/server_cert.pem", "server cert PEM")
+keyFile := flag.String("key", "../certs/server_key.pem", "server key PEM")
+caFile := flag.String("ca", "../certs/ca.pem", "CA cert to verify clients (for mutual TLS)")
+
+// client/main.go
+caFile := flag.String("ca", "../certs/ca.pem", "CA cert to trust")
+```
+
+Sunucu, `server_cert.pem` içindeki **yaprak + ara CA** zincirini olduğu gibi gönderir; `tls.LoadX509KeyPair` dosyadaki bütün sertifikaları okur. İstemci yalnızca kökü (`certs/ca.pem`) bilir ve ara CA'yı el sıkışmadan öğrenir. mTLS'te aynı şey ters yönde olur: istemci zincirini gönderir, sunucunun `ClientCAs` havuzunda yalnızca kök vardır.
+
+---
+
+## Dosya: `Makefile`
+
+Hedefler dosyalara bağlı: `make certs` ikinci kez çalıştığında var olan CA'yı ezmez.
+
+```makefile
+.PHONY: certs renew-certs clean-certs server server-mutual client client-mutual test
+
+MINICA = go run ./minica
+
+certs: certs/server_cert.pem certs/client_cert.pem
+
+pki/issuing/ca.pem:
+	$(MINICA) init -cn "go-tls-full-project Root CA" -cert certs/ca.pem
+	$(MINICA) intermediate -cn "go-tls-full-project Issuing CA"
+
+certs/server_cert.pem: pki/issuing/ca.pem
+	$(MINICA) issue -type server -dns localhost,server -ip 127.0.0.1 -cert $@ -key certs/server_key.pem
+
+certs/client_cert.pem: pki/issuing/ca.pem
+	$(MINICA) issue -type client -cn client.local -cert $@ -key certs/client_key.pem
+
+# 30 gün içinde dolacak sertifikaları aynı anahtarla yeniler (cron için uygun).
+renew-certs:
+	$(MINICA) renew -expiring 720h
+
+clean-certs:
+	rm -rf pki certs
+
+server:
+	cd server && go run main.go
+
+server-mutual:
+	cd server && MUTUAL=true go run main.go
+
+client:
+	cd client && go run main.go
+
+client-mutual:
+	cd client && go run main.go --cert ../certs/client_cert.pem --key ../certs/client_key.pem --mutual
+
+test:
+	go test ./...
+```
+
+---
+
+## Dosya: `Dockerfile`
+
+Eski Dockerfile'a göre değişenler:
+
+* `apk add openssl bash` yok. Sertifikaları builder aşamasında derlenen `minica` üretiyor.
+* Var olmayan `server.crt` yerine gerçekten üretilen `certs/` dizini kopyalanıyor.
+* Çalışma imajı `distroless/static`: içinde kabuk yok, uygulama root olmayan kullanıcıyla çalışıyor.
+* CA anahtarlarının durduğu `pki/` imaja girmiyor.
+
+```dockerfile
+# 1. Derleme: openssl ya da bash gerekmiyor, sertifikaları minica üretir
+FROM golang:1.24-alpine AS builder
+
+WORKDIR /app
+
+# Dış bağımlılık yok; go.sum da yok
+COPY go.mod ./
+COPY minica/ ./minica/
+COPY server/ ./server/
+COPY client/ ./client/
+
+RUN CGO_ENABLED=0 go build -o /out/minica ./minica && \
+    CGO_ENABLED=0 go build -o /out/server ./server && \
+    CGO_ENABLED=0 go build -o /out/client ./client
+
+# Geliştirme sertifikaları. "server" SAN'ı compose ağındaki servis adı içindir.
+RUN /out/minica init -cn "go-tls-full-project Root CA" -cert certs/ca.pem && \
+    /out/minica intermediate -cn "go-tls-full-project Issuing CA" && \
+    /out/minica issue -type server -dns localhost,server -ip 127.0.0.1 \
+        -cert certs/server_cert.pem -key certs/server_key.pem && \
+    /out/minica issue -type client -cn client.local \
+        -cert certs/client_cert.pem -key certs/client_key.pem
+
+# 2. Çalışma imajı: yalnızca statik binary'ler ve sertifikalar.
+# pki/ (CA anahtarları) bilerek kopyalanmaz.
+FROM gcr.io/distroless/static-debian12:nonroot
+WORKDIR /app
+
+COPY --from=builder /out/server /out/client ./
+COPY --from=builder --chown=65532:65532 /app/certs ./certs
+
+EXPOSE 8443
+CMD ["./server", "-cert", "certs/server_cert.pem", "-key", "certs/server_key.pem", "-ca", "certs/ca.pem"]
+```
+
+---
+
+## Dosya: `docker-compose.yml`
+
+Eski dosyada istemci ayrı bir servisti ve `network_mode: host` kullanıyordu. Bu, compose ağında `localhost:8443`'e ulaşmıyordu. Daha önemlisi, iki servis ayrı ayrı build edilirse iki **farklı CA** üretilebilirdi. İstemci artık aynı imajdan, sunucu konteynerinin içinde çalıştırılıyor.
+
+```yaml
+services:
+  server:
+    build: .
+    image: go-tls-full-project
+    ports:
+      - "8443:8443"
+    environment:
+      MUTUAL: "true"
+```
+
+---
+
+## Dosya: `.gitignore`
+
+```text
+pki/
+certs/
+```
+
+---
+
+## Dosya: `README.md`
+
+````markdown
+# go-tls-full-project
+
+Bu proje, Go ile TLS kullanarak güvenli bir sunucu ve istemci örneği içerir. Sertifikaları saf Go ile yazılmış `minica` aracı üretir: kök ve ara CA oluşturur, sunucu ve istemci sertifikası verir, CSR imzalar, sertifikaları yeniler ve iptal eder.
+
+### İçindekiler
+- `minica/` - Özel CA aracı. Verilen her sertifikayı `pki/index.json` içinde izler.
+- `server/main.go` - TLS sunucusu. İki mod:
+  - Normal TLS (server cert sadece)
+  - Mutual TLS (istemciden sertifika doğrulama) — `MUTUAL=true` ortam değişkeni ile aktifleşir
+- `client/main.go` - TLS istemcisi. İki mod:
+  - Server doğrulama only (CA trust)
+  - Mutual TLS (istemci sertifikası sunar) — `--mutual` flag ile
+
+### Gereksinimler
+- Go 1.24+ (OpenSSL gerekmez)
+
+### Hızlı kullanım
+1. Sertifikaları üret:
+
+```bash
+make certs
+```
+
+Bu komut `pki/` altında kök (`root`) ve ara (`issuing`) CA'yı, `certs/` altında da şu dosyaları oluşturur:
+
+```text
+certs/ca.pem           kök sertifika, iki tarafın güven dosyası
+certs/server_cert.pem  sunucu sertifikası + ara CA (localhost, server, 127.0.0.1)
+certs/server_key.pem
+certs/client_cert.pem  istemci sertifikası + ara CA (CN=client.local)
+certs/client_key.pem
+```
+
+2. Sunucuyu çalıştır (normal TLS):
+
+```bash
+make server
+```
+
+3. İstemci (server doğrulama):
+
+```bash
+make client
+```
+
+4. Mutual TLS testi (istemci sertifikası ile):
+
+```bash
+make server-mutual
+make client-mutual
+```
+
+5. Sertifika yönetimi:
+
+```bash
+go run ./minica list                                   # index
+go run ./minica renew -expiring 720h                   # 30 günde dolacakları yenile
+go run ./minica revoke -serial 5341BB -reason keyCompromise
+go run ./minica sign -type server -csr req.csr -cert certs/api_cert.pem
+```
+
+```text
+Not: Sunucu 8443 portunda dinler. pki/ dizini CA anahtarlarını içerir; repoya ve imajlara girmemelidir.
+```
+````
+
+---
+
+## 📌 Çalıştırma
+
+### 1. Sertifikaları üret
+
+```bash
+$ make certs
+go run ./minica init -cn "go-tls-full-project Root CA" -cert certs/ca.pem
+kök CA "root": pki/root/ca.pem (bitiş 2036-10-15)
+güven dosyası: certs/ca.pem
+go run ./minica intermediate -cn "go-tls-full-project Issuing CA"
+ara CA "issuing": pki/issuing/ca.pem (seri 729B43D2D6DB5C1812C34C5B40AE0525, bitiş 2031-10-17)
+go run ./minica issue -type server -dns localhost,server -ip 127.0.0.1 -cert certs/server_cert.pem -key certs/server_key.pem
+server sertifikası "localhost": certs/server_cert.pem, certs/server_key.pem (seri 6DD7D8D905C734FE3FFDB826F1AAC411, bitiş 2027-10-18)
+go run ./minica issue -type client -cn client.local -cert certs/client_cert.pem -key certs/client_key.pem
+client sertifikası "client.local": certs/client_cert.pem, certs/client_key.pem (seri 5341BB1C427A1FA06491DDB32F22671F, bitiş 2027-10-18)
+
+$ ls -l certs
+-rw-r--r-- 1 user user  599 Oct 18 21:18 ca.pem
+-rw-r--r-- 1 user user 1259 Oct 18 21:18 client_cert.pem
+-rw------- 1 user user  241 Oct 18 21:18 client_key.pem
+-rw-r--r-- 1 user user 1308 Oct 18 21:18 server_cert.pem
+-rw------- 1 user user  241 Oct 18 21:18 server_key.pem
+```
+
+Anahtarlar `0600` izinle yazılır. Üretilen zincir openssl ile de doğrulanıyor (openssl burada yalnızca kontrol için kullanıldı):
+
+```bash
+$ openssl verify -CAfile certs/ca.pem -untrusted pki/issuing/ca.pem certs/server_cert.pem
+certs/server_cert.pem: OK
+```
+
+### 2. Normal TLS ve mutual TLS
+
+```bash
+$ make server            # ayrı terminalde
+$ make client
+status: 200 OK
+body:
+Hello, TLS world!
+
+$ make server-mutual     # ayrı terminalde
+$ make client-mutual
+status: 200 OK
+body:
+Hello, mutual TLS client CN=client.local
+
+$ make client            # sertifikasız istemci reddedilir
+2026/10/18 21:18:21 GET error: Get "https://localhost:8443/": remote error: tls: certificate required
+```
+
+Sunucu logu:
+
+```text
+2026/10/18 21:18:20 Mutual TLS: enabled (require client cert)
+2026/10/18 21:18:20 Listening on :8443 (TLS)
+2026/10/18 21:18:21 http: TLS handshake error from 127.0.0.1:44476: tls: client didn't provide a certificate
+```
+
+### 3. Index, yenileme, CSR imzalama, iptal
+
+```bash
+$ go run ./minica list
+SERIAL        CA       KIND    SUBJECT                            NOT AFTER   STATUS
+6DD7D8D905C7  issuing  server  CN=localhost                       2027-10-18  valid
+5341BB1C427A  issuing  client  CN=client.local                    2027-10-18  valid
+729B43D2D6DB  root     ca      CN=go-tls-full-project Issuing CA  2031-10-17  valid
+
+$ go run ./minica renew -serial 6DD7D8D9
+yenilendi 6DD7D8D905C734FE3FFDB826F1AAC411 -> 94804311E70C324E429AE158ECFF0665 "localhost" (bitiş 2027-10-18)
+```
+
+Yeni sertifika `certs/server_cert.pem` dosyasının üzerine yazılır. Anahtar değişmez, sunucuyu yeniden başlatmak yeterlidir.
+
+CSR başka bir makinede, anahtarın hiç çıkmadığı yerde üretilebilir; örneğin openssl ile, Go'da `x509.CreateCertificateRequest` ile ya da Kubernetes araçlarıyla. `-dns` ile verilen adlar CSR'dakilere eklenir:
+
+```bash
+$ go run ./minica sign -type server -csr api.csr -dns api.internal.example -days 90 -cert certs/api_cert.pem
+CSR imzalandı "api.internal": certs/api_cert.pem (seri 11B7DC4C769B89431A085B7E53B60A3A, bitiş 2027-01-16)
+
+$ go run ./minica revoke -serial 5341BB1C -reason keyCompromise
+iptal edildi 5341BB1C427A1FA06491DDB32F22671F CN=client.local (keyCompromise)
+
+$ go run ./minica renew -serial 5341BB1C
+minica: 5341BB1C427A1FA06491DDB32F22671F iptal edilmiş; iptal edilmiş bir anahtarla yeniden sertifika verilmez
+
+$ go run ./minica list
+SERIAL        CA       KIND    SUBJECT                            NOT AFTER   STATUS
+11B7DC4C769B  issuing  server  CN=api.internal                    2027-01-16  valid
+6DD7D8D905C7  issuing  server  CN=localhost                       2027-10-18  valid -> 94804311E70C
+5341BB1C427A  issuing  client  CN=client.local                    2027-10-18  revoked (keyCompromise)
+94804311E70C  issuing  server  CN=localhost                       2027-10-18  valid
+729B43D2D6DB  root     ca      CN=go-tls-full-project Issuing CA  2031-10-17  valid
+```
+
+`pki/index.json` içindeki bir kayıt:
+
+```json
+{
+  "serial": "6DD7D8D905C734FE3FFDB826F1AAC411",
+  "ca": "issuing",
+  "kind": "server",
+  "subject": "CN=localhost",
+  "dns": [
+    "localhost",
+    "server"
+  ],
+  "ips": [
+    "127.0.0.1"
+  ],
+  "not_before": "2026-10-18T21:13:13Z",
+  "not_after": "2027-10-18T21:18:13Z",
+  "cert_file": "certs/server_cert.pem",
+  "renewed_by": "94804311E70C324E429AE158ECFF0665"
+}
+```
+
+### 4. Docker
+
+```bash
+docker compose up --build -d
+
+# istemciyi aynı imajdan, sunucu konteynerinin içinde çalıştır
+docker compose exec server ./client -ca certs/ca.pem \
+    -cert certs/client_cert.pem -key certs/client_key.pem -mutual
+
+# ya da sertifikaları dışarı alıp host'tan bağlan
+docker compose cp server:/app/certs ./certs-docker
+cd client && go run . -ca ../certs-docker/ca.pem \
+    -cert ../certs-docker/client_cert.pem -key ../certs-docker/client_key.pem -mutual
+```
+
+```text
+status: 200 OK
+body:
+Hello, mutual TLS client CN=client.local
+```
+
+---
+
+## 📌 generate_certs.sh → minica
+
+| | `generate_certs.sh` | `minica` |
+|---|---|---|
+| Bağımlılık | openssl + bash | yalnızca Go |
+| CA | tek seviye, kök her sertifikayı imzalar | kök + ara CA, pathlen 0 |
+| Anahtar | RSA | ECDSA P-256 varsayılan (`-alg` ile P-384, Ed25519, RSA) |
+| Seri numarası | openssl'in `ca.srl` dosyası | 128 bit rastgele |
+| Zincir dosyası | yok | sertifika dosyası = yaprak + ara CA |
+| Yenileme / iptal | yok | `renew`, `revoke`, `list` |
+| Kayıt | yok | `pki/index.json` |
+| İkinci çalıştırma | CA'yı yeniden üretir, eski sertifikalar geçersiz olur | CA varsa durur (`zaten var`) |
+
+---
+
+### Son notlar
+
+* `revoke` şimdilik yalnızca index'i değiştirir. İptal edilen sertifikayı sunucu ve istemci **henüz bilmiyor**, çünkü bunu dağıtacak bir CRL ya da OCSP yok. Index'teki `revoked_at` ve `reason` alanları bu dağıtım için saklanıyor.
+* CA anahtarları diskte şifresiz (`0600`) durur. Bu proje test/dev amaçlıdır. Üretimde kök anahtarı çevrimdışı tutun, ara CA için Vault, cert-manager ya da bir KMS kullanın.
+* Docker imajındaki sertifikalar build sırasında üretilir ve imajı alan herkes istemci anahtarını da alır. Gerçek ortamda `certs/` bir volume ya da secret olarak bağlanmalıdır.
+
+İstersen bir sonraki adımda index'teki iptal kayıtlarından **CRL** üretelim. Bunun yanında küçük bir **OCSP responder** yazıp sunucunun OCSP yanıtını el sıkışmaya **staple** etmesini sağlayabiliriz. İstemci de iptal edilmiş sertifikaları gerçekten reddeder. Bunu ister misin?
+EVET
+
+# Go TLS Full Project
+
+Önceki sürümde `minica revoke` iptali yalnızca `pki/index.json` içine yazıyordu. İptal edilen bir sertifika sunucu ve istemci tarafından hâlâ kabul ediliyordu, çünkü iptal bilgisini dağıtan bir mekanizma yoktu. `crypto/x509` zinciri doğrular ama iptal durumuna hiç bakmaz.
+
+Bu güncellemeyle iptal bilgisi dağıtılıyor ve iki taraf da kontrol ediyor:
+
+* `minica crl` index'ten CA imzalı bir **CRL** dosyası üretir.
+* `minica serve` her CA için bir **OCSP yanıtlayıcısı** ve güncel CRL sunan bir HTTP sunucusu açar.
+* Verilen sertifikalara OCSP ve CRL adresleri yazılır (**AIA** ve **CRL Distribution Points** uzantıları).
+* Sunucu kendi sertifikasının OCSP yanıtını düzenli olarak alır ve el sıkışmaya ekler (**OCSP stapling**).
+* İstemci sunucu sertifikasını önce staple ile, yoksa OCSP ile, o da olmazsa CRL ile kontrol eder. Sunucu da mTLS'te istemci sertifikasını aynı şekilde kontrol eder.
+* Yanıtlayıcıya ulaşılamazsa ne olacağı seçilebilir: **soft** modda bağlantı kabul edilir ve log yazılır, **strict** modda reddedilir.
+
+Standart kütüphanede OCSP olmadığı için OCSP istek/yanıt kodlaması `golang.org/x/crypto/ocsp` ile yapılıyor. Bu, projenin ilk dış bağımlılığı. CRL tarafı (`x509.CreateRevocationList`, `x509.ParseRevocationList`) standart kütüphaneden geliyor.
+
+---
+
+## Yeni dizin yapısı
+
+```text
+go-tls-full-project/
+├── go.mod                // golang.org/x/crypto eklendi
+├── go.sum
+├── Makefile              // ocsp ve crl hedefleri
+├── Dockerfile
+├── docker-compose.yml
+├── README.md
+├── revocation/
+│   ├── responder.go      // OCSP yanıtlayıcısı (http.Handler)
+│   ├── checker.go        // staple → OCSP → CRL kontrolü, soft/strict
+│   ├── stapler.go        // sunucu tarafı OCSP stapling
+│   └── revocation_test.go
+├── minica/
+│   ├── main.go           // crl ve serve komutları, -ocsp/-crl bayrakları
+│   ├── pki.go            // CRL üretimi, seri durumu
+│   ├── index.go          // Lookup
+│   ├── serve.go          // yeni: /ocsp/<ca> ve /crl/<ca>.crl
+│   ├── keys.go
+│   └── minica_test.go
+├── server/main.go        // stapling + istemci sertifikası iptal kontrolü
+└── client/main.go        // sunucu sertifikası iptal kontrolü
+```
+
+---
+
+## Dosya: `go.mod`
+
+```go
+module go-tls-full-project
+
+go 1.24.0
+
+require golang.org/x/crypto v0.43.0
+```
+
+`go.sum` dosyası `go mod tidy` ile üretilir.
+
+---
+
+## Dosya: `revocation/responder.go`
+
+Yanıtlayıcı, serinin durumunu kendisi bilmez; `Lookup` fonksiyonu ile sorar. `minica serve` bu fonksiyona index'i bağlar. Yanıtları CA'nın kendi anahtarı imzalar, ayrı bir OCSP imzalama sertifikası kullanılmaz.
+
+Başka bir CA'nın verdiği sertifika sorulursa yanıt "unknown" değil `unauthorized` olur. Aynı seri numarası farklı CA'larda bulunabileceği için istekteki issuer hash'leri kontrol edilir.
+
+```go
+// Package revocation, sertifika iptal bilgisinin iki tarafını içerir: CA
+// adına OCSP sorularını yanıtlayan Responder ve TLS bağlantısındaki
+// sertifikaları OCSP staple, çevrimiçi OCSP ve CRL ile kontrol eden Checker.
+// Sunucu tarafında Stapler, kendi sertifikasının OCSP yanıtını düzenli
+// olarak alıp el sıkışmaya ekler.
+package revocation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// Status, bir serinin CA'daki durumudur. Known false ise CA bu seriyi hiç
+// vermemiştir; OCSP'de bu "unknown" demektir, "good" değil.
+type Status struct {
+	Known     bool
+	Revoked   bool
+	RevokedAt time.Time
+	Reason    int // RFC 5280 CRLReason kodu
+}
+
+// Responder, tek bir CA için OCSP (RFC 6960) yanıtlayıcısıdır. Yanıtlar
+// doğrudan CA anahtarıyla imzalanır; RFC 6960 4.2.2.2'ye göre sertifikayı
+// veren CA'nın kendisi her zaman yetkili yanıtlayıcıdır, ayrı bir OCSP
+// imzalama sertifikası gerekmez.
+//
+// Hem POST (gövdede DER istek) hem GET (yolda base64 istek) desteklenir.
+// Handler bir önekin altına bağlanacaksa http.StripPrefix ile kullanılır.
+type Responder struct {
+	Issuer   *x509.Certificate
+	Signer   crypto.Signer
+	Lookup   func(serial *big.Int) (Status, error)
+	Validity time.Duration // ThisUpdate ile NextUpdate arası; 0 ise 1 saat
+	Now      func() time.Time
+}
+
+// maxRequestSize, tek sertifikalık bir OCSP isteği için fazlasıyla yeterlidir.
+const maxRequestSize = 10 << 10
+
+func (r *Responder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var der []byte
+	switch req.Method {
+	case http.MethodPost:
+		body, err := io.ReadAll(io.LimitReader(req.Body, maxRequestSize+1))
+		if err != nil || len(body) > maxRequestSize {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+			return
+		}
+		der = body
+	case http.MethodGet:
+		// RFC 6960 A.1: GET {url}/{base64(DER)}. Base64 içindeki '/' yolu
+		// bölmüş olabilir; önekten sonrası olduğu gibi birleştirilir.
+		b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(req.URL.Path, "/"))
+		if err != nil {
+			writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+			return
+		}
+		der = b
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	ocspReq, err := ocsp.ParseRequest(der)
+	if err != nil {
+		writeOCSP(w, ocsp.MalformedRequestErrorResponse, 0)
+		return
+	}
+	if !r.issuedBy(ocspReq) {
+		// Başka bir CA'nın sertifikası sorulmuş.
+		writeOCSP(w, ocsp.UnauthorizedErrorResponse, 0)
+		return
+	}
+	st, err := r.Lookup(ocspReq.SerialNumber)
+	if err != nil {
+		writeOCSP(w, ocsp.InternalErrorErrorResponse, 0)
+		return
+	}
+
+	now := time.Now()
+	if r.Now != nil {
+		now = r.Now()
+	}
+	validity := r.Validity
+	if validity <= 0 {
+		validity = time.Hour
+	}
+	tmpl := ocsp.Response{
+		Status:       ocsp.Unknown,
+		SerialNumber: ocspReq.SerialNumber,
+		ThisUpdate:   now.Add(-time.Minute).Truncate(time.Second),
+		NextUpdate:   now.Add(validity).Truncate(time.Second),
+		IssuerHash:   ocspReq.HashAlgorithm, // CertID istekteki hash ile aynı olmalı
+	}
+	switch {
+	case st.Revoked:
+		tmpl.Status = ocsp.Revoked
+		tmpl.RevokedAt = st.RevokedAt
+		tmpl.RevocationReason = st.Reason
+	case st.Known:
+		tmpl.Status = ocsp.Good
+	}
+	resp, err := ocsp.CreateResponse(r.Issuer, r.Issuer, tmpl, r.Signer)
+	if err != nil {
+		writeOCSP(w, ocsp.InternalErrorErrorResponse, 0)
+		return
+	}
+	maxAge := time.Duration(0)
+	if req.Method == http.MethodGet {
+		// Yalnızca GET yanıtları HTTP önbelleklerinde tutulabilir (RFC 5019).
+		maxAge = validity / 2
+	}
+	writeOCSP(w, resp, maxAge)
+}
+
+func writeOCSP(w http.ResponseWriter, resp []byte, maxAge time.Duration) {
+	w.Header().Set("Content-Type", "application/ocsp-response")
+	if maxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d, public, no-transform, must-revalidate", int(maxAge.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.Write(resp)
+}
+
+// issuedBy, istekteki CertID'nin bu CA'yı gösterip göstermediğine bakar.
+// ocsp.ParseResponseForCert bu hash'leri kontrol etmez; seri numaraları
+// CA'lar arasında çakışabileceği için kontrol yanıtlayıcıya düşer.
+func (r *Responder) issuedBy(req *ocsp.Request) bool {
+	if !req.HashAlgorithm.Available() {
+		return false
+	}
+	var spki struct {
+		Algorithm asn1.RawValue
+		PublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(r.Issuer.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return false
+	}
+	h := req.HashAlgorithm.New()
+	h.Write(r.Issuer.RawSubject)
+	nameHash := h.Sum(nil)
+	h.Reset()
+	h.Write(spki.PublicKey.RightAlign())
+	keyHash := h.Sum(nil)
+	return bytes.Equal(nameHash, req.IssuerNameHash) && bytes.Equal(keyHash, req.IssuerKeyHash)
+}
+```
+
+---
+
+## Dosya: `revocation/checker.go`
+
+`Checker.VerifyConnection` doğrudan `tls.Config.VerifyConnection` alanına verilir. Bu fonksiyon Go'nun zincir doğrulamasından **sonra** çalışır, bu yüzden `cs.VerifiedChains` dolu gelir. Zincirdeki her sertifika (kök hariç) kendi issuer'ına karşı kontrol edilir:
+
+1. Yaprak için staple edilmiş yanıt varsa önce ona bakılır. Staple geçersiz ya da süresi dolmuşsa log yazılır ve çevrimiçi kontrole geçilir.
+2. Sertifikadaki OCSP adresleri sırayla denenir.
+3. Hiçbiri yanıt vermezse CRL Distribution Points denenir. CRL'ler `NextUpdate` zamanına kadar önbellekte tutulur.
+
+Sertifikada hiç OCSP/CRL adresi yoksa kontrol yapılmaz. Eski sertifikalar ve Docker imajındaki sertifikalar bu yüzden çalışmaya devam eder.
+
+OCSP yanıtı kabul edilmeden önce şunlar kontrol edilir: imza, yanıt içinde gelen imzalayan sertifikanın issuer ya da issuer'ın `OCSPSigning` yetkisi verdiği bir sertifika olması, seri numarası ve `ThisUpdate`/`NextUpdate` aralığı. `x/crypto/ocsp` bunların bir kısmını yapmıyor.
+
+```go
+package revocation
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// Mode, iptal bilgisine hiç ulaşılamadığında ne yapılacağını belirler.
+// Sertifika iptal edilmişse iki modda da bağlantı reddedilir.
+type Mode int
+
+const (
+	// Soft, yanıtlayıcıya ve CRL'ye ulaşılamazsa bağlantıya izin verir
+	// (tarayıcıların çoğunun davranışı).
+	Soft Mode = iota
+	// Strict, iptal durumu kanıtlanamayan sertifikayı reddeder.
+	Strict
+)
+
+// ParseMode, komut satırındaki "soft" ve "strict" değerlerini çözer.
+func ParseMode(s string) (Mode, error) {
+	switch s {
+	case "soft":
+		return Soft, nil
+	case "strict":
+		return Strict, nil
+	}
+	return 0, fmt.Errorf("revocation: bilinmeyen mod %q (soft ya da strict)", s)
+}
+
+// ErrUnavailable, Strict modda iptal durumu öğrenilemediğinde döner.
+var ErrUnavailable = errors.New("revocation: iptal durumu öğrenilemedi")
+
+// RevokedError, iptal edilmiş bir sertifika bulunduğunda döner.
+type RevokedError struct {
+	Subject string
+	Serial  *big.Int
+	At      time.Time
+	Reason  int
+	Source  string // "staple", "ocsp" ya da "crl"
+}
+
+func (e *RevokedError) Error() string {
+	return fmt.Sprintf("revocation: %s (seri %X) %s tarihinde iptal edilmiş (neden %d, kaynak %s)",
+		e.Subject, e.Serial, e.At.UTC().Format(time.RFC3339), e.Reason, e.Source)
+}
+
+// skew, OCSP ve CRL zaman alanları için tanınan saat farkıdır.
+const skew = 5 * time.Minute
+
+// Checker, doğrulanmış bir zincirdeki her sertifikanın (kök hariç) iptal
+// durumunu kontrol eder. Sıra: yaprak için staple, sonra sertifikadaki OCSP
+// adresleri, sonra CRL dağıtım noktaları. Sertifikada hiçbiri yoksa kontrol
+// edilecek bir şey yoktur ve sertifika kabul edilir.
+//
+// Sıfır değeri Soft modda kullanıma hazırdır. Checker eşzamanlı kullanım
+// için güvenlidir.
+type Checker struct {
+	Client    *http.Client // nil ise 5 sn zaman aşımlı bir istemci
+	Mode      Mode
+	CRLMaxAge time.Duration // CRL'nin önbellekte kalacağı en uzun süre; 0 ise 10 dk
+	Logf      func(format string, args ...any)
+	Now       func() time.Time
+
+	mu   sync.Mutex
+	crls map[string]cachedCRL
+}
+
+type cachedCRL struct {
+	list    *x509.RevocationList
+	expires time.Time
+}
+
+var defaultClient = &http.Client{Timeout: 5 * time.Second}
+
+// VerifyConnection, tls.Config.VerifyConnection olarak kullanılır. İstemcide
+// sunucunun zincirini (varsa staple ile), sunucuda istemcinin zincirini
+// kontrol eder. Standart zincir doğrulamasından sonra çağrılır, bu yüzden
+// VerifiedChains'e güvenebilir.
+func (c *Checker) VerifyConnection(cs tls.ConnectionState) error {
+	if len(cs.VerifiedChains) == 0 {
+		return nil // karşı taraf sertifika göndermedi (mTLS kapalı)
+	}
+	return c.CheckChain(context.Background(), cs.VerifiedChains[0], cs.OCSPResponse)
+}
+
+// CheckChain, chain[i]'yi chain[i+1]'e göre kontrol eder. staple yalnızca
+// yaprak için kullanılır; ara CA'lar her zaman çevrimiçi kontrol edilir.
+func (c *Checker) CheckChain(ctx context.Context, chain []*x509.Certificate, staple []byte) error {
+	for i := 0; i+1 < len(chain); i++ {
+		cert, issuer := chain[i], chain[i+1]
+		if i == 0 && len(staple) > 0 {
+			resp, err := verifyOCSP(staple, cert, issuer, c.now())
+			if err == nil {
+				switch resp.Status {
+				case ocsp.Good:
+					c.logf("revocation: %s good (staple)", cert.Subject)
+					continue
+				case ocsp.Revoked:
+					return revoked(cert, resp, "staple")
+				}
+			} else {
+				c.logf("revocation: %s: staple kullanılamadı: %v", cert.Subject, err)
+			}
+		}
+		if err := c.Check(ctx, cert, issuer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Check, tek bir sertifikayı çevrimiçi kontrol eder.
+func (c *Checker) Check(ctx context.Context, cert, issuer *x509.Certificate) error {
+	if len(cert.OCSPServer) == 0 && len(cert.CRLDistributionPoints) == 0 {
+		return nil
+	}
+	var errs []error
+	for _, url := range cert.OCSPServer {
+		resp, err := FetchOCSP(ctx, c.client(), url, cert, issuer, c.now())
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		switch resp.Status {
+		case ocsp.Good:
+			c.logf("revocation: %s good (ocsp %s)", cert.Subject, url)
+			return nil
+		case ocsp.Revoked:
+			return revoked(cert, resp, "ocsp")
+		}
+		errs = append(errs, fmt.Errorf("%s: yanıtlayıcı seriyi tanımıyor", url))
+	}
+	for _, url := range cert.CRLDistributionPoints {
+		list, err := c.crl(ctx, url, issuer)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, e := range list.RevokedCertificateEntries {
+			if e.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return &RevokedError{Subject: cert.Subject.String(), Serial: cert.SerialNumber,
+					At: e.RevocationTime, Reason: e.ReasonCode, Source: "crl"}
+			}
+		}
+		c.logf("revocation: %s good (crl %s)", cert.Subject, url)
+		return nil
+	}
+	msgs := make([]string, len(errs))
+	for i, e := range errs {
+		msgs[i] = e.Error()
+	}
+	err := fmt.Errorf("%w: %s: %s", ErrUnavailable, cert.Subject, strings.Join(msgs, "; "))
+	if c.Mode == Strict {
+		return err
+	}
+	c.logf("%v (soft mod, kabul edildi)", err)
+	return nil
+}
+
+// crl, CRL'yi indirir, issuer imzasını ve süresini doğrular. Sonuç
+// NextUpdate'e kadar, ama en fazla CRLMaxAge boyunca önbellekte tutulur;
+// aksi halde yeni bir iptal, CRL'nin geçerlilik süresi (çoğu zaman günler)
+// bitene kadar görünmez.
+func (c *Checker) crl(ctx context.Context, url string, issuer *x509.Certificate) (*x509.RevocationList, error) {
+	now := c.now()
+	c.mu.Lock()
+	cached, ok := c.crls[url]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.list, nil
+	}
+
+	der, err := get(ctx, c.client(), url)
+	if err != nil {
+		return nil, err
+	}
+	list, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+	if err := list.CheckSignatureFrom(issuer); err != nil {
+		return nil, fmt.Errorf("%s: CRL imzası: %w", url, err)
+	}
+	if !list.NextUpdate.IsZero() && now.After(list.NextUpdate.Add(skew)) {
+		return nil, fmt.Errorf("%s: CRL süresi %s tarihinde dolmuş", url, list.NextUpdate.Format(time.RFC3339))
+	}
+
+	maxAge := c.CRLMaxAge
+	if maxAge <= 0 {
+		maxAge = 10 * time.Minute
+	}
+	expires := now.Add(maxAge)
+	if !list.NextUpdate.IsZero() && list.NextUpdate.Before(expires) {
+		expires = list.NextUpdate
+	}
+	c.mu.Lock()
+	if c.crls == nil {
+		c.crls = make(map[string]cachedCRL)
+	}
+	c.crls[url] = cachedCRL{list: list, expires: expires}
+	c.mu.Unlock()
+	return list, nil
+}
+
+func (c *Checker) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+	return defaultClient
+}
+
+func (c *Checker) now() time.Time {
+	if c.Now != nil {
+		return c.Now()
+	}
+	return time.Now()
+}
+
+func (c *Checker) logf(format string, args ...any) {
+	if c.Logf != nil {
+		c.Logf(format, args...)
+	}
+}
+
+func revoked(cert *x509.Certificate, resp *ocsp.Response, source string) error {
+	return &RevokedError{Subject: cert.Subject.String(), Serial: cert.SerialNumber,
+		At: resp.RevokedAt, Reason: resp.RevocationReason, Source: source}
+}
+
+// FetchOCSP, url'deki yanıtlayıcıya POST ile sorar ve yanıtı verifyOCSP ile
+// doğrular. Stapler da aynı fonksiyonu kullanır.
+func FetchOCSP(ctx context.Context, client *http.Client, url string, cert, issuer *x509.Certificate, now time.Time) (*ocsp.Response, error) {
+	reqDER, err := ocsp.CreateRequest(cert, issuer, &ocsp.RequestOptions{Hash: crypto.SHA256})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqDER))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	req.Header.Set("Accept", "application/ocsp-response")
+	body, err := do(client, req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := verifyOCSP(body, cert, issuer, now)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", url, err)
+	}
+	return resp, nil
+}
+
+// verifyOCSP, yanıtın issuer tarafından (ya da issuer'ın yetkilendirdiği
+// bir sertifikayla) bu seri için imzalandığını ve şu an geçerli olduğunu
+// kontrol eder. Süresi dolmuş bir "good" yanıt, iptalden önce alınmış ve
+// tekrar oynatılan bir yanıt olabilir.
+func verifyOCSP(der []byte, cert, issuer *x509.Certificate, now time.Time) (*ocsp.Response, error) {
+	resp, err := ocsp.ParseResponseForCert(der, cert, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Certificate != nil && !resp.Certificate.Equal(issuer) && !hasOCSPSigning(resp.Certificate) {
+		// ParseResponseForCert bu kontrolü yapmaz: CA'nın verdiği herhangi
+		// bir sunucu sertifikası aksi halde yanıt imzalayabilirdi.
+		return nil, errors.New("OCSP yanıtını imzalayan sertifikada OCSPSigning EKU yok")
+	}
+	if now.Add(skew).Before(resp.ThisUpdate) {
+		return nil, fmt.Errorf("OCSP yanıtı gelecekten (thisUpdate %s)", resp.ThisUpdate.Format(time.RFC3339))
+	}
+	if !resp.NextUpdate.IsZero() && now.After(resp.NextUpdate.Add(skew)) {
+		return nil, fmt.Errorf("OCSP yanıtının süresi dolmuş (nextUpdate %s)", resp.NextUpdate.Format(time.RFC3339))
+	}
+	return resp, nil
+}
+
+func hasOCSPSigning(c *x509.Certificate) bool {
+	for _, u := range c.ExtKeyUsage {
+		if u == x509.ExtKeyUsageOCSPSigning {
+			return true
+		}
+	}
+	return false
+}
+
+func get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return do(client, req)
+}
+
+func do(client *http.Client, req *http.Request) ([]byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: HTTP %s", req.URL, resp.Status)
+	}
+	// CRL'ler büyüyebilir; yine de sınırsız okuma yapılmaz.
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", req.URL, err)
+	}
+	return body, nil
+}
+```
+
+---
+
+## Dosya: `revocation/stapler.go`
+
+`Stapler`, sertifikayı `tls.Certificate.OCSPStaple` alanı dolu bir kopyasıyla `GetCertificate` üzerinden sunar. Yanıt, geçerlilik süresinin yarısında yenilenir. Yenileme başarısız olursa eski staple süresi dolana kadar kullanılır; süresi dolmuş bir yanıt staple edilmez.
+
+İptal edilmiş bir yanıt da staple edilir. Sunucu bunu saklarsa istemci yine OCSP'ye gidip iptali görür, yani saklamak bir şey kazandırmaz. Bunun yerine sunucu logu "UYARI" satırı yazar.
+
+```go
+package revocation
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// Stapler, sunucu sertifikasının OCSP yanıtını düzenli olarak alır ve
+// tls.Certificate.OCSPStaple alanına koyar. İstemciler böylece
+// yanıtlayıcıya kendileri gitmeden (ve sunucuya kimin bağlandığını
+// yanıtlayıcıya söylemeden) iptal durumunu el sıkışmada öğrenir.
+type Stapler struct {
+	Client *http.Client // nil ise 5 sn zaman aşımlı bir istemci
+	Logf   func(format string, args ...any)
+
+	base   tls.Certificate
+	leaf   *x509.Certificate
+	issuer *x509.Certificate
+	cert   atomic.Pointer[tls.Certificate]
+	next   time.Time // mevcut staple'ın NextUpdate'i
+}
+
+// ErrNoOCSPServer, sertifikada OCSP adresi olmadığında NewStapler'dan döner.
+var ErrNoOCSPServer = errors.New("revocation: sertifikada OCSP adresi yok")
+
+// NewStapler, zinciri (yaprak + issuer) içeren bir sertifika ister; issuer
+// olmadan OCSP isteği kurulamaz.
+func NewStapler(cert tls.Certificate) (*Stapler, error) {
+	if len(cert.Certificate) < 2 {
+		return nil, errors.New("revocation: staple için sertifika dosyasında issuer (ara CA) da olmalı")
+	}
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return nil, ErrNoOCSPServer
+	}
+	issuer, err := x509.ParseCertificate(cert.Certificate[1])
+	if err != nil {
+		return nil, err
+	}
+	cert.Leaf = leaf
+	s := &Stapler{base: cert, leaf: leaf, issuer: issuer}
+	s.cert.Store(&cert)
+	return s, nil
+}
+
+// GetCertificate, tls.Config.GetCertificate olarak kullanılır.
+func (s *Stapler) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return s.cert.Load(), nil
+}
+
+// Refresh, yeni bir yanıt alır ve staple'ı değiştirir. Bir sonraki
+// yenilemenin zamanını döndürür: yanıtın geçerlilik süresinin yarısı.
+// Yanıt "revoked" ise yine de staple edilir; yalan söylemek yerine
+// istemcinin bağlantıyı reddetmesine izin veririz.
+func (s *Stapler) Refresh(ctx context.Context) (time.Time, error) {
+	now := time.Now()
+	client := s.Client
+	if client == nil {
+		client = defaultClient
+	}
+	resp, err := FetchOCSP(ctx, client, s.leaf.OCSPServer[0], s.leaf, s.issuer, now)
+	if err == nil && resp.Status == ocsp.Unknown {
+		err = errors.New("revocation: yanıtlayıcı sunucu sertifikasını tanımıyor")
+	}
+	if err != nil {
+		// Süresi dolmuş bir staple göndermek, hiç göndermemekten kötüdür:
+		// Strict istemci onu reddeder, oysa staple yoksa kendisi sorabilir.
+		if !s.next.IsZero() && now.After(s.next) {
+			s.logf("ocsp staple: süresi doldu, kaldırıldı")
+			base := s.base
+			s.cert.Store(&base)
+			s.next = time.Time{}
+		}
+		return now.Add(time.Minute), err
+	}
+
+	cert := s.base
+	cert.OCSPStaple = resp.Raw
+	s.cert.Store(&cert)
+	s.next = resp.NextUpdate
+	if resp.Status == ocsp.Revoked {
+		s.logf("ocsp staple: UYARI: sunucu sertifikası %s tarihinde iptal edilmiş", resp.RevokedAt.Format(time.RFC3339))
+	} else {
+		s.logf("ocsp staple: good, nextUpdate %s", resp.NextUpdate.Format(time.RFC3339))
+	}
+	if resp.NextUpdate.IsZero() {
+		return now.Add(time.Hour), nil
+	}
+	return resp.ThisUpdate.Add(resp.NextUpdate.Sub(resp.ThisUpdate) / 2), nil
+}
+
+// Run, ctx iptal edilene kadar staple'ı yeniler. Refresh ile aynı
+// goroutine'den çağrılmalıdır; ikisi birlikte eşzamanlı çalıştırılmaz.
+func (s *Stapler) Run(ctx context.Context) {
+	for {
+		next, err := s.Refresh(ctx)
+		if err != nil {
+			s.logf("ocsp staple: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+	}
+}
+
+func (s *Stapler) logf(format string, args ...any) {
+	if s.Logf != nil {
+		s.Logf(format, args...)
+	}
+}
+```
+
+---
+
+## Dosya: `revocation/revocation_test.go`
+
+Testler gerçek bir ağa çıkmaz. `testCA` yardımcısı, bir `httptest.Server` üzerinde OCSP yanıtlayıcısı ve CRL sunar. İstenen senaryolar:
+
+* iptal edilen sunucu sertifikası staple ile reddedilir,
+* iptal edilen istemci sertifikası CRL ile reddedilir,
+* süresi dolmuş staple atlanır ve çevrimiçi OCSP'ye gidilir,
+* yanıtlayıcı kapalıyken soft mod bağlantıyı kabul eder, strict mod reddeder,
+* başka bir CA'nın imzaladığı ya da başka bir seri için verilmiş sahte OCSP yanıtları reddedilir.
+
+```go
+package revocation
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// testCA, minica'dan bağımsız küçük bir CA'dır: iptal durumunu bellekte tutar
+// ve kendi OCSP yanıtlayıcısını ve CRL'ini bir httptest sunucusunda sunar.
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+	srv  *httptest.Server
+	hits atomic.Int32 // OCSP isteği sayısı
+	skew atomic.Int64 // yanıtlayıcının saatine eklenen süre
+
+	mu      sync.Mutex
+	issued  map[string]bool
+	revoked map[string]time.Time
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	ca := &testCA{cert: cert, key: key, issued: map[string]bool{}, revoked: map[string]time.Time{}}
+
+	responder := &Responder{Issuer: cert, Signer: key, Validity: time.Hour, Lookup: ca.status,
+		Now: func() time.Time { return time.Now().Add(time.Duration(ca.skew.Load())) }}
+	mux := http.NewServeMux()
+	mux.Handle("/ocsp/", http.StripPrefix("/ocsp", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ca.hits.Add(1)
+		responder.ServeHTTP(w, r)
+	})))
+	mux.HandleFunc("/crl", func(w http.ResponseWriter, r *http.Request) {
+		ca.mu.Lock()
+		list := &x509.RevocationList{Number: big.NewInt(time.Now().UnixNano()),
+			ThisUpdate: time.Now().Add(-time.Minute), NextUpdate: time.Now().Add(time.Hour)}
+		for s, at := range ca.revoked {
+			n, _ := new(big.Int).SetString(s, 16)
+			list.RevokedCertificateEntries = append(list.RevokedCertificateEntries,
+				x509.RevocationListEntry{SerialNumber: n, RevocationTime: at, ReasonCode: 1})
+		}
+		ca.mu.Unlock()
+		der, err := x509.CreateRevocationList(rand.Reader, list, cert, key)
+		if err != nil {
+			http.Error(w, err.Error(), 500)
+			return
+		}
+		w.Write(der)
+	})
+	ca.srv = httptest.NewServer(mux)
+	t.Cleanup(ca.srv.Close)
+	return ca
+}
+
+func (ca *testCA) status(serial *big.Int) (Status, error) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	s := serial.Text(16)
+	if at, ok := ca.revoked[s]; ok {
+		return Status{Known: true, Revoked: true, RevokedAt: at, Reason: 1}, nil
+	}
+	return Status{Known: ca.issued[s]}, nil
+}
+
+func (ca *testCA) revoke(c *x509.Certificate) {
+	ca.mu.Lock()
+	ca.revoked[c.SerialNumber.Text(16)] = time.Now().Add(-time.Second).Truncate(time.Second)
+	ca.mu.Unlock()
+}
+
+// leaf, ca'nın imzaladığı bir sertifika ve zinciri (yaprak + CA) döndürür.
+// ocspURL ve crlURL boşsa sertifikaya yazılmaz.
+func (ca *testCA) leaf(t *testing.T, usage x509.ExtKeyUsage, ocspURL, crlURL string) tls.Certificate {
+	t.Helper()
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	serial, _ := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	tmpl := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: "leaf"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	if ocspURL != "" {
+		tmpl.OCSPServer = []string{ocspURL}
+	}
+	if crlURL != "" {
+		tmpl.CRLDistributionPoints = []string{crlURL}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca.mu.Lock()
+	ca.issued[serial.Text(16)] = true
+	ca.mu.Unlock()
+	leaf, _ := x509.ParseCertificate(der)
+	return tls.Certificate{Certificate: [][]byte{der, ca.cert.Raw}, PrivateKey: key, Leaf: leaf}
+}
+
+func (ca *testCA) pool() *x509.CertPool {
+	p := x509.NewCertPool()
+	p.AddCert(ca.cert)
+	return p
+}
+
+func TestResponderGETAndPOST(t *testing.T) {
+	ca := newTestCA(t)
+	good := ca.leaf(t, x509.ExtKeyUsageServerAuth, "", "").Leaf
+	bad := ca.leaf(t, x509.ExtKeyUsageServerAuth, "", "").Leaf
+	ca.revoke(bad)
+	other := newTestCA(t)
+	stranger := other.leaf(t, x509.ExtKeyUsageServerAuth, "", "").Leaf
+
+	ask := func(method string, c, issuer *x509.Certificate) (*ocsp.Response, error) {
+		der, _ := ocsp.CreateRequest(c, issuer, nil)
+		var resp *http.Response
+		var err error
+		if method == http.MethodGet {
+			resp, err = http.Get(ca.srv.URL + "/ocsp/" + base64.StdEncoding.EncodeToString(der))
+		} else {
+			resp, err = http.Post(ca.srv.URL+"/ocsp/", "application/ocsp-request", bytes.NewReader(der))
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		return ocsp.ParseResponseForCert(body, c, issuer)
+	}
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		if r, err := ask(method, good, ca.cert); err != nil || r.Status != ocsp.Good {
+			t.Errorf("%s good: %v %v", method, r, err)
+		}
+		if r, err := ask(method, bad, ca.cert); err != nil || r.Status != ocsp.Revoked || r.RevocationReason != 1 {
+			t.Errorf("%s revoked: %v %v", method, r, err)
+		}
+	}
+	// Başka CA'nın sertifikası: bu CA onun adına yanıt vermez.
+	var rerr ocsp.ResponseError
+	if _, err := ask(http.MethodPost, stranger, other.cert); !errors.As(err, &rerr) || rerr.Status != ocsp.Unauthorized {
+		t.Errorf("yabancı CA: %v", err)
+	}
+	resp, _ := http.Post(ca.srv.URL+"/ocsp/", "application/ocsp-request", bytes.NewReader([]byte("çöp")))
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !bytes.Equal(body, ocsp.MalformedRequestErrorResponse) {
+		t.Errorf("bozuk istek: %x", body)
+	}
+}
+
+// tlsServer, cert ile bir HTTPS test sunucusu başlatır.
+func tlsServer(t *testing.T, cfg *tls.Config) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+	srv.TLS = cfg
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0) // beklenen el sıkışma hataları
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func fetch(t *testing.T, url string, cfg *tls.Config) error {
+	t.Helper()
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: cfg}}
+	defer client.CloseIdleConnections()
+	resp, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestClientRejectsRevokedServerViaStaple(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.leaf(t, x509.ExtKeyUsageServerAuth, ca.srv.URL+"/ocsp/", "")
+	stapler, err := NewStapler(cert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stapler.Logf = t.Logf
+	if _, err := stapler.Refresh(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	srv := tlsServer(t, &tls.Config{GetCertificate: stapler.GetCertificate})
+
+	checker := &Checker{Mode: Strict, Logf: t.Logf}
+	// httptest kendi sertifikasını da Certificates'e koyar; SNI olmadan
+	// GetCertificate çağrılmaz. ServerName bu yüzden açıkça veriliyor.
+	cfg := &tls.Config{RootCAs: ca.pool(), ServerName: "localhost", VerifyConnection: checker.VerifyConnection}
+	before := ca.hits.Load()
+	if err := fetch(t, srv.URL, cfg); err != nil {
+		t.Fatal("iptal öncesi:", err)
+	}
+	if ca.hits.Load() != before {
+		t.Error("staple varken istemci yanıtlayıcıya gitti")
+	}
+
+	ca.revoke(cert.Leaf)
+	if _, err := stapler.Refresh(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	err = fetch(t, srv.URL, cfg)
+	var rerr *RevokedError
+	if !errors.As(err, &rerr) || rerr.Source != "staple" {
+		t.Fatalf("iptal edilmiş sunucu kabul edildi ya da yanlış hata: %v", err)
+	}
+}
+
+func TestServerRejectsRevokedClientCert(t *testing.T) {
+	ca := newTestCA(t)
+	serverCert := ca.leaf(t, x509.ExtKeyUsageServerAuth, "", "")
+	clientCert := ca.leaf(t, x509.ExtKeyUsageClientAuth, "", ca.srv.URL+"/crl")
+
+	// CRL önbelleği kapalıya yakın: iptal bir sonraki bağlantıda görünmeli.
+	checker := &Checker{Mode: Strict, CRLMaxAge: time.Nanosecond, Logf: t.Logf}
+	srv := tlsServer(t, &tls.Config{
+		Certificates:     []tls.Certificate{serverCert},
+		ClientCAs:        ca.pool(),
+		ClientAuth:       tls.RequireAndVerifyClientCert,
+		VerifyConnection: checker.VerifyConnection,
+	})
+	cfg := &tls.Config{RootCAs: ca.pool(), Certificates: []tls.Certificate{clientCert}}
+	if err := fetch(t, srv.URL, cfg); err != nil {
+		t.Fatal("iptal öncesi:", err)
+	}
+	ca.revoke(clientCert.Leaf)
+	if err := fetch(t, srv.URL, cfg); err == nil {
+		t.Fatal("iptal edilmiş istemci sertifikası kabul edildi")
+	}
+}
+
+func TestExpiredStapleFallsBackToOCSP(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.leaf(t, x509.ExtKeyUsageServerAuth, ca.srv.URL+"/ocsp/", "")
+
+	// İki saat önce alınmış bir "good" staple: nextUpdate'i bir saat önce geçti.
+	ca.skew.Store(int64(-2 * time.Hour))
+	stapler, _ := NewStapler(cert)
+	stapler.Refresh(t.Context())
+	staple := stapler.cert.Load().OCSPStaple
+	ca.skew.Store(0)
+
+	ca.revoke(cert.Leaf)
+	checker := &Checker{Mode: Strict, Logf: t.Logf}
+	var rerr *RevokedError
+	err := checker.CheckChain(t.Context(), []*x509.Certificate{cert.Leaf, ca.cert}, staple)
+	if !errors.As(err, &rerr) || rerr.Source != "ocsp" {
+		t.Fatalf("eski 'good' staple kabul edildi: %v", err)
+	}
+}
+
+func TestSoftAndStrictWhenUnavailable(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.leaf(t, x509.ExtKeyUsageServerAuth, ca.srv.URL+"/ocsp/", ca.srv.URL+"/crl")
+	ca.srv.Close()
+
+	soft := &Checker{Mode: Soft, Logf: t.Logf}
+	if err := soft.Check(t.Context(), cert.Leaf, ca.cert); err != nil {
+		t.Errorf("soft: %v", err)
+	}
+	strict := &Checker{Mode: Strict}
+	if err := strict.Check(t.Context(), cert.Leaf, ca.cert); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("strict: %v", err)
+	}
+	// Adres içermeyen sertifika her iki modda da kabul edilir.
+	plain := ca.leaf(t, x509.ExtKeyUsageServerAuth, "", "")
+	if err := strict.Check(t.Context(), plain.Leaf, ca.cert); err != nil {
+		t.Errorf("adressiz sertifika: %v", err)
+	}
+}
+
+func TestForgedOCSPResponsesRejected(t *testing.T) {
+	ca := newTestCA(t)
+	cert := ca.leaf(t, x509.ExtKeyUsageServerAuth, "", "")
+	now := time.Now()
+	tmpl := ocsp.Response{Status: ocsp.Good, SerialNumber: cert.Leaf.SerialNumber,
+		ThisUpdate: now.Add(-time.Minute), NextUpdate: now.Add(time.Hour)}
+
+	// Başka bir CA'nın imzaladığı "good".
+	other := newTestCA(t)
+	forged, _ := ocsp.CreateResponse(ca.cert, other.cert, tmpl, other.key)
+	if _, err := verifyOCSP(forged, cert.Leaf, ca.cert, now); err == nil {
+		t.Error("başka CA'nın imzası kabul edildi")
+	}
+
+	// CA'nın verdiği sıradan bir sunucu sertifikasıyla imzalanmış "good":
+	// zincir geçerli ama OCSPSigning EKU yok.
+	rogue := ca.leaf(t, x509.ExtKeyUsageServerAuth, "", "")
+	tmpl.Certificate = rogue.Leaf
+	forged, _ = ocsp.CreateResponse(ca.cert, rogue.Leaf, tmpl, rogue.PrivateKey.(crypto.Signer))
+	if _, err := verifyOCSP(forged, cert.Leaf, ca.cert, now); err == nil {
+		t.Error("OCSPSigning EKU'su olmayan yanıtlayıcı kabul edildi")
+	}
+}
+```
+
+---
+
+## Dosya: `minica/pki.go` (değişenler)
+
+* İptal nedenleri artık RFC 5280 kodlarıyla birlikte bir map'te tutuluyor, çünkü CRL ve OCSP nedeni sayı olarak taşır. `Revoke` nedeni bu map'te arar; hata mesajı geçerli adları sıralı listeler.
+* `Request`'e `URLs` alanı eklendi. `Issue`, bu alandaki OCSP/CRL adreslerini sertifikaya yazar.
+* `InitIntermediate` yeni bir `urls URLs` parametresi alır ve aynı şeyi ara CA için yapar. Bu adresler kök CA'nın yanıtlayıcısını gösterir.
+* `Renew`, eski sertifikadaki adresleri yeni sertifikaya kopyalar.
+* `CRL` ve `Status` yeni: biri CRL üretir, diğeri OCSP yanıtlayıcısına serinin durumunu verir.
+
+Değişmeyen fonksiyonlar (`InitRoot`, `validate`, `issueTemplate`, …) önceki sürümdeki gibidir.
+
+```go
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+	"maps"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"go-tls-full-project/revocation"
+)
+
+// InitIntermediate, parent'ın imzaladığı bir ara CA oluşturur. pathLen 0
+// (varsayılan) ara CA'nın yalnızca yaprak sertifika verebileceği anlamına
+// gelir; kök anahtarı böylece çevrimdışı kalabilir. urls, ara CA'nın
+// iptal durumunun sorulacağı adreslerdir, yani kök CA'nın yanıtlayıcısı.
+func (p *PKI) InitIntermediate(parent *CA, name, cn, alg string, days, pathLen int, urls URLs) (*CA, error) {
+	if pc := parent.Cert; pc.MaxPathLen == 0 && pc.MaxPathLenZero ||
+		pc.MaxPathLen > 0 && pathLen >= pc.MaxPathLen {
+		return nil, fmt.Errorf("CA %q altında bu derinlikte ara CA'ya izin yok (pathlen %d)", parent.Name, pc.MaxPathLen)
+	}
+	key, err := generateKey(alg)
+	if err != nil {
+		return nil, err
+	}
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              p.clampNotAfter(parent, now.AddDate(0, 0, days)),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		MaxPathLen:            pathLen,
+		MaxPathLenZero:        pathLen == 0,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	urls.apply(tmpl)
+	cert, err := sign(tmpl, parent.Cert, key.Public(), parent.Key)
+	if err != nil {
+		return nil, err
+	}
+	bundle := append([]*x509.Certificate{cert}, parent.Bundle...)
+	if err := p.saveCA(name, bundle, key); err != nil {
+		return nil, err
+	}
+	if err := p.record(parent, cert, "ca", filepath.Join(p.caDir(name), "ca.pem")); err != nil {
+		return nil, err
+	}
+	return &CA{Name: name, Cert: cert, Key: key, Bundle: bundle}, nil
+}
+
+// Request, bir yaprak sertifikada istenen kimliktir. Anahtar ya minica'nın
+// ürettiği anahtardır ya da bir CSR'dan gelir; CA özel anahtarı hiç görmez.
+type Request struct {
+	Kind      string // server ya da client
+	CN        string
+	DNS       []string
+	IPs       []net.IP
+	Emails    []string
+	Days      int
+	PublicKey crypto.PublicKey
+	URLs      URLs // sertifikaya yazılacak OCSP/CRL adresleri
+}
+
+// Issue, isteği ca ile imzalar, index'e yazar ve sertifikanın bir kopyasını
+// CA dizininde saklar. certFile, index'e "teslim edilen yer" olarak yazılır.
+func (p *PKI) Issue(ca *CA, r Request, certFile string) (*x509.Certificate, error) {
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               pkix.Name{CommonName: r.CN},
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              p.clampNotAfter(ca, now.AddDate(0, 0, r.Days)),
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		DNSNames:              r.DNS,
+		IPAddresses:           r.IPs,
+		EmailAddresses:        r.Emails,
+	}
+	if _, ok := r.PublicKey.(*rsa.PublicKey); ok {
+		// Yalnızca RSA anahtar taşımada (TLS 1.2 RSA kex) kullanılabilir.
+		tmpl.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+	if r.Kind == "server" {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	} else {
+		tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	}
+	r.URLs.apply(tmpl)
+	return p.issueTemplate(ca, tmpl, r.PublicKey, r.Kind, certFile)
+}
+
+// Renew, var olan bir sertifikayı aynı açık anahtar, aynı konu ve aynı
+// SAN'larla, yeni seri ve yeni süreyle yeniden verir. OCSP/CRL adresleri de
+// eski sertifikadan kopyalanır. Anahtar değişmediği
+// için sunucuda yalnızca sertifika dosyası değişir. Eski sertifika süresi
+// dolana kadar geçerli kalır; hemen geçersiz olması gerekiyorsa ayrıca
+// revoke edilmelidir.
+func (p *PKI) Renew(old *Entry, days int, certFile string) (*x509.Certificate, error) {
+	if old.Kind == "ca" {
+		return nil, fmt.Errorf("%s bir CA; CA'lar renew ile yenilenmez, yeni bir ara CA oluşturun", old.Serial)
+	}
+	if st := old.Status(p.Now()); st == "revoked" {
+		return nil, fmt.Errorf("%s iptal edilmiş; iptal edilmiş bir anahtarla yeniden sertifika verilmez", old.Serial)
+	}
+	if old.RenewedBy != "" {
+		return nil, fmt.Errorf("%s zaten %s ile yenilendi", old.Serial, old.RenewedBy)
+	}
+	ca, err := p.LoadCA(old.CA)
+	if err != nil {
+		return nil, err
+	}
+	prev, err := readCerts(p.issuedPath(old.CA, old.Serial))
+	if err != nil {
+		return nil, err
+	}
+	c := prev[0]
+	now := p.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          newSerial(),
+		Subject:               c.Subject,
+		NotBefore:             now.Add(-5 * time.Minute),
+		NotAfter:              p.clampNotAfter(ca, now.AddDate(0, 0, days)),
+		BasicConstraintsValid: true,
+		KeyUsage:              c.KeyUsage,
+		ExtKeyUsage:           c.ExtKeyUsage,
+		DNSNames:              c.DNSNames,
+		IPAddresses:           c.IPAddresses,
+		EmailAddresses:        c.EmailAddresses,
+		OCSPServer:            c.OCSPServer,
+		CRLDistributionPoints: c.CRLDistributionPoints,
+	}
+	if certFile == "" {
+		certFile = old.CertFile
+	}
+	cert, err := p.issueTemplate(ca, tmpl, c.PublicKey, old.Kind, certFile)
+	if err != nil {
+		return nil, err
+	}
+	old.RenewedBy = serialString(cert.SerialNumber)
+	return cert, p.Index.Save()
+}
+
+// Revoke, kaydı iptal edilmiş olarak işaretler. Dağıtım ayrı bir adımdır:
+// minica serve bir sonraki OCSP isteğinde iptali görür, minica crl ise yeni
+// bir CRL üretir.
+func (p *PKI) Revoke(e *Entry, reason string) error {
+	if _, ok := revocationReasons[reason]; !ok {
+		return fmt.Errorf("bilinmeyen iptal nedeni %q (%v)", reason, slices.Sorted(maps.Keys(revocationReasons)))
+	}
+	if e.RevokedAt != nil {
+		return fmt.Errorf("%s zaten %s tarihinde iptal edilmiş", e.Serial, e.RevokedAt.Format(time.DateOnly))
+	}
+	now := p.Now().UTC().Truncate(time.Second)
+	e.RevokedAt = &now
+	e.Reason = reason
+	return p.Index.Save()
+}
+
+// revocationReasons, RFC 5280 5.3.1'deki CRLReason adları ve kodlarıdır.
+// Aynı kodlar CRL girişine ve OCSP yanıtına yazılır (7 kullanılmıyor).
+var revocationReasons = map[string]int{
+	"unspecified":          0,
+	"keyCompromise":        1,
+	"cACompromise":         2,
+	"affiliationChanged":   3,
+	"superseded":           4,
+	"cessationOfOperation": 5,
+	"certificateHold":      6,
+	"privilegeWithdrawn":   9,
+}
+
+// URLs, sertifikaya yazılan iptal bilgisi adresleridir. İstemciler iptal
+// durumunu buradan sorar; boş bırakılırsa sertifika iptal edilse bile bunu
+// öğrenmenin bir yolu yoktur.
+type URLs struct {
+	OCSP string // ör. http://localhost:8889/ocsp/issuing
+	CRL  string // ör. http://localhost:8889/crl/issuing.crl
+}
+
+func (u URLs) apply(tmpl *x509.Certificate) {
+	if u.OCSP != "" {
+		tmpl.OCSPServer = []string{u.OCSP}
+	}
+	if u.CRL != "" {
+		tmpl.CRLDistributionPoints = []string{u.CRL}
+	}
+}
+
+// CRL, ca'nın iptal ettiği ve süresi henüz dolmamış sertifikaların
+// listesini DER olarak üretir. Süresi dolan sertifika zaten reddedileceği
+// için listeden düşer; CRL böylece sınırsız büyümez. CRL numarası RFC 5280
+// gereği artan olmalıdır; ayrı bir sayaç dosyası yerine zaman kullanılır.
+func (p *PKI) CRL(ca *CA, validity time.Duration) ([]byte, error) {
+	now := p.Now()
+	tmpl := &x509.RevocationList{
+		Number:     big.NewInt(now.UnixNano()),
+		ThisUpdate: now.Add(-time.Minute),
+		NextUpdate: now.Add(validity),
+	}
+	for _, e := range p.Index.Entries {
+		if e.CA != ca.Name || e.RevokedAt == nil || now.After(e.NotAfter) {
+			continue
+		}
+		serial, ok := new(big.Int).SetString(e.Serial, 16)
+		if !ok {
+			return nil, fmt.Errorf("index: geçersiz seri %q", e.Serial)
+		}
+		tmpl.RevokedCertificateEntries = append(tmpl.RevokedCertificateEntries, x509.RevocationListEntry{
+			SerialNumber:   serial,
+			RevocationTime: *e.RevokedAt,
+			ReasonCode:     revocationReasons[e.Reason],
+		})
+	}
+	return x509.CreateRevocationList(rand.Reader, tmpl, ca.Cert, ca.Key)
+}
+
+// Status, OCSP yanıtlayıcısı için serinin ca altındaki durumunu döndürür.
+func (p *PKI) Status(ca string, serial *big.Int) revocation.Status {
+	e := p.Index.Lookup(ca, serialString(serial))
+	if e == nil {
+		return revocation.Status{}
+	}
+	st := revocation.Status{Known: true}
+	if e.RevokedAt != nil {
+		st.Revoked, st.RevokedAt, st.Reason = true, *e.RevokedAt, revocationReasons[e.Reason]
+	}
+	return st
+}
+```
+
+CRL yalnızca **süresi dolmamış** iptalleri listeler. Süresi dolan sertifika zaten zincir doğrulamasında reddedilir; onu listede tutmak CRL'i gereksiz yere büyütür. `Number` alanı `UnixNano` ile üretilir, böylece her yeni CRL bir öncekinden büyük numara alır.
+
+---
+
+## Dosya: `minica/index.go` (eklenen)
+
+```go
+// Lookup, ca'nın verdiği seriyi tam eşleşmeyle arar. Seriler CA'lar arasında
+// tekil olsa da OCSP sorusu her zaman belirli bir CA adına gelir.
+func (idx *Index) Lookup(ca, serial string) *Entry {
+	for _, e := range idx.Entries {
+		if e.CA == ca && e.Serial == serial {
+			return e
+		}
+	}
+	return nil
+}
+```
+
+---
+
+## Dosya: `minica/main.go` (değişenler)
+
+* `crl` ve `serve` komutları `commands` tablosuna ve kullanım metnine eklendi. `run` değişmedi: komutu bu tablodan seçer.
+* `-ocsp` ve `-crl` bayraklarını `addURLFlags` tanımlar. `intermediate` bunları doğrudan `InitIntermediate`'e verir. `issue` ve `sign` ise `identityFlags` üzerinden alır ve `Request.URLs`'e yazar.
+* `renew` için bayrak yok; adresler eski sertifikadan gelir.
+
+Değişmeyen komutlar (`cmdInit`, `cmdIssue`, `cmdSign`, `cmdRenew`, `cmdRevoke`, `cmdList`) ve yardımcılar önceki sürümdeki gibidir.
+
+```go
+// minica, TLS örnek projesi için küçük bir özel CA aracıdır: kök ve ara CA
+// oluşturur, sunucu/istemci sertifikası verir, CSR imzalar, yeniler ve
+// iptal eder; iptalleri CRL ve OCSP ile dağıtır. Verilen her sertifika
+// pki/index.json dosyasında izlenir.
+//
+//	minica init         -cn "Example Root CA" -cert certs/ca.pem
+//	minica intermediate -cn "Example Issuing CA" -ocsp http://localhost:8889/ocsp/root
+//	minica issue        -type server -dns localhost -ip 127.0.0.1 -cert certs/server_cert.pem -key certs/server_key.pem \
+//	                    -ocsp http://localhost:8889/ocsp/issuing -crl http://localhost:8889/crl/issuing.crl
+//	minica issue        -type client -cn client.local -cert certs/client_cert.pem -key certs/client_key.pem
+//	minica sign         -type server -csr req.csr -cert api.pem
+//	minica renew        -expiring 720h
+//	minica revoke       -serial 3F2A9C -reason keyCompromise
+//	minica crl          -ca issuing -out certs/issuing.crl
+//	minica serve        -addr localhost:8889
+//	minica list
+package main
+
+import (
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+var commands = map[string]func(fs *flag.FlagSet, args []string, out io.Writer) error{
+	"init":         cmdInit,
+	"intermediate": cmdIntermediate,
+	"issue":        cmdIssue,
+	"sign":         cmdSign,
+	"renew":        cmdRenew,
+	"revoke":       cmdRevoke,
+	"crl":          cmdCRL,
+	"serve":        cmdServe,
+	"list":         cmdList,
+}
+
+const usage = `kullanım: minica <komut> [bayraklar]
+
+komutlar:
+  init          kök CA oluştur
+  intermediate  kökün imzaladığı ara CA oluştur
+  issue         anahtar üret ve sunucu/istemci sertifikası ver
+  sign          dışarıda üretilmiş bir CSR'ı imzala
+  renew         sertifikayı aynı anahtarla yeniden ver
+  revoke        sertifikayı iptal et
+  crl           CA imzalı CRL dosyası üret
+  serve         OCSP yanıtlayıcısını ve CRL'leri HTTP ile sun
+  list          index'i göster
+
+Her komutun bayrakları için: minica <komut> -h
+CA dizini -dir ile ya da MINICA_DIR ile seçilir (varsayılan ./pki).
+`
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return flag.ErrHelp
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("bilinmeyen komut %q", args[0])
+	}
+	fs := flag.NewFlagSet("minica "+args[0], flag.ContinueOnError)
+	fs.String("dir", envOr("MINICA_DIR", "pki"), "CA durum dizini")
+	return cmd(fs, args[1:], out)
+}
+
+func cmdIntermediate(fs *flag.FlagSet, args []string, out io.Writer) error {
+	parent := fs.String("parent", "root", "imzalayan CA")
+	name := fs.String("name", "issuing", "ara CA adı")
+	cn := fs.String("cn", "minica issuing CA", "ara CA'nın Common Name'i")
+	alg := fs.String("alg", "ecdsa-p256", "anahtar algoritması")
+	days := fs.Int("days", 1825, "geçerlilik süresi (gün)")
+	pathLen := fs.Int("pathlen", 0, "altında izin verilen ara CA sayısı")
+	urls := addURLFlags(fs)
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	pca, err := p.LoadCA(*parent)
+	if err != nil {
+		return err
+	}
+	ca, err := p.InitIntermediate(pca, *name, *cn, *alg, *days, *pathLen, *urls)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "ara CA %q: %s/ca.pem (seri %s, bitiş %s)\n", ca.Name, p.caDir(ca.Name),
+		serialString(ca.Cert.SerialNumber), ca.Cert.NotAfter.Format(time.DateOnly))
+	return nil
+}
+
+// addURLFlags, sertifikaya yazılacak iptal bilgisi adreslerinin bayraklarıdır.
+func addURLFlags(fs *flag.FlagSet) *URLs {
+	u := &URLs{}
+	fs.StringVar(&u.OCSP, "ocsp", "", "sertifikaya yazılacak OCSP adresi")
+	fs.StringVar(&u.CRL, "crl", "", "sertifikaya yazılacak CRL adresi")
+	return u
+}
+
+// identityFlags, issue ve sign'ın ortak bayraklarıdır.
+type identityFlags struct {
+	ca, kind, cn   *string
+	dns, ip, email listFlag
+	days           *int
+	certFile       *string
+	urls           *URLs
+}
+
+func addIdentityFlags(fs *flag.FlagSet) *identityFlags {
+	f := &identityFlags{
+		ca:       fs.String("ca", "issuing", "imzalayan CA"),
+		kind:     fs.String("type", "server", "server ya da client"),
+		cn:       fs.String("cn", "", "Common Name (sunucuda varsayılan ilk SAN)"),
+		days:     fs.Int("days", 365, "geçerlilik süresi (gün)"),
+		certFile: fs.String("cert", "", "sertifika zincirinin yazılacağı dosya"),
+		urls:     addURLFlags(fs),
+	}
+	fs.Var(&f.dns, "dns", "DNS SAN (virgülle ayrılmış ya da tekrarlanabilir)")
+	fs.Var(&f.ip, "ip", "IP SAN")
+	fs.Var(&f.email, "email", "e-posta SAN")
+	return f
+}
+
+func (f *identityFlags) request() (Request, error) {
+	r := Request{Kind: *f.kind, CN: *f.cn, DNS: f.dns, Emails: f.email, Days: *f.days, URLs: *f.urls}
+	for _, s := range f.ip {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return r, fmt.Errorf("geçersiz IP %q", s)
+		}
+		r.IPs = append(r.IPs, ip)
+	}
+	return r, nil
+}
+
+func cmdCRL(fs *flag.FlagSet, args []string, out io.Writer) error {
+	caName := fs.String("ca", "issuing", "CRL'i imzalayacak CA")
+	outFile := fs.String("out", "", "CRL dosyası, DER (varsayılan <ca>.crl)")
+	validity := fs.Duration("validity", 7*24*time.Hour, "nextUpdate'e kadar geçen süre")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	if *outFile == "" {
+		*outFile = *caName + ".crl"
+	}
+	ca, err := p.LoadCA(*caName)
+	if err != nil {
+		return err
+	}
+	der, err := p.CRL(ca, *validity)
+	if err != nil {
+		return err
+	}
+	if err := writeFile(*outFile, der, 0o644); err != nil {
+		return err
+	}
+	list, err := x509.ParseRevocationList(der)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "CRL %q: %s (%d iptal, nextUpdate %s)\n", ca.Name, *outFile,
+		len(list.RevokedCertificateEntries), list.NextUpdate.Format(time.DateTime))
+	return nil
+}
+
+func cmdServe(fs *flag.FlagSet, args []string, out io.Writer) error {
+	addr := fs.String("addr", "localhost:8889", "dinleme adresi")
+	ocspValidity := fs.Duration("ocsp-validity", time.Hour, "OCSP yanıtlarının geçerlilik süresi")
+	crlValidity := fs.Duration("crl-validity", 24*time.Hour, "sunulan CRL'lerin geçerlilik süresi")
+	p, err := parse(fs, args)
+	if err != nil {
+		return err
+	}
+	h, names, err := newServeMux(p.Dir, *ocspValidity, *crlValidity, log.Printf)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		fmt.Fprintf(out, "CA %q: OCSP http://%s/ocsp/%s, CRL http://%s/crl/%s.crl\n", name, *addr, name, *addr, name)
+	}
+	return http.ListenAndServe(*addr, h)
+}
+```
+
+---
+
+## Dosya: `minica/serve.go`
+
+Index her istekte yeniden okunur. Bu sayede `minica revoke` çalıştıktan sonra sunucuyu yeniden başlatmaya gerek kalmaz; bir sonraki OCSP isteği iptali görür. CRL de her istekte yeniden üretilir.
+
+```go
+package main
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-tls-full-project/revocation"
+)
+
+// newServeMux, dir altındaki her CA için iki uç nokta kurar:
+//
+//	/ocsp/<ca>       OCSP (POST, ya da GET /ocsp/<ca>/<base64>)
+//	/crl/<ca>.crl    her istekte yeniden imzalanan CRL (DER)
+//
+// CA sertifikaları ve anahtarları açılışta bir kez yüklenir; index ise her
+// istekte yeniden okunur, böylece "minica revoke" sunucuyu yeniden
+// başlatmadan hemen etkili olur.
+func newServeMux(dir string, ocspValidity, crlValidity time.Duration, logf func(string, ...any)) (http.Handler, []string, error) {
+	p, err := openPKI(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	dirs, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	mux := http.NewServeMux()
+	var names []string
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, d.Name(), "ca.pem")); err != nil {
+			continue
+		}
+		ca, err := p.LoadCA(d.Name())
+		if err != nil {
+			return nil, nil, err
+		}
+		names = append(names, ca.Name)
+
+		responder := &revocation.Responder{
+			Issuer:   ca.Cert,
+			Signer:   ca.Key,
+			Validity: ocspValidity,
+			Lookup: func(serial *big.Int) (revocation.Status, error) {
+				cur, err := openPKI(dir)
+				if err != nil {
+					return revocation.Status{}, err
+				}
+				st := cur.Status(ca.Name, serial)
+				logf("ocsp %s %X: %s", ca.Name, serial, statusName(st))
+				return st, nil
+			},
+		}
+		prefix := "/ocsp/" + ca.Name
+		mux.Handle(prefix, http.StripPrefix(prefix, responder))
+		mux.Handle(prefix+"/", http.StripPrefix(prefix, responder))
+
+		mux.HandleFunc("GET /crl/"+ca.Name+".crl", func(w http.ResponseWriter, r *http.Request) {
+			cur, err := openPKI(dir)
+			if err == nil {
+				var der []byte
+				if der, err = cur.CRL(ca, crlValidity); err == nil {
+					logf("crl %s: %d bayt", ca.Name, len(der))
+					w.Header().Set("Content-Type", "application/pkix-crl")
+					w.Write(der)
+					return
+				}
+			}
+			logf("crl %s: %v", ca.Name, err)
+			http.Error(w, "CRL üretilemedi", http.StatusInternalServerError)
+		})
+	}
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("%s altında CA yok (önce minica init)", dir)
+	}
+	return mux, names, nil
+}
+
+func statusName(st revocation.Status) string {
+	switch {
+	case st.Revoked:
+		return "revoked"
+	case st.Known:
+		return "good"
+	}
+	return "unknown"
+}
+```
+
+---
+
+## Dosya: `minica/minica_test.go` (değişenler)
+
+`InitIntermediate` çağrıları yeni `URLs{}` argümanını alır. Bu yüzden `newTestPKI` ve `TestIntermediateCannotOutliveRootOrSignCAs` aşağıda tam hâliyle yer alıyor. Diğer mevcut testler aynıdır. Yeni importlar ve testler:
+
+* `TestCRLListsRevokedUnexpired` CRL içeriğini sınar.
+* `TestServeOCSPAndCRL`, `minica serve`'ü `revocation.Checker` ile uçtan uca sınar.
+* `TestCLIRevocationFlags` bayrakların sertifikaya yazıldığını, `renew`'da korunduğunu ve `crl` komutunun çıktısını sınar.
+
+```go
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"go-tls-full-project/revocation"
+)
+
+// newTestPKI, kök + ara CA'sı hazır bir PKI döndürür.
+func newTestPKI(t *testing.T) (*PKI, *CA, *CA) {
+	t.Helper()
+	p, err := openPKI(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := p.InitRoot("root", "Test Root", "ecdsa-p256", 3650)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuing, err := p.InitIntermediate(root, "issuing", "Test Issuing", "ecdsa-p256", 1825, 0, URLs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, root, issuing
+}
+
+func TestIntermediateCannotOutliveRootOrSignCAs(t *testing.T) {
+	p, err := openPKI(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, err := p.InitRoot("root", "Short Root", "ecdsa-p256", 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	issuing, err := p.InitIntermediate(root, "issuing", "Issuing", "ecdsa-p256", 1825, 0, URLs{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issuing.Cert.NotAfter.After(root.Cert.NotAfter) {
+		t.Error("ara CA kökten uzun yaşıyor")
+	}
+	if issuing.Cert.MaxPathLen != 0 || !issuing.Cert.MaxPathLenZero {
+		t.Error("pathlen 0 yazılmadı")
+	}
+	// pathlen 0 olan ara CA'nın altına CA koymak, doğrulanamayan bir zincir
+	// üretir; minica bunu baştan reddetmeli.
+	if _, err := p.InitIntermediate(issuing, "sub", "Sub", "ecdsa-p256", 10, 0, URLs{}); err == nil {
+		t.Error("pathlen 0 olan CA altına ara CA oluşturuldu")
+	}
+	if _, err := os.Stat(filepath.Join(p.Dir, "sub")); err == nil {
+		t.Error("reddedilen CA için dizin oluştu")
+	}
+}
+
+func TestCRLListsRevokedUnexpired(t *testing.T) {
+	p, _, issuing := newTestPKI(t)
+	issue(t, p, issuing, Request{Kind: "client", CN: "alice"})
+	issue(t, p, issuing, Request{Kind: "client", CN: "bob"})
+	alice, bob := p.Index.Entries[1], p.Index.Entries[2]
+	if err := p.Revoke(alice, "keyCompromise"); err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := p.CRL(issuing, 24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := x509.ParseRevocationList(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := list.CheckSignatureFrom(issuing.Cert); err != nil {
+		t.Fatal("CRL imzası:", err)
+	}
+	if len(list.RevokedCertificateEntries) != 1 {
+		t.Fatalf("CRL %d giriş, 1 bekleniyordu", len(list.RevokedCertificateEntries))
+	}
+	e := list.RevokedCertificateEntries[0]
+	if serialString(e.SerialNumber) != alice.Serial || e.ReasonCode != 1 {
+		t.Errorf("giriş = %X neden %d", e.SerialNumber, e.ReasonCode)
+	}
+
+	// Süresi dolan iptal edilmiş sertifika listeden düşer.
+	if err := p.Revoke(bob, "superseded"); err != nil {
+		t.Fatal(err)
+	}
+	p.Now = func() time.Time { return alice.NotAfter.Add(time.Hour) }
+	der, _ = p.CRL(issuing, 24*time.Hour)
+	list, _ = x509.ParseRevocationList(der)
+	if len(list.RevokedCertificateEntries) != 0 {
+		t.Errorf("süresi dolmuş girişler CRL'de kaldı: %d", len(list.RevokedCertificateEntries))
+	}
+}
+
+func TestServeOCSPAndCRL(t *testing.T) {
+	p, root, issuing := newTestPKI(t)
+	h, names, err := newServeMux(p.Dir, time.Hour, time.Hour, t.Logf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "issuing,root" {
+		t.Errorf("CA'lar = %v", names)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	urls := URLs{OCSP: srv.URL + "/ocsp/issuing", CRL: srv.URL + "/crl/issuing.crl"}
+	certFile, _ := issue(t, p, issuing, Request{Kind: "server", DNS: []string{"localhost"}, URLs: urls})
+	chain, _ := readCerts(certFile)
+	leaf := chain[0]
+	if leaf.OCSPServer[0] != urls.OCSP || leaf.CRLDistributionPoints[0] != urls.CRL {
+		t.Fatalf("URL'ler sertifikaya yazılmadı: %v %v", leaf.OCSPServer, leaf.CRLDistributionPoints)
+	}
+
+	ctx := t.Context()
+	checker := &revocation.Checker{Mode: revocation.Strict, Logf: t.Logf}
+	if err := checker.Check(ctx, leaf, issuing.Cert); err != nil {
+		t.Fatal("iptal öncesi:", err)
+	}
+
+	// revoke, serve yeniden başlatılmadan etkili olmalı.
+	if err := p.Revoke(p.Index.Entries[len(p.Index.Entries)-1], "keyCompromise"); err != nil {
+		t.Fatal(err)
+	}
+	var rerr *revocation.RevokedError
+	if err := checker.Check(ctx, leaf, issuing.Cert); !errors.As(err, &rerr) || rerr.Source != "ocsp" {
+		t.Fatalf("OCSP ile iptal görülmedi: %v", err)
+	}
+
+	// Yalnızca CRL adresi olan bir kopya: aynı sonuç CRL'den gelmeli.
+	crlOnly := *leaf
+	crlOnly.OCSPServer = nil
+	if err := checker.Check(ctx, &crlOnly, issuing.Cert); !errors.As(err, &rerr) || rerr.Source != "crl" {
+		t.Fatalf("CRL ile iptal görülmedi: %v", err)
+	}
+
+	// Başka bir CA'nın sertifikası yanlış yanıtlayıcıya sorulursa "good" alınmamalı.
+	wrong := &revocation.Checker{Mode: revocation.Strict}
+	inter := *issuing.Cert
+	inter.OCSPServer = []string{urls.OCSP}
+	inter.CRLDistributionPoints = nil
+	if err := wrong.Check(ctx, &inter, root.Cert); !errors.Is(err, revocation.ErrUnavailable) {
+		t.Fatalf("yanlış CA için yanıt: %v", err)
+	}
+}
+
+func TestCLIRevocationFlags(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("MINICA_DIR", filepath.Join(dir, "pki"))
+	certFile, crlFile := filepath.Join(dir, "server.pem"), filepath.Join(dir, "issuing.crl")
+	var out bytes.Buffer
+	steps := [][]string{
+		{"init"},
+		{"intermediate", "-ocsp", "http://pki.test/ocsp/root", "-crl", "http://pki.test/crl/root.crl"},
+		{"issue", "-type", "server", "-dns", "localhost", "-cert", certFile, "-key", filepath.Join(dir, "server-key.pem"),
+			"-ocsp", "http://pki.test/ocsp/issuing", "-crl", "http://pki.test/crl/issuing.crl"},
+		// renew adresleri eski sertifikadan almalı
+		{"renew", "-expiring", "9000h"},
+	}
+	for _, args := range steps {
+		if err := run(args, &out); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+	chain, err := readCerts(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, inter := chain[0], chain[1]
+	if !slices.Equal(leaf.OCSPServer, []string{"http://pki.test/ocsp/issuing"}) ||
+		!slices.Equal(leaf.CRLDistributionPoints, []string{"http://pki.test/crl/issuing.crl"}) {
+		t.Errorf("yenilenen sertifikada adresler: %v %v", leaf.OCSPServer, leaf.CRLDistributionPoints)
+	}
+	if !slices.Equal(inter.OCSPServer, []string{"http://pki.test/ocsp/root"}) {
+		t.Errorf("ara CA'da OCSP adresi: %v", inter.OCSPServer)
+	}
+
+	serial := serialString(leaf.SerialNumber)
+	if err := run([]string{"revoke", "-serial", serial, "-reason", "stolen"}, &out); err == nil ||
+		!strings.Contains(err.Error(), "keyCompromise") {
+		t.Errorf("bilinmeyen neden: %v", err)
+	}
+	for _, args := range [][]string{
+		{"revoke", "-serial", serial, "-reason", "keyCompromise"},
+		{"crl", "-out", crlFile},
+	} {
+		if err := run(args, &out); err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+	}
+	der, err := os.ReadFile(crlFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := x509.ParseRevocationList(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.RevokedCertificateEntries) != 1 || serialString(list.RevokedCertificateEntries[0].SerialNumber) != serial {
+		t.Errorf("CRL girişleri: %+v", list.RevokedCertificateEntries)
+	}
+}
+```
+
+---
+
+## Dosya: `server/main.go`
+
+```go
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+
+	"go-tls-full-project/revocation"
+)
+
+func main() {
+	addr := flag.String("addr", ":8443", "listen address")
+	certFile := flag.String("cert", "../certs/server_cert.pem", "server cert PEM")
+	keyFile := flag.String("key", "../certs/server_key.pem", "server key PEM")
+	caFile := flag.String("ca", "../certs/ca.pem", "CA cert to verify clients (for mutual TLS)")
+	staple := flag.Bool("staple", true, "staple OCSP responses for the server cert")
+	revMode := flag.String("revocation", "soft", "client cert revocation check when OCSP/CRL is unreachable: soft or strict")
+	flag.Parse()
+
+	mode, err := revocation.ParseMode(*revMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mutual := false
+	if v := os.Getenv("MUTUAL"); v == "true" || v == "1" {
+		mutual = true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			fmt.Fprintf(w, "Hello, mutual TLS client CN=%s\n", r.TLS.PeerCertificates[0].Subject.CommonName)
+			return
+		}
+		w.Write([]byte("Hello, TLS world!\n"))
+	})
+
+	cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+	if err != nil {
+		log.Fatalf("failed to load server key pair: %v", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if *staple {
+		stapler, err := revocation.NewStapler(cert)
+		switch {
+		case errors.Is(err, revocation.ErrNoOCSPServer):
+			log.Println("OCSP stapling: disabled (certificate has no OCSP URL)")
+		case err != nil:
+			log.Fatalf("OCSP stapling: %v", err)
+		default:
+			stapler.Logf = log.Printf
+			// Run fetches the first response right away; if the responder is
+			// down the server still starts and staples once a response arrives.
+			go stapler.Run(context.Background())
+			cfg.Certificates = nil
+			cfg.GetCertificate = stapler.GetCertificate
+			log.Println("OCSP stapling: enabled")
+		}
+	}
+
+	if mutual {
+		// load CA pool for client cert verification
+		caPEM, err := ioutil.ReadFile(*caFile)
+		if err != nil {
+			log.Fatalf("failed to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			log.Fatalf("failed to append CA cert")
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		// after chain verification, check the client cert's revocation status (OCSP, then CRL)
+		checker := &revocation.Checker{Mode: mode, Logf: log.Printf}
+		cfg.VerifyConnection = checker.VerifyConnection
+		log.Printf("Mutual TLS: enabled (require client cert, revocation %s)", *revMode)
+	} else {
+		log.Println("Mutual TLS: disabled")
+	}
+
+	server := &http.Server{
+		Addr:      *addr,
+		Handler:   mux,
+		TLSConfig: cfg,
+	}
+
+	log.Printf("Listening on %s (TLS)\n", *addr)
+	log.Fatal(server.ListenAndServeTLS("", "")) // certs come from TLSConfig
+}
+```
+
+`cfg.Certificates` stapling açıkken boş bırakılır. İkisi birden doluysa `crypto/tls` yalnızca `Certificates`'ı kullanır ve staple hiç gönderilmez.
+
+---
+
+## Dosya: `client/main.go`
+
+```go
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+
+	"go-tls-full-project/revocation"
+)
+
+func main() {
+	caFile := flag.String("ca", "../certs/ca.pem", "CA cert to trust")
+	certFile := flag.String("cert", "", "client cert PEM (for mutual TLS)")
+	keyFile := flag.String("key", "", "client key PEM (for mutual TLS)")
+	url := flag.String("url", "https://localhost:8443/", "server URL")
+	mutual := flag.Bool("mutual", false, "use client cert (mutual TLS)")
+	revMode := flag.String("revocation", "soft", "server cert revocation check when OCSP/CRL is unreachable: soft or strict")
+	flag.Parse()
+
+	mode, err := revocation.ParseMode(*revMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// load CA
+	caPEM, err := ioutil.ReadFile(*caFile)
+	if err != nil {
+		log.Fatalf("failed to read CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		log.Fatalf("failed to append CA cert")
+	}
+
+	// revocation: use the server's stapled OCSP response if present, otherwise
+	// ask the OCSP/CRL URLs in the cert. Revoked certs are rejected in both modes.
+	checker := &revocation.Checker{Mode: mode, Logf: log.Printf}
+
+	cfg := &tls.Config{
+		RootCAs:          pool,
+		MinVersion:       tls.VersionTLS12,
+		VerifyConnection: checker.VerifyConnection,
+		// ServerName: "localhost", // set if CN/SAN differs
+	}
+
+	if *mutual {
+		if *certFile == "" || *keyFile == "" {
+			log.Fatalln("mutual mode requires --cert and --key")
+		}
+		cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+		if err != nil {
+			log.Fatalf("failed to load client key pair: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	tr := &http.Transport{TLSClientConfig: cfg}
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Get(*url)
+	if err != nil {
+		log.Fatalf("GET error: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := ioutil.ReadAll(resp.Body)
+	fmt.Printf("status: %s\n", resp.Status)
+	fmt.Printf("body:\n%s\n", string(b))
+
+	os.Exit(0)
+}
+```
+
+---
+
+## Dosya: `Makefile`
+
+Sertifikalara yazılan adresler `PKI_ADDR` ile belirlenir. Adres değişirse sertifikaları yeniden üretmek gerekir (`make clean-certs certs`).
+
+```make
+.PHONY: certs renew-certs clean-certs ocsp crl server server-mutual client client-mutual test
+
+MINICA = go run ./minica
+
+# Sertifikalara yazılan OCSP/CRL adresleri; "make ocsp" bu adreste dinler.
+PKI_ADDR = localhost:8889
+PKI_URL  = http://$(PKI_ADDR)
+
+certs: certs/server_cert.pem certs/client_cert.pem
+
+pki/issuing/ca.pem:
+	$(MINICA) init -cn "go-tls-full-project Root CA" -cert certs/ca.pem
+	$(MINICA) intermediate -cn "go-tls-full-project Issuing CA" \
+		-ocsp $(PKI_URL)/ocsp/root -crl $(PKI_URL)/crl/root.crl
+
+certs/server_cert.pem: pki/issuing/ca.pem
+	$(MINICA) issue -type server -dns localhost,server -ip 127.0.0.1 -cert $@ -key certs/server_key.pem \
+		-ocsp $(PKI_URL)/ocsp/issuing -crl $(PKI_URL)/crl/issuing.crl
+
+certs/client_cert.pem: pki/issuing/ca.pem
+	$(MINICA) issue -type client -cn client.local -cert $@ -key certs/client_key.pem \
+		-ocsp $(PKI_URL)/ocsp/issuing -crl $(PKI_URL)/crl/issuing.crl
+
+# 30 gün içinde dolacak sertifikaları aynı anahtarla yeniler (cron için uygun).
+renew-certs:
+	$(MINICA) renew -expiring 720h
+
+# OCSP yanıtlayıcısı + CRL sunucusu (ayrı terminalde açık kalmalı).
+ocsp:
+	$(MINICA) serve -addr $(PKI_ADDR)
+
+# Çevrimdışı dağıtım için CRL dosyaları.
+crl:
+	$(MINICA) crl -ca root -out certs/root.crl
+	$(MINICA) crl -ca issuing -out certs/issuing.crl
+
+clean-certs:
+	rm -rf pki certs
+
+server:
+	cd server && go run main.go
+
+server-mutual:
+	cd server && MUTUAL=true go run main.go
+
+client:
+	cd client && go run main.go
+
+client-mutual:
+	cd client && go run main.go --cert ../certs/client_cert.pem --key ../certs/client_key.pem --mutual
+
+test:
+	go test ./...
+```
+
+---
+
+## Dosya: `Dockerfile`
+
+Yalnızca iki değişiklik var: `go.mod`/`go.sum` kopyalanıp bağımlılıklar indiriliyor ve `revocation/` paketi kopyalanıyor. İmajdaki sertifikalara OCSP/CRL adresi **yazılmıyor**. İmajda yanıtlayıcı çalışmıyor ve CA anahtarları (`pki/`) imaja bilerek girmiyor. Sunucu bu durumda stapling'i kendiliğinden kapatır.
+
+```dockerfile
+# 1. Derleme: openssl ya da bash gerekmiyor, sertifikaları minica üretir
+FROM golang:1.24-alpine AS builder
+
+WORKDIR /app
+
+# Tek dış bağımlılık golang.org/x/crypto (OCSP için)
+COPY go.mod go.sum ./
+RUN go mod download
+
+COPY revocation/ ./revocation/
+COPY minica/ ./minica/
+COPY server/ ./server/
+COPY client/ ./client/
+
+RUN CGO_ENABLED=0 go build -o /out/minica ./minica && \
+    CGO_ENABLED=0 go build -o /out/server ./server && \
+    CGO_ENABLED=0 go build -o /out/client ./client
+
+# Geliştirme sertifikaları. "server" SAN'ı compose ağındaki servis adı içindir.
+# OCSP/CRL adresi yazılmıyor: imajda yanıtlayıcı çalışmadığı için sunucu
+# stapling'i kendiliğinden kapatır (bkz. "make ocsp" ile yerel kurulum).
+RUN /out/minica init -cn "go-tls-full-project Root CA" -cert certs/ca.pem && \
+    /out/minica intermediate -cn "go-tls-full-project Issuing CA" && \
+    /out/minica issue -type server -dns localhost,server -ip 127.0.0.1 \
+        -cert certs/server_cert.pem -key certs/server_key.pem && \
+    /out/minica issue -type client -cn client.local \
+        -cert certs/client_cert.pem -key certs/client_key.pem
+
+# 2. Çalışma imajı: yalnızca statik binary'ler ve sertifikalar.
+# pki/ (CA anahtarları) bilerek kopyalanmaz.
+FROM gcr.io/distroless/static-debian12:nonroot
+WORKDIR /app
+
+COPY --from=builder /out/server /out/client ./
+COPY --from=builder --chown=65532:65532 /app/certs ./certs
+
+EXPOSE 8443
+CMD ["./server", "-cert", "certs/server_cert.pem", "-key", "certs/server_key.pem", "-ca", "certs/ca.pem"]
+```
+
+---
+
+## Dosya: `README.md`
+
+````markdown
+# go-tls-full-project
+
+Bu proje, Go ile TLS kullanarak güvenli bir sunucu ve istemci örneği içerir. Sertifikaları saf Go ile yazılmış `minica` aracı üretir: kök ve ara CA oluşturur, sunucu ve istemci sertifikası verir, CSR imzalar, sertifikaları yeniler ve iptal eder. İptaller CRL ve OCSP ile yayımlanır; sunucu kendi OCSP yanıtını el sıkışmaya ekler (stapling), iki taraf da karşı tarafın sertifikasının iptal durumunu kontrol eder.
+
+### İçindekiler
+- `minica/` - Özel CA aracı. Verilen her sertifikayı `pki/index.json` içinde izler.
+- `revocation/` - OCSP yanıtlayıcısı, OCSP/CRL iptal kontrolü (`Checker`) ve OCSP stapling (`Stapler`).
+- `server/main.go` - TLS sunucusu. İki mod:
+  - Normal TLS (server cert sadece)
+  - Mutual TLS (istemciden sertifika doğrulama) — `MUTUAL=true` ortam değişkeni ile aktifleşir
+- `client/main.go` - TLS istemcisi. İki mod:
+  - Server doğrulama only (CA trust)
+  - Mutual TLS (istemci sertifikası sunar) — `--mutual` flag ile
+
+### Gereksinimler
+- Go 1.24+ (OpenSSL gerekmez)
+
+### Hızlı kullanım
+1. Sertifikaları üret:
+
+```bash
+make certs
+```
+
+Bu komut `pki/` altında kök (`root`) ve ara (`issuing`) CA'yı, `certs/` altında da şu dosyaları oluşturur:
+
+```text
+certs/ca.pem           kök sertifika, iki tarafın güven dosyası
+certs/server_cert.pem  sunucu sertifikası + ara CA (localhost, server, 127.0.0.1)
+certs/server_key.pem
+certs/client_cert.pem  istemci sertifikası + ara CA (CN=client.local)
+certs/client_key.pem
+```
+
+2. Sunucuyu çalıştır (normal TLS):
+
+```bash
+make server
+```
+
+3. İstemci (server doğrulama):
+
+```bash
+make client
+```
+
+4. Mutual TLS testi (istemci sertifikası ile):
+
+```bash
+make server-mutual
+make client-mutual
+```
+
+5. Sertifika yönetimi:
+
+```bash
+go run ./minica list                                   # index
+go run ./minica renew -expiring 720h                   # 30 günde dolacakları yenile
+go run ./minica revoke -serial 5341BB -reason keyCompromise
+go run ./minica sign -type server -csr req.csr -cert certs/api_cert.pem
+```
+
+6. İptal kontrolü (OCSP + CRL):
+
+```bash
+make ocsp                    # ayrı terminalde: OCSP yanıtlayıcısı + CRL sunucusu (localhost:8889)
+make server-mutual           # OCSP yanıtını staple eder, istemci sertifikasını kontrol eder
+make client-mutual           # staple'ı (yoksa OCSP/CRL'i) kontrol eder
+make crl                     # çevrimdışı kullanım için certs/root.crl ve certs/issuing.crl
+```
+
+`minica revoke` sonrasında iptal, bir sonraki OCSP isteğinde görülür. Staple edilmiş yanıtlar en geç geçerlilik süresinin yarısında (varsayılan 30 dk) yenilenir. Yanıtlayıcıya ulaşılamazsa varsayılan `soft` mod bağlantıya izin verir ve log yazar; `-revocation strict` (istemcide `--revocation strict`) bağlantıyı reddeder.
+
+```text
+Not: Sunucu 8443 portunda dinler. pki/ dizini CA anahtarlarını içerir; repoya ve imajlara girmemelidir.
+```
+````
+
+---
+
+## 📌 Çalıştırma
+
+### 1. Sertifikalar ve yanıtlayıcı
+
+```bash
+make clean-certs certs
+make ocsp          # ayrı terminal
+```
+
+```
+CA "issuing": OCSP http://localhost:8889/ocsp/issuing, CRL http://localhost:8889/crl/issuing.crl
+CA "root": OCSP http://localhost:8889/ocsp/root, CRL http://localhost:8889/crl/root.crl
+```
+
+### 2. mTLS, iki taraf da kontrol ediyor
+
+```bash
+make server-mutual
+make client-mutual
+```
+
+Sunucu:
+
+```
+OCSP stapling: enabled
+Mutual TLS: enabled (require client cert, revocation soft)
+Listening on :8443 (TLS)
+ocsp staple: good, nextUpdate 2026-10-18T22:27:14Z
+revocation: CN=client.local good (ocsp http://localhost:8889/ocsp/issuing)
+revocation: CN=go-tls-full-project Issuing CA good (ocsp http://localhost:8889/ocsp/root)
+```
+
+İstemci:
+
+```
+revocation: CN=localhost good (staple)
+revocation: CN=go-tls-full-project Issuing CA good (ocsp http://localhost:8889/ocsp/root)
+status: 200 OK
+body:
+Hello, mutual TLS client CN=client.local
+```
+
+Sunucu sertifikası staple ile kontrol edildi, istemci OCSP'ye yalnızca ara CA için gitti.
+
+### 3. İstemci sertifikasını iptal et
+
+```bash
+go run ./minica revoke -serial E0986448 -reason keyCompromise
+make client-mutual
+```
+
+İstemci:
+
+```
+GET error: Get "https://localhost:8443/": remote error: tls: bad certificate
+```
+
+Sunucu:
+
+```
+http: TLS handshake error from 127.0.0.1:57548: revocation: CN=client.local (seri E09864485AE9EB222DC69C78AFFC8066) 2026-10-18T21:27:20Z tarihinde iptal edilmiş (neden 1, kaynak ocsp)
+```
+
+Sunucu yeniden başlatılmadı: `minica serve` index'i her istekte okuduğu için iptal hemen görüldü.
+
+### 4. Sunucu sertifikasını iptal et
+
+```bash
+go run ./minica revoke -serial B03C6C7F -reason keyCompromise
+make server        # yeniden başlat: staple hemen yenilensin
+make client
+```
+
+Sunucu:
+
+```
+OCSP stapling: enabled
+ocsp staple: UYARI: sunucu sertifikası 2026-10-18T21:27:20Z tarihinde iptal edilmiş
+```
+
+İstemci:
+
+```
+GET error: Get "https://localhost:8443/": revocation: CN=localhost (seri B03C6C7F0D6326D60213066B67E4BFC9) 2026-10-18T21:27:20Z tarihinde iptal edilmiş (neden 1, kaynak staple)
+```
+
+Sunucu yeniden başlatılmasaydı elindeki "good" staple'ı en fazla 30 dakika daha kullanırdı (OCSP geçerliliği 1 saat, yenileme yarı ömürde). İstemci staple'ın süresi içinde ona güvenir; OCSP'nin bilinen ödünleşimi budur. Süreyi kısaltmak için `minica serve -ocsp-validity 10m` kullanılabilir.
+
+### 5. CRL
+
+```bash
+make crl
+openssl crl -inform DER -in certs/issuing.crl -noout -text
+```
+
+```
+CRL "root": certs/root.crl (0 iptal, nextUpdate 2026-10-25 21:27:25)
+CRL "issuing": certs/issuing.crl (2 iptal, nextUpdate 2026-10-25 21:27:25)
+
+Certificate Revocation List (CRL):
+        ...
+Revoked Certificates:
+    Serial Number: E09864485AE9EB222DC69C78AFFC8066
+        Revocation Date: Oct 18 21:27:20 2026 GMT
+        CRL entry extensions:
+            X509v3 CRL Reason Code:
+                Key Compromise
+    Serial Number: B03C6C7F0D6326D60213066B67E4BFC9
+        ...
+```
+
+İptal edilen sertifikalar yenilenemez (`renew` reddeder). Yeni sertifika için eski dosya silinip `make certs` çalıştırılır:
+
+```bash
+rm certs/server_cert.pem certs/client_cert.pem && make certs
+```
+
+### 6. Yanıtlayıcı kapalı: soft ve strict
+
+`make ocsp` durdurulup sunucu yeniden başlatıldığında staple alınamaz:
+
+```
+ocsp staple: Post "http://localhost:8889/ocsp/issuing": dial tcp 127.0.0.1:8889: connect: connection refused
+```
+
+Soft modda (varsayılan) istemci log yazar ve devam eder:
+
+```
+$ make client
+revocation: iptal durumu öğrenilemedi: CN=localhost: Post "http://localhost:8889/ocsp/issuing": dial tcp 127.0.0.1:8889: connect: connection refused; Get "http://localhost:8889/crl/issuing.crl": dial tcp 127.0.0.1:8889: connect: connection refused (soft mod, kabul edildi)
+revocation: iptal durumu öğrenilemedi: CN=go-tls-full-project Issuing CA: Post "http://localhost:8889/ocsp/root": ... (soft mod, kabul edildi)
+status: 200 OK
+body:
+Hello, TLS world!
+```
+
+Strict modda bağlantı kurulmaz:
+
+```
+$ cd client && go run main.go --revocation strict
+GET error: Get "https://localhost:8443/": revocation: iptal durumu öğrenilemedi: CN=localhost: Post "http://localhost:8889/ocsp/issuing": dial tcp 127.0.0.1:8889: connect: connection refused; Get "http://localhost:8889/crl/issuing.crl": dial tcp 127.0.0.1:8889: connect: connection refused
+```
+
+### 7. Testler
+
+```bash
+make test
+```
+
+```
+ok  	go-tls-full-project/minica	0.033s
+ok  	go-tls-full-project/revocation	0.017s
+```
+
+---
+
+### Son notlar
+
+* **Soft mod varsayılan.** Yanıtlayıcının kesintisi bütün TLS trafiğini durdurmasın diye tarayıcıların çoğu da böyle çalışır. Bunun bedeli şu: yanıtlayıcıya ulaşmayı engelleyebilen bir saldırgan iptali de gizleyebilir. Stapling bu riski sunucu tarafında azaltır. İç ağda ve mTLS'te `strict` tercih edilmelidir.
+* Yanıtları CA anahtarı doğrudan imzalıyor. `minica serve` bu yüzden CA anahtarlarını okuyabilmek zorunda. Üretimde ayrı bir **OCSP imzalama sertifikası** (`ExtKeyUsageOCSPSigning`) verilir ve yalnızca onun anahtarı yanıtlayıcıda durur. `Checker` bu tür yanıtları zaten kabul ediyor.
+* `Checker` OCSP isteğini POST ile gönderir. GET isteğindeki base64 yol `/` ve `+` içerebilir ve `http.ServeMux` `//` içeren yolları temizleyip yönlendirir. Yanıtlayıcı GET'i de destekler ve GET yanıtlarını önbelleğe alınabilir işaretler.
+* Yalnızca yaprak sertifika staple edilir. Ara CA'nın durumu için istemci yine kök CA'nın yanıtlayıcısına gider; TLS 1.3'teki çoklu staple'ı `crypto/tls` desteklemiyor.
+
+İstersen bir sonraki adımda sertifikaya **OCSP Must-Staple** uzantısını (`status_request`, RFC 7633) ekleyip staple göndermeyen sunucuyu istemcide reddedebiliriz. Ayrıca yanıtlayıcı için ayrı bir OCSP imzalama sertifikası verebiliriz. Bunu ister misin?
+EVET
+
+# Go TLS Full Project
+
+Must-Staple'a geçmeden önce projeyi `fips140.go`'da yazdığımız **`cryptopolicy`** paketine bağlayalım. Şu an TLS ayarları ve anahtar türleri projede üç ayrı yerde elle yazılı:
+
+* `server/main.go` ve `client/main.go` yalnızca `MinVersion: tls.VersionTLS12` ayarlıyor. Suite'ler ve eğriler `crypto/tls`'in varsayılanları, yani Go sürümüyle değişiyor.
+* `minica/keys.go` kendi anahtar listesini tutuyor. `minica sign` ise CSR'daki anahtara hiç bakmıyor: RSA 1024 bir CSR da imzalanıyor.
+* Diskteki `certs/` ve `pki/` dosyalarının bir politikaya uyup uymadığını gösteren bir şey yok.
+
+Bu güncellemeyle dördü de aynı profilden okuyor:
+
+* `server` ve `client` `-profile` bayrağını (ya da `TLS_PROFILE`'ı) alır ve `tls.Config`'i `Profile.Apply` ile kurar. Sunucu kendi sertifikası profile uymuyorsa başlamaz.
+* `minica` `-profile` bayrağını (ya da `MINICA_PROFILE`'ı) alır. Anahtarlar `Profile.GenerateKey` ile üretilir, imzalanan CSR'ların anahtarı ve imza algoritması kontrol edilir.
+* `make audit` `certs/` ve `pki/` altını `cryptopolicy audit` ile denetler.
+
+Profiller (`modern`, `fips`, `legacy`) ve paketin kendisi için `fips140.go`'ya bakın.
+
+---
+
+## Yeni dizin yapısı
+
+`cryptopolicy` ayrı bir modül. Proje onu `replace` ile yanındaki dizinden alır:
+
+```text
+.
+├── cryptopolicy/             // fips140.go'daki modül
+└── go-tls-full-project/
+    ├── go.mod                // cryptopolicy + replace
+    ├── Makefile              // PROFILE değişkeni, audit hedefi
+    ├── Dockerfile            // build bağlamı artık bir üst dizin
+    ├── docker-compose.yml
+    ├── README.md
+    ├── minica/
+    │   ├── main.go           // -profile bayrağı
+    │   ├── keys.go           // generateKey → Profile.GenerateKey, CSR kontrolü
+    │   └── minica_test.go
+    ├── server/main.go        // -profile, Apply
+    └── client/main.go        // -profile, Apply
+```
+
+---
+
+## Dosya: `go.mod`
+
+```go
+module go-tls-full-project
+
+go 1.24.0
+
+require (
+	cryptopolicy v0.0.0
+	golang.org/x/crypto v0.43.0
+)
+
+replace cryptopolicy => ../cryptopolicy
+```
+
+`cryptopolicy`'nin dış bağımlılığı olmadığı için `go.sum` değişmez.
+
+---
+
+## Dosya: `minica/keys.go` (değişenler)
+
+`keyAlgs` listesi ve `generateKey`'deki `switch` kaldırıldı. Anahtar türü adları aynı kaldığı için `-alg` değerleri de değişmedi; `ecdsa-p521` yeni, ama yalnızca profil izin veriyorsa.
+
+```go
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"cryptopolicy/policy"
+)
+
+// profile, anahtar üretimine ve imzalanan CSR'lara uygulanan kripto
+// politikasıdır. parse, -profile bayrağına (ya da MINICA_PROFILE'a) göre
+// ayarlar; bayrak verilmezse FIPS modunda fips, değilse modern kullanılır.
+var profile = policy.Default()
+
+// generateKey, -alg'deki anahtarı profil izin veriyorsa üretir. Değerler
+// cryptopolicy'nin anahtar türleridir: ecdsa-p256, ecdsa-p384, ecdsa-p521,
+// ed25519, rsa-2048, rsa-3072, rsa-4096. Boş alg profilin varsayılanıdır.
+func generateKey(alg string) (crypto.Signer, error) {
+	return profile.GenerateKey(alg)
+}
+```
+
+`readCSR` imza kontrolünden sonra CSR'daki anahtarı ve imza algoritmasını da profile göre denetler:
+
+```go
+func readCSR(path string) (*x509.CertificateRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+		return nil, fmt.Errorf("%s: CERTIFICATE REQUEST bloğu yok", path)
+	}
+	csr, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	// İmza, isteği yapanın özel anahtara sahip olduğunu kanıtlar.
+	if err := csr.CheckSignature(); err != nil {
+		return nil, fmt.Errorf("%s: CSR imzası geçersiz: %w", path, err)
+	}
+	// İsteği yapanın anahtarı CA'nınkiyle aynı politikaya uymalı; aksi hâlde
+	// minica RSA 1024 bir anahtara da sertifika verirdi.
+	if err := profile.CheckPublicKey(csr.PublicKey); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := profile.CheckSignatureAlgorithm(csr.SignatureAlgorithm); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return csr, nil
+}
+```
+
+---
+
+## Dosya: `minica/main.go` (değişenler)
+
+`-profile` de `-dir` gibi her komutta geçerli. Bu yüzden `run` içinde tanımlanıp `parse` içinde okunuyor. `usage` metnine de politikayı anlatan son satır eklendi:
+
+```go
+import (
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"cryptopolicy/policy"
+)
+
+const usage = `kullanım: minica <komut> [bayraklar]
+
+komutlar:
+  init          kök CA oluştur
+  intermediate  kökün imzaladığı ara CA oluştur
+  issue         anahtar üret ve sunucu/istemci sertifikası ver
+  sign          dışarıda üretilmiş bir CSR'ı imzala
+  renew         sertifikayı aynı anahtarla yeniden ver
+  revoke        sertifikayı iptal et
+  crl           CA imzalı CRL dosyası üret
+  serve         OCSP yanıtlayıcısını ve CRL'leri HTTP ile sun
+  list          index'i göster
+
+Her komutun bayrakları için: minica <komut> -h
+CA dizini -dir ile ya da MINICA_DIR ile seçilir (varsayılan ./pki).
+Kripto politikası -profile ile ya da MINICA_PROFILE ile seçilir (modern, fips, legacy).
+`
+
+func run(args []string, out io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return flag.ErrHelp
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("bilinmeyen komut %q", args[0])
+	}
+	fs := flag.NewFlagSet("minica "+args[0], flag.ContinueOnError)
+	fs.String("dir", envOr("MINICA_DIR", "pki"), "CA durum dizini")
+	fs.String("profile", os.Getenv("MINICA_PROFILE"), "kripto politikası: modern, fips ya da legacy")
+	return cmd(fs, args[1:], out)
+}
+
+// parse, bayrakları ayrıştırır, -profile'daki politikayı seçer ve -dir'deki
+// PKI'yı açar. Fazladan konumsal argüman genellikle bayrakların yanlış
+// sırada yazıldığını gösterir.
+func parse(fs *flag.FlagSet, args []string) (*PKI, error) {
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	if fs.NArg() > 0 {
+		return nil, fmt.Errorf("beklenmeyen argüman %q", fs.Arg(0))
+	}
+	prof, err := policy.Lookup(fs.Lookup("profile").Value.String())
+	if err != nil {
+		return nil, err
+	}
+	if err := prof.CheckRuntime(); err != nil {
+		fmt.Fprintf(os.Stderr, "uyarı: %v\n", err)
+	}
+	profile = prof
+	return openPKI(fs.Lookup("dir").Value.String())
+}
+```
+
+`minica` yalnızca uyarır, başlamayı reddetmez. CA işlemleri çevrimdışı yapılır ve ürettiği dosyaları `make audit` sonradan denetleyebilir.
+
+---
+
+## Dosya: `minica/minica_test.go` (eklenen test)
+
+Import'lara `crypto/rsa` ve `cryptopolicy/policy` eklendi:
+
+```go
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"cryptopolicy/policy"
+	"go-tls-full-project/revocation"
+)
+```
+
+Yeni test:
+
+```go
+func TestProfileLimitsKeys(t *testing.T) {
+	t.Cleanup(func() { profile = policy.Default() })
+	dir := t.TempDir()
+	t.Setenv("MINICA_DIR", filepath.Join(dir, "pki"))
+	var out bytes.Buffer
+
+	// P-521 modern profilde yok, fips profilinde var.
+	if err := run([]string{"init", "-profile", "modern", "-alg", "ecdsa-p521"}, &out); err == nil {
+		t.Fatal("modern profilde P-521 kök CA oluşturuldu")
+	}
+	if err := run([]string{"init", "-profile", "fips", "-alg", "ecdsa-p521"}, &out); err != nil {
+		t.Fatal(err)
+	}
+	if err := run([]string{"init", "-profile", "yok"}, &out); err == nil {
+		t.Error("bilinmeyen profil kabul edildi")
+	}
+	if err := run([]string{"intermediate", "-profile", "legacy", "-alg", "rsa-1024"}, &out); err == nil {
+		t.Error("legacy profilde RSA 1024 ara CA oluşturuldu")
+	}
+
+	// Profil CSR'daki anahtara da uygulanır.
+	weak, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{CommonName: "weak.internal"},
+	}, weak)
+	if err != nil {
+		t.Fatal(err)
+	}
+	csrFile := filepath.Join(dir, "weak.csr")
+	os.WriteFile(csrFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), 0o644)
+	profile = policy.Legacy
+	if _, err := readCSR(csrFile); err == nil || !strings.Contains(err.Error(), "RSA 1024") {
+		t.Errorf("RSA 1024 CSR: %v", err)
+	}
+}
+```
+
+---
+
+## Dosya: `server/main.go`
+
+`MinVersion` satırı kaldırıldı; sürüm aralığı profilden gelir. `Apply` en son çağrılır: mTLS kurulduysa `revocation.Checker`'ın `VerifyConnection`'ı zaten atanmıştır ve `Apply` onu silmeden sarar. Önce profil kontrolü, sonra iptal kontrolü çalışır.
+
+```go
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+
+	"cryptopolicy/policy"
+	"go-tls-full-project/revocation"
+)
+
+func main() {
+	addr := flag.String("addr", ":8443", "listen address")
+	certFile := flag.String("cert", "../certs/server_cert.pem", "server cert PEM")
+	keyFile := flag.String("key", "../certs/server_key.pem", "server key PEM")
+	caFile := flag.String("ca", "../certs/ca.pem", "CA cert to verify clients (for mutual TLS)")
+	staple := flag.Bool("staple", true, "staple OCSP responses for the server cert")
+	revMode := flag.String("revocation", "soft", "client cert revocation check when OCSP/CRL is unreachable: soft or strict")
+	profile := flag.String("profile", os.Getenv("TLS_PROFILE"), "crypto policy: modern, fips or legacy (default: fips in FIPS mode, else modern)")
+	flag.Parse()
+
+	p, err := policy.Lookup(*profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := p.CheckRuntime(); err != nil {
+		log.Fatal(err)
+	}
+
+	mode, err := revocation.ParseMode(*revMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mutual := false
+	if v := os.Getenv("MUTUAL"); v == "true" || v == "1" {
+		mutual = true
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+			fmt.Fprintf(w, "Hello, mutual TLS client CN=%s\n", r.TLS.PeerCertificates[0].Subject.CommonName)
+			return
+		}
+		w.Write([]byte("Hello, TLS world!\n"))
+	})
+
+	cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+	if err != nil {
+		log.Fatalf("failed to load server key pair: %v", err)
+	}
+	// checked here rather than by Apply: with stapling the cert is served
+	// by GetCertificate and cfg.Certificates is empty
+	if err := p.CheckTLSCertificate(cert).Err(); err != nil {
+		log.Fatalf("server cert: %v", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if *staple {
+		stapler, err := revocation.NewStapler(cert)
+		switch {
+		case errors.Is(err, revocation.ErrNoOCSPServer):
+			log.Println("OCSP stapling: disabled (certificate has no OCSP URL)")
+		case err != nil:
+			log.Fatalf("OCSP stapling: %v", err)
+		default:
+			stapler.Logf = log.Printf
+			// Run fetches the first response right away; if the responder is
+			// down the server still starts and staples once a response arrives.
+			go stapler.Run(context.Background())
+			cfg.Certificates = nil
+			cfg.GetCertificate = stapler.GetCertificate
+			log.Println("OCSP stapling: enabled")
+		}
+	}
+
+	if mutual {
+		// load CA pool for client cert verification
+		caPEM, err := ioutil.ReadFile(*caFile)
+		if err != nil {
+			log.Fatalf("failed to read CA file: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			log.Fatalf("failed to append CA cert")
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+		// after chain verification, check the client cert's revocation status (OCSP, then CRL)
+		checker := &revocation.Checker{Mode: mode, Logf: log.Printf}
+		cfg.VerifyConnection = checker.VerifyConnection
+		log.Printf("Mutual TLS: enabled (require client cert, revocation %s)", *revMode)
+	} else {
+		log.Println("Mutual TLS: disabled")
+	}
+
+	// last, so the policy check wraps the revocation checker's VerifyConnection
+	if err := p.Apply(cfg); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Crypto policy: %s (%s–%s)", p.Name, tls.VersionName(p.MinVersion), tls.VersionName(p.MaxVersion))
+
+	server := &http.Server{
+		Addr:      *addr,
+		Handler:   mux,
+		TLSConfig: cfg,
+	}
+
+	log.Printf("Listening on %s (TLS)\n", *addr)
+	log.Fatal(server.ListenAndServeTLS("", "")) // certs come from TLSConfig
+}
+```
+
+---
+
+## Dosya: `client/main.go`
+
+```go
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+
+	"cryptopolicy/policy"
+	"go-tls-full-project/revocation"
+)
+
+func main() {
+	caFile := flag.String("ca", "../certs/ca.pem", "CA cert to trust")
+	certFile := flag.String("cert", "", "client cert PEM (for mutual TLS)")
+	keyFile := flag.String("key", "", "client key PEM (for mutual TLS)")
+	url := flag.String("url", "https://localhost:8443/", "server URL")
+	mutual := flag.Bool("mutual", false, "use client cert (mutual TLS)")
+	revMode := flag.String("revocation", "soft", "server cert revocation check when OCSP/CRL is unreachable: soft or strict")
+	profile := flag.String("profile", os.Getenv("TLS_PROFILE"), "crypto policy: modern, fips or legacy (default: fips in FIPS mode, else modern)")
+	flag.Parse()
+
+	p, err := policy.Lookup(*profile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mode, err := revocation.ParseMode(*revMode)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// load CA
+	caPEM, err := ioutil.ReadFile(*caFile)
+	if err != nil {
+		log.Fatalf("failed to read CA file: %v", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		log.Fatalf("failed to append CA cert")
+	}
+
+	// revocation: use the server's stapled OCSP response if present, otherwise
+	// ask the OCSP/CRL URLs in the cert. Revoked certs are rejected in both modes.
+	checker := &revocation.Checker{Mode: mode, Logf: log.Printf}
+
+	cfg := &tls.Config{
+		RootCAs:          pool,
+		VerifyConnection: checker.VerifyConnection,
+		// ServerName: "localhost", // set if CN/SAN differs
+	}
+
+	if *mutual {
+		if *certFile == "" || *keyFile == "" {
+			log.Fatalln("mutual mode requires --cert and --key")
+		}
+		cert, err := tls.LoadX509KeyPair(*certFile, *keyFile)
+		if err != nil {
+			log.Fatalf("failed to load client key pair: %v", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	// versions, suites and curves come from the profile; the server's chain
+	// is checked against it before the revocation checker runs
+	if err := p.Apply(cfg); err != nil {
+		log.Fatalf("client cert: %v", err)
+	}
+
+	tr := &http.Transport{TLSClientConfig: cfg}
+	client := &http.Client{Transport: tr}
+
+	resp, err := client.Get(*url)
+	if err != nil {
+		log.Fatalf("GET error: %v", err)
+	}
+	defer resp.Body.Close()
+	b, _ := ioutil.ReadAll(resp.Body)
+	fmt.Printf("status: %s\n", resp.Status)
+	fmt.Printf("body:\n%s\n", string(b))
+
+	os.Exit(0)
+}
+```
+
+`Apply`'ın döndürdüğü hata yalnızca `cfg.Certificates`'ten gelebilir, yani `--mutual` ile verilen istemci sertifikasından.
+
+---
+
+## Dosya: `Makefile`
+
+`PROFILE` bütün hedeflere ortam değişkeni olarak geçer: `make certs PROFILE=fips` anahtarları fips profiline göre üretir, `make server PROFILE=fips` sunucuyu o profille başlatır.
+
+```make
+.PHONY: certs renew-certs clean-certs ocsp crl audit server server-mutual client client-mutual test
+
+MINICA = go run ./minica
+
+# Kripto politikası: modern, fips ya da legacy. minica MINICA_PROFILE'ı,
+# server ve client TLS_PROFILE'ı okur. fips için GODEBUG=fips140=on da gerekir.
+PROFILE ?= modern
+export MINICA_PROFILE = $(PROFILE)
+export TLS_PROFILE = $(PROFILE)
+
+# Sertifikalara yazılan OCSP/CRL adresleri; "make ocsp" bu adreste dinler.
+PKI_ADDR = localhost:8889
+PKI_URL  = http://$(PKI_ADDR)
+
+certs: certs/server_cert.pem certs/client_cert.pem
+
+pki/issuing/ca.pem:
+	$(MINICA) init -cn "go-tls-full-project Root CA" -cert certs/ca.pem
+	$(MINICA) intermediate -cn "go-tls-full-project Issuing CA" \
+		-ocsp $(PKI_URL)/ocsp/root -crl $(PKI_URL)/crl/root.crl
+
+certs/server_cert.pem: pki/issuing/ca.pem
+	$(MINICA) issue -type server -dns localhost,server -ip 127.0.0.1 -cert $@ -key certs/server_key.pem \
+		-ocsp $(PKI_URL)/ocsp/issuing -crl $(PKI_URL)/crl/issuing.crl
+
+certs/client_cert.pem: pki/issuing/ca.pem
+	$(MINICA) issue -type client -cn client.local -cert $@ -key certs/client_key.pem \
+		-ocsp $(PKI_URL)/ocsp/issuing -crl $(PKI_URL)/crl/issuing.crl
+
+# 30 gün içinde dolacak sertifikaları aynı anahtarla yeniler (cron için uygun).
+renew-certs:
+	$(MINICA) renew -expiring 720h
+
+# OCSP yanıtlayıcısı + CRL sunucusu (ayrı terminalde açık kalmalı).
+ocsp:
+	$(MINICA) serve -addr $(PKI_ADDR)
+
+# Çevrimdışı dağıtım için CRL dosyaları.
+crl:
+	$(MINICA) crl -ca root -out certs/root.crl
+	$(MINICA) crl -ca issuing -out certs/issuing.crl
+
+# certs/ ve pki/ altındaki anahtar ve sertifikaları profile göre denetler;
+# ihlal varsa 1 ile çıkar.
+audit:
+	go run cryptopolicy/cmd/cryptopolicy audit -q -profile $(PROFILE) certs pki
+
+clean-certs:
+	rm -rf pki certs
+
+server:
+	cd server && go run main.go
+
+server-mutual:
+	cd server && MUTUAL=true go run main.go
+
+client:
+	cd client && go run main.go
+
+client-mutual:
+	cd client && go run main.go --cert ../certs/client_cert.pem --key ../certs/client_key.pem --mutual
+
+test:
+	go test ./...
+```
+
+---
+
+## Dosya: `Dockerfile`
+
+`go.mod`'daki `replace` bir üst dizini gösterdiği için build bağlamı artık `go-tls-full-project/`'in bir üstü. Profil `PROFILE` build argümanıyla seçilir.
+
+```dockerfile
+# 1. Derleme: openssl ya da bash gerekmiyor, sertifikaları minica üretir
+FROM golang:1.24-alpine AS builder
+
+WORKDIR /app
+
+# go.mod'daki "replace cryptopolicy => ../cryptopolicy" /cryptopolicy'yi
+# gösterir. Build bağlamı bu yüzden bir üst dizindir (bkz. docker-compose.yml).
+COPY cryptopolicy/ /cryptopolicy/
+
+# Tek dış bağımlılık golang.org/x/crypto (OCSP için)
+COPY go-tls-full-project/go.mod go-tls-full-project/go.sum ./
+RUN go mod download
+
+COPY go-tls-full-project/revocation/ ./revocation/
+COPY go-tls-full-project/minica/ ./minica/
+COPY go-tls-full-project/server/ ./server/
+COPY go-tls-full-project/client/ ./client/
+
+RUN CGO_ENABLED=0 go build -o /out/minica ./minica && \
+    CGO_ENABLED=0 go build -o /out/server ./server && \
+    CGO_ENABLED=0 go build -o /out/client ./client
+
+# Anahtarlar ve sertifikalar bu profile göre üretilir; sunucu da aynı
+# profille çalışır.
+ARG PROFILE=modern
+ENV MINICA_PROFILE=${PROFILE}
+
+# Geliştirme sertifikaları. "server" SAN'ı compose ağındaki servis adı içindir.
+# OCSP/CRL adresi yazılmıyor: imajda yanıtlayıcı çalışmadığı için sunucu
+# stapling'i kendiliğinden kapatır (bkz. "make ocsp" ile yerel kurulum).
+RUN /out/minica init -cn "go-tls-full-project Root CA" -cert certs/ca.pem && \
+    /out/minica intermediate -cn "go-tls-full-project Issuing CA" && \
+    /out/minica issue -type server -dns localhost,server -ip 127.0.0.1 \
+        -cert certs/server_cert.pem -key certs/server_key.pem && \
+    /out/minica issue -type client -cn client.local \
+        -cert certs/client_cert.pem -key certs/client_key.pem
+
+# 2. Çalışma imajı: yalnızca statik binary'ler ve sertifikalar.
+# pki/ (CA anahtarları) bilerek kopyalanmaz.
+FROM gcr.io/distroless/static-debian12:nonroot
+WORKDIR /app
+
+COPY --from=builder /out/server /out/client ./
+COPY --from=builder --chown=65532:65532 /app/certs ./certs
+
+ARG PROFILE=modern
+ENV TLS_PROFILE=${PROFILE}
+
+EXPOSE 8443
+CMD ["./server", "-cert", "certs/server_cert.pem", "-key", "certs/server_key.pem", "-ca", "certs/ca.pem"]
+```
+
+---
+
+## Dosya: `docker-compose.yml`
+
+```yaml
+services:
+  server:
+    build:
+      context: ..
+      dockerfile: go-tls-full-project/Dockerfile
+      args:
+        PROFILE: ${PROFILE:-modern}
+    image: go-tls-full-project
+    ports:
+      - "8443:8443"
+    environment:
+      MUTUAL: "true"
+      # fips profili FIPS modu kapalıyken başlamaz:
+      #   PROFILE=fips GODEBUG=fips140=on docker compose up --build
+      GODEBUG: ${GODEBUG:-}
+```
+
+`docker build` doğrudan çalıştırılacaksa da bağlam üst dizin olmalı:
+
+```bash
+docker build -f go-tls-full-project/Dockerfile --build-arg PROFILE=fips -t go-tls-full-project .
+```
+
+---
+
+## Dosya: `README.md` (eklenen bölüm)
+
+````markdown
+### Kripto politikası
+
+TLS ayarları ve anahtar türleri `../cryptopolicy` modülündeki profillerden gelir:
+
+| Profil | TLS | Not |
+|---|---|---|
+| `modern` | yalnızca 1.3 | varsayılan |
+| `fips` | 1.2 – 1.3 | yalnızca FIPS 140-3 onaylı algoritmalar; `GODEBUG=fips140=on` gerekir |
+| `legacy` | 1.0 – 1.3 | eski istemciler için; RSA < 2048 yine reddedilir |
+
+Profil `PROFILE` ile seçilir ve `minica`, `server`, `client`'a geçer:
+
+```bash
+make clean-certs certs PROFILE=fips
+GODEBUG=fips140=on make server PROFILE=fips
+make audit PROFILE=fips      # certs/ ve pki/ profile uyuyor mu?
+```
+````
+
+---
+
+## 📌 Çalıştırma
+
+### 1. minica profili uygular
+
+```bash
+$ go run ./minica init -profile modern -alg ecdsa-p521 -cert certs/ca.pem
+minica: policy(modern): key: ECDSA P-521 izinli değil (P-256, P-384)
+$ MINICA_PROFILE=fips go run ./minica init -alg ecdsa-p521 -cert certs/ca.pem
+uyarı: policy(fips): runtime: FIPS 140-3 modu kapalı (GODEBUG="fips140=on" ile çalıştırın)
+kök CA "root": pki/root/ca.pem (bitiş 2036-10-15)
+güven dosyası: certs/ca.pem
+$ go run ./minica intermediate
+ara CA "issuing": pki/issuing/ca.pem (seri A20DE4A6153E39F51A8CFAEF0CCE054E, bitiş 2031-10-17)
+```
+
+RSA 1024 anahtarlı bir CSR imzalanmaz:
+
+```bash
+$ openssl req -newkey rsa:1024 -nodes -keyout weak.key -out weak.csr -subj /CN=weak.internal
+$ go run ./minica sign -type server -csr weak.csr -cert weak.pem -dns weak.internal
+minica: weak.csr: policy(modern): key: RSA 1024 bit (en az 2048)
+```
+
+### 2. Denetim
+
+Kök CA yukarıda fips profiliyle P-521 olarak oluşturuldu. fips profiline uyuyor ama modern profiline uymuyor:
+
+```bash
+$ go run ./minica issue -type server -dns localhost -cert certs/server_cert.pem -key certs/server_key.pem
+server sertifikası "localhost": certs/server_cert.pem, certs/server_key.pem (seri D5DEF09652FC4ADEEE7D9B44A1C8E7AA, bitiş 2027-10-18)
+$ make audit PROFILE=fips
+go run cryptopolicy/cmd/cryptopolicy audit -q -profile fips certs pki
+9 blok, 0 ihlalli (profil fips)
+$ make audit PROFILE=modern
+go run cryptopolicy/cmd/cryptopolicy audit -q -profile modern certs pki
+FAIL  certs/ca.pem#1 CERTIFICATE CN=minica root CA ECDSA P-521 ECDSA-SHA512
+      key: ECDSA P-521 izinli değil (P-256, P-384)
+FAIL  pki/root/ca.key#1 PRIVATE KEY ECDSA P-521
+      key: ECDSA P-521 izinli değil (P-256, P-384)
+FAIL  pki/root/ca.pem#1 CERTIFICATE CN=minica root CA ECDSA P-521 ECDSA-SHA512
+      key: ECDSA P-521 izinli değil (P-256, P-384)
+9 blok, 3 ihlalli (profil modern)
+exit status 1
+make: *** [Makefile:46: audit] Error 1
+```
+
+### 3. Sunucu ve istemci
+
+Sunucu kök sertifikayı göndermez, yalnızca yaprak ve ara CA'yı gönderir. Bu yüzden `modern` sunucu ve istemci yukarıdaki P-521 kökle de bağlanır; kökün kendisi güven deposundadır ve `make audit` onu ayrıca yakalar.
+
+```bash
+$ cd server && go run main.go -staple=false -profile modern
+2026/10/18 22:23:18 Mutual TLS: disabled
+2026/10/18 22:23:18 Crypto policy: modern (TLS 1.3–TLS 1.3)
+2026/10/18 22:23:18 Listening on :8443 (TLS)
+```
+
+```bash
+$ cd client && go run main.go -profile fips
+status: 200 OK
+body:
+Hello, TLS world!
+```
+
+### 4. Testler
+
+```bash
+make test
+```
+
+```
+ok  	go-tls-full-project/minica	0.087s
+ok  	go-tls-full-project/revocation	0.047s
+```
+
+---
+
+### Son notlar
+
+* **`renew` anahtarı değiştirmez.** Profil sıkılaştırıldığında eski bir anahtarla verilmiş sertifika yenilenmeye devam eder. Bunu `make audit` yakalar; böyle sertifikalar `issue` ile yeni anahtarla yeniden verilmeli.
+* **Stapling açıkken `Apply` sunucu sertifikasını görmez.** Sertifika `GetCertificate` ile verilir ve `cfg.Certificates` boştur. Bu yüzden sunucu sertifikayı yükledikten hemen sonra `CheckTLSCertificate` ile kendisi kontrol eder.
+* **`fips` profili Docker imajında da `GODEBUG=fips140=on` ister.** Sunucu FIPS modu kapalıyken `fips` profiliyle başlamayı reddeder; compose dosyasındaki `GODEBUG` satırı bunun içindir.
+* `legacy` profili TLS 1.0'ı açar. Bu proje iki Go programı arasında konuştuğu için `legacy` yalnızca eski bir istemciyi denemek içindir.
+
+İstersen bir sonraki adımda baştaki Must-Staple önerisine dönebiliriz: `minica issue -must-staple` ile sertifikaya `status_request` uzantısını (RFC 7633) ekleyip staple göndermeyen sunucuyu istemcide reddedelim. Bunu ister misin?
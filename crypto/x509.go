@@ -607,4 +607,443 @@ make run
 Böylece hem **Go kodları** hem de **Docker ortamı** hazır 🚀
 
 👉 İstersen ben buna bir de **docker-compose.yml** ekleyip farklı konteynerlerde “CA” ve “client doğrulama” çalıştırabilirim. İstiyor musun?
+EVET
+*/
+
+/*
+## 🔹 docker-compose: CA ve doğrulama ayrı konteynerlerde
+
+Compose'a geçmeden önce bir düzeltme. Yukarıdaki `verify_chain.go` gerçekte şu çıktıyı verir:
+
+```
+❌ Doğrulama başarısız: x509: certificate specifies an incompatible key usage
+```
+
+`x509.VerifyOptions` içinde `KeyUsages` boş bırakılırsa `Verify` **ServerAuth** arar. Üretilen sertifika ise `ExtKeyUsageClientAuth` taşıyan bir istemci sertifikası. "Zincir doğrulandı" çıktısını almak için `KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}` gerekir.
+
+`generate_certs.go`'daki diğer sorunlar:
+
+* bütün hatalar `_` ile yutuluyor,
+* seriler sabit (`1, 2, 3`),
+* her çalıştırmada CA yeniden üretiliyor; eski sertifikalar geçersiz kalıyor.
+
+Bu yüzden CA tarafında elle yazılmış üreteç yerine TLS projesindeki (`crypto/tls-Go-tls-full-project.go`) **`minica`** aracını kullanıyoruz. `minica` kök + ara CA kurar, istemci sertifikasını **yaprak + ara CA** zinciri olarak tek dosyaya yazar ve verdiği her sertifikayı `pki/index.json` içinde tutar.
+
+Proje yapısı:
+
+```
+x509-chain-demo/
+│── go.mod              // go mod init x509-chain-demo
+│── minica/             // TLS projesinden kopyalanır
+│── verify_chain.go     // güncellendi
+│── docker-compose.yml
+│── pki/                (ca servisi üretir: CA anahtarları + index)
+│── certs/              (ca servisi üretir: rootCA.pem, client.pem, client.key)
+```
+
+`generate_certs.go` ve `certs/intermediateCA.pem` artık gerekmiyor. Ara CA, `client.pem` içinde yaprağın arkasından gelir.
+
+---
+
+## 🔹 1. `verify_chain.go`
+*/
+``go
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"os"
+)
+
+// loadCerts, PEM dosyasındaki bütün sertifikaları sırayla okur.
+// minica'nın yazdığı client.pem = yaprak + ara CA.
+func loadCerts(path string) []*x509.Certificate {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		log.Fatalf("%s: sertifika yok", path)
+	}
+	return certs
+}
+
+func main() {
+	roots := x509.NewCertPool()
+	for _, c := range loadCerts("certs/rootCA.pem") {
+		roots.AddCert(c)
+	}
+
+	chain := loadCerts("certs/client.pem")
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		// Boş bırakılırsa Verify ServerAuth arar; istemci sertifikası için
+		// bu "incompatible key usage" hatası verir.
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	chains, err := chain[0].Verify(opts)
+	if err != nil {
+		fmt.Println("❌ Doğrulama başarısız:", err)
+		os.Exit(1)
+	}
+	fmt.Println("✅ Zincir doğrulandı! Sertifika yolu:")
+	for _, c := range chains[0] {
+		fmt.Println("   →", c.Subject.CommonName)
+	}
+}
+``
+/*
+---
+
+## 🔹 2. `docker-compose.yml`
+
+* `ca` servisi CA'yı yalnızca ilk seferde kurar. Sonraki çalıştırmalarda aynı CA ile yeni bir istemci sertifikası verir.
+* `verify` servisi `ca` başarıyla bitince başlar. Bu konteynere yalnızca `certs/` ve `verify_chain.go` bağlanır; CA anahtarlarını hiç görmez.
+* `verify_chain.go` yalnızca standart kütüphane kullandığı için `go.mod` olmadan `go run` ile çalışır.
+*/
+``yaml
+services:
+  ca:
+    image: golang:1.24-alpine
+    working_dir: /src
+    volumes:
+      - .:/src
+    command:
+      - sh
+      - -c
+      - |
+        test -f pki/issuing/ca.pem || {
+          go run ./minica init -cn "My Root CA" -cert certs/rootCA.pem &&
+          go run ./minica intermediate -cn "My Intermediate CA"
+        } &&
+        go run ./minica issue -type client -cn client.local -cert certs/client.pem -key certs/client.key
+
+  verify:
+    image: golang:1.24-alpine
+    working_dir: /src
+    # Doğrulayan taraf yalnızca sertifikaları görür; CA anahtarları (pki/) bu konteynere girmez.
+    volumes:
+      - ./certs:/src/certs:ro
+      - ./verify_chain.go:/src/verify_chain.go:ro
+    depends_on:
+      ca:
+        condition: service_completed_successfully
+    command: ["go", "run", "verify_chain.go"]
+``
+/*
+---
+
+## 🔹 Çalıştırma
+*/
+``bash
+docker compose run --rm verify
+``
+/*
+📌 Çıktı (ilk çalıştırma):
+
+```
+kök CA "root": pki/root/ca.pem (bitiş 2036-10-15)
+güven dosyası: certs/rootCA.pem
+ara CA "issuing": pki/issuing/ca.pem (seri 25C14270E820749743D7D388CC9F54B9, bitiş 2031-10-17)
+client sertifikası "client.local": certs/client.pem, certs/client.key (seri 4FAD55F152A811D6738C67CA3AF2C6C3, bitiş 2027-10-18)
+✅ Zincir doğrulandı! Sertifika yolu:
+   → client.local
+   → My Intermediate CA
+   → My Root CA
+```
+
+İkinci çalıştırmada CA yeniden kurulmaz. Yalnızca yeni bir istemci sertifikası verilir ve index büyür:
+
+```
+$ go run ./minica list
+SERIAL        CA       KIND    SUBJECT                NOT AFTER   STATUS
+4FAD55F152A8  issuing  client  CN=client.local        2027-10-18  valid
+C7527EE2D7F4  issuing  client  CN=client.local        2027-10-18  valid
+25C14270E820  root     ca      CN=My Intermediate CA  2031-10-17  valid
+```
+
+---
+
+`verify_chain.go` hâlâ yalnızca zinciri ve anahtar kullanımını kontrol ediyor. `minica revoke` ile iptal edilmiş bir sertifikayı da "doğrulandı" diye geçirir, çünkü iptal bilgisini dağıtan bir **CRL** ya da **OCSP** yok.
+
+👉 İstersen bir sonraki adımda index'ten CRL üretip, yerel bir OCSP responder ile doğrulamaya iptal kontrolü de ekleyebilirim. Bunu ister misin?
+EVET
+*/
+
+/*
+Tamam 👍 `minica` artık iptalleri yayımlıyor: `minica crl` CA imzalı bir **CRL** dosyası yazıyor, `minica serve` de her CA için bir **OCSP yanıtlayıcısı** açıyor. Sunucu/istemci tarafındaki OCSP ve stapling kısmı TLS projesinde (`revocation` paketi). Burada `verify_chain.go`'ya **yalnızca standart kütüphane ile** CRL kontrolü ekleyelim.
+
+---
+
+## 🔹 Neden ayrı bir kontrol gerekiyor?
+
+`x509.Certificate.Verify` zinciri, imzaları, süreleri ve anahtar kullanımını kontrol eder. **İptal durumuna hiç bakmaz.** İptal edilmiş bir sertifika da `Verify`'dan geçer, bu yüzden iptal kontrolü `Verify`'dan sonra ayrıca yapılmalıdır.
+
+CRL kontrolünde dikkat edilecek üç nokta var:
+
+* **CRL'in imzası** doğrulanmalı (`RevocationList.CheckSignatureFrom`). Yoksa herkes boş bir CRL yazıp iptali gizleyebilir.
+* **NextUpdate** geçmişse CRL bayattır; o tarihten sonraki iptaller listede olmayabilir.
+* Issuer'ın CRL'i hiç yoksa sertifika **reddedilir** (fail-closed). "CRL bulamadım, geçsin" demek iptal kontrolünü anlamsız kılar.
+
+Zincirdeki her sertifika (kök hariç) kendi issuer'ının CRL'inde aranır: istemci sertifikası ara CA'nın CRL'inde, ara CA da kökün CRL'inde.
+
+---
+
+## 🔹 `verify_chain.go` (CRL kontrollü)
+*/
+``go
+package main
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// loadCerts, PEM dosyasındaki bütün sertifikaları sırayla okur.
+// minica'nın yazdığı client.pem = yaprak + ara CA.
+func loadCerts(path string) []*x509.Certificate {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var certs []*x509.Certificate
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		log.Fatalf("%s: sertifika yok", path)
+	}
+	return certs
+}
+
+// loadCRLs, "minica crl" ile yazılmış DER CRL dosyalarını okur.
+func loadCRLs(paths []string) []*x509.RevocationList {
+	var lists []*x509.RevocationList
+	for _, path := range paths {
+		der, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		list, err := x509.ParseRevocationList(der)
+		if err != nil {
+			log.Fatalf("%s: %v", path, err)
+		}
+		lists = append(lists, list)
+	}
+	return lists
+}
+
+// checkRevocation, zincirdeki her sertifikayı (kök hariç) issuer'ının
+// CRL'inde arar. CRL'in imzası issuer'a karşı doğrulanır; yoksa herkes
+// boş bir "CRL" yazıp iptali gizleyebilirdi. Issuer'ın geçerli bir CRL'i
+// yoksa sertifika reddedilir (fail-closed).
+func checkRevocation(chain []*x509.Certificate, lists []*x509.RevocationList, now time.Time) error {
+	for i := 0; i+1 < len(chain); i++ {
+		cert, issuer := chain[i], chain[i+1]
+		var list *x509.RevocationList
+		for _, l := range lists {
+			if l.CheckSignatureFrom(issuer) == nil {
+				list = l
+				break
+			}
+		}
+		if list == nil {
+			return fmt.Errorf("%s için CRL yok", issuer.Subject.CommonName)
+		}
+		if now.After(list.NextUpdate) {
+			return fmt.Errorf("%s CRL'inin süresi %s tarihinde dolmuş", issuer.Subject.CommonName, list.NextUpdate.Format(time.DateTime))
+		}
+		for _, e := range list.RevokedCertificateEntries {
+			if e.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return fmt.Errorf("%s %s tarihinde iptal edilmiş (neden %d)",
+					cert.Subject.CommonName, e.RevocationTime.Format(time.DateTime), e.ReasonCode)
+			}
+		}
+	}
+	return nil
+}
+
+func main() {
+	crls := flag.String("crl", "certs/issuing.crl,certs/root.crl", "virgülle ayrılmış CRL dosyaları")
+	flag.Parse()
+
+	roots := x509.NewCertPool()
+	for _, c := range loadCerts("certs/rootCA.pem") {
+		roots.AddCert(c)
+	}
+
+	chain := loadCerts("certs/client.pem")
+	intermediates := x509.NewCertPool()
+	for _, c := range chain[1:] {
+		intermediates.AddCert(c)
+	}
+
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		// Boş bırakılırsa Verify ServerAuth arar; istemci sertifikası için
+		// bu "incompatible key usage" hatası verir.
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+
+	chains, err := chain[0].Verify(opts)
+	if err != nil {
+		fmt.Println("❌ Doğrulama başarısız:", err)
+		os.Exit(1)
+	}
+	// Verify iptali bilmez; zincir geçerli olsa bile CRL'e bakılmalı.
+	if err := checkRevocation(chains[0], loadCRLs(strings.Split(*crls, ",")), time.Now()); err != nil {
+		fmt.Println("❌ İptal kontrolü başarısız:", err)
+		os.Exit(1)
+	}
+	fmt.Println("✅ Zincir doğrulandı, iptal edilmemiş! Sertifika yolu:")
+	for _, c := range chains[0] {
+		fmt.Println("   →", c.Subject.CommonName)
+	}
+}
+``
+/*
+* CRL dosyası `minica crl` ile yazılan **DER** biçimidir; `x509.ParseRevocationList` doğrudan okur.
+* Hangi CRL'in hangi issuer'a ait olduğu imzadan anlaşılır. Yalnızca isim eşleştirmek yetmez: aynı isimli başka bir CA'nın CRL'i de eşleşirdi.
+* `RevokedCertificateEntries` Go 1.21'de geldi; eski `RevokedCertificates` alanı kullanımdan kaldırıldı.
+
+---
+
+## 🔹 `docker-compose.yml`
+
+`minica` artık TLS projesindeki `revocation` paketine (OCSP yanıtlayıcısı için) ve `golang.org/x/crypto`'ya bağlı. Bu yüzden `minica/` dizinini kopyalamak yerine TLS projesi salt okunur bağlanıp oradan derleniyor. `ca` servisi sertifikayı verdikten sonra iki CRL'i de yazar. `verify` konteyneri yine yalnızca `certs/` dizinini görür.
+*/
+``yaml
+services:
+  ca:
+    image: golang:1.24-alpine
+    working_dir: /src
+    volumes:
+      - .:/src
+      # minica artık revocation paketine ve x/crypto'ya bağlı; kopyalamak
+      # yerine TLS projesinden derleniyor.
+      - ../go-tls-full-project:/tls:ro
+    command:
+      - sh
+      - -c
+      - |
+        go build -C /tls -o /usr/local/bin/minica ./minica &&
+        { test -f pki/issuing/ca.pem || {
+          minica init -cn "My Root CA" -cert certs/rootCA.pem &&
+          minica intermediate -cn "My Intermediate CA"
+        }; } &&
+        minica issue -type client -cn client.local -cert certs/client.pem -key certs/client.key &&
+        minica crl -ca issuing -out certs/issuing.crl &&
+        minica crl -ca root -out certs/root.crl
+
+  verify:
+    image: golang:1.24-alpine
+    working_dir: /src
+    # Doğrulayan taraf yalnızca sertifikaları ve CRL'leri görür; CA anahtarları (pki/) bu konteynere girmez.
+    volumes:
+      - ./certs:/src/certs:ro
+      - ./verify_chain.go:/src/verify_chain.go:ro
+    depends_on:
+      ca:
+        condition: service_completed_successfully
+    command: ["go", "run", "verify_chain.go"]
+``
+/*
+Dizin yapısı:
+
+```
+.
+├── docker-compose.yml
+├── verify_chain.go
+├── certs/                 // rootCA.pem, client.pem, client.key, issuing.crl, root.crl
+└── pki/                   // CA anahtarları ve index (verify görmez)
+../go-tls-full-project/    // minica buradan derlenir
+```
+
+---
+
+## 🔹 Çalıştırma
+*/
+``bash
+docker compose run --rm verify
+``
+/*
+📌 Çıktı (ilk çalıştırma):
+
+```
+kök CA "root": pki/root/ca.pem (bitiş 2036-10-15)
+güven dosyası: certs/rootCA.pem
+ara CA "issuing": pki/issuing/ca.pem (seri 56EA62F79525105697E9B267721016D2, bitiş 2031-10-17)
+client sertifikası "client.local": certs/client.pem, certs/client.key (seri E85D8A82578838D71FD6702366B048FB, bitiş 2027-10-18)
+CRL "issuing": certs/issuing.crl (0 iptal, nextUpdate 2026-10-25 21:28:27)
+CRL "root": certs/root.crl (0 iptal, nextUpdate 2026-10-25 21:28:27)
+✅ Zincir doğrulandı, iptal edilmemiş! Sertifika yolu:
+   → client.local
+   → My Intermediate CA
+   → My Root CA
+```
+
+Sertifikayı iptal edip yalnızca CRL'i yeniden üretelim (yeni sertifika vermeden):
+*/
+``bash
+docker compose run --rm ca sh -c 'go build -C /tls -o /usr/local/bin/minica ./minica &&
+  minica revoke -serial E85D8A82 -reason keyCompromise &&
+  minica crl -ca issuing -out certs/issuing.crl'
+docker compose run --rm --no-deps verify
+``
+/*
+📌 Çıktı:
+
+```
+iptal edildi E85D8A82578838D71FD6702366B048FB CN=client.local (keyCompromise)
+CRL "issuing": certs/issuing.crl (1 iptal, nextUpdate 2026-10-25 21:28:27)
+❌ İptal kontrolü başarısız: client.local 2026-10-18 21:28:27 tarihinde iptal edilmiş (neden 1)
+exit status 1
+```
+
+Zincir hâlâ geçerli; sertifikayı reddeden yalnızca CRL kontrolü. Neden kodu `1`, RFC 5280'deki `keyCompromise` değeridir.
+
+---
+
+## 🔹 CRL mi, OCSP mi?
+
+| | CRL | OCSP |
+|---|---|---|
+| Ne dağıtılır | CA'nın bütün iptal listesi | tek bir seri için imzalı yanıt |
+| Çevrimdışı kullanım | ✅ dosya kopyalanır | ❌ yanıtlayıcıya erişim gerekir (staple hariç) |
+| Tazelik | `NextUpdate`'e kadar (burada 7 gün) | yanıt geçerliliği (burada 1 saat) |
+| Standart kütüphane | ✅ `x509.CreateRevocationList`, `ParseRevocationList` | ❌ `golang.org/x/crypto/ocsp` |
+| Boyut | iptal sayısıyla büyür | sabit, küçük |
+
+Bu örnekteki gibi sertifikaları dosya olarak dağıtılan, ağa çıkmayan bir doğrulayıcı için CRL daha uygun. TLS bağlantılarında ise OCSP stapling daha yaygın; TLS projesindeki `revocation.Checker` önce staple'a, sonra OCSP'ye, en son CRL'e bakıyor.
+
+👉 İstersen bir sonraki adımda CRL'e **delta CRL** ekleyip büyük listelerde yalnızca son iptallerin dağıtılmasını ya da `verify_chain.go`'ya `-ocsp` bayrağıyla çevrimiçi kontrol eklemeyi gösterebilirim. Bunu ister misin?
 */
@@ -253,10 +253,12 @@ Bu yaklaşım, gerçek dünyadaki projelerde **temiz kod** ve **sürdürülebili
 */
 ``
 go-crud/
+│── go.mod           (module go-crud)
 │── main.go
 │── user.go          (entity/model)
 │── user_repository.go
 │── user_service.go
+│── sqlmap/          (reflect/reflect.go'daki struct ↔ satır eşleyici)
 ``
 /*
 ---
@@ -268,46 +270,54 @@ package main
 
 import "database/sql"
 
-// User struct'ı veritabanındaki users tablosunu temsil eder
+// User struct'ı veritabanındaki users tablosunu temsil eder.
+// db tag'leri sütun adlarıdır; id'yi AUTO_INCREMENT ürettiği için "auto".
 type User struct {
-	ID    int
-	Name  string
-	Age   int
-	Email sql.NullString
+	ID    int            `db:"id,pk,auto"`
+	Name  string         `db:"name"`
+	Age   int            `db:"age"`
+	Email sql.NullString `db:"email"`
 }
 ``
 /*
 ---
 
 ## 📌 `user_repository.go` (Repository Layer)
+
+Sorgular ve `Scan` çağrıları artık elle yazılmıyor: `sqlmap`, `User`'ın `db` tag'lerinden parametreli sorguyu üretir ve satırları isimle struct'a okur. Tabloya sütun eklendiğinde sadece `User` değişir.
 */
 ``
 package main
 
 import (
+	"context"
 	"database/sql"
-	"log"
+	"errors"
+
+	"go-crud/sqlmap"
 )
 
+const usersTable = "users"
+
 // UserRepository veritabanı işlemlerini içerir
 type UserRepository struct {
 	db *sql.DB
+	m  *sqlmap.Mapper
 }
 
 // NewUserRepository yeni repository döner
 func NewUserRepository(db *sql.DB) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: db, m: sqlmap.New(sqlmap.Question)} // MySQL: ?
 }
 
 // CREATE
 func (r *UserRepository) Create(user User) (int64, error) {
-	stmt, err := r.db.Prepare("INSERT INTO users(name, age, email) VALUES(?, ?, ?)")
+	query, args, err := r.m.Insert(usersTable, user)
 	if err != nil {
 		return 0, err
 	}
-	defer stmt.Close()
 
-	res, err := stmt.Exec(user.Name, user.Age, user.Email)
+	res, err := r.db.Exec(query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -317,34 +327,22 @@ func (r *UserRepository) Create(user User) (int64, error) {
 
 // READ ALL
 func (r *UserRepository) GetAll() ([]User, error) {
-	rows, err := r.db.Query("SELECT id, name, age, email FROM users")
+	query, err := r.m.Select(usersTable, User{}, "")
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var users []User
-	for rows.Next() {
-		var u User
-		if err := rows.Scan(&u.ID, &u.Name, &u.Age, &u.Email); err != nil {
-			return nil, err
-		}
-		users = append(users, u)
-	}
-
-	if err := rows.Err(); err != nil {
-		log.Fatal(err)
-	}
-	return users, nil
+	return sqlmap.QueryAll[User](context.Background(), r.db, query)
 }
 
 // READ ONE
 func (r *UserRepository) GetByID(id int) (*User, error) {
-	var u User
-	err := r.db.QueryRow("SELECT id, name, age, email FROM users WHERE id=?", id).
-		Scan(&u.ID, &u.Name, &u.Age, &u.Email)
+	query, err := r.m.Select(usersTable, User{}, "id = ?")
+	if err != nil {
+		return nil, err
+	}
+	u, err := sqlmap.QueryOne[User](context.Background(), r.db, query, id)
 
-	if err == sql.ErrNoRows {
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
@@ -355,14 +353,21 @@ func (r *UserRepository) GetByID(id int) (*User, error) {
 
 // UPDATE
 func (r *UserRepository) Update(user User) error {
-	_, err := r.db.Exec("UPDATE users SET name=?, age=?, email=? WHERE id=?",
-		user.Name, user.Age, user.Email, user.ID)
+	query, args, err := r.m.Update(usersTable, user)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(query, args...)
 	return err
 }
 
 // DELETE
 func (r *UserRepository) Delete(id int) error {
-	_, err := r.db.Exec("DELETE FROM users WHERE id=?", id)
+	query, args, err := r.m.Delete(usersTable, User{ID: id})
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(query, args...)
 	return err
 }
 ``
@@ -539,11 +544,13 @@ Framework olarak **Gin** kullanacağım çünkü çok popüler, hızlı ve öğr
 */
 ``
 go-crud-api/
+│── go.mod              (module go-crud-api)
 │── main.go             (API server)
 │── user.go             (model/entity)
 │── user_repository.go  (repository)
 │── user_service.go     (service)
 │── user_handler.go     (HTTP handlers - REST API)
+│── sqlmap/             (struct ↔ satır eşleyici)
 ``
 /*
 ---
@@ -556,10 +563,10 @@ package main
 import "database/sql"
 
 type User struct {
-	ID    int            `json:"id"`
-	Name  string         `json:"name"`
-	Age   int            `json:"age"`
-	Email sql.NullString `json:"email"`
+	ID    int            `json:"id" db:"id,pk,auto"`
+	Name  string         `json:"name" db:"name"`
+	Age   int            `json:"age" db:"age"`
+	Email sql.NullString `json:"email" db:"email"`
 }
 ``
 /*
@@ -570,19 +577,31 @@ type User struct {
 ``
 package main
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"go-crud-api/sqlmap"
+)
+
+const usersTable = "users"
 
 type UserRepository struct {
 	db *sql.DB
+	m  *sqlmap.Mapper
 }
 
 func NewUserRepository(db *sql.DB) *UserRepository {
-	return &UserRepository{db: db}
+	return &UserRepository{db: db, m: sqlmap.New(sqlmap.Question)}
 }
 
 func (r *UserRepository) Create(user User) (int64, error) {
-	res, err := r.db.Exec("INSERT INTO users(name, age, email) VALUES(?, ?, ?)",
-		user.Name, user.Age, user.Email)
+	query, args, err := r.m.Insert(usersTable, user)
+	if err != nil {
+		return 0, err
+	}
+	res, err := r.db.Exec(query, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -590,28 +609,20 @@ func (r *UserRepository) Create(user User) (int64, error) {
 }
 
 func (r *UserRepository) GetAll() ([]User, error) {
-	rows, err := r.db.Query("SELECT id, name, age, email FROM users")
+	query, err := r.m.Select(usersTable, User{}, "")
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-
-	var users []User
-	for rows.Next() {
-		var u User
-		if err := rows.Scan(&u.ID, &u.Name, &u.Age, &u.Email); err != nil {
-			return nil, err
-		}
-		users = append(users, u)
-	}
-	return users, nil
+	return sqlmap.QueryAll[User](context.Background(), r.db, query)
 }
 
 func (r *UserRepository) GetByID(id int) (*User, error) {
-	var u User
-	err := r.db.QueryRow("SELECT id, name, age, email FROM users WHERE id=?", id).
-		Scan(&u.ID, &u.Name, &u.Age, &u.Email)
-	if err == sql.ErrNoRows {
+	query, err := r.m.Select(usersTable, User{}, "id = ?")
+	if err != nil {
+		return nil, err
+	}
+	u, err := sqlmap.QueryOne[User](context.Background(), r.db, query, id)
+	if errors.Is(err, sql.ErrNoRows) {
 		return nil, nil
 	}
 	if err != nil {
@@ -621,13 +632,20 @@ func (r *UserRepository) GetByID(id int) (*User, error) {
 }
 
 func (r *UserRepository) Update(user User) error {
-	_, err := r.db.Exec("UPDATE users SET name=?, age=?, email=? WHERE id=?",
-		user.Name, user.Age, user.Email, user.ID)
+	query, args, err := r.m.Update(usersTable, user)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(query, args...)
 	return err
 }
 
 func (r *UserRepository) Delete(id int) error {
-	_, err := r.db.Exec("DELETE FROM users WHERE id=?", id)
+	query, args, err := r.m.Delete(usersTable, User{ID: id})
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(query, args...)
 	return err
 }
 ``
@@ -593,6 +593,8 @@ UserID: 42, Email: user@example.com, ExpiresAt: 2025-08-29T11:08:26Z
 
 Yani artık elimizde **tamamen JWT standardına uyumlu** bir sistem var ✅
 
+⚠️ Bu örnek JWT'nin **biçimini** gösterir ama standarda tam uymaz: `exp` string olarak yazılıyor (RFC 7519 saniye cinsinden sayı ister), imzalar `!=` ile karşılaştırılıyor (zamanlama saldırısına açık) ve `alg` hiç kontrol edilmiyor. Gerçek projede dosyanın sonundaki `jwt` paketini kullan.
+
 ---
 
 👉 İstersen bu JWT sistemini **middleware** olarak ekleyelim.
@@ -789,4 +791,1668 @@ func main() {
 👉 Burada context kullanımını eklemedik. İstersen ben sana payload’taki `UserID` ve `Email` bilgisini **context.Context içine ekleyip handler’larda erişilebilir hale getirecek** versiyonunu yazayım.
 
 Bunu da ister misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Tamam 👍 Context'e geçmeden önce bir adım geri atıp JWT kısmını sağlamlaştıralım; çünkü yukarıdaki middleware, altındaki `GenerateJWT`/`ValidateJWT` kadar güvenli.
+
+# 🔐 Standartlara Uygun JWT/JWS Kütüphanesi: `jwt`
+
+## 1. Eski Örnekteki Sorunlar
+
+* **`exp` string olarak yazılıyor:** `time.Time` JSON'a `"2025-08-29T11:08:26Z"` diye gider. RFC 7519 ise `exp`, `nbf`, `iat` için **NumericDate** (1970'ten beri saniye, sayı) ister. Başka dilde yazılmış bir servis bu token'ı okuyamaz ya da süresiz sayar.
+* **İmza `!=` ile karşılaştırılıyor:** String karşılaştırması ilk farklı byte'ta durur; süre ölçülerek imza tahmin edilebilir. `crypto/subtle.ConstantTimeCompare` (bkz. `crypto/subtle.go`) kullanılmalı.
+* **`alg` hiç okunmuyor:** Header'da ne yazarsa yazsın HS256 varsayılıyor. Gerçek kütüphanelerde tersi de tehlikeli: `alg`'ı token'dan alıp ona göre doğrulamak `"alg":"none"` ve "RSA açık anahtarını HMAC sırrı yapma" saldırılarına yol açar.
+* **Yalnızca HS256, tek sabit anahtar:** Anahtar döndürme (rotation), `kid`, açık anahtarlı algoritmalar yok.
+* **`aud`, `iss`, saat kayması (clock skew) kontrolü yok.**
+
+## 2. Hedef
+
+* `exp` / `nbf` / `iat` → **NumericDate**
+* İmza karşılaştırma → **`crypto/subtle`**
+* Algoritmalar → **HS256/384/512, RS256, PS256, ES256/384, EdDSA** (repodaki `crypto/hmac.go`, `crypto/rsa.go`, `crypto/ecdsa.go`, `crypto/ed25519.go` örneklerinin üzerine)
+* Doğrulama → izin verilen algoritma listesi, `iss`, `aud`, `Leeway` (saat kayması)
+* Anahtarlar → **JWKS** (`{"keys":[...]}`) ve `kid` ile seçim
+* Birlikte çalışabilirlik → RFC test vektörleri ve **Node.js** ile karşılıklı imza/doğrulama testi
+
+---
+
+## 3. Proje Yapısı
+*/
+``
+jwtdemo/
+├── go.mod              # module jwtdemo, go 1.25
+├── main.go             # JWKS + middleware demosu
+└── jwt/
+    ├── alg.go          # HS*, RS256, PS256, ES*, EdDSA
+    ├── claims.go       # NumericDate, Audience, RegisteredClaims, doğrulama
+    ├── jws.go          # Header, Signer, Parser, hatalar
+    ├── jwk.go          # JWK, KeySet (JWKS)
+    ├── pem.go          # PEM anahtar okuma
+    ├── jwt_test.go
+    └── testdata/
+        └── interop.mjs # Node.js tarafı
+``
+/*
+📌 `go 1.25` gerekir: EC anahtarlarını JWK'ye çevirirken `ecdsa.ParseUncompressedPublicKey`, `ecdsa.ParseRawPrivateKey` ve `(*ecdsa.PublicKey).Bytes` kullanılıyor.
+
+---
+
+## 4. Algoritmalar: `jwt/alg.go`
+*/
+``go
+// Package jwt, RFC 7515 (JWS), RFC 7518 (JWA), RFC 7517 (JWK) ve
+// RFC 7519 (JWT) ile uyumlu küçük bir JWT kütüphanesidir.
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"fmt"
+	"math/big"
+
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// Algorithm, bir JWS imza algoritmasıdır. Key tipleri:
+//
+//	HS*   → []byte (imza ve doğrulama)
+//	RS*   → *rsa.PrivateKey / *rsa.PublicKey
+//	PS*   → *rsa.PrivateKey / *rsa.PublicKey
+//	ES*   → *ecdsa.PrivateKey / *ecdsa.PublicKey
+//	EdDSA → ed25519.PrivateKey / ed25519.PublicKey
+type Algorithm interface {
+	Name() string
+	Sign(key any, signingInput []byte) ([]byte, error)
+	Verify(key any, signingInput, sig []byte) error
+}
+
+var (
+	HS256 Algorithm = &hmacAlg{"HS256", crypto.SHA256}
+	HS384 Algorithm = &hmacAlg{"HS384", crypto.SHA384}
+	HS512 Algorithm = &hmacAlg{"HS512", crypto.SHA512}
+	RS256 Algorithm = &rsaAlg{"RS256", crypto.SHA256, false}
+	PS256 Algorithm = &rsaAlg{"PS256", crypto.SHA256, true}
+	ES256 Algorithm = &ecdsaAlg{"ES256", crypto.SHA256, elliptic.P256()}
+	ES384 Algorithm = &ecdsaAlg{"ES384", crypto.SHA384, elliptic.P384()}
+	EdDSA Algorithm = edAlg{}
+)
+
+var algorithms = map[string]Algorithm{}
+
+func init() {
+	for _, a := range []Algorithm{HS256, HS384, HS512, RS256, PS256, ES256, ES384, EdDSA} {
+		algorithms[a.Name()] = a
+	}
+}
+
+// LookupAlgorithm, "alg" değerine karşılık gelen algoritmayı döndürür.
+// "none" hiçbir zaman desteklenmez.
+func LookupAlgorithm(name string) (Algorithm, bool) {
+	a, ok := algorithms[name]
+	return a, ok
+}
+
+func digest(h crypto.Hash, data []byte) []byte {
+	w := h.New()
+	w.Write(data)
+	return w.Sum(nil)
+}
+
+func keyError(alg string, key any) error {
+	return fmt.Errorf("%w: %s için %T kullanılamaz", ErrInvalidKey, alg, key)
+}
+
+// ---- HMAC ----
+
+type hmacAlg struct {
+	name string
+	hash crypto.Hash
+}
+
+func (a *hmacAlg) Name() string { return a.name }
+
+func (a *hmacAlg) sum(key any, data []byte) ([]byte, error) {
+	k, ok := key.([]byte)
+	if !ok {
+		return nil, keyError(a.name, key)
+	}
+	// RFC 7518 3.2: anahtar en az hash çıktısı kadar olmalı.
+	if len(k) < a.hash.Size() {
+		return nil, fmt.Errorf("%w: %s anahtarı en az %d byte olmalı", ErrInvalidKey, a.name, a.hash.Size())
+	}
+	m := hmac.New(a.hash.New, k)
+	m.Write(data)
+	return m.Sum(nil), nil
+}
+
+func (a *hmacAlg) Sign(key any, data []byte) ([]byte, error) { return a.sum(key, data) }
+
+func (a *hmacAlg) Verify(key any, data, sig []byte) error {
+	want, err := a.sum(key, data)
+	if err != nil {
+		return err
+	}
+	// != yerine sabit zamanlı karşılaştırma: hangi byte'ta farklılaştığı
+	// süreden anlaşılamaz.
+	if subtle.ConstantTimeCompare(want, sig) != 1 {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// ---- RSA (PKCS#1 v1.5 ve PSS) ----
+
+type rsaAlg struct {
+	name string
+	hash crypto.Hash
+	pss  bool
+}
+
+func (a *rsaAlg) Name() string { return a.name }
+
+var pssOptions = &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+
+func (a *rsaAlg) Sign(key any, data []byte) ([]byte, error) {
+	k, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, keyError(a.name, key)
+	}
+	if a.pss {
+		return rsa.SignPSS(rand.Reader, k, a.hash, digest(a.hash, data), pssOptions)
+	}
+	return rsa.SignPKCS1v15(rand.Reader, k, a.hash, digest(a.hash, data))
+}
+
+func (a *rsaAlg) Verify(key any, data, sig []byte) error {
+	k, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return keyError(a.name, key)
+	}
+	// RFC 7518 3.3: 2048 bitten kısa RSA anahtarları kabul edilmez.
+	if k.N.BitLen() < 2048 {
+		return fmt.Errorf("%w: RSA anahtarı %d bit, en az 2048 gerekir", ErrInvalidKey, k.N.BitLen())
+	}
+	var err error
+	if a.pss {
+		err = rsa.VerifyPSS(k, a.hash, digest(a.hash, data), sig, pssOptions)
+	} else {
+		err = rsa.VerifyPKCS1v15(k, a.hash, digest(a.hash, data), sig)
+	}
+	if err != nil {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// ---- ECDSA ----
+
+// JWS, ECDSA imzasını ASN.1 DER değil, sabit uzunlukta R || S olarak
+// taşır (RFC 7518 3.4). ecdsa.SignASN1 çıktısı bu yüzden doğrudan
+// kullanılamaz.
+type ecdsaAlg struct {
+	name  string
+	hash  crypto.Hash
+	curve elliptic.Curve
+}
+
+func (a *ecdsaAlg) Name() string { return a.name }
+
+func (a *ecdsaAlg) size() int { return (a.curve.Params().BitSize + 7) / 8 }
+
+func (a *ecdsaAlg) Sign(key any, data []byte) ([]byte, error) {
+	k, ok := key.(*ecdsa.PrivateKey)
+	if !ok || k.Curve != a.curve {
+		return nil, keyError(a.name, key)
+	}
+	r, s, err := ecdsa.Sign(rand.Reader, k, digest(a.hash, data))
+	if err != nil {
+		return nil, err
+	}
+	n := a.size()
+	sig := make([]byte, 2*n)
+	r.FillBytes(sig[:n])
+	s.FillBytes(sig[n:])
+	return sig, nil
+}
+
+func (a *ecdsaAlg) Verify(key any, data, sig []byte) error {
+	k, ok := key.(*ecdsa.PublicKey)
+	if !ok || k.Curve != a.curve {
+		return keyError(a.name, key)
+	}
+	n := a.size()
+	if len(sig) != 2*n {
+		return ErrSignatureInvalid
+	}
+	r := new(big.Int).SetBytes(sig[:n])
+	s := new(big.Int).SetBytes(sig[n:])
+	if !ecdsa.Verify(k, digest(a.hash, data), r, s) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+
+// ---- EdDSA (Ed25519, RFC 8037) ----
+
+type edAlg struct{}
+
+func (edAlg) Name() string { return "EdDSA" }
+
+func (edAlg) Sign(key any, data []byte) ([]byte, error) {
+	k, ok := key.(ed25519.PrivateKey)
+	if !ok || len(k) != ed25519.PrivateKeySize {
+		return nil, keyError("EdDSA", key)
+	}
+	return ed25519.Sign(k, data), nil
+}
+
+func (edAlg) Verify(key any, data, sig []byte) error {
+	k, ok := key.(ed25519.PublicKey)
+	if !ok || len(k) != ed25519.PublicKeySize {
+		return keyError("EdDSA", key)
+	}
+	if !ed25519.Verify(k, data, sig) {
+		return ErrSignatureInvalid
+	}
+	return nil
+}
+``
+/*
+### Önemli noktalar
+
+* **HMAC:** `subtle.ConstantTimeCompare` ile karşılaştırma. Anahtar hash çıktısından kısaysa (HS256 için 32 byte) reddedilir; `"super-secret-key"` (16 byte) artık kabul edilmez.
+* **RSA:** PS256'da salt uzunluğu hash boyutuna eşittir (RFC 7518 3.5). 2048 bitten kısa anahtarlar reddedilir.
+* **ECDSA:** JWS imzası **R || S** (P-256 için 64 byte) biçimindedir. `crypto/ecdsa.go`'daki `SignASN1` DER üretir; onu doğrudan koyarsan başka diller token'ı doğrulayamaz.
+* **Anahtar tipi kontrolü:** Her algoritma yalnızca kendi anahtar tipini kabul eder. `HS256`'ya `*rsa.PublicKey` ya da PEM byte'ları verilemez.
+
+---
+
+## 5. Claim'ler: `jwt/claims.go`
+*/
+``go
+package jwt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// NumericDate, RFC 7519'daki "1970'ten beri geçen saniye" sayısıdır.
+// JSON'da string değil, sayı olarak yazılır: "exp": 1735689600
+type NumericDate struct {
+	time.Time
+}
+
+// NewNumericDate, t'yi saniyeye yuvarlayarak NumericDate üretir.
+func NewNumericDate(t time.Time) *NumericDate {
+	return &NumericDate{t.Truncate(time.Second)}
+}
+
+func (d NumericDate) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, d.Unix(), 10), nil
+}
+
+// UnmarshalJSON, kesirli değerleri de kabul eder (ör. 1300819380.5);
+// bazı kütüphaneler exp'i float olarak yazar.
+func (d *NumericDate) UnmarshalJSON(b []byte) error {
+	f, err := strconv.ParseFloat(string(b), 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+		return fmt.Errorf("jwt: geçersiz NumericDate %s", b)
+	}
+	sec, frac := math.Modf(f)
+	d.Time = time.Unix(int64(sec), int64(frac*1e9))
+	return nil
+}
+
+// Audience, "aud" claim'idir. RFC 7519 hem tek string'e hem de string
+// dizisine izin verir; ikisi de okunur.
+type Audience []string
+
+// MarshalJSON, tek değerli listeyi string olarak yazar; çoğu kütüphane
+// bu biçimi bekler.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+func (a *Audience) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(b, []byte(`"`)) {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return err
+		}
+		*a = Audience{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return fmt.Errorf("jwt: aud string ya da string dizisi olmalı: %w", err)
+	}
+	*a = list
+	return nil
+}
+
+// RegisteredClaims, RFC 7519 4.1'deki kayıtlı claim'lerdir. Uygulamaya
+// özel claim'ler bu tipi gömerek eklenir:
+//
+//	type UserClaims struct {
+//		jwt.RegisteredClaims
+//		Email string `json:"email"`
+//	}
+type RegisteredClaims struct {
+	Issuer    string       `json:"iss,omitempty"`
+	Subject   string       `json:"sub,omitempty"`
+	Audience  Audience     `json:"aud,omitempty"`
+	ExpiresAt *NumericDate `json:"exp,omitempty"`
+	NotBefore *NumericDate `json:"nbf,omitempty"`
+	IssuedAt  *NumericDate `json:"iat,omitempty"`
+	ID        string       `json:"jti,omitempty"`
+}
+
+// Registered, Claims arayüzünü sağlar; gömülen tiplerde otomatik gelir.
+func (c *RegisteredClaims) Registered() *RegisteredClaims { return c }
+
+// Claims, Parser.Parse'a verilebilecek claim tipleridir.
+type Claims interface {
+	Registered() *RegisteredClaims
+}
+
+// validate, zaman ve kimlik claim'lerini p'nin kurallarına göre denetler.
+func (p *Parser) validate(c *RegisteredClaims) error {
+	now := time.Now()
+	if p.Now != nil {
+		now = p.Now()
+	}
+	switch {
+	case c.ExpiresAt == nil && !p.AllowNoExp:
+		return ErrMissingExp
+	case c.ExpiresAt != nil && !now.Before(c.ExpiresAt.Add(p.Leeway)):
+		return fmt.Errorf("%w: exp %s", ErrTokenExpired, c.ExpiresAt.UTC().Format(time.RFC3339))
+	case c.NotBefore != nil && now.Add(p.Leeway).Before(c.NotBefore.Time):
+		return fmt.Errorf("%w: nbf %s", ErrTokenNotValidYet, c.NotBefore.UTC().Format(time.RFC3339))
+	case c.IssuedAt != nil && now.Add(p.Leeway).Before(c.IssuedAt.Time):
+		return fmt.Errorf("%w: iat %s", ErrTokenNotValidYet, c.IssuedAt.UTC().Format(time.RFC3339))
+	case p.Issuer != "" && c.Issuer != p.Issuer:
+		return fmt.Errorf("%w: %q", ErrInvalidIssuer, c.Issuer)
+	case p.Audience != "" && !slices.Contains(c.Audience, p.Audience):
+		return fmt.Errorf("%w: %q", ErrInvalidAudience, []string(c.Audience))
+	}
+	return nil
+}
+``
+/*
+📌 `exp` sınırı dahil değildir: `now == exp` olduğunda token artık geçersizdir (RFC 7519 4.1.4).
+📌 `Leeway`, sunucular arasındaki saat farkını tolere eder; hem `exp` hem `nbf`/`iat` için uygulanır.
+📌 `aud` tek string ya da dizi olabilir; ikisi de okunur.
+
+---
+
+## 6. İmzalama ve Doğrulama: `jwt/jws.go`
+*/
+``go
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMalformed           = errors.New("jwt: token biçimi bozuk")
+	ErrAlgorithmNotAllowed = errors.New("jwt: algoritmaya izin verilmiyor")
+	ErrInvalidKey          = errors.New("jwt: anahtar uygun değil")
+	ErrKeyNotFound         = errors.New("jwt: anahtar bulunamadı")
+	ErrSignatureInvalid    = errors.New("jwt: imza geçersiz")
+	ErrMissingExp          = errors.New("jwt: exp claim'i yok")
+	ErrTokenExpired        = errors.New("jwt: token süresi dolmuş")
+	ErrTokenNotValidYet    = errors.New("jwt: token henüz geçerli değil")
+	ErrInvalidIssuer       = errors.New("jwt: iss beklenen değil")
+	ErrInvalidAudience     = errors.New("jwt: aud beklenen değeri içermiyor")
+)
+
+// b64 padding'siz base64url'dir. Strict, kullanılmayan bitleri sıfır
+// olmayan girdileri reddeder; aksi halde aynı imza birden fazla
+// string'le yazılabilirdi.
+var b64 = base64.RawURLEncoding.Strict()
+
+// Header, JOSE header'ıdır (RFC 7515 4.1).
+type Header struct {
+	Alg  string   `json:"alg"`
+	Typ  string   `json:"typ,omitempty"`
+	Kid  string   `json:"kid,omitempty"`
+	Crit []string `json:"crit,omitempty"`
+}
+
+// Signer, token üretir. Key, Alg'in beklediği özel anahtardır.
+type Signer struct {
+	Alg   Algorithm
+	Key   any
+	KeyID string // header'a "kid" olarak yazılır; JWKS'te anahtarı bulmak için
+}
+
+// Sign, claims'i JSON'a çevirip imzalı bir JWT üretir.
+func (s *Signer) Sign(claims any) (string, error) {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	return s.sign(Header{Alg: s.Alg.Name(), Typ: "JWT", Kid: s.KeyID}, payload)
+}
+
+// SignPayload, payload'u olduğu gibi imzalar (JWT olmayan JWS için).
+func (s *Signer) SignPayload(payload []byte) (string, error) {
+	return s.sign(Header{Alg: s.Alg.Name(), Kid: s.KeyID}, payload)
+}
+
+func (s *Signer) sign(h Header, payload []byte) (string, error) {
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return "", err
+	}
+	input := b64.EncodeToString(hb) + "." + b64.EncodeToString(payload)
+	sig, err := s.Alg.Sign(s.Key, []byte(input))
+	if err != nil {
+		return "", err
+	}
+	return input + "." + b64.EncodeToString(sig), nil
+}
+
+// KeyResolver, header'a bakarak doğrulama anahtarını bulur. *KeySet bu
+// arayüzü sağlar; tek anahtar için StaticKey kullanılabilir.
+type KeyResolver interface {
+	Key(h *Header) (any, error)
+}
+
+// StaticKey, her token için aynı anahtarı döndürür.
+type StaticKey struct{ K any }
+
+func (s StaticKey) Key(*Header) (any, error) { return s.K, nil }
+
+// Parser, token doğrulama kurallarıdır. Sıfır değeri hiçbir token'ı kabul
+// etmez: en az Algorithms ve Keys verilmelidir.
+type Parser struct {
+	Algorithms []string    // izin verilen "alg" değerleri
+	Keys       KeyResolver // doğrulama anahtarları
+	Issuer     string      // boş değilse "iss" eşit olmalı
+	Audience   string      // boş değilse "aud" bu değeri içermeli
+	Leeway     time.Duration
+	AllowNoExp bool             // exp olmayan token'ları da kabul et
+	Now        func() time.Time // test için; nil ise time.Now
+}
+
+// Verify, compact JWS'in imzasını doğrular ve header ile ham payload'u
+// döndürür. Claim kontrolü yapmaz; JWT için Parse kullanılır.
+func (p *Parser) Verify(token string) (*Header, []byte, error) {
+	hs, rest, ok1 := strings.Cut(token, ".")
+	ps, ss, ok2 := strings.Cut(rest, ".")
+	if !ok1 || !ok2 || strings.Contains(ss, ".") {
+		return nil, nil, fmt.Errorf("%w: üç parça olmalı", ErrMalformed)
+	}
+	hb, err1 := b64.DecodeString(hs)
+	payload, err2 := b64.DecodeString(ps)
+	sig, err3 := b64.DecodeString(ss)
+	if err := errors.Join(err1, err2, err3); err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrMalformed, err)
+	}
+	var h Header
+	if err := json.Unmarshal(hb, &h); err != nil {
+		return nil, nil, fmt.Errorf("%w: header: %v", ErrMalformed, err)
+	}
+	// RFC 7515 4.1.11: anlamadığımız kritik uzantı varsa reddetmeliyiz.
+	if len(h.Crit) > 0 {
+		return nil, nil, fmt.Errorf("%w: desteklenmeyen crit %q", ErrMalformed, h.Crit)
+	}
+	// Algoritmayı token değil, Parser seçer: "none" ya da beklenmeyen bir
+	// alg ile gelen token imza kontrolüne bile ulaşmaz.
+	if !slices.Contains(p.Algorithms, h.Alg) {
+		return nil, nil, fmt.Errorf("%w: %q", ErrAlgorithmNotAllowed, h.Alg)
+	}
+	alg, ok := LookupAlgorithm(h.Alg)
+	if !ok {
+		return nil, nil, fmt.Errorf("%w: %q desteklenmiyor", ErrAlgorithmNotAllowed, h.Alg)
+	}
+	if p.Keys == nil {
+		return nil, nil, ErrKeyNotFound
+	}
+	key, err := p.Keys.Key(&h)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := alg.Verify(key, []byte(hs+"."+ps), sig); err != nil {
+		return nil, nil, err
+	}
+	return &h, payload, nil
+}
+
+// Parse, token'ı doğrular, payload'u claims'e çözer ve exp/nbf/iat/iss/aud
+// kurallarını uygular.
+func (p *Parser) Parse(token string, claims Claims) (*Header, error) {
+	h, payload, err := p.Verify(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrMalformed, err)
+	}
+	if err := p.validate(claims.Registered()); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+``
+/*
+📌 **Algoritmayı token değil, `Parser.Algorithms` belirler.** `"alg":"none"` listede olsa bile `LookupAlgorithm` onu tanımaz.
+📌 `crit` header'ı olan token reddedilir; bilmediğimiz bir uzantıyı görmezden gelmek RFC'ye aykırıdır.
+📌 Base64 çözümü `Strict` modda: aynı imzanın ikinci bir yazımı kabul edilmez.
+
+---
+
+## 7. JWK ve JWKS: `jwt/jwk.go`
+*/
+``go
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// JWK, tek bir JSON Web Key'dir (RFC 7517). Key şu tiplerden biridir:
+// []byte, *rsa.PublicKey, *rsa.PrivateKey, *ecdsa.PublicKey,
+// *ecdsa.PrivateKey, ed25519.PublicKey, ed25519.PrivateKey.
+type JWK struct {
+	Key       any
+	KeyID     string // "kid"
+	Algorithm string // "alg"; boş değilse yalnızca bu algoritmayla kullanılır
+	Use       string // "use"; "sig" ya da boş
+}
+
+// rawJWK, JWK'nin JSON biçimidir. Tüm sayılar base64url big-endian'dır.
+type rawJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"`
+	P   string `json:"p,omitempty"`
+	Q   string `json:"q,omitempty"`
+	DP  string `json:"dp,omitempty"`
+	DQ  string `json:"dq,omitempty"`
+	QI  string `json:"qi,omitempty"`
+	K   string `json:"k,omitempty"`
+}
+
+// errUnsupportedKty, KeySet'in atladığı anahtarları işaretler.
+var errUnsupportedKty = errors.New("jwt: desteklenmeyen kty")
+
+var curves = map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384()}
+
+func curveName(c elliptic.Curve) string {
+	for name, cc := range curves {
+		if cc == c {
+			return name
+		}
+	}
+	return ""
+}
+
+// Public, özel anahtar yerine açık anahtarı taşıyan kopyayı döndürür.
+// JWKS yayınlarken yalnızca Public() çıktısı kullanılmalıdır.
+func (k JWK) Public() JWK {
+	switch key := k.Key.(type) {
+	case *rsa.PrivateKey:
+		k.Key = &key.PublicKey
+	case *ecdsa.PrivateKey:
+		k.Key = &key.PublicKey
+	case ed25519.PrivateKey:
+		k.Key = key.Public()
+	}
+	return k
+}
+
+func (k JWK) MarshalJSON() ([]byte, error) {
+	r := rawJWK{Kid: k.KeyID, Alg: k.Algorithm, Use: k.Use}
+	enc := b64.EncodeToString
+	switch key := k.Key.(type) {
+	case []byte:
+		r.Kty, r.K = "oct", enc(key)
+	case *rsa.PublicKey:
+		r.Kty, r.N, r.E = "RSA", enc(key.N.Bytes()), enc(big.NewInt(int64(key.E)).Bytes())
+	case *rsa.PrivateKey:
+		if len(key.Primes) != 2 {
+			return nil, fmt.Errorf("jwt: çok asallı RSA anahtarı desteklenmiyor")
+		}
+		pub := key.PublicKey
+		r.Kty, r.N, r.E = "RSA", enc(pub.N.Bytes()), enc(big.NewInt(int64(pub.E)).Bytes())
+		r.D, r.P, r.Q = enc(key.D.Bytes()), enc(key.Primes[0].Bytes()), enc(key.Primes[1].Bytes())
+		// CRT değerlerini çoğu kütüphane (ör. Node, Web Crypto) zorunlu tutar.
+		key.Precompute()
+		r.DP, r.DQ, r.QI = enc(key.Precomputed.Dp.Bytes()), enc(key.Precomputed.Dq.Bytes()), enc(key.Precomputed.Qinv.Bytes())
+	case *ecdsa.PublicKey:
+		if err := r.setEC(key); err != nil {
+			return nil, err
+		}
+	case *ecdsa.PrivateKey:
+		if err := r.setEC(&key.PublicKey); err != nil {
+			return nil, err
+		}
+		d, err := key.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		r.D = enc(d)
+	case ed25519.PublicKey:
+		r.Kty, r.Crv, r.X = "OKP", "Ed25519", enc(key)
+	case ed25519.PrivateKey:
+		r.Kty, r.Crv, r.X, r.D = "OKP", "Ed25519", enc(key.Public().(ed25519.PublicKey)), enc(key.Seed())
+	default:
+		return nil, fmt.Errorf("jwt: %T JWK'ye çevrilemez", k.Key)
+	}
+	return json.Marshal(r)
+}
+
+// setEC, x ve y'yi eğrinin boyutunda sabit uzunlukta yazar
+// (RFC 7518 6.2.1.2).
+func (r *rawJWK) setEC(key *ecdsa.PublicKey) error {
+	r.Kty, r.Crv = "EC", curveName(key.Curve)
+	if r.Crv == "" {
+		return fmt.Errorf("jwt: desteklenmeyen eğri %s", key.Curve.Params().Name)
+	}
+	b, err := key.Bytes() // 0x04 || X || Y
+	if err != nil {
+		return err
+	}
+	n := (len(b) - 1) / 2
+	r.X, r.Y = b64.EncodeToString(b[1:1+n]), b64.EncodeToString(b[1+n:])
+	return nil
+}
+
+func (k *JWK) UnmarshalJSON(b []byte) error {
+	var r rawJWK
+	if err := json.Unmarshal(b, &r); err != nil {
+		return err
+	}
+	key, err := r.key()
+	if err != nil {
+		return err
+	}
+	*k = JWK{Key: key, KeyID: r.Kid, Algorithm: r.Alg, Use: r.Use}
+	return nil
+}
+
+func (r *rawJWK) key() (any, error) {
+	var err error
+	dec := func(s, field string) []byte {
+		if err != nil {
+			return nil
+		}
+		if s == "" {
+			err = fmt.Errorf("jwt: %s anahtarında %q alanı yok", r.Kty, field)
+			return nil
+		}
+		var b []byte
+		if b, err = b64.DecodeString(s); err != nil {
+			err = fmt.Errorf("jwt: %s.%s: %v", r.Kty, field, err)
+		}
+		return b
+	}
+	num := func(s, field string) *big.Int { return new(big.Int).SetBytes(dec(s, field)) }
+
+	switch r.Kty {
+	case "oct":
+		k := dec(r.K, "k")
+		return k, err
+	case "RSA":
+		pub := &rsa.PublicKey{N: num(r.N, "n")}
+		e := num(r.E, "e")
+		if err != nil {
+			return nil, err
+		}
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, fmt.Errorf("jwt: RSA üssü geçersiz")
+		}
+		pub.E = int(e.Int64())
+		if r.D == "" {
+			return pub, nil
+		}
+		priv := &rsa.PrivateKey{PublicKey: *pub, D: num(r.D, "d"), Primes: []*big.Int{num(r.P, "p"), num(r.Q, "q")}}
+		if err != nil {
+			return nil, err
+		}
+		if err := priv.Validate(); err != nil {
+			return nil, fmt.Errorf("jwt: RSA anahtarı geçersiz: %w", err)
+		}
+		priv.Precompute()
+		return priv, nil
+	case "EC":
+		c, ok := curves[r.Crv]
+		if !ok {
+			return nil, fmt.Errorf("%w: EC eğrisi %q", errUnsupportedKty, r.Crv)
+		}
+		x, y := dec(r.X, "x"), dec(r.Y, "y")
+		n := (c.Params().BitSize + 7) / 8
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != n || len(y) != n {
+			return nil, fmt.Errorf("jwt: %s koordinatları %d byte olmalı", r.Crv, n)
+		}
+		// ParseUncompressedPublicKey noktanın eğri üzerinde olduğunu da
+		// denetler; geçersiz nokta saldırılarına karşı şarttır.
+		pub, err := ecdsa.ParseUncompressedPublicKey(c, append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return nil, fmt.Errorf("jwt: EC açık anahtarı geçersiz: %w", err)
+		}
+		if r.D == "" {
+			return pub, nil
+		}
+		priv, err := ecdsa.ParseRawPrivateKey(c, dec(r.D, "d"))
+		if err != nil {
+			return nil, fmt.Errorf("jwt: EC özel anahtarı geçersiz: %w", err)
+		}
+		if !priv.PublicKey.Equal(pub) {
+			return nil, fmt.Errorf("jwt: EC özel anahtarı x/y ile eşleşmiyor")
+		}
+		return priv, nil
+	case "OKP":
+		if r.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: OKP eğrisi %q", errUnsupportedKty, r.Crv)
+		}
+		x := dec(r.X, "x")
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("jwt: Ed25519 açık anahtarı %d byte olmalı", ed25519.PublicKeySize)
+		}
+		if r.D == "" {
+			return ed25519.PublicKey(x), nil
+		}
+		seed := dec(r.D, "d")
+		if err != nil || len(seed) != ed25519.SeedSize {
+			return nil, fmt.Errorf("jwt: Ed25519 özel anahtarı geçersiz")
+		}
+		priv := ed25519.NewKeyFromSeed(seed)
+		if !priv.Public().(ed25519.PublicKey).Equal(ed25519.PublicKey(x)) {
+			return nil, fmt.Errorf("jwt: Ed25519 özel anahtarı x ile eşleşmiyor")
+		}
+		return priv, nil
+	}
+	return nil, fmt.Errorf("%w: %q", errUnsupportedKty, r.Kty)
+}
+
+// KeySet, bir JWKS belgesidir: {"keys": [...]}. Parser.Keys olarak
+// verildiğinde token header'ındaki "kid" ile anahtar seçer.
+type KeySet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// ParseKeySet, JWKS'i okur. RFC 7517 5 gereği tanınmayan anahtar
+// tipleri hata değildir, atlanır; bozuk bir anahtar ise hatadır.
+func ParseKeySet(data []byte) (*KeySet, error) {
+	var raw struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("jwt: JWKS: %w", err)
+	}
+	set := &KeySet{}
+	for i, m := range raw.Keys {
+		var k JWK
+		if err := json.Unmarshal(m, &k); err != nil {
+			if errors.Is(err, errUnsupportedKty) {
+				continue
+			}
+			return nil, fmt.Errorf("jwt: JWKS keys[%d]: %w", i, err)
+		}
+		set.Keys = append(set.Keys, k)
+	}
+	return set, nil
+}
+
+// Key, KeyResolver arayüzünü sağlar. kid verilmişse eşleşen anahtar,
+// verilmemişse kümede tek anahtar varsa o kullanılır. Anahtarın alg veya
+// use alanı token'la çelişiyorsa anahtar kullanılmaz.
+func (s *KeySet) Key(h *Header) (any, error) {
+	var found *JWK
+	for i := range s.Keys {
+		k := &s.Keys[i]
+		if h.Kid != "" && k.KeyID != h.Kid {
+			continue
+		}
+		if (k.Algorithm != "" && k.Algorithm != h.Alg) || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("%w: kid %q birden fazla anahtarla eşleşiyor", ErrKeyNotFound, h.Kid)
+		}
+		found = k
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: kid %q, alg %s", ErrKeyNotFound, h.Kid, h.Alg)
+	}
+	// Doğrulama her zaman açık anahtarla yapılır; küme yanlışlıkla özel
+	// anahtar içerse bile.
+	return found.Public().Key, nil
+}
+``
+/*
+### Önemli noktalar
+
+* **Bilinmeyen anahtar tipleri atlanır** (ör. `secp256k1`, `X25519`). Auth sunucusu JWKS'e yeni bir tip eklediğinde API servisleri çökmez.
+* **EC noktası doğrulanır:** Eğri üzerinde olmayan `x`/`y` reddedilir.
+* **`alg` ve `use` bağlayıcıdır:** `"alg":"ES256"` olan bir anahtar HS256 token'ı için asla seçilmez; `"use":"enc"` olan anahtar imza doğrulamada kullanılmaz.
+* **`KeySet.Key` her zaman açık anahtarı döndürür.** JWKS yayınlarken de `Public()` kullan; `MarshalJSON` özel anahtarı olduğu gibi yazar.
+
+---
+
+## 8. PEM Anahtarlar: `jwt/pem.go`
+
+`crypto/ecdsa_file_sign_verify_cli_go.go` gibi örneklerde diske yazılan anahtarlar doğrudan kullanılabilir:
+*/
+``go
+package jwt
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// ParsePrivateKeyPEM, "PRIVATE KEY" (PKCS#8), "EC PRIVATE KEY" ve
+// "RSA PRIVATE KEY" bloklarını okur; crypto örneklerinde diske yazılan
+// anahtarlar doğrudan Signer.Key olarak kullanılabilir.
+func ParsePrivateKeyPEM(data []byte) (any, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: PEM bloğu bulunamadı")
+	}
+	switch block.Type {
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	return nil, fmt.Errorf("jwt: beklenmeyen PEM tipi %q", block.Type)
+}
+
+// ParsePublicKeyPEM, "PUBLIC KEY" (PKIX) bloğunu ya da bir sertifikanın
+// açık anahtarını okur.
+func ParsePublicKeyPEM(data []byte) (any, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwt: PEM bloğu bulunamadı")
+	}
+	switch block.Type {
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+	return nil, fmt.Errorf("jwt: beklenmeyen PEM tipi %q", block.Type)
+}
+``
+/*
+---
+
+## 9. Kullanım: `main.go`
+
+Senaryo: bir **auth servisi** ES256 ile token üretir ve açık anahtarını JWKS olarak yayınlar. **API servisi** yalnızca JWKS'i bilir; middleware token'ı doğrulayıp claim'leri `context`'e koyar (bir önceki sorunun cevabı da bu 🙂).
+*/
+``go
+package main
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"jwtdemo/jwt"
+)
+
+type UserClaims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email"`
+}
+
+type ctxKey struct{}
+
+// JWTMiddleware, Bearer token'ı doğrular ve claim'leri context'e koyar.
+func JWTMiddleware(p *jwt.Parser, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			http.Error(w, "token yok", http.StatusUnauthorized)
+			return
+		}
+		var c UserClaims
+		if _, err := p.Parse(token, &c); err != nil {
+			// Ayrıntı loglanır, istemciye genel bir mesaj döner.
+			log.Printf("token reddedildi: %v", err)
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			http.Error(w, "geçersiz token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), ctxKey{}, &c)))
+	})
+}
+
+func profile(w http.ResponseWriter, r *http.Request) {
+	c := r.Context().Value(ctxKey{}).(*UserClaims)
+	fmt.Fprintf(w, "Merhaba %s (sub=%s)", c.Email, c.Subject)
+}
+
+func main() {
+	log.SetFlags(0)
+
+	// 1. Auth servisi: ES256 anahtarı ve yayınlanan JWKS.
+	priv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	key := jwt.JWK{Key: priv, KeyID: "2024-05", Algorithm: "ES256", Use: "sig"}
+	jwks, _ := json.MarshalIndent(jwt.KeySet{Keys: []jwt.JWK{key.Public()}}, "", "  ")
+	fmt.Printf("JWKS:\n%s\n\n", jwks)
+
+	signer := &jwt.Signer{Alg: jwt.ES256, Key: priv, KeyID: key.KeyID}
+	now := time.Now()
+	token, err := signer.Sign(UserClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "https://auth.example.com",
+			Subject:   "42",
+			Audience:  jwt.Audience{"blog-api"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(15 * time.Minute)),
+		},
+		Email: "user@example.com",
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	header, payload, _ := strings.Cut(token, ".")
+	payload, _, _ = strings.Cut(payload, ".")
+	fmt.Println("Token  :", token[:40]+"...")
+	fmt.Println("Header :", decode(header))
+	fmt.Println("Payload:", decode(payload))
+	fmt.Println()
+
+	// 2. API servisi: yalnızca JWKS'i bilir.
+	set, err := jwt.ParseKeySet(jwks)
+	if err != nil {
+		log.Fatal(err)
+	}
+	parser := &jwt.Parser{
+		Algorithms: []string{"ES256", "EdDSA"},
+		Keys:       set,
+		Issuer:     "https://auth.example.com",
+		Audience:   "blog-api",
+		Leeway:     30 * time.Second,
+	}
+	api := JWTMiddleware(parser, http.HandlerFunc(profile))
+
+	call := func(name, auth string) {
+		req := httptest.NewRequest("GET", "/profile", nil)
+		if auth != "" {
+			req.Header.Set("Authorization", "Bearer "+auth)
+		}
+		rec := httptest.NewRecorder()
+		api.ServeHTTP(rec, req)
+		fmt.Printf("%-18s → %d %s\n", name, rec.Code, strings.TrimSpace(rec.Body.String()))
+	}
+	call("geçerli token", token)
+	call("token yok", "")
+
+	expired, _ := signer.Sign(UserClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer: "https://auth.example.com", Audience: jwt.Audience{"blog-api"},
+		ExpiresAt: jwt.NewNumericDate(now.Add(-time.Minute)),
+	}})
+	call("süresi dolmuş", expired)
+
+	other, _ := signer.Sign(UserClaims{RegisteredClaims: jwt.RegisteredClaims{
+		Issuer: "https://auth.example.com", Audience: jwt.Audience{"shop-api"},
+		ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+	}})
+	call("başka audience", other)
+
+	hs, _ := (&jwt.Signer{Alg: jwt.HS256, Key: []byte("super-secret-key-super-secret-key"), KeyID: key.KeyID}).Sign(UserClaims{})
+	call("HS256 token", hs)
+
+	tampered := token[:len(token)-4] + "AAAA"
+	call("değiştirilmiş imza", tampered)
+
+	// 3. Hata türleri errors.Is ile ayırt edilebilir.
+	_, err = parser.Parse(expired, &UserClaims{})
+	fmt.Println()
+	fmt.Println("errors.Is(err, jwt.ErrTokenExpired):", errors.Is(err, jwt.ErrTokenExpired))
+}
+
+func decode(s string) string {
+	b, _ := base64.RawURLEncoding.DecodeString(s)
+	return string(b)
+}
+``
+/*
+## 📌 Örnek Çıktı
+
+```
+JWKS:
+{
+  "keys": [
+    {
+      "kty": "EC",
+      "kid": "2024-05",
+      "alg": "ES256",
+      "use": "sig",
+      "crv": "P-256",
+      "x": "34lSsVDl9Tt1j2Z3_lns-1dY30XKzpxCJEuAp-EXeUw",
+      "y": "SLneKGxrTA3vwbxu4dnkxb46q1fiivD_hnh1A6-V72s"
+    }
+  ]
+}
+
+Token  : eyJhbGciOiJFUzI1NiIsInR5cCI6IkpXVCIsImtp...
+Header : {"alg":"ES256","typ":"JWT","kid":"2024-05"}
+Payload: {"iss":"https://auth.example.com","sub":"42","aud":"blog-api","exp":1792356636,"iat":1792355736,"email":"user@example.com"}
+
+geçerli token      → 200 Merhaba user@example.com (sub=42)
+token yok          → 401 token yok
+token reddedildi: jwt: token süresi dolmuş: exp 2026-10-18T20:34:36Z
+süresi dolmuş      → 401 geçersiz token
+token reddedildi: jwt: aud beklenen değeri içermiyor: ["shop-api"]
+başka audience     → 401 geçersiz token
+token reddedildi: jwt: algoritmaya izin verilmiyor: "HS256"
+HS256 token        → 401 geçersiz token
+token reddedildi: jwt: imza geçersiz
+değiştirilmiş imza → 401 geçersiz token
+
+errors.Is(err, jwt.ErrTokenExpired): true
+```
+
+📌 `exp` artık `1792356636` gibi bir sayı. Token'ı jwt.io'ya yapıştırıp JWKS'teki açık anahtarla doğrulayabilirsin.
+
+---
+
+## 10. Testler: `jwt/jwt_test.go`
+
+Testler dört grupta:
+
+1. **RFC test vektörleri:** RFC 7515 Ek A.1'deki HS256 token'ı birebir doğrulanır. RFC 8037 Ek A.4'teki Ed25519 imzası birebir üretilir (Ed25519 deterministiktir).
+2. **Round-trip:** Her algoritmayla imzala → JWKS'e yayınla → JSON'dan geri oku → doğrula.
+3. **Kurallar ve saldırılar:** `exp`/`nbf`/`iat`/`Leeway`/`iss`/`aud` tablosu; `none`, alg confusion, değiştirilmiş payload, kanonik olmayan base64, `crit`, kısa HMAC ve 1024 bit RSA anahtarı.
+4. **Node.js ile birlikte çalışma:** Go anahtarları JWK olarak Node'a verir. Node kendi `crypto` modülüyle token üretir, Go doğrular; Go'nun token'larını da Node doğrular. `node` yoksa test atlanır.
+*/
+``go
+package jwt
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 7515 Ek A.1: HS256 örnek token'ı ve anahtarı.
+const (
+	rfcHS256Key   = `{"kty":"oct","k":"AyM1SysPpbyDfgZld3umj1qzKObwVMkoqQ-EstJQLr_T-1qS0gZH75aKtMN3Yj0iPS4hcgUuTwjAzZr1Z9CAow"}`
+	rfcHS256Token = "eyJ0eXAiOiJKV1QiLA0KICJhbGciOiJIUzI1NiJ9" +
+		".eyJpc3MiOiJqb2UiLA0KICJleHAiOjEzMDA4MTkzODAsDQogImh0dHA6Ly9leGFtcGxlLmNvbS9pc19yb290Ijp0cnVlfQ" +
+		".dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func TestRFC7515HS256(t *testing.T) {
+	var k JWK
+	if err := json.Unmarshal([]byte(rfcHS256Key), &k); err != nil {
+		t.Fatal(err)
+	}
+	var claims struct {
+		RegisteredClaims
+		IsRoot bool `json:"http://example.com/is_root"`
+	}
+	p := &Parser{
+		Algorithms: []string{"HS256"},
+		Keys:       StaticKey{k.Key},
+		Issuer:     "joe",
+		Now:        func() time.Time { return time.Unix(1300819380-10, 0) },
+	}
+	if _, err := p.Parse(rfcHS256Token, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if !claims.IsRoot || claims.ExpiresAt.Unix() != 1300819380 {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	// Aynı token bugün süresi dolmuş sayılmalı.
+	p.Now = nil
+	if _, err := p.Parse(rfcHS256Token, &claims); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("err = %v, want ErrTokenExpired", err)
+	}
+}
+
+// RFC 8037 Ek A.4: Ed25519 deterministik olduğu için imza birebir aynı
+// çıkmalı.
+func TestRFC8037EdDSA(t *testing.T) {
+	const (
+		jwk  = `{"kty":"OKP","crv":"Ed25519","d":"nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A","x":"11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}`
+		want = "eyJhbGciOiJFZERTQSJ9.RXhhbXBsZSBvZiBFZDI1NTE5IHNpZ25pbmc" +
+			".hgyY0il_MGCjP0JzlnLWG1PPOt7-09PGcvMg3AIbQR6dWbhijcNR4ki4iylGjg5BhVsPt9g7sVvpAr_MuM0KAg"
+	)
+	var k JWK
+	if err := json.Unmarshal([]byte(jwk), &k); err != nil {
+		t.Fatal(err)
+	}
+	got, err := (&Signer{Alg: EdDSA, Key: k.Key}).SignPayload([]byte("Example of Ed25519 signing"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("token\n got %s\nwant %s", got, want)
+	}
+	p := &Parser{Algorithms: []string{"EdDSA"}, Keys: &KeySet{Keys: []JWK{k.Public()}}}
+	if _, payload, err := p.Verify(want); err != nil || string(payload) != "Example of Ed25519 signing" {
+		t.Errorf("Verify = %q, %v", payload, err)
+	}
+}
+
+// testKeys, desteklenen her algoritma için kid'i alg adı olan bir özel
+// anahtar üretir.
+func testKeys(t testing.TB) []JWK {
+	t.Helper()
+	secret := func(n int) []byte {
+		b := make([]byte, n)
+		rand.Read(b)
+		return b
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p256, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	_, edKey, _ := ed25519.GenerateKey(rand.Reader)
+	keys := []JWK{
+		{Key: secret(32), Algorithm: "HS256"},
+		{Key: secret(48), Algorithm: "HS384"},
+		{Key: secret(64), Algorithm: "HS512"},
+		{Key: rsaKey, Algorithm: "RS256"},
+		{Key: rsaKey, Algorithm: "PS256"},
+		{Key: p256, Algorithm: "ES256"},
+		{Key: p384, Algorithm: "ES384"},
+		{Key: edKey, Algorithm: "EdDSA"},
+	}
+	for i := range keys {
+		keys[i].KeyID = keys[i].Algorithm
+	}
+	return keys
+}
+
+// publicSet, anahtarları JWKS olarak yayınlar ve geri okur; yani
+// doğrulama her zaman JSON'dan gelen anahtarlarla yapılır.
+func publicSet(t testing.TB, keys []JWK) *KeySet {
+	t.Helper()
+	var set KeySet
+	for _, k := range keys {
+		if _, ok := k.Key.([]byte); ok {
+			set.Keys = append(set.Keys, k) // HMAC'te açık anahtar yok
+		} else {
+			set.Keys = append(set.Keys, k.Public())
+		}
+	}
+	data, err := json.Marshal(set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(`"d":`)) {
+		t.Fatal("JWKS özel anahtar içeriyor")
+	}
+	ks, err := ParseKeySet(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ks
+}
+
+type userClaims struct {
+	RegisteredClaims
+	Email string `json:"email"`
+}
+
+func TestRoundTrip(t *testing.T) {
+	keys := testKeys(t)
+	p := &Parser{Keys: publicSet(t, keys), Issuer: "auth", Audience: "api"}
+	for _, k := range keys {
+		p.Algorithms = append(p.Algorithms, k.Algorithm)
+	}
+	now := time.Now()
+	in := userClaims{
+		RegisteredClaims: RegisteredClaims{
+			Issuer: "auth", Subject: "42", Audience: Audience{"api"},
+			IssuedAt: NewNumericDate(now), ExpiresAt: NewNumericDate(now.Add(time.Minute)),
+		},
+		Email: "user@example.com",
+	}
+	for _, k := range keys {
+		t.Run(k.Algorithm, func(t *testing.T) {
+			alg, _ := LookupAlgorithm(k.Algorithm)
+			token, err := (&Signer{Alg: alg, Key: k.Key, KeyID: k.KeyID}).Sign(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out userClaims
+			h, err := p.Parse(token, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if h.Kid != k.KeyID || out.Email != in.Email || !out.ExpiresAt.Equal(in.ExpiresAt.Time) {
+				t.Errorf("header %+v, claims %+v", h, out)
+			}
+		})
+	}
+}
+
+func TestNumericDate(t *testing.T) {
+	c := RegisteredClaims{ExpiresAt: NewNumericDate(time.Unix(1735689600, 999))}
+	b, _ := json.Marshal(c)
+	if string(b) != `{"exp":1735689600}` {
+		t.Errorf("Marshal = %s", b)
+	}
+	for in, want := range map[string]int64{`1735689600`: 1735689600, `1735689600.75`: 1735689600} {
+		var d NumericDate
+		if err := d.UnmarshalJSON([]byte(in)); err != nil || d.Unix() != want {
+			t.Errorf("Unmarshal(%s) = %v, %v", in, d.Unix(), err)
+		}
+	}
+	var d NumericDate
+	if err := json.Unmarshal([]byte(`"2025-08-29T11:08:26Z"`), &d); err == nil {
+		t.Error("string exp kabul edildi")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	at := func(d time.Duration) *NumericDate { return NewNumericDate(now.Add(d)) }
+	base := func() RegisteredClaims {
+		return RegisteredClaims{Issuer: "auth", Audience: Audience{"web", "api"}, ExpiresAt: at(time.Minute)}
+	}
+	tests := []struct {
+		name   string
+		edit   func(*RegisteredClaims, *Parser)
+		target error
+	}{
+		{"geçerli", func(*RegisteredClaims, *Parser) {}, nil},
+		{"exp yok", func(c *RegisteredClaims, _ *Parser) { c.ExpiresAt = nil }, ErrMissingExp},
+		{"exp yok izinli", func(c *RegisteredClaims, p *Parser) { c.ExpiresAt = nil; p.AllowNoExp = true }, nil},
+		{"exp tam şimdi", func(c *RegisteredClaims, _ *Parser) { c.ExpiresAt = at(0) }, ErrTokenExpired},
+		{"exp leeway içinde", func(c *RegisteredClaims, p *Parser) { c.ExpiresAt = at(-20 * time.Second); p.Leeway = 30 * time.Second }, nil},
+		{"exp leeway dışında", func(c *RegisteredClaims, p *Parser) { c.ExpiresAt = at(-40 * time.Second); p.Leeway = 30 * time.Second }, ErrTokenExpired},
+		{"nbf gelecekte", func(c *RegisteredClaims, _ *Parser) { c.NotBefore = at(10 * time.Second) }, ErrTokenNotValidYet},
+		{"nbf leeway içinde", func(c *RegisteredClaims, p *Parser) { c.NotBefore = at(10 * time.Second); p.Leeway = 30 * time.Second }, nil},
+		{"iat gelecekte", func(c *RegisteredClaims, _ *Parser) { c.IssuedAt = at(time.Hour) }, ErrTokenNotValidYet},
+		{"yanlış iss", func(c *RegisteredClaims, _ *Parser) { c.Issuer = "evil" }, ErrInvalidIssuer},
+		{"aud yok", func(c *RegisteredClaims, _ *Parser) { c.Audience = nil }, ErrInvalidAudience},
+		{"yanlış aud", func(c *RegisteredClaims, _ *Parser) { c.Audience = Audience{"mobile"} }, ErrInvalidAudience},
+	}
+	key := bytes.Repeat([]byte("k"), 32)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := base()
+			p := &Parser{Algorithms: []string{"HS256"}, Keys: StaticKey{key}, Issuer: "auth", Audience: "api", Now: func() time.Time { return now }}
+			tt.edit(&c, p)
+			token, err := (&Signer{Alg: HS256, Key: key}).Sign(c)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = p.Parse(token, &RegisteredClaims{})
+			if tt.target == nil && err != nil || tt.target != nil && !errors.Is(err, tt.target) {
+				t.Errorf("err = %v, want %v", err, tt.target)
+			}
+		})
+	}
+}
+
+func TestRejects(t *testing.T) {
+	keys := testKeys(t)
+	set := publicSet(t, keys)
+	hsKey := keys[0].Key.([]byte)
+	rsaPub := &keys[3].Key.(*rsa.PrivateKey).PublicKey
+	exp := RegisteredClaims{ExpiresAt: NewNumericDate(time.Now().Add(time.Minute))}
+	sign := func(alg Algorithm, key any, kid string) string {
+		tok, err := (&Signer{Alg: alg, Key: key, KeyID: kid}).Sign(exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tok
+	}
+	good := sign(HS256, hsKey, "HS256")
+	parts := strings.Split(good, ".")
+
+	// RSA açık anahtarını HMAC sırrı gibi kullanan klasik "alg confusion".
+	der, _ := x509.MarshalPKIXPublicKey(rsaPub)
+	pubPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	confused := sign(HS256, pubPEM, "RS256")
+
+	none := b64.EncodeToString([]byte(`{"alg":"none"}`)) + "." + parts[1] + "."
+	crit := b64.EncodeToString([]byte(`{"alg":"HS256","kid":"HS256","crit":["exp"]}`)) + "." + parts[1]
+	crit += "." + sigOf(t, HS256, hsKey, crit)
+
+	// Son karakterin kullanılmayan bitlerini değiştirmek standart dışı ama
+	// gevşek decoder'ların kabul ettiği ikinci bir yazım üretir.
+	sig := []byte(parts[2])
+	sig[len(sig)-1] ^= 1
+
+	tests := []struct {
+		name   string
+		token  string
+		algs   []string
+		target error
+	}{
+		{"none", none, []string{"HS256", "none"}, ErrAlgorithmNotAllowed},
+		{"izinsiz alg", good, []string{"RS256"}, ErrAlgorithmNotAllowed},
+		{"alg confusion", confused, []string{"HS256", "RS256"}, ErrKeyNotFound},
+		{"değiştirilmiş payload", parts[0] + "." + b64.EncodeToString([]byte(`{"exp":9999999999}`)) + "." + parts[2], []string{"HS256"}, ErrSignatureInvalid},
+		{"kanonik olmayan base64", parts[0] + "." + parts[1] + "." + string(sig), []string{"HS256"}, ErrMalformed},
+		{"crit", crit, []string{"HS256"}, ErrMalformed},
+		{"iki parça", parts[0] + "." + parts[1], []string{"HS256"}, ErrMalformed},
+		{"bilinmeyen kid", sign(HS256, hsKey, "yok"), []string{"HS256"}, ErrKeyNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Parser{Algorithms: tt.algs, Keys: set}
+			if _, err := p.Parse(tt.token, &RegisteredClaims{}); !errors.Is(err, tt.target) {
+				t.Errorf("err = %v, want %v", err, tt.target)
+			}
+		})
+	}
+
+	// kid'siz token'da bile RSA anahtarı HMAC için kullanılamaz.
+	p := &Parser{Algorithms: []string{"HS256"}, Keys: StaticKey{rsaPub}}
+	if _, err := p.Parse(sign(HS256, hsKey, ""), &RegisteredClaims{}); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("HS256 + RSA anahtarı: err = %v", err)
+	}
+	if _, err := (&Signer{Alg: HS256, Key: []byte("kısa")}).Sign(exp); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("kısa HMAC anahtarı: err = %v", err)
+	}
+	small, _ := rsa.GenerateKey(rand.Reader, 1024)
+	p = &Parser{Algorithms: []string{"RS256"}, Keys: StaticKey{&small.PublicKey}}
+	if _, err := p.Parse(sign(RS256, small, ""), &RegisteredClaims{}); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("1024 bit RSA: err = %v", err)
+	}
+}
+
+func sigOf(t *testing.T, alg Algorithm, key any, input string) string {
+	s, err := alg.Sign(key, []byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b64.EncodeToString(s)
+}
+
+func TestKeySet(t *testing.T) {
+	const jwks = `{"keys":[
+		{"kty":"EC","crv":"P-256","kid":"a","use":"sig","alg":"ES256",
+		 "x":"f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU","y":"x_FEzRu9m36HLN_tue659LNpXW6pCyStikYjKIWI5a0"},
+		{"kty":"EC","crv":"P-256","kid":"enc","use":"enc",
+		 "x":"f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU","y":"x_FEzRu9m36HLN_tue659LNpXW6pCyStikYjKIWI5a0"},
+		{"kty":"EC","crv":"secp256k1","kid":"k1","x":"AA","y":"AA"},
+		{"kty":"X","kid":"x"}
+	]}`
+	set, err := ParseKeySet([]byte(jwks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Keys) != 2 {
+		t.Fatalf("%d anahtar okundu, bilinmeyen tipler atlanmalıydı", len(set.Keys))
+	}
+	if k, err := set.Key(&Header{Alg: "ES256", Kid: "a"}); err != nil {
+		t.Error(err)
+	} else if _, ok := k.(*ecdsa.PublicKey); !ok {
+		t.Errorf("anahtar %T", k)
+	}
+	for _, h := range []Header{{Alg: "ES384", Kid: "a"}, {Alg: "ES256", Kid: "enc"}, {Alg: "ES256", Kid: "b"}} {
+		if _, err := set.Key(&h); !errors.Is(err, ErrKeyNotFound) {
+			t.Errorf("Key(%+v) err = %v", h, err)
+		}
+	}
+
+	// Eğri üzerinde olmayan nokta reddedilmeli.
+	bad := strings.Replace(jwks, "f83OJ3D2xF1Bg8vub9tLe1gHMzV76e8Tus9uPHvRVEU", "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", 1)
+	if _, err := ParseKeySet([]byte(bad)); err == nil {
+		t.Error("geçersiz EC noktası kabul edildi")
+	}
+}
+
+// TestNodeInterop, Node.js'in crypto modülüyle karşılıklı imza/doğrulama
+// yapar: Node'un ürettiği token'ları Go doğrular, Go'nunkileri Node.
+func TestNodeInterop(t *testing.T) {
+	if _, err := exec.LookPath("node"); err != nil {
+		t.Skip("node bulunamadı")
+	}
+	keys := testKeys(t)
+	var goTokens []string
+	for _, k := range keys {
+		alg, _ := LookupAlgorithm(k.Algorithm)
+		tok, err := (&Signer{Alg: alg, Key: k.Key, KeyID: k.KeyID}).Sign(RegisteredClaims{
+			Issuer: "go", ExpiresAt: NewNumericDate(time.Now().Add(time.Minute)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		goTokens = append(goTokens, tok)
+	}
+	in, _ := json.Marshal(map[string]any{"keys": keys, "tokens": goTokens})
+	cmd := exec.Command("node", "testdata/interop.mjs")
+	cmd.Stdin = bytes.NewReader(in)
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("node: %v\n%s", err, out)
+	}
+	var res struct {
+		Tokens   map[string]string
+		Verified map[string]bool
+	}
+	if err := json.Unmarshal(out, &res); err != nil {
+		t.Fatal(err)
+	}
+
+	p := &Parser{Keys: publicSet(t, keys), Issuer: "node", Audience: "admin"}
+	for _, k := range keys {
+		p.Algorithms = append(p.Algorithms, k.Algorithm)
+	}
+	for _, k := range keys {
+		if !res.Verified[k.Algorithm] {
+			t.Errorf("%s: Node, Go token'ını doğrulayamadı", k.Algorithm)
+		}
+		var c struct {
+			RegisteredClaims
+			Lang string `json:"lang"`
+		}
+		if _, err := p.Parse(res.Tokens[k.Algorithm], &c); err != nil || c.Lang != "js" {
+			t.Errorf("%s: Node token'ı: %v (%+v)", k.Algorithm, err, c)
+		}
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	keys := testKeys(b)
+	set := publicSet(b, keys)
+	for _, k := range keys {
+		alg, _ := LookupAlgorithm(k.Algorithm)
+		tok, _ := (&Signer{Alg: alg, Key: k.Key, KeyID: k.KeyID}).Sign(RegisteredClaims{ExpiresAt: NewNumericDate(time.Now().Add(time.Hour))})
+		p := &Parser{Algorithms: []string{k.Algorithm}, Keys: set}
+		b.Run(k.Algorithm, func(b *testing.B) {
+			for b.Loop() {
+				if _, err := p.Parse(tok, &RegisteredClaims{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+``
+/*
+### `jwt/testdata/interop.mjs`
+*/
+``javascript
+// Go testinden çağrılır: stdin'den {keys, tokens} okur.
+//  - keys: özel JWK listesi (kid = alg)
+//  - tokens: Go'nun ürettiği token'lar
+// Her anahtarla Node'un kendi crypto'suyla bir JWT imzalar ve Go
+// token'larını doğrular; sonucu stdout'a JSON olarak yazar.
+import crypto from "node:crypto";
+
+const input = JSON.parse(await new Response(process.stdin).text());
+const b64 = (b) => Buffer.from(b).toString("base64url");
+const hashOf = { "256": "sha256", "384": "sha384", "512": "sha512" };
+
+function params(alg) {
+	const hash = hashOf[alg.slice(2)];
+	switch (alg.slice(0, 2)) {
+		case "RS": return { hash, opts: {} };
+		case "PS": return { hash, opts: { padding: crypto.constants.RSA_PKCS1_PSS_PADDING, saltLength: crypto.constants.RSA_PSS_SALTLEN_DIGEST } };
+		case "ES": return { hash, opts: { dsaEncoding: "ieee-p1363" } };
+	}
+	return { hash: null, opts: {} }; // EdDSA
+}
+
+function sign(jwk, input) {
+	if (jwk.kty === "oct") {
+		return crypto.createHmac(hashOf[jwk.alg.slice(2)], Buffer.from(jwk.k, "base64url")).update(input).digest();
+	}
+	const { hash, opts } = params(jwk.alg);
+	const key = crypto.createPrivateKey({ key: jwk, format: "jwk" });
+	return crypto.sign(hash, Buffer.from(input), { key, ...opts });
+}
+
+function verify(jwk, input, sig) {
+	if (jwk.kty === "oct") {
+		return crypto.timingSafeEqual(sign(jwk, input), sig);
+	}
+	const { hash, opts } = params(jwk.alg);
+	const { d, p, q, dp, dq, qi, ...pub } = jwk;
+	const key = crypto.createPublicKey({ key: pub, format: "jwk" });
+	return crypto.verify(hash, Buffer.from(input), { key, ...opts }, sig);
+}
+
+const now = Math.floor(Date.now() / 1000);
+const out = { tokens: {}, verified: {} };
+for (const jwk of input.keys) {
+	const header = b64(JSON.stringify({ alg: jwk.alg, typ: "JWT", kid: jwk.kid }));
+	const payload = b64(JSON.stringify({ iss: "node", sub: "42", aud: ["api", "admin"], iat: now, exp: now + 60, lang: "js" }));
+	out.tokens[jwk.alg] = `${header}.${payload}.${b64(sign(jwk, `${header}.${payload}`))}`;
+}
+for (const token of input.tokens) {
+	const [h, p, s] = token.split(".");
+	const header = JSON.parse(Buffer.from(h, "base64url"));
+	const claims = JSON.parse(Buffer.from(p, "base64url"));
+	const jwk = input.keys.find((k) => k.kid === header.kid);
+	out.verified[header.alg] = verify(jwk, `${h}.${p}`, Buffer.from(s, "base64url")) && typeof claims.exp === "number";
+}
+process.stdout.write(JSON.stringify(out));
+``
+/*
+Çalıştırma:
+*/
+``bash
+go test ./jwt/ -v
+go test ./jwt/ -run x -bench .
+``
+/*
+## 📌 Örnek Çıktı
+
+```
+--- PASS: TestRFC7515HS256 (0.00s)
+--- PASS: TestRFC8037EdDSA (0.00s)
+--- PASS: TestRoundTrip (0.07s)
+--- PASS: TestNumericDate (0.00s)
+--- PASS: TestValidate (0.00s)
+--- PASS: TestRejects (0.08s)
+--- PASS: TestKeySet (0.00s)
+--- PASS: TestNodeInterop (0.14s)
+ok  	jwtdemo/jwt
+
+BenchmarkVerify/HS256         	  501966	      2407 ns/op	     896 B/op	      13 allocs/op
+BenchmarkVerify/HS384         	  361765	      3479 ns/op	    1248 B/op	      13 allocs/op
+BenchmarkVerify/HS512         	  358215	      3384 ns/op	    1280 B/op	      13 allocs/op
+BenchmarkVerify/RS256         	   36685	     32767 ns/op	    2144 B/op	      18 allocs/op
+BenchmarkVerify/PS256         	   35336	     34117 ns/op	    2096 B/op	      22 allocs/op
+BenchmarkVerify/ES256         	   14296	     83843 ns/op	    1760 B/op	      29 allocs/op
+BenchmarkVerify/ES384         	    1860	    638711 ns/op	    2280 B/op	      37 allocs/op
+BenchmarkVerify/EdDSA         	   22743	     53017 ns/op	     416 B/op	       7 allocs/op
+```
+
+📌 HMAC en hızlısı ama sırrı bilen herkes token **üretebilir**. Birden fazla servis token doğruluyorsa ES256/EdDSA + JWKS daha güvenli: servisler yalnızca açık anahtarı bilir.
+
+---
+
+## 🔎 Özet
+
+| | Eski örnek | `jwt` paketi |
+|---|---|---|
+| `exp` | `time.Time` → string | NumericDate → sayı |
+| İmza karşılaştırma | `!=` | `subtle.ConstantTimeCompare` |
+| `alg` | okunmuyor | `Parser.Algorithms` ile sınırlı |
+| Algoritmalar | HS256 | HS256/384/512, RS256, PS256, ES256/384, EdDSA |
+| Anahtar | sabit `[]byte` | JWKS + `kid` |
+| `iss` / `aud` / saat kayması | yok | var |
+| Diğer dillerle uyum | yok | RFC vektörleri + Node testi |
+
+---
+
+👉 İstersen bir sonraki adımda API servisinin JWKS'i auth sunucusundan (`/.well-known/jwks.json`) periyodik olarak çekip önbelleğe alan, bilinmeyen bir `kid` gelince de yenileyen bir `RemoteKeySet` yazalım.
+
+Bunu ister misin?
+*/
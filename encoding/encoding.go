@@ -477,4 +477,1100 @@ Tarayıcıda veya `curl` ile test et:
 ---
 
 👉 İstersen bu örneğe bir de **`gob` ile struct encode/decode** ekleyeyim (örneğin `POST /gob` ile binary formatta gönderip çözsün). Onu da ister misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Süper 👍 `gob`'u ayrı bir `POST /gob` endpoint'i olarak eklemek yerine projeyi bir adım ileri taşıyalım. Çünkü her format için ayrı endpoint yazınca:
+
+* `/json`, `/xml`, `/csv` aynı veriyi **üç kez** farklı kodla yazıyor,
+* yeni bir format (gob, JSON Lines...) için **yeni handler** gerekiyor,
+* istemci formatı **URL ile** seçiyor; HTTP'nin bunun için zaten bir mekanizması var: **`Accept`** ve **`Content-Type`** başlıkları.
+
+# 📌 Hedef: Tek Kaynak, Çok Format
+
+* Tek kaynak: **`/users`** (`GET` listeler, `POST` ekler)
+* Yanıt formatı **`Accept`** başlığından seçilir (q-değerleriyle: `application/json;q=0.5, application/xml`)
+* İstek gövdesi **`Content-Type`**'a göre okunur
+* Formatlar: **JSON**, **XML**, **CSV**, **gob** ve akış için **JSON Lines** (`application/x-ndjson`)
+* Uygun format yoksa **406 Not Acceptable**, gövde tipi desteklenmiyorsa **415 Unsupported Media Type**
+* Yeni format = **bir codec yazıp kaydetmek**
+
+`/base64` ve `/hex` ise birer **veri temsili değil**, byte kodlamasıdır; bu yüzden kaynak formatları arasına girmiyorlar.
+
+---
+
+# 📌 Proje Yapısı
+*/
+``
+myapp/
+├── go.mod          # module myapp
+├── main.go         # /users kaynağı
+├── tsv.go          # paket dışından eklenen örnek format
+├── codec/
+│   ├── codec.go    # Codec arayüzü, Registry, Accept ayrıştırma
+│   └── formats.go  # JSON, JSON Lines, XML, CSV, gob
+└── client/
+    └── main.go     # gob konuşan Go istemcisi
+``
+/*
+---
+
+# 📌 `codec/codec.go`: Arayüz ve İçerik Anlaşması
+
+Her format üç şey sağlar: medya tipi, bir `Encoder` ve bir `Decoder`. Encoder/Decoder **tek tek değer** üzerinden çalışır; böylece JSON Lines ve gob gibi akış formatları ile JSON dizisi ya da XML kök elemanı gibi "çerçeveli" formatlar aynı arayüze oturur.
+*/
+``go
+// Package codec, HTTP içerik anlaşmasını (content negotiation) ve
+// kayıtlı formatlar arasında akış halinde encode/decode işlemini yapar.
+package codec
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Codec, bir medya tipini okuyup yazabilen formattır. Yeni bir format
+// eklemek için bu arayüzü sağlayıp Registry.Register çağırmak yeterlidir.
+type Codec interface {
+	MediaType() string // ör. "application/json"
+	NewEncoder(w io.Writer) Encoder
+	NewDecoder(r io.Reader) Decoder
+}
+
+// Encoder, değerleri birer birer yazar. Dizi açma/kapama gibi çerçeve
+// işleri formatın kendisine aittir; Close çağrılınca tamamlanır.
+type Encoder interface {
+	Encode(v any) error
+	Close() error
+}
+
+// Decoder, değerleri birer birer okur; veri bitince io.EOF döner.
+// Tek bir değer de liste de aynı şekilde okunur.
+type Decoder interface {
+	Decode(v any) error
+}
+
+// Registry, sunucunun desteklediği codec'lerdir. Kayıt sırası sunucunun
+// tercih sırasıdır: Accept yoksa ya da eşitlik varsa öndeki seçilir.
+type Registry struct {
+	entries []entry
+	types   map[string]Codec // medya tipi ve takma adlar → codec
+}
+
+type entry struct {
+	codec Codec
+	names []string // MediaType ve takma adlar
+}
+
+// Register, c'yi kaydeder. aliases, hem Accept'te hem Content-Type'ta
+// kabul edilen ek medya tipleridir (ör. XML için "text/xml").
+func (reg *Registry) Register(c Codec, aliases ...string) {
+	if reg.types == nil {
+		reg.types = map[string]Codec{}
+	}
+	e := entry{codec: c}
+	for _, t := range append([]string{c.MediaType()}, aliases...) {
+		t = strings.ToLower(t)
+		e.names = append(e.names, t)
+		reg.types[t] = c
+	}
+	reg.entries = append(reg.entries, e)
+}
+
+// MediaTypes, kayıtlı codec'lerin medya tiplerini tercih sırasıyla verir.
+func (reg *Registry) MediaTypes() []string {
+	out := make([]string, len(reg.entries))
+	for i, e := range reg.entries {
+		out[i] = e.codec.MediaType()
+	}
+	return out
+}
+
+// Lookup, Content-Type değerine karşılık gelen codec'i bulur; charset gibi
+// parametreler yok sayılır.
+func (reg *Registry) Lookup(contentType string) (Codec, bool) {
+	t, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, false
+	}
+	c, ok := reg.types[t]
+	return c, ok
+}
+
+// acceptRange, Accept başlığındaki tek bir medya aralığıdır.
+type acceptRange struct {
+	typ, sub string
+	q        float64
+}
+
+// parseAccept, Accept başlığını ayrıştırır. Bozuk girdiler ve geçersiz
+// q değerleri RFC 9110 12.5.1'deki gibi yok sayılır.
+func parseAccept(header string) []acceptRange {
+	var out []acceptRange
+	for part := range strings.SplitSeq(header, ",") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		t, params, err := mime.ParseMediaType(part)
+		if err != nil {
+			continue
+		}
+		typ, sub, ok := strings.Cut(t, "/")
+		if !ok || typ == "*" && sub != "*" {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			q, err = strconv.ParseFloat(s, 64)
+			if err != nil || q < 0 || q > 1 {
+				continue
+			}
+		}
+		out = append(out, acceptRange{typ, sub, q})
+	}
+	return out
+}
+
+// match, medya tipi için en belirgin aralığın q değerini ve belirginlik
+// derecesini döndürür (3: tam eşleşme, 2: type/*, 1: */*, 0: eşleşme yok).
+func match(ranges []acceptRange, mediaType string) (q float64, spec int) {
+	typ, sub, _ := strings.Cut(mediaType, "/")
+	for _, r := range ranges {
+		s := 0
+		switch {
+		case r.typ == typ && r.sub == sub:
+			s = 3
+		case r.typ == typ && r.sub == "*":
+			s = 2
+		case r.typ == "*":
+			s = 1
+		}
+		if s > spec {
+			q, spec = r.q, s
+		}
+	}
+	return q, spec
+}
+
+// Select, Accept başlığına göre en uygun codec'i seçer. Önce q değeri,
+// eşitlikte daha belirgin aralık, o da eşitse kayıt sırası kazanır; yani
+// "text/csv, */*" CSV'yi seçer. q=0 "bu tipi istemiyorum" demektir.
+//
+// Codec bir takma adla seçildiyse (ör. "Accept: text/xml") dönen
+// codec'in MediaType'ı o addır; yanıt istenen tiple etiketlenir.
+func (reg *Registry) Select(accept string) (Codec, bool) {
+	if len(reg.entries) == 0 {
+		return nil, false
+	}
+	if strings.TrimSpace(accept) == "" {
+		return reg.entries[0].codec, true
+	}
+	ranges := parseAccept(accept)
+	var (
+		best     Codec
+		bestQ    float64
+		bestSpec int
+	)
+	for _, e := range reg.entries {
+		// Takma adlar yalnızca açıkça istendiğinde seçilir: "text/*"
+		// isteyen istemciye text/xml değil text/csv gider.
+		for i, name := range e.names {
+			q, spec := match(ranges, name)
+			if i > 0 && spec < 3 {
+				continue
+			}
+			if q > bestQ || q == bestQ && spec > bestSpec {
+				best, bestQ, bestSpec = e.codec, q, spec
+				if i > 0 {
+					best = aliased{e.codec, name}
+				}
+			}
+		}
+	}
+	return best, best != nil && bestQ > 0
+}
+
+// aliased, bir codec'i takma adıyla sunar.
+type aliased struct {
+	Codec
+	name string
+}
+
+func (a aliased) MediaType() string { return a.name }
+
+// Negotiate, isteğin Accept başlığına göre codec seçer. Uygun codec yoksa
+// desteklenen tipleri listeleyen 406 yanıtını yazar ve false döner.
+func (reg *Registry) Negotiate(w http.ResponseWriter, r *http.Request) (Codec, bool) {
+	w.Header().Add("Vary", "Accept")
+	c, ok := reg.Select(r.Header.Get("Accept"))
+	if !ok {
+		http.Error(w, "desteklenen tipler: "+strings.Join(reg.MediaTypes(), ", "), http.StatusNotAcceptable)
+	}
+	return c, ok
+}
+
+// RequestCodec, gövdenin Content-Type'ına göre codec seçer. Tip yoksa ya
+// da desteklenmiyorsa 415 yazar; Accept-Post başlığı kabul edilen
+// tipleri bildirir.
+func (reg *Registry) RequestCodec(w http.ResponseWriter, r *http.Request) (Codec, bool) {
+	c, ok := reg.Lookup(r.Header.Get("Content-Type"))
+	if !ok {
+		types := strings.Join(reg.MediaTypes(), ", ")
+		w.Header().Set("Accept-Post", types)
+		http.Error(w, "desteklenmeyen Content-Type; kabul edilenler: "+types, http.StatusUnsupportedMediaType)
+	}
+	return c, ok
+}
+
+// NewResponseEncoder, Content-Type ve durum kodunu yazıp c için bir
+// Encoder döndürür.
+func NewResponseEncoder(w http.ResponseWriter, c Codec, status int) Encoder {
+	ct := c.MediaType()
+	if strings.HasPrefix(ct, "text/") {
+		ct += "; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", ct)
+	w.WriteHeader(status)
+	return c.NewEncoder(w)
+}
+``
+/*
+### Seçim kuralları
+
+| `Accept` | Seçilen | Neden |
+|---|---|---|
+| *(yok)* | JSON | ilk kayıtlı codec |
+| `application/json;q=0.5, application/xml` | XML | q daha yüksek |
+| `text/csv, application/*;q=0.1` | CSV | q daha yüksek |
+| `text/csv, application/*` | CSV | q eşit, `text/csv` daha belirgin |
+| `text/*` | CSV | takma ad `text/xml` yalnızca açıkça istenince seçilir |
+| `text/xml` | XML | yanıt `Content-Type: text/xml` ile döner |
+| `application/json;q=0, application/*` | XML | q=0 "istemiyorum" demek |
+| `image/png` | — | **406** |
+
+📌 Her yanıta `Vary: Accept` eklenir; önbellekler (CDN, proxy) aynı URL'nin farklı formatlarını karıştırmaz.
+
+---
+
+# 📌 `codec/formats.go`: Formatlar
+*/
+``go
+package codec
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+)
+
+// flusher, http.ResponseWriter gibi tamponlu yazıcıları temsil eder.
+type flusher interface{ Flush() }
+
+// ---- JSON: tek nesne ya da dizi ----
+
+// JSON, application/json codec'idir. Yazarken her zaman dizi üretir;
+// okurken hem tek nesneyi hem de diziyi kabul eder.
+type JSON struct{}
+
+func (JSON) MediaType() string { return "application/json" }
+
+func (JSON) NewEncoder(w io.Writer) Encoder { return &jsonEncoder{w: w} }
+
+func (JSON) NewDecoder(r io.Reader) Decoder { return &jsonDecoder{r: bufio.NewReader(r)} }
+
+type jsonEncoder struct {
+	w io.Writer
+	n int
+}
+
+func (e *jsonEncoder) Encode(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	sep := ",\n"
+	if e.n == 0 {
+		sep = "[\n"
+	}
+	e.n++
+	_, err = fmt.Fprintf(e.w, "%s  %s", sep, b)
+	return err
+}
+
+func (e *jsonEncoder) Close() error {
+	if e.n == 0 {
+		_, err := io.WriteString(e.w, "[]\n")
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n]\n")
+	return err
+}
+
+type jsonDecoder struct {
+	r     *bufio.Reader
+	dec   *json.Decoder
+	array bool // gövde '[' ile başladı
+	done  bool
+}
+
+func (d *jsonDecoder) Decode(v any) error {
+	if d.done {
+		return io.EOF
+	}
+	if d.dec == nil {
+		// İlk anlamlı karaktere bakıp dizi mi tek nesne mi karar ver.
+		c, err := peekNonSpace(d.r)
+		if err != nil {
+			return err
+		}
+		d.dec = json.NewDecoder(d.r)
+		if c == '[' {
+			d.array = true
+			d.dec.Token()
+		}
+	}
+	if !d.array {
+		d.done = true
+		if err := d.dec.Decode(v); err != nil {
+			return err
+		}
+		return expectEOF(d.dec)
+	}
+	if !d.dec.More() {
+		d.done = true
+		if _, err := d.dec.Token(); err != nil { // ']'
+			return err
+		}
+		if err := expectEOF(d.dec); err != nil {
+			return err
+		}
+		return io.EOF
+	}
+	return d.dec.Decode(v)
+}
+
+func peekNonSpace(r *bufio.Reader) (byte, error) {
+	for {
+		c, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch c {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return c, r.UnreadByte()
+	}
+}
+
+// expectEOF, JSON değerinden sonra çöp veri kalmadığını doğrular.
+func expectEOF(dec *json.Decoder) error {
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("codec: JSON değerinden sonra fazladan veri var")
+	}
+	return nil
+}
+
+// ---- JSON Lines: her satırda bir değer ----
+
+// JSONLines, satır satır JSON'dur (NDJSON). Her değer yazıldığında
+// flush edilir; istemci listenin tamamını beklemeden işlemeye başlar.
+type JSONLines struct{}
+
+func (JSONLines) MediaType() string { return "application/x-ndjson" }
+
+func (JSONLines) NewEncoder(w io.Writer) Encoder {
+	return &jsonlEncoder{w: w, enc: json.NewEncoder(w)}
+}
+
+func (JSONLines) NewDecoder(r io.Reader) Decoder { return json.NewDecoder(r) }
+
+type jsonlEncoder struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func (e *jsonlEncoder) Encode(v any) error {
+	if err := e.enc.Encode(v); err != nil { // Encode satır sonu ekler
+		return err
+	}
+	if f, ok := e.w.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (e *jsonlEncoder) Close() error { return nil }
+
+// ---- XML ----
+
+// XML, application/xml codec'idir. Liste Root adlı bir elemanın içine
+// yazılır; okurken kök Root ise çocukları tek tek, değilse kökün
+// kendisi tek değer olarak okunur.
+type XML struct {
+	Root string // ör. "users"
+}
+
+func (XML) MediaType() string { return "application/xml" }
+
+func (c XML) NewEncoder(w io.Writer) Encoder {
+	return &xmlEncoder{w: w, enc: xml.NewEncoder(w), root: xml.StartElement{Name: xml.Name{Local: c.Root}}}
+}
+
+func (c XML) NewDecoder(r io.Reader) Decoder {
+	return &xmlDecoder{dec: xml.NewDecoder(r), root: c.Root}
+}
+
+type xmlEncoder struct {
+	w       io.Writer
+	enc     *xml.Encoder
+	root    xml.StartElement
+	started bool
+}
+
+func (e *xmlEncoder) start() error {
+	if e.started {
+		return nil
+	}
+	e.started = true
+	e.enc.Indent("", "  ")
+	if _, err := io.WriteString(e.w, xml.Header); err != nil {
+		return err
+	}
+	return e.enc.EncodeToken(e.root)
+}
+
+func (e *xmlEncoder) Encode(v any) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	return e.enc.Encode(v)
+}
+
+func (e *xmlEncoder) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+	if err := e.enc.EncodeToken(e.root.End()); err != nil {
+		return err
+	}
+	if err := e.enc.Flush(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+type xmlDecoder struct {
+	dec    *xml.Decoder
+	root   string
+	inRoot bool
+	done   bool
+}
+
+func (d *xmlDecoder) Decode(v any) error {
+	for !d.done {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if !d.inRoot && t.Name.Local == d.root {
+				d.inRoot = true
+				continue
+			}
+			if !d.inRoot {
+				d.done = true // kök, listenin değil tek bir değerin kendisi
+			}
+			return d.dec.DecodeElement(v, &t)
+		case xml.EndElement:
+			d.done = true
+		}
+	}
+	return io.EOF
+}
+
+// ---- CSV ----
+
+// CSVRecord, CSV'ye yazılıp okunabilen tiplerin sağlaması gereken
+// arayüzdür; CSV düz bir tablo olduğu için alan eşlemesi tipe bırakılır.
+type CSVRecord interface {
+	CSVHeader() []string
+	MarshalCSV() []string
+	UnmarshalCSV(rec []string) error
+}
+
+// CSV, text/csv codec'idir. İlk satır başlıktır; okurken sütunlar
+// başlığa göre eşlenir, yani sıraları farklı olabilir. Başlıkta olmayan
+// sütunlar UnmarshalCSV'ye boş string olarak verilir (ör. yeni kayıtta id).
+type CSV struct{}
+
+func (CSV) MediaType() string { return "text/csv" }
+
+func (CSV) NewEncoder(w io.Writer) Encoder { return &csvEncoder{w: csv.NewWriter(w)} }
+
+func (CSV) NewDecoder(r io.Reader) Decoder { return &csvDecoder{r: csv.NewReader(r)} }
+
+type csvEncoder struct {
+	w      *csv.Writer
+	header bool
+}
+
+func (e *csvEncoder) Encode(v any) error {
+	rec, ok := v.(CSVRecord)
+	if !ok {
+		return fmt.Errorf("codec: %T CSVRecord değil", v)
+	}
+	if !e.header {
+		e.header = true
+		if err := e.w.Write(rec.CSVHeader()); err != nil {
+			return err
+		}
+	}
+	return e.w.Write(rec.MarshalCSV())
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type csvDecoder struct {
+	r     *csv.Reader
+	order []int // order[i]: tipin i. sütununun dosyadaki indeksi; yoksa -1
+}
+
+func (d *csvDecoder) Decode(v any) error {
+	rec, ok := v.(CSVRecord)
+	if !ok {
+		return fmt.Errorf("codec: %T CSVRecord değil", v)
+	}
+	if d.order == nil {
+		head, err := d.r.Read()
+		if err != nil {
+			return err
+		}
+		for _, col := range rec.CSVHeader() {
+			d.order = append(d.order, slices.Index(head, col))
+		}
+	}
+	row, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+	fields := make([]string, len(d.order))
+	for i, j := range d.order {
+		if j >= 0 {
+			fields[i] = row[j]
+		}
+	}
+	return rec.UnmarshalCSV(fields)
+}
+
+// ---- gob ----
+
+// Gob, Go'ya özgü ikili formattır; Go istemcileri arasında en hızlı
+// seçenektir. Değerler ardışık olarak akar.
+type Gob struct{}
+
+func (Gob) MediaType() string { return "application/x-gob" }
+
+func (Gob) NewEncoder(w io.Writer) Encoder { return gobEncoder{gob.NewEncoder(w)} }
+
+func (Gob) NewDecoder(r io.Reader) Decoder { return gob.NewDecoder(r) }
+
+type gobEncoder struct{ *gob.Encoder }
+
+func (gobEncoder) Close() error { return nil }
+``
+/*
+### Önemli noktalar
+
+* **JSON**, yazarken her zaman dizi üretir; okurken hem `{...}` hem `[...]` kabul eder. Değerden sonra fazladan veri varsa hata verir.
+* **JSON Lines**, her değerden sonra `Flush` eder. Büyük listelerde istemci ilk satırı, sunucu son satırı yazmadan alır.
+* **XML**, listeyi `<users>` kökü içine yazar. Okurken `<users>...</users>` da tek bir `<user>...</user>` da olur.
+* **CSV**, düz tablo olduğu için alan eşlemesini tipe bırakır (`CSVRecord`). Sütunlar başlığa göre eşlendiği için sıraları serbesttir.
+* **gob**'da çerçeve yoktur; değerler ardışık akar, `io.EOF` ile biter.
+
+---
+
+# 📌 `main.go`
+*/
+``go
+package main
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"myapp/codec"
+)
+
+// Ortak veri
+type User struct {
+	XMLName xml.Name `json:"-" xml:"user"`
+	ID      int      `json:"id" xml:"id"`
+	Name    string   `json:"name" xml:"name"`
+	Age     int      `json:"age" xml:"age"`
+}
+
+// CSV sütunları (codec.CSVRecord)
+func (u *User) CSVHeader() []string { return []string{"id", "name", "age"} }
+func (u *User) MarshalCSV() []string {
+	return []string{strconv.Itoa(u.ID), u.Name, strconv.Itoa(u.Age)}
+}
+func (u *User) UnmarshalCSV(rec []string) error {
+	id, err1 := strconv.Atoi(rec[0])
+	age, err2 := strconv.Atoi(rec[2])
+	if rec[0] == "" {
+		id, err1 = 0, nil // yeni kayıtta id boş bırakılabilir
+	}
+	if err := errors.Join(err1, err2); err != nil {
+		return err
+	}
+	u.ID, u.Name, u.Age = id, rec[1], age
+	return nil
+}
+
+func (u *User) validate() error {
+	if u.Name == "" || u.Age < 0 || u.Age > 150 {
+		return fmt.Errorf("geçersiz kullanıcı: name=%q age=%d", u.Name, u.Age)
+	}
+	return nil
+}
+
+// Bellek içi veri deposu
+type store struct {
+	mu     sync.Mutex
+	users  []User
+	nextID int
+}
+
+func (s *store) all() []User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]User(nil), s.users...)
+}
+
+func (s *store) add(us []User) []User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range us {
+		s.nextID++
+		us[i].ID = s.nextID
+		s.users = append(s.users, us[i])
+	}
+	return us
+}
+
+type server struct {
+	codecs codec.Registry
+	store  store
+}
+
+// maxBody, POST gövdesinin üst sınırıdır.
+const maxBody = 1 << 20
+
+func (s *server) list(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.codecs.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	s.write(w, c, http.StatusOK, s.store.all())
+}
+
+func (s *server) create(w http.ResponseWriter, r *http.Request) {
+	// Yanıt formatı baştan belirlenir: 406 olacaksa kayıt hiç eklenmez.
+	out, ok := s.codecs.Negotiate(w, r)
+	if !ok {
+		return
+	}
+	in, ok := s.codecs.RequestCodec(w, r)
+	if !ok {
+		return
+	}
+
+	dec := in.NewDecoder(http.MaxBytesReader(w, r.Body, maxBody))
+	var batch []User
+	for {
+		var u User
+		err := dec.Decode(&u)
+		if err == io.EOF {
+			break
+		}
+		if err == nil {
+			err = u.validate()
+		}
+		if err != nil {
+			http.Error(w, fmt.Sprintf("%d. kayıt okunamadı: %v", len(batch)+1, err), http.StatusBadRequest)
+			return
+		}
+		batch = append(batch, u)
+	}
+	if len(batch) == 0 {
+		http.Error(w, "gövde boş", http.StatusBadRequest)
+		return
+	}
+	s.write(w, out, http.StatusCreated, s.store.add(batch))
+}
+
+func (s *server) write(w http.ResponseWriter, c codec.Codec, status int, users []User) {
+	enc := codec.NewResponseEncoder(w, c, status)
+	for i := range users {
+		if err := enc.Encode(&users[i]); err != nil {
+			// Header gitti; yapılabilecek tek şey loglamak.
+			log.Printf("yanıt yazılamadı: %v", err)
+			return
+		}
+	}
+	if err := enc.Close(); err != nil {
+		log.Printf("yanıt yazılamadı: %v", err)
+	}
+}
+
+func main() {
+	s := &server{}
+	// Kayıt sırası = tercih sırası. Yeni format eklemek tek satırdır.
+	s.codecs.Register(codec.JSON{})
+	s.codecs.Register(codec.XML{Root: "users"}, "text/xml")
+	s.codecs.Register(codec.CSV{})
+	s.codecs.Register(codec.JSONLines{}, "application/jsonl")
+	s.codecs.Register(codec.Gob{})
+	s.codecs.Register(TSV{}) // tsv.go: paket dışından eklenen format
+
+	s.store.add([]User{
+		{Name: "Ali", Age: 30},
+		{Name: "Ayşe", Age: 25},
+		{Name: "Mehmet", Age: 40},
+	})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users", s.list)
+	mux.HandleFunc("POST /users", s.create)
+
+	fmt.Println("🚀 Sunucu çalışıyor: http://localhost:8080/users")
+	log.Fatal(http.ListenAndServe(":8080", mux))
+}
+``
+/*
+📌 `POST`'ta önce `Accept` kontrol edilir: yanıt verilemeyecekse (406) kayıt da eklenmez.
+📌 Gövdedeki kayıtlar önce okunup doğrulanır, sonra **tek seferde** eklenir; 3. kayıt bozuksa ilk ikisi de eklenmez.
+📌 `http.MaxBytesReader`, gövdeyi 1 MB ile sınırlar.
+
+---
+
+# 📌 Yeni Format Eklemek: `tsv.go`
+
+Codec paketini değiştirmeden, `main` paketinde sekmeyle ayrılmış değerler (TSV) formatı:
+*/
+``go
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"myapp/codec"
+)
+
+// TSV, codec paketinin dışında yazılmış örnek bir format: sekmeyle
+// ayrılmış değerler. Sunucuya eklemek için tek satır yeter:
+//
+//	s.codecs.Register(TSV{})
+type TSV struct{}
+
+func (TSV) MediaType() string { return "text/tab-separated-values" }
+
+func (TSV) NewEncoder(w io.Writer) codec.Encoder {
+	cw := csv.NewWriter(w)
+	cw.Comma = '\t'
+	return &tsvEncoder{w: cw}
+}
+
+func (TSV) NewDecoder(r io.Reader) codec.Decoder {
+	cr := csv.NewReader(r)
+	cr.Comma = '\t'
+	return &tsvDecoder{r: cr}
+}
+
+type tsvEncoder struct {
+	w *csv.Writer
+	n int
+}
+
+func (e *tsvEncoder) Encode(v any) error {
+	rec, ok := v.(codec.CSVRecord)
+	if !ok {
+		return fmt.Errorf("tsv: %T CSVRecord değil", v)
+	}
+	if e.n == 0 {
+		e.w.Write(rec.CSVHeader())
+	}
+	e.n++
+	return e.w.Write(rec.MarshalCSV())
+}
+
+func (e *tsvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type tsvDecoder struct {
+	r      *csv.Reader
+	header bool
+}
+
+// Decode, sütunların CSVHeader sırasında olmasını bekler.
+func (d *tsvDecoder) Decode(v any) error {
+	rec, ok := v.(codec.CSVRecord)
+	if !ok {
+		return fmt.Errorf("tsv: %T CSVRecord değil", v)
+	}
+	if !d.header {
+		d.header = true
+		if _, err := d.r.Read(); err != nil {
+			return err
+		}
+	}
+	row, err := d.r.Read()
+	if err != nil {
+		return err
+	}
+	return rec.UnmarshalCSV(row)
+}
+``
+/*
+`main.go`'da tek satır:
+*/
+``go
+s.codecs.Register(TSV{}) // tsv.go: paket dışından eklenen format
+``
+/*
+Handler'lara dokunulmadı; `GET` ve `POST` TSV'yi hemen destekler, 406/415 mesajlarındaki listeye de otomatik girer.
+
+---
+
+# 📌 gob İstemcisi: `client/main.go`
+
+Sunucuyla aynı alanlara sahip bir `User` tipi yeterli. gob alan adlarıyla eşlediği için `XMLName` gibi fazla alanlar sorun olmaz.
+*/
+``go
+// Gob istemcisi: aynı User tipini paylaşan bir Go programı, sunucuyla
+// JSON yerine gob konuşabilir.
+package main
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+)
+
+type User struct {
+	ID   int
+	Name string
+	Age  int
+}
+
+func main() {
+	// Gönder: iki kullanıcı, ardışık gob değerleri olarak.
+	var body bytes.Buffer
+	enc := gob.NewEncoder(&body)
+	for _, u := range []User{{Name: "Burak", Age: 33}, {Name: "Selin", Age: 27}} {
+		if err := enc.Encode(u); err != nil {
+			log.Fatal(err)
+		}
+	}
+	req, _ := http.NewRequest("POST", "http://localhost:8080/users", &body)
+	req.Header.Set("Content-Type", "application/x-gob")
+	req.Header.Set("Accept", "application/x-gob")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	fmt.Println("Durum:", resp.Status, "|", resp.Header.Get("Content-Type"))
+
+	// Oku: yanıt da gob akışıdır.
+	dec := gob.NewDecoder(resp.Body)
+	for {
+		var u User
+		if err := dec.Decode(&u); err == io.EOF {
+			break
+		} else if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%+v\n", u)
+	}
+}
+``
+/*
+---
+
+# 📌 Çalıştırma
+*/
+``bash
+go run .
+``
+/*
+### Okuma (`GET`)
+*/
+``bash
+curl -i localhost:8080/users
+curl -H "Accept: application/json;q=0.5, application/xml" localhost:8080/users
+curl -H "Accept: text/csv, application/*;q=0.1" localhost:8080/users
+curl -H "Accept: application/x-ndjson" localhost:8080/users
+curl -i -H "Accept: image/png" localhost:8080/users
+``
+/*
+## Çıktı
+*/
+``
+HTTP/1.1 200 OK
+Content-Type: application/json
+Vary: Accept
+
+[
+  {"id":1,"name":"Ali","age":30},
+  {"id":2,"name":"Ayşe","age":25},
+  {"id":3,"name":"Mehmet","age":40}
+]
+
+<?xml version="1.0" encoding="UTF-8"?>
+<users>
+  <user>
+    <id>1</id>
+    <name>Ali</name>
+    <age>30</age>
+  </user>
+  <user>
+    <id>2</id>
+    <name>Ayşe</name>
+    <age>25</age>
+  </user>
+  <user>
+    <id>3</id>
+    <name>Mehmet</name>
+    <age>40</age>
+  </user>
+</users>
+
+id,name,age
+1,Ali,30
+2,Ayşe,25
+3,Mehmet,40
+
+{"id":1,"name":"Ali","age":30}
+{"id":2,"name":"Ayşe","age":25}
+{"id":3,"name":"Mehmet","age":40}
+
+HTTP/1.1 406 Not Acceptable
+Content-Type: text/plain; charset=utf-8
+Vary: Accept
+
+desteklenen tipler: application/json, application/xml, text/csv, application/x-ndjson, application/x-gob, text/tab-separated-values
+``
+/*
+### Yazma (`POST`)
+
+CSV gönderip JSON Lines almak, tek bir XML eleman göndermek, desteklenmeyen tip ve bozuk kayıt:
+*/
+``bash
+printf 'name,age\nZeynep,28\nCan,35\n' | \
+  curl -i -X POST -H 'Content-Type: text/csv' -H 'Accept: application/x-ndjson' --data-binary @- localhost:8080/users
+
+curl -X POST -H 'Content-Type: application/xml' \
+  --data '<user><name>Elif</name><age>22</age></user>' localhost:8080/users
+
+curl -i -X POST -H 'Content-Type: application/yaml' --data 'name: x' localhost:8080/users
+
+curl -i -X POST -H 'Content-Type: application/json' \
+  --data '[{"name":"Oya","age":41},{"name":"","age":-3}]' localhost:8080/users
+``
+/*
+## Çıktı
+*/
+``
+HTTP/1.1 201 Created
+Content-Type: application/x-ndjson
+Vary: Accept
+Transfer-Encoding: chunked
+
+{"id":4,"name":"Zeynep","age":28}
+{"id":5,"name":"Can","age":35}
+
+[
+  {"id":6,"name":"Elif","age":22}
+]
+
+HTTP/1.1 415 Unsupported Media Type
+Accept-Post: application/json, application/xml, text/csv, application/x-ndjson, application/x-gob, text/tab-separated-values
+Content-Type: text/plain; charset=utf-8
+Vary: Accept
+
+desteklenmeyen Content-Type; kabul edilenler: application/json, application/xml, text/csv, application/x-ndjson, application/x-gob, text/tab-separated-values
+
+HTTP/1.1 400 Bad Request
+2. kayıt okunamadı: geçersiz kullanıcı: name="" age=-3
+``
+/*
+📌 JSON Lines yanıtı `Transfer-Encoding: chunked` ile gelir: her satır yazıldığı anda flush edildi.
+
+### gob ve TSV
+*/
+``bash
+go run ./client
+
+printf 'id\tname\tage\n\tGül\t29\n' | curl -X POST \
+  -H 'Content-Type: text/tab-separated-values' -H 'Accept: text/tab-separated-values' \
+  --data-binary @- localhost:8080/users
+``
+/*
+## Çıktı
+*/
+``
+Durum: 201 Created | application/x-gob
+{ID:7 Name:Burak Age:33}
+{ID:8 Name:Selin Age:27}
+
+id	name	age
+9	Gül	29
+``
+/*
+---
+
+# 📌 Özet
+
+| | Eski demo | Yeni demo |
+|---|---|---|
+| Endpoint | `/json`, `/xml`, `/csv`, `/base64`, `/hex` | tek `/users` |
+| Format seçimi | URL | `Accept` (q-değerleriyle) |
+| Veri gönderme | yok | `POST`, `Content-Type`'a göre |
+| Formatlar | JSON, XML, CSV | JSON, XML, CSV, gob, JSON Lines (+ TSV) |
+| Hatalar | yok | 406, 415, 400 |
+| Yeni format | yeni handler | `Register(codec)` |
+
+👉 İstersen bir sonraki adımda `Accept-Encoding` ile **gzip** sıkıştırmayı da aynı mantıkla ekleyelim: codec'lerin üzerine bir `Content-Encoding` katmanı, yine q-değerleriyle.
+
+Bunu ister misin?
+*/
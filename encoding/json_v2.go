@@ -512,4 +512,1234 @@ Hata: json: unknown field "debug"
 ---
 
 👉 İstersen ben bu projeyi biraz daha büyütüp **Decoder ile stream işleme** de ekleyebilirim (büyük JSON dosyalarını nasıl farklı işlediklerini görebilirsin). İster misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Tamam 👍 Ama büyütmeden önce bir düzeltme yapmam gerekiyor. Yukarıdaki mini projeyi Go 1.27 ile gerçekten çalıştırdım, çıktı şu:
+
+```
+=== encoding/json (eski) ===
+Sonuç: {Port:8080 Host:localhost}
+
+=== encoding/json/v2 (yeni) ===
+Sonuç: {Port:8080 Host:localhost}
+```
+
+Yani **v2 de bilinmeyen alanı varsayılan olarak yok sayıyor.** "Beklenen Çıktı" bölümündeki `unknown field "debug"` hatası yanlıştı, karşılaştırma tablosundaki "Bilinmeyen Alanlar → hata fırlatır" satırı da öyle. Bilinmeyen alanda hata almak için seçeneği açıkça vermek gerekiyor:
+
+```go
+err = jsonv2.Unmarshal([]byte(data), &cfg2, jsonv2.RejectUnknownMembers(true))
+// json: cannot unmarshal JSON string into Go main.Config: unknown object member name "debug"
+```
+
+İkinci düzeltme sürümle ilgili: `encoding/json/v2` Go 1.23'te değil, **Go 1.25'te** `GOEXPERIMENT=jsonv2` ile deneysel olarak geldi. Go 1.27'de standart API'nin parçası, ek bayrak gerekmiyor. Aşağıdaki kod Go 1.27 içindir.
+
+Asıl farklar başka yerlerde ve bunlar tek bir `Config` örneğiyle görünmüyor. Bu yüzden mini projeyi büyütmek yerine, geçişi **veriye dayanarak** planlamamızı sağlayacak bir araç yazalım.
+
+---
+
+# 📌 Proje: `jsoncompat`, v1 → v2 Uyumluluk Denetleyicisi
+
+Araç şunu yapıyor:
+
+1. Bir **korpustaki** (gerçek trafikten, loglardan, test verisinden toplanmış JSON belgeleri) her belgeyi servisin kendi Go tipine hem `encoding/json` hem `encoding/json/v2` ile çözer (**decode**).
+2. v1'in ürettiği değeri iki sürümle yeniden kodlar (**encode**). Bugün servisin bellekte tuttuğu değer odur; istemcilere giden JSON'un değişip değişmeyeceğini bu gösterir.
+3. Sonuçları JSON ağacı olarak karşılaştırır ve farkları JSON Pointer yoluyla raporlar (`/address/city`).
+4. Her farkı bir **kurala** bağlar: harf duyarsız eşleşme, tekrarlanan anahtar, nil slice, geçersiz UTF-8, `omitempty`, zaman biçimi…
+
+## Sınıflandırma nasıl yapılıyor?
+
+Tahmin yürütmek yerine deney yapılıyor. `encoding/json` paketi, v1 davranışını parça parça geri getiren **uyumluluk seçenekleri** sunuyor: `json.FormatNilSliceAsNull`, `jsontext.AllowDuplicateNames`, `json.OmitEmptyWithLegacySemantics`… Go 1.27'de `encoding/json` zaten bu seçeneklerin hepsi açık bir v2 olarak çalışıyor.
+
+Bir fark bulununca v2 tekrar çalıştırılır, bu sefer seçenekler **teker teker** eklenir. Hangi seçenek farkı ortadan kaldırıyorsa farkın sebebi odur. Seçenek kalıcı olarak eklenir ve kalan farklar için aynı işlem tekrarlanır. Hiçbir seçenek işe yaramazsa fark "sınıflandırılamadı" (`?`) olarak raporlanır.
+
+İki incelik var:
+
+* **Gizlenen farklar:** v2 bir belgede hata verirse (ör. tekrarlanan anahtar) diğer farklar görünmez. Hatayı kaldıran seçenek eklenince arkasındakiler ortaya çıkar ve bunlar da raporlanır.
+* **Yan ürünler:** Bazen bir seçenek bir farkı kapatırken yeni bir hata yaratır. `"city"` ve `"City"` aynı nesnede ise v1 harf duyarsız eşleşip son değeri alır. v2'ye harf duyarsız eşleşmeyi açınca bu sefer iki anahtar çakışır ve hata verir. Bu hata gerçek bir v1/v2 farkı değil, denemenin yan ürünüdür. Rapora yazılmaz.
+
+---
+
+# 📦 Proje Yapısı
+
+```
+jsoncompat/
+├── go.mod                  // module jsoncompat, go 1.27
+├── compat/
+│   ├── rules.go            // bilinen farklar ve onları kapatan seçenekler
+│   ├── check.go            // çöz, kodla, karşılaştır, sınıflandır
+│   ├── report.go           // toplu rapor (metin / JSON)
+│   └── compat_test.go
+├── models/models.go        // geçişi planlanan servisin tipleri
+├── cmd/jsoncompat/main.go  // komut satırı aracı
+└── corpus/
+    ├── Config/             // .json: her dosya bir belge
+    ├── User/               // .ndjson: her satır bir belge
+    └── Event/              // .jsonl: her satır bir belge
+```
+
+---
+
+# 1️⃣ Kurallar (`compat/rules.go`)
+
+Sıra önemli. Önce belgeyi tamamen reddettiren farklar (tekrarlanan anahtar, geçersiz UTF-8) denenir. Diğer farklar ancak belge çözülebildiğinde görünür.
+*/
+``go
+package compat
+
+import (
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+)
+
+// Rule, v1 ile v2 arasındaki bilinen bir davranış farkıdır. Option, v2'ye
+// verildiğinde o farkı ortadan kaldıran v1 uyumluluk seçeneğidir. Bir
+// fark, hangi seçenekle kaybolduğuna bakılarak sınıflandırılır; yani
+// sınıflandırma tahmin değil, deneydir.
+type Rule struct {
+	Name   string
+	Title  string
+	Advice string
+	Option jsonv2.Options
+}
+
+// Rules, denenme sırasıyla kurallardır. Sıra önemlidir: bir belgede hem
+// tekrarlanan anahtar hem harf farkı varsa önce hatayı kaldıran kural
+// denenir, diğer fark ancak belge çözülebildiğinde görünür.
+var Rules = []Rule{
+	{
+		Name:   "duplicate-names",
+		Title:  "tekrarlanan anahtar",
+		Advice: "v1 son değeri alır, v2 hata verir. Veriyi üreten tarafı düzeltin; düzeltilemiyorsa jsontext.AllowDuplicateNames(true).",
+		Option: jsontext.AllowDuplicateNames(true),
+	},
+	{
+		Name:   "invalid-utf8",
+		Title:  "geçersiz UTF-8",
+		Advice: "v1 bozuk byte'ları U+FFFD ile değiştirir, v2 hata verir. Kaynağı düzeltin ya da jsontext.AllowInvalidUTF8(true).",
+		Option: jsontext.AllowInvalidUTF8(true),
+	},
+	{
+		Name:   "case-insensitive",
+		Title:  "harf duyarsız alan eşleşmesi",
+		Advice: "v1 \"PORT\" anahtarını `json:\"port\"` alanına yazar, v2 yok sayar. Alan etiketine `,case:ignore` ekleyin (v1 bu seçeneği yok sayar) ya da json.MatchCaseInsensitiveNames(true).",
+		Option: jsonv2.JoinOptions(jsonv2.MatchCaseInsensitiveNames(true), json.MatchCaseSensitiveDelimiter(true)),
+	},
+	{
+		Name:   "time-format",
+		Title:  "gevşek RFC 3339 zaman",
+		Advice: "v1'in kabul ettiği bazı RFC 3339 dışı zamanları v2 reddeder. Üreticiyi düzeltin ya da json.ParseTimeWithLooseRFC3339(true).",
+		Option: json.ParseTimeWithLooseRFC3339(true),
+	},
+	{
+		Name:   "duration",
+		Title:  "time.Duration biçimi",
+		Advice: "v1 nanosaniye sayısı yazar, v2'nin varsayılan biçimi yok ve hata verir. Go 1.27 `format` etiket seçeneğini reddettiği için json.FormatDurationAsNano(true) kullanın ya da alanı int64 nanosaniyeye çevirin.",
+		Option: json.FormatDurationAsNano(true),
+	},
+	{
+		Name:   "nil-slice",
+		Title:  "nil slice: null yerine []",
+		Advice: "v2 nil slice'ı [] yazar ([]byte için \"\"). İstemciler null bekliyorsa json.FormatNilSliceAsNull(true).",
+		Option: jsonv2.FormatNilSliceAsNull(true),
+	},
+	{
+		Name:   "nil-map",
+		Title:  "nil map: null yerine {}",
+		Advice: "v2 nil map'i {} yazar. İstemciler null bekliyorsa json.FormatNilMapAsNull(true).",
+		Option: jsonv2.FormatNilMapAsNull(true),
+	},
+	{
+		Name:   "omitempty",
+		Title:  "omitempty anlamı",
+		Advice: "v1 sıfır değeri (0, false) atlar, v2 yalnızca boş JSON değerini (\"\", [], {}, null) atlar. Sıfır değerin atlanması isteniyorsa `omitzero` kullanın; iki sürümde de aynı çalışır.",
+		Option: json.OmitEmptyWithLegacySemantics(true),
+	},
+	{
+		Name:   "string-option",
+		Title:  "`,string` etiketi",
+		Advice: "v1 `,string`'i bool ve string alanlarda da uygular, tırnaklı \"null\" değerini kabul eder; v2 yalnızca sayıları tırnaklar. Alanı düzeltin ya da json.StringifyWithLegacySemantics(true).",
+		Option: json.StringifyWithLegacySemantics(true),
+	},
+	{
+		Name:   "base64-newline",
+		Title:  "base64 içinde satır sonu",
+		Advice: "v1 []byte çözerken \\r ve \\n karakterlerini atlar, v2 hata verir. Üreticiyi düzeltin ya da json.ParseBytesWithLooseRFC4648(true).",
+		Option: json.ParseBytesWithLooseRFC4648(true),
+	},
+	{
+		Name:   "bytes",
+		Title:  "[]byte kodlaması",
+		Advice: "v1 adlandırılmış byte slice'larını da base64 yazar ve []byte'ı sayı dizisinden çözebilir; v2 yalnızca []byte'ı base64 sayar. json.FormatBytesWithLegacySemantics(true).",
+		Option: json.FormatBytesWithLegacySemantics(true),
+	},
+	{
+		Name:   "byte-array",
+		Title:  "[N]byte kodlaması",
+		Advice: "v1 [N]byte'ı sayı dizisi, v2 base64 yazar. Eski biçim için json.FormatByteArrayAsArray(true).",
+		Option: json.FormatByteArrayAsArray(true),
+	},
+	{
+		Name:   "array-length",
+		Title:  "sabit uzunluklu dizi",
+		Advice: "v1 fazla elemanı atar, eksikleri sıfırlar; v2 uzunluk tutmazsa hata verir. Veriyi ya da tipi düzeltin.",
+		Option: json.UnmarshalArrayFromAnyLength(true),
+	},
+	{
+		Name:   "methods",
+		Title:  "MarshalJSON / UnmarshalJSON çağrısı",
+		Advice: "v1 pointer alıcılı metotları adreslenemeyen değerlerde çağırmaz ve nil pointer taşıyan arayüzde metodu yine çağırır; v2 tam tersi. Metotları kontrol edin ya da json.CallMethodsWithLegacySemantics(true).",
+		Option: json.CallMethodsWithLegacySemantics(true),
+	},
+	{
+		Name:   "struct-errors",
+		Title:  "hatalı struct tanımı",
+		Advice: "v1 çakışan alan adlarını ve bozuk etiketleri sessizce geçer, v2 çalışma zamanında hata verir. Tipi düzeltin.",
+		Option: json.ReportErrorsWithLegacySemantics(true),
+	},
+}
+``
+/*
+
+📌 Go 1.27'de bir sürpriz var: v2 dokümanlarında geçen `format` etiket seçeneği (`json:"timeout,format:nano"`, `json:"tags,format:emitnull"`) şu an **her tipte reddediliyor**. Bunu denedim, hem v1 hem v2 şu hatayı veriyor: `Go struct field Timeout has unsupported 'format' tag option`. Bu yüzden öneriler etiket yerine çağrı yerinde verilen seçeneklere dayanıyor. `case:ignore` ve `omitzero` ise iki sürümde de çalışıyor.
+
+---
+
+# 2️⃣ Karşılaştırma (`compat/check.go`)
+
+* İki sürümün çözdüğü değerler **aynı kodlayıcıyla** (v1) JSON ağacına çevrilir. Böylece karşılaştırma, kodlayıcı farklarından etkilenmez.
+* Karşılaştırma **anlamsal**: anahtar sırası, boşluklar ve HTML kaçışları fark sayılmıyor. v1 `<` karakterini `\u003c` olarak yazar, v2 olduğu gibi yazar. İkisi de aynı string'i temsil eder.
+* İki sürüm de belgeyi reddederse hata mesajlarının farklı olması fark sayılmaz.
+* Bir taraf hata verirse, diğer tarafın **hatanın gösterdiği yoldaki** değeri yazılır. Böylece raporda belgenin tamamı değil, yalnızca `/point: v1=[0,300]` görünür.
+*/
+``go
+// Package compat, aynı JSON belgesini encoding/json (v1) ve
+// encoding/json/v2 ile çözüp yeniden kodlar ve iki sürümün farklı davrandığı
+// yerleri sınıflandırır.
+package compat
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/json/jsontext"
+	jsonv2 "encoding/json/v2"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Phase, farkın görüldüğü aşamadır.
+type Phase string
+
+const (
+	Decode Phase = "decode" // belge → Go değeri
+	Encode Phase = "encode" // v1'in çözdüğü değer → JSON
+)
+
+// Diff, tek bir JSON yolundaki farktır. V1 ve V2 kısaltılmış JSON
+// değerleri ya da "hata: ..." metnidir. Rule nil ise fark bilinen
+// kuralların hiçbiriyle açıklanamamıştır.
+type Diff struct {
+	Phase Phase
+	Path  string // JSON Pointer; "" belgenin tamamı
+	V1    string
+	V2    string
+	Rule  *Rule
+
+	err bool // taraflardan biri hata verdi
+}
+
+func (d Diff) String() string {
+	path := d.Path
+	if path == "" {
+		path = "/"
+	}
+	return fmt.Sprintf("%s %s: v1=%s v2=%s", d.Phase, path, d.V1, d.V2)
+}
+
+// Check, doc'u t tipine iki sürümle çözer, v1'in sonucunu iki sürümle
+// yeniden kodlar ve farkları döner. Fark yoksa nil döner.
+func Check(t reflect.Type, doc []byte) []Diff {
+	base := decodeV1(t, doc)
+	diffs := classify(Decode, base.outcome, func(opts jsonv2.Options) outcome {
+		return decodeV2(t, doc, opts).outcome
+	})
+	// Encode aşaması v1'in ürettiği değer üzerinde çalışır: bugün
+	// servisin bellekte tuttuğu değer odur.
+	if base.err == nil {
+		v1 := encodeV1(base.value)
+		diffs = append(diffs, classify(Encode, v1, func(opts jsonv2.Options) outcome {
+			return encodeV2(base.value, opts)
+		})...)
+	}
+	return diffs
+}
+
+// outcome, bir çözme ya da kodlamanın karşılaştırılabilir sonucudur:
+// ya bir JSON ağacı (map[string]any, []any, json.Number, string, bool,
+// nil) ya da bir hata.
+type outcome struct {
+	tree any
+	err  error
+}
+
+type decoded struct {
+	outcome
+	value any // *T
+}
+
+func decodeV1(t reflect.Type, doc []byte) decoded {
+	p := reflect.New(t).Interface()
+	if err := json.Unmarshal(doc, p); err != nil {
+		return decoded{outcome: outcome{err: err}}
+	}
+	return decoded{outcome: snapshot(p), value: p}
+}
+
+func decodeV2(t reflect.Type, doc []byte, opts jsonv2.Options) decoded {
+	p := reflect.New(t).Interface()
+	if err := jsonv2.Unmarshal(doc, p, opts); err != nil {
+		return decoded{outcome: outcome{err: err}}
+	}
+	return decoded{outcome: snapshot(p), value: p}
+}
+
+// snapshot, çözülmüş Go değerini karşılaştırılabilir bir ağaca çevirir.
+// Tarafsız olmak için iki sürümün sonucu da v1 ile kodlanır; v1'in
+// kodlayamadığı bir değer (ör. NaN) karşılaştırmada hata olarak görünür.
+func snapshot(v any) outcome {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return outcome{err: fmt.Errorf("karşılaştırma için kodlanamadı: %w", err)}
+	}
+	return parse(b)
+}
+
+func encodeV1(v any) outcome {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return outcome{err: err}
+	}
+	return parse(b)
+}
+
+func encodeV2(v any, opts jsonv2.Options) outcome {
+	b, err := jsonv2.Marshal(v, opts)
+	if err != nil {
+		return outcome{err: err}
+	}
+	return parse(b)
+}
+
+// parse, kodlanmış çıktıyı ağaca çevirir. Karşılaştırma anlamsaldır:
+// anahtar sırası, boşluklar ve HTML kaçışları (v1 "<" yerine "\u003c"
+// yazar) fark sayılmaz.
+func parse(b []byte) outcome {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return outcome{err: err}
+	}
+	return outcome{tree: tree}
+}
+
+// classify, base ile v2 sonucu arasındaki farkları kurallara atar. Her
+// turda henüz uygulanmamış kurallar tek tek eklenip denenir; farkı
+// ortadan kaldıran kural kalıcı olarak eklenir. Hiçbir kural ilerleme
+// sağlamazsa kalan farklar sınıflandırılamamış olarak döner.
+//
+// Bir hata kalkınca arkasında gizlenen farklar görünür hale gelir; bunlar
+// gerçektir. Ama bazen bir fark ancak yeni bir hata pahasına kapanır:
+// "city" ve "City" aynı belgedeyse harf duyarsız eşleşme v2'de çakışma
+// hatası verir. Bu yüzden önce yeni hata yaratmayan kurallar aranır;
+// başka yol yoksa yaratılan hata denemenin yan ürünü sayılır ve sonradan
+// kapandığında rapora yazılmaz.
+func classify(phase Phase, base outcome, run func(jsonv2.Options) outcome) []Diff {
+	var applied []jsonv2.Options
+	used := make([]bool, len(Rules))
+	artifact := map[string]bool{}
+	cur := compare(base, run(nil))
+	var out []Diff
+	for len(cur) > 0 {
+		i, next, ok := step(base, cur, applied, used, run, true)
+		if !ok {
+			i, next, ok = step(base, cur, applied, used, run, false)
+		}
+		if !ok {
+			break
+		}
+		for _, d := range without(cur, next) {
+			if artifact[d.Path] {
+				continue
+			}
+			d.Phase, d.Rule = phase, &Rules[i]
+			out = append(out, d)
+		}
+		if introducesErr(cur, next) {
+			for _, d := range without(next, cur) {
+				artifact[d.Path] = true
+			}
+		}
+		used[i] = true
+		applied = append(applied, Rules[i].Option)
+		cur = next
+	}
+	for _, d := range cur {
+		d.Phase = phase
+		out = append(out, d)
+	}
+	return out
+}
+
+// step, en az bir farkı kapatan ilk kuralı bulur. strict ise kural yeni
+// bir hata yaratmamalıdır. Bir hatayı kaldırıp arkasındaki başka bir
+// hatayı açığa çıkarmak yaratmak sayılmaz.
+func step(base outcome, cur []Diff, applied []jsonv2.Options, used []bool, run func(jsonv2.Options) outcome, strict bool) (int, []Diff, bool) {
+	for i := range Rules {
+		if used[i] {
+			continue
+		}
+		next := compare(base, run(jsonv2.JoinOptions(append(slices.Clone(applied), Rules[i].Option)...)))
+		if len(without(cur, next)) == 0 {
+			continue
+		}
+		if strict && introducesErr(cur, next) {
+			continue
+		}
+		return i, next, true
+	}
+	return 0, nil, false
+}
+
+func introducesErr(cur, next []Diff) bool {
+	isErr := func(d Diff) bool { return d.err }
+	return !slices.ContainsFunc(without(cur, next), isErr) && slices.ContainsFunc(without(next, cur), isErr)
+}
+
+// without, a'da olup b'de aynı yolda bulunmayan farkları döner.
+func without(a, b []Diff) []Diff {
+	var out []Diff
+	for _, d := range a {
+		if !slices.ContainsFunc(b, func(e Diff) bool { return e.Path == d.Path }) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+func compare(v1, v2 outcome) []Diff {
+	switch {
+	case v1.err != nil && v2.err != nil:
+		return nil // iki sürüm de reddediyor; mesaj farkı önemsiz
+	case v1.err != nil || v2.err != nil:
+		// Hata olan tarafın karşısında belgenin tamamı yerine hatanın
+		// gösterdiği yoldaki değer gösterilir.
+		path := errPath(v2.err)
+		return []Diff{{Path: path, V1: show(v1, path), V2: show(v2, path), err: true}}
+	}
+	var diffs []Diff
+	walk("", v1.tree, v2.tree, &diffs)
+	return diffs
+}
+
+// errPath, v2 hatasındaki JSON Pointer'ı çıkarır. v1 hatası yerini
+// bildirmediği için yalnızca v2'ninki kullanılır.
+func errPath(err error) string {
+	var se *jsonv2.SemanticError
+	if errors.As(err, &se) {
+		return string(se.JSONPointer)
+	}
+	var te *jsontext.SyntacticError
+	if errors.As(err, &te) {
+		return string(te.JSONPointer)
+	}
+	return ""
+}
+
+// missing, bir tarafta olmayan anahtarı gösterir. Ağaçta nil zaten JSON
+// null'ı temsil ettiği için ayrı bir işaret gerekiyor.
+type missing struct{}
+
+func walk(path string, a, b any, diffs *[]Diff) {
+	am, aok := a.(map[string]any)
+	bm, bok := b.(map[string]any)
+	if aok && bok {
+		keys := make([]string, 0, len(am)+len(bm))
+		for k := range am {
+			keys = append(keys, k)
+		}
+		for k := range bm {
+			if _, ok := am[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			av, ok := am[k]
+			if !ok {
+				av = missing{}
+			}
+			bv, ok := bm[k]
+			if !ok {
+				bv = missing{}
+			}
+			walk(path+"/"+escape(k), av, bv, diffs)
+		}
+		return
+	}
+	as, aok := a.([]any)
+	bs, bok := b.([]any)
+	if aok && bok && len(as) == len(bs) {
+		for i := range as {
+			walk(fmt.Sprintf("%s/%d", path, i), as[i], bs[i], diffs)
+		}
+		return
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, Diff{Path: path, V1: short(a), V2: short(b)})
+	}
+}
+
+// escape, RFC 6901'e göre JSON Pointer bileşenini kaçışlar.
+func escape(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func show(o outcome, path string) string {
+	if o.err != nil {
+		return "hata: " + o.err.Error()
+	}
+	return short(lookup(o.tree, path))
+}
+
+// lookup, ağaçta JSON Pointer ile gösterilen değeri bulur; yol yoksa
+// missing döner.
+func lookup(tree any, path string) any {
+	if path == "" {
+		return tree
+	}
+	for _, tok := range strings.Split(path[1:], "/") {
+		tok = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+		switch v := tree.(type) {
+		case map[string]any:
+			var ok bool
+			if tree, ok = v[tok]; !ok {
+				return missing{}
+			}
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(v) {
+				return missing{}
+			}
+			tree = v[i]
+		default:
+			return missing{}
+		}
+	}
+	return tree
+}
+
+func short(v any) string {
+	if _, ok := v.(missing); ok {
+		return "(yok)"
+	}
+	b, _ := json.Marshal(v)
+	if r := []rune(string(b)); len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return string(b)
+}
+``
+/*
+
+---
+
+# 3️⃣ Rapor (`compat/report.go`)
+
+Kurallar etkiledikleri **belge sayısına** göre sıralanır: geçişte önce en çok belgeyi etkileyen farkla uğraşmak gerekir. Her kural için farklı belgelerden birkaç örnek saklanır. `-json` çıktısı CI'da saklanıp zaman içinde izlenebilir.
+*/
+``go
+package compat
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"text/tabwriter"
+)
+
+// Report, bir korpusun toplu sonucudur.
+type Report struct {
+	Docs     int         // kontrol edilen belge sayısı
+	Affected int         // en az bir farkı olan belge sayısı
+	Stats    []*RuleStat // etkilenen belge sayısına göre azalan
+	byName   map[string]*RuleStat
+	examples int
+}
+
+// RuleStat, bir kuralın korpustaki etkisidir.
+type RuleStat struct {
+	Rule     *Rule     // nil: sınıflandırılamadı
+	Docs     int       // bu kuraldan etkilenen belge
+	Diffs    int       // toplam fark
+	Examples []Example // ilk birkaç örnek
+}
+
+// Example, bir farkın korpustaki yeridir.
+type Example struct {
+	Source string // dosya ya da dosya:satır
+	Type   string
+	Diff   Diff
+}
+
+// NewReport, her kural için en fazla examples örnek saklayan boş bir
+// rapor döner.
+func NewReport(examples int) *Report {
+	return &Report{byName: map[string]*RuleStat{}, examples: examples}
+}
+
+// Add, bir belgenin farklarını rapora ekler.
+func (r *Report) Add(source, typ string, diffs []Diff) {
+	r.Docs++
+	if len(diffs) == 0 {
+		return
+	}
+	r.Affected++
+	seen := map[*RuleStat]bool{}
+	for _, d := range diffs {
+		name := "?"
+		if d.Rule != nil {
+			name = d.Rule.Name
+		}
+		st := r.byName[name]
+		if st == nil {
+			st = &RuleStat{Rule: d.Rule}
+			r.byName[name] = st
+			r.Stats = append(r.Stats, st)
+		}
+		st.Diffs++
+		if !seen[st] {
+			seen[st] = true
+			st.Docs++
+			// Aynı belgeden birden çok örnek almak yerine farklı
+			// belgelerden örnek toplanır.
+			if len(st.Examples) < r.examples {
+				st.Examples = append(st.Examples, Example{source, typ, d})
+			}
+		}
+	}
+	slices.SortStableFunc(r.Stats, func(a, b *RuleStat) int { return cmp.Compare(b.Docs, a.Docs) })
+}
+
+// WriteText, raporu insan okuyacak biçimde yazar.
+func (r *Report) WriteText(w io.Writer) error {
+	pct := func(n int) float64 {
+		if r.Docs == 0 {
+			return 0
+		}
+		return 100 * float64(n) / float64(r.Docs)
+	}
+	fmt.Fprintf(w, "%d belge, %d tanesinde fark var (%%%.1f)\n\n", r.Docs, r.Affected, pct(r.Affected))
+	if len(r.Stats) == 0 {
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "KURAL\tBELGE\t%\tFARK")
+	for _, st := range r.Stats {
+		fmt.Fprintf(tw, "%s\t%d\t%.1f\t%d\n", st.name(), st.Docs, pct(st.Docs), st.Diffs)
+	}
+	tw.Flush()
+
+	for _, st := range r.Stats {
+		title, advice := "sınıflandırılamadı", "Bilinen v1 seçeneklerinin hiçbiri bu farkı kaldırmıyor; elle inceleyin."
+		if st.Rule != nil {
+			title, advice = st.Rule.Title, st.Rule.Advice
+		}
+		fmt.Fprintf(w, "\n== %s: %s\n%s\n", st.name(), title, advice)
+		for _, ex := range st.Examples {
+			fmt.Fprintf(w, "  %s (%s) %s\n", ex.Source, ex.Type, ex.Diff)
+		}
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
+
+// WriteJSON, raporu başka araçların (ör. CI panosu) okuyacağı biçimde
+// yazar.
+func (r *Report) WriteJSON(w io.Writer) error {
+	type example struct {
+		Source string `json:"source"`
+		Type   string `json:"type"`
+		Phase  Phase  `json:"phase"`
+		Path   string `json:"path"`
+		V1     string `json:"v1"`
+		V2     string `json:"v2"`
+	}
+	type stat struct {
+		Rule     string    `json:"rule"`
+		Docs     int       `json:"docs"`
+		Diffs    int       `json:"diffs"`
+		Examples []example `json:"examples"`
+	}
+	out := struct {
+		Docs     int    `json:"docs"`
+		Affected int    `json:"affected"`
+		Rules    []stat `json:"rules"`
+	}{Docs: r.Docs, Affected: r.Affected, Rules: []stat{}}
+	for _, st := range r.Stats {
+		s := stat{Rule: st.name(), Docs: st.Docs, Diffs: st.Diffs}
+		for _, ex := range st.Examples {
+			s.Examples = append(s.Examples, example{ex.Source, ex.Type, ex.Diff.Phase, ex.Diff.Path, ex.Diff.V1, ex.Diff.V2})
+		}
+		out.Rules = append(out.Rules, s)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+func (st *RuleStat) name() string {
+	if st.Rule == nil {
+		return "?"
+	}
+	return st.Rule.Name
+}
+``
+/*
+
+---
+
+# 4️⃣ Tipler ve Komut Satırı
+
+Go, tipleri çalışma zamanında bir paketten yükleyemez. Bu yüzden araç tipleri **import ediyor**. Kendi servisiniz için `cmd/jsoncompat/main.go`'yu kopyalayıp `types` tablosunu kendi paketinizle doldurmanız yeterli. Korpustaki dizin adı, tablodaki addır.
+
+### `models/models.go`
+*/
+``go
+// Package models, geçişi planlanan servisin tipleridir. Araç bu paketi
+// import eder; kendi projenizde yerine kendi paketinizi koyun.
+package models
+
+import "time"
+
+type Config struct {
+	Port    int               `json:"port"`
+	Host    string            `json:"host"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Retries int               `json:"retries,omitempty"`
+	Timeout time.Duration     `json:"timeout"`
+}
+
+type User struct {
+	ID        int64     `json:"id,string"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Admin     bool      `json:"admin,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	Avatar    []byte    `json:"avatar,omitempty"`
+	Roles     []string  `json:"roles"`
+	Address   *Address  `json:"address,omitempty"`
+}
+
+type Address struct {
+	City string `json:"city"`
+	Zip  string `json:"zip"`
+}
+
+type Event struct {
+	Kind    string         `json:"kind"`
+	At      time.Time      `json:"at"`
+	Point   [2]float64     `json:"point"`
+	Payload map[string]any `json:"payload"`
+}
+``
+/*
+
+### `cmd/jsoncompat/main.go`
+*/
+``go
+// jsoncompat, bir JSON korpusunu encoding/json ve encoding/json/v2 ile
+// karşılaştırır.
+//
+//	go run ./cmd/jsoncompat [-corpus dir] [-examples n] [-json]
+//
+// Korpusta her alt dizinin adı bir tip adıdır; içindeki .json dosyaları
+// tek belge, .ndjson/.jsonl dosyaları satır başına bir belge sayılır:
+//
+//	corpus/Config/prod.json
+//	corpus/User/2025-06.ndjson
+//
+// Fark bulunursa çıkış kodu 1, okuma hatasında 2'dir.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"jsoncompat/compat"
+	"jsoncompat/models"
+)
+
+// types, korpus dizin adlarını Go tiplerine eşler. Kendi projenizde
+// bu tabloyu kendi tiplerinizle doldurun.
+var types = map[string]reflect.Type{
+	"Config": reflect.TypeFor[models.Config](),
+	"User":   reflect.TypeFor[models.User](),
+	"Event":  reflect.TypeFor[models.Event](),
+}
+
+func main() {
+	corpus := flag.String("corpus", "corpus", "korpus dizini")
+	examples := flag.Int("examples", 3, "her kural için gösterilecek örnek sayısı")
+	asJSON := flag.Bool("json", false, "raporu JSON olarak yaz")
+	flag.Parse()
+
+	report := compat.NewReport(*examples)
+	err := filepath.WalkDir(*corpus, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(*corpus, path)
+		name, _, ok := strings.Cut(filepath.ToSlash(rel), "/")
+		if !ok {
+			return nil // kökteki dosyalar (README vb.) atlanır
+		}
+		t, ok := types[name]
+		if !ok {
+			return fmt.Errorf("%s: bilinmeyen tip %q (bilinenler: %s)", path, name, strings.Join(slices.Sorted(maps.Keys(types)), ", "))
+		}
+		switch filepath.Ext(path) {
+		case ".json":
+			doc, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			report.Add(path, name, compat.Check(t, doc))
+		case ".ndjson", ".jsonl":
+			return eachLine(path, func(line int, doc []byte) {
+				report.Add(fmt.Sprintf("%s:%d", path, line), name, compat.Check(t, doc))
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *asJSON {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		err = report.WriteText(os.Stdout)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if report.Affected > 0 {
+		os.Exit(1)
+	}
+}
+
+func eachLine(path string, fn func(line int, doc []byte)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 16<<20)
+	for n := 1; sc.Scan(); n++ {
+		if doc := bytes.TrimSpace(sc.Bytes()); len(doc) > 0 {
+			fn(n, doc)
+		}
+	}
+	return sc.Err()
+}
+``
+/*
+
+---
+
+# 5️⃣ Korpus
+
+Gerçek bir geçişte korpus üretim trafiğinden örneklenir. Buradaki örnek her kuralı en az bir kez tetikleyecek şekilde hazırlandı.
+
+### `corpus/Config/`
+*/
+``json
+// prod.json
+{
+  "port": 8080,
+  "host": "api.example.com",
+  "tags": ["prod", "eu"],
+  "labels": {"team": "ödeme"},
+  "retries": 3,
+  "timeout": 5000000000
+}
+
+// legacy.json
+{"Port": 9090, "HOST": "legacy.internal", "tags": ["old"], "timeout": 0}
+
+// override.json
+{"port": 80, "host": "a", "port": 8081, "timeout": 1000000000, "retries": 0}
+
+// minimal.json
+{"port": 7000, "host": "localhost"}
+``
+/*
+### `corpus/User/2025-03.ndjson`
+*/
+``json
+{"id":"1","name":"Ayşe","email":"ayse@example.com","created_at":"2025-03-01T09:30:00Z","roles":["admin"],"admin":true}
+{"id":"2","name":"Mehmet","email":"m@example.com","created_at":"2025-03-02T10:00:00+03:00","roles":null}
+{"id":"3","name":"Zeynep","email":"z@example.com","created_at":"2025-03-03T10:00:00.5Z","roles":[],"address":{"city":"İzmir","zip":"35000"}}
+{"id":"4","name":"Can","email":"c@example.com","created_at":"2025-03-04T10:00:00,5Z","roles":["dev"]}
+{"id":"5","name":"Elif","email":"e@example.com","created_at":"2025-03-05T24:00:00Z","roles":["dev"]}
+{"id":"6","name":"Deniz","email":"d@example.com","created_at":"2025-03-06T10:00:00Z","roles":["dev"],"avatar":"iVBORw0K\nGgo="}
+{"id":"null","name":"Anonim","email":"","created_at":"2025-03-07T10:00:00Z","roles":[]}
+{"id":"8","Name":"Burak","EMAIL":"b@example.com","created_at":"2025-03-08T10:00:00Z","roles":["ops"]}
+{"id":"9","name":"Selin","email":"s@example.com","created_at":"2025-03-09T10:00:00Z","roles":["dev"],"address":{"city":"Bursa","City":"Ankara","zip":"16000"}}
+``
+/*
+10\. satırda `"name"` değeri Latin-5 ile yazılmış bir "Gül": `ü` yerine tek bir `0xFC` byte'ı var, yani geçerli UTF-8 değil. Bu byte bu dosyaya konamadığı için burada gösterilmiyor.
+
+### `corpus/Event/2025-05.jsonl`
+*/
+``json
+{"kind":"click","at":"2025-05-01T12:00:00Z","point":[10.5,20],"payload":{"button":"left"}}
+{"kind":"scroll","at":"2025-05-01T12:00:01Z","point":[0,300,1],"payload":{}}
+{"kind":"resize","at":"2025-05-01T12:00:02Z","point":[1024],"payload":null}
+{"kind":"key","at":"2025-05-01T12:00:03Z","point":[0,0],"payload":{"key":"<Enter>"}}
+``
+/*
+---
+
+# ▶️ Çalıştırma
+
+```bash
+go build -o jsoncompat ./cmd/jsoncompat
+./jsoncompat -corpus corpus
+echo $?   # 1: fark var
+```
+*/
+``bash
+18 belge, 14 tanesinde fark var (%77.8)
+
+KURAL             BELGE  %     FARK
+omitempty         11     61.1  11
+duration          4      22.2  7
+nil-slice         3      16.7  3
+case-insensitive  3      16.7  5
+array-length      2      11.1  2
+duplicate-names   1      5.6   1
+nil-map           1      5.6   1
+time-format       1      5.6   1
+base64-newline    1      5.6   1
+string-option     1      5.6   1
+invalid-utf8      1      5.6   1
+
+== omitempty: omitempty anlamı
+v1 sıfır değeri (0, false) atlar, v2 yalnızca boş JSON değerini ("", [], {}, null) atlar. Sıfır değerin atlanması isteniyorsa `omitzero` kullanın; iki sürümde de aynı çalışır.
+  corpus/Config/legacy.json (Config) encode /retries: v1=(yok) v2=0
+  corpus/Config/minimal.json (Config) encode /retries: v1=(yok) v2=0
+  corpus/Config/override.json (Config) encode /retries: v1=(yok) v2=0
+
+== duration: time.Duration biçimi
+v1 nanosaniye sayısı yazar, v2'nin varsayılan biçimi yok ve hata verir. Go 1.27 `format` etiket seçeneğini reddettiği için json.FormatDurationAsNano(true) kullanın ya da alanı int64 nanosaniyeye çevirin.
+  corpus/Config/legacy.json (Config) decode /timeout: v1=0 v2=hata: json: cannot unmarshal into Go time.Duration within "/timeout": no default representation
+  corpus/Config/minimal.json (Config) encode /timeout: v1=0 v2=hata: json: cannot marshal from Go time.Duration within "/timeout": no default representation
+  corpus/Config/override.json (Config) decode /timeout: v1=1000000000 v2=hata: json: cannot unmarshal into Go time.Duration within "/timeout": no default representation
+
+== nil-slice: nil slice: null yerine []
+v2 nil slice'ı [] yazar ([]byte için ""). İstemciler null bekliyorsa json.FormatNilSliceAsNull(true).
+  corpus/Config/minimal.json (Config) encode /tags: v1=null v2=[]
+  corpus/Config/override.json (Config) encode /tags: v1=null v2=[]
+  corpus/User/2025-03.ndjson:2 (User) encode /roles: v1=null v2=[]
+
+== case-insensitive: harf duyarsız alan eşleşmesi
+v1 "PORT" anahtarını `json:"port"` alanına yazar, v2 yok sayar. Alan etiketine `,case:ignore` ekleyin (v1 bu seçeneği yok sayar) ya da json.MatchCaseInsensitiveNames(true).
+  corpus/Config/legacy.json (Config) decode /host: v1="legacy.internal" v2=""
+  corpus/User/2025-03.ndjson:8 (User) decode /email: v1="b@example.com" v2=""
+  corpus/User/2025-03.ndjson:9 (User) decode /address/city: v1="Ankara" v2="Bursa"
+
+== array-length: sabit uzunluklu dizi
+v1 fazla elemanı atar, eksikleri sıfırlar; v2 uzunluk tutmazsa hata verir. Veriyi ya da tipi düzeltin.
+  corpus/Event/2025-05.jsonl:2 (Event) decode /point: v1=[0,300] v2=hata: json: cannot unmarshal JSON array into Go [2]float64 within "/point": too many array elements
+  corpus/Event/2025-05.jsonl:3 (Event) decode /point: v1=[1024,0] v2=hata: json: cannot unmarshal JSON array into Go [2]float64 within "/point": too few array elements
+
+== duplicate-names: tekrarlanan anahtar
+v1 son değeri alır, v2 hata verir. Veriyi üreten tarafı düzeltin; düzeltilemiyorsa jsontext.AllowDuplicateNames(true).
+  corpus/Config/override.json (Config) decode /port: v1=8081 v2=hata: jsontext: duplicate object member name "port"
+
+== nil-map: nil map: null yerine {}
+v2 nil map'i {} yazar. İstemciler null bekliyorsa json.FormatNilMapAsNull(true).
+  corpus/Event/2025-05.jsonl:3 (Event) encode /payload: v1=null v2={}
+
+== time-format: gevşek RFC 3339 zaman
+v1'in kabul ettiği bazı RFC 3339 dışı zamanları v2 reddeder. Üreticiyi düzeltin ya da json.ParseTimeWithLooseRFC3339(true).
+  corpus/User/2025-03.ndjson:4 (User) decode /created_at: v1="2025-03-04T10:00:00.5Z" v2=hata: json: cannot unmarshal JSON string into Go time.Time within "/created_at": parsing time "2025-03-04T10:00:00,5Z" as "2006-01-02T15:04:05Z07:00": cannot parse "," as "."
+
+== base64-newline: base64 içinde satır sonu
+v1 []byte çözerken \r ve \n karakterlerini atlar, v2 hata verir. Üreticiyi düzeltin ya da json.ParseBytesWithLooseRFC4648(true).
+  corpus/User/2025-03.ndjson:6 (User) decode /avatar: v1="iVBORw0KGgo=" v2=hata: json: cannot unmarshal JSON string into Go []uint8 within "/avatar": illegal character '\n' at offset 8
+
+== string-option: `,string` etiketi
+v1 `,string`'i bool ve string alanlarda da uygular, tırnaklı "null" değerini kabul eder; v2 yalnızca sayıları tırnaklar. Alanı düzeltin ya da json.StringifyWithLegacySemantics(true).
+  corpus/User/2025-03.ndjson:7 (User) decode /id: v1="0" v2=hata: json: cannot unmarshal JSON string "null" into Go int64 within "/id": invalid syntax
+
+== invalid-utf8: geçersiz UTF-8
+v1 bozuk byte'ları U+FFFD ile değiştirir, v2 hata verir. Kaynağı düzeltin ya da jsontext.AllowInvalidUTF8(true).
+  corpus/User/2025-03.ndjson:10 (User) decode /name: v1="G�l" v2=hata: jsontext: invalid UTF-8 within "/name" after offset 20
+``
+/*
+📌 Burada görüyoruz ki:
+
+* **En büyük iş `omitempty`.** 18 belgenin 11'inde çıktı değişiyor: `retries: 0` ve `admin: false` artık yazılıyor. Çözüm basit, `int` ve `bool` alanlarında `omitempty` yerine `omitzero`. Bu değişiklik v1'de de aynı çalıştığı için geçişten **önce** yapılabilir.
+* **`time.Duration` kırıcı.** v2 varsayılan olarak Duration'ı ne çözüyor ne kodluyor, `Config` belgelerinin hepsi etkileniyor. `minimal.json`'da `timeout` alanı yok, bu yüzden decode sorunsuz ama encode yine hata veriyor.
+* **`override.json`** iki ayrı sorun taşıyor. Önce tekrarlanan `port` anahtarı belgeyi reddettiriyor. O kalkınca arkasındaki Duration hatası görünüyor ve o da raporlanıyor.
+* **9. satır** (`"city"` ve `"City"`) yalnızca `case-insensitive` altında görünüyor. Sınıflandırma sırasında çıkan çakışma hatası yan ürün olduğu için rapora girmiyor.
+* `<Enter>` içeren olay (4. satır) rapora girmiyor. v1 ve v2 onu farklı kaçışlıyor ama değer aynı.
+* `User` 5. satırdaki `24:00:00` saati de raporda yok. İki sürüm de reddediyor, bu bir fark değil.
+
+JSON çıktısı (`-json -examples 1`, kısaltılmış):
+*/
+``json
+{
+  "docs": 18,
+  "affected": 14,
+  "rules": [
+    {
+      "rule": "omitempty",
+      "docs": 11,
+      "diffs": 11,
+      "examples": [
+        {
+          "source": "corpus/Config/legacy.json",
+          "type": "Config",
+          "phase": "encode",
+          "path": "/retries",
+          "v1": "(yok)",
+          "v2": "0"
+        }
+      ]
+    },
+    ...
+  ]
+}
+``
+/*
+---
+
+# 🧪 Testler (`compat/compat_test.go`)
+
+Her kural için küçük bir tip ve belge kullanılıyor. Gizlenen farklar ile yan ürünler için ayrı testler var. `clean` tipi, önerilerin (`omitzero`, Duration yerine `int64`) uygulandığı halidir ve hiçbir fark üretmemelidir.
+*/
+``go
+package compat
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+type server struct {
+	Port    int               `json:"port"`
+	Host    string            `json:"host"`
+	Tags    []string          `json:"tags"`
+	Labels  map[string]string `json:"labels"`
+	Retries int               `json:"retries,omitempty"`
+	Timeout time.Duration     `json:"timeout"`
+}
+
+type clean struct {
+	Port    int      `json:"port"`
+	Tags    []string `json:"tags,omitempty"`
+	Retries int      `json:"retries,omitzero"`
+	Timeout int64    `json:"timeout_ns"`
+}
+
+type misc struct {
+	ID     int64      `json:"id,string"`
+	At     time.Time  `json:"at"`
+	Avatar []byte     `json:"avatar"`
+	Point  [2]float64 `json:"point"`
+	Addr   struct {
+		City string `json:"city"`
+	} `json:"addr"`
+}
+
+// rules, farkların kural adlarını sıralı ve tekrarsız döner.
+func rules(diffs []Diff) []string {
+	var names []string
+	for _, d := range diffs {
+		name := "?"
+		if d.Rule != nil {
+			name = d.Rule.Name
+		}
+		names = append(names, string(d.Phase)+":"+name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  reflect.Type
+		doc  string
+		want []string
+	}{
+		{"aynı", reflect.TypeFor[clean](), `{"port":1,"tags":["a"],"retries":2,"timeout_ns":5}`, nil},
+		{"boş alanlar", reflect.TypeFor[clean](), `{"port":1}`, nil},
+		{"nil slice ve map", reflect.TypeFor[server](), `{"port":1,"host":"a","retries":1,"timeout":0}`,
+			[]string{"decode:duration", "encode:duration", "encode:nil-map", "encode:nil-slice"}},
+		{"omitempty", reflect.TypeFor[server](), `{"tags":[],"labels":{},"timeout":0}`,
+			[]string{"decode:duration", "encode:duration", "encode:omitempty"}},
+		{"harf", reflect.TypeFor[clean](), `{"PORT":8080}`, []string{"decode:case-insensitive"}},
+		{"tekrar", reflect.TypeFor[clean](), `{"port":1,"port":2}`, []string{"decode:duplicate-names"}},
+		{"utf8", reflect.TypeFor[server](), "{\"host\":\"a\xffb\",\"tags\":[],\"labels\":{},\"retries\":1}",
+			[]string{"decode:invalid-utf8", "encode:duration"}},
+		{"zaman", reflect.TypeFor[misc](), `{"id":"1","at":"2025-01-02T03:04:05,5Z","avatar":"AA==","point":[0,0]}`,
+			[]string{"decode:time-format"}},
+		{"base64", reflect.TypeFor[misc](), `{"id":"1","avatar":"AAEC\nAw==","point":[0,0]}`,
+			[]string{"decode:base64-newline"}},
+		{"string", reflect.TypeFor[misc](), `{"id":"null","avatar":"AA==","point":[0,0]}`,
+			[]string{"decode:string-option"}},
+		{"dizi", reflect.TypeFor[misc](), `{"id":"1","avatar":"AA==","point":[1,2,3]}`,
+			[]string{"decode:array-length"}},
+		{"bozuk json", reflect.TypeFor[clean](), `{"port":`, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs := Check(tt.typ, []byte(tt.doc))
+			if got := rules(diffs); !slices.Equal(got, tt.want) {
+				t.Errorf("kurallar = %v, beklenen %v\n%v", got, tt.want, diffs)
+			}
+		})
+	}
+}
+
+// TestMaskedErrors: tekrarlanan anahtar hatası Duration hatasını gizler;
+// ilki kalkınca ikincisi de raporlanmalı.
+func TestMaskedErrors(t *testing.T) {
+	diffs := Check(reflect.TypeFor[server](), []byte(`{"port":1,"port":2,"timeout":5,"tags":[],"labels":{},"retries":1}`))
+	got := rules(diffs)
+	want := []string{"decode:duplicate-names", "decode:duration", "encode:duration"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("kurallar = %v, beklenen %v", got, want)
+	}
+}
+
+// TestArtifacts: "city" ve "City" birlikteyken harf duyarsız eşleşme v2'de
+// çakışma hatası verir. Bu hata v1/v2 farkı değildir, raporlanmamalı.
+func TestArtifacts(t *testing.T) {
+	diffs := Check(reflect.TypeFor[misc](), []byte(`{"id":"1","avatar":"AA==","point":[0,0],"addr":{"city":"Bursa","City":"Ankara"}}`))
+	if len(diffs) != 1 {
+		t.Fatalf("%d fark, beklenen 1: %v", len(diffs), diffs)
+	}
+	d := diffs[0]
+	if d.Rule == nil || d.Rule.Name != "case-insensitive" || d.Path != "/addr/city" || d.V1 != `"Ankara"` || d.V2 != `"Bursa"` {
+		t.Fatalf("fark = %+v", d)
+	}
+}
+
+func TestErrorShowsValueAtPath(t *testing.T) {
+	diffs := Check(reflect.TypeFor[misc](), []byte(`{"id":"1","avatar":"AA==","point":[7,8,9]}`))
+	if len(diffs) != 1 || diffs[0].Path != "/point" || diffs[0].V1 != "[7,8]" || !strings.HasPrefix(diffs[0].V2, "hata: ") {
+		t.Fatalf("farklar = %v", diffs)
+	}
+}
+
+func TestReport(t *testing.T) {
+	typ := reflect.TypeFor[clean]()
+	r := NewReport(1)
+	r.Add("a.json", "clean", Check(typ, []byte(`{"PORT":1}`)))
+	r.Add("b.json", "clean", Check(typ, []byte(`{"Port":2}`)))
+	r.Add("c.json", "clean", Check(typ, []byte(`{"port":1,"port":2}`)))
+	r.Add("d.json", "clean", Check(typ, []byte(`{"port":3}`)))
+	if r.Docs != 4 || r.Affected != 3 {
+		t.Fatalf("Docs=%d Affected=%d", r.Docs, r.Affected)
+	}
+	if len(r.Stats) != 2 || r.Stats[0].Rule.Name != "case-insensitive" || r.Stats[0].Docs != 2 || len(r.Stats[0].Examples) != 1 {
+		t.Fatalf("Stats[0] = %+v", r.Stats[0])
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var out struct {
+		Docs  int `json:"docs"`
+		Rules []struct {
+			Rule string `json:"rule"`
+			Docs int    `json:"docs"`
+		} `json:"rules"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Docs != 4 || len(out.Rules) != 2 || out.Rules[1].Rule != "duplicate-names" {
+		t.Fatalf("JSON rapor = %s", buf.Bytes())
+	}
+
+	buf.Reset()
+	r.WriteText(&buf)
+	if !strings.Contains(buf.String(), "4 belge, 3 tanesinde fark var (%75.0)") {
+		t.Fatalf("metin rapor:\n%s", buf.String())
+	}
+}
+``
+/*
+
+```bash
+go test -v ./compat
+```
+*/
+``bash
+--- PASS: TestCheck (0.00s)
+--- PASS: TestMaskedErrors (0.00s)
+--- PASS: TestArtifacts (0.00s)
+--- PASS: TestErrorShowsValueAtPath (0.00s)
+--- PASS: TestReport (0.00s)
+PASS
+ok  	jsoncompat/compat	0.005s
+``
+/*
+---
+
+# ⚠️ Sınırlar
+
+* **Bilinmeyen alanlar raporlanmıyor**, çünkü iki sürüm de varsayılan olarak yok sayıyor. v2'ye geçerken `RejectUnknownMembers(true)` açılacaksa bu ayrı bir deneme gerektirir.
+* Her belge **sıfır değerli** yeni bir değere çözülüyor. Dolu bir struct'ın üzerine çözme (`MergeWithLegacySemantics`) farkları bu yüzden görünmez.
+* Karşılaştırma v1 kodlayıcısının gördüğü alanlarla sınırlı. Dışa aktarılmamış alanlar ve `json:"-"` alanlar karşılaştırılmaz.
+* Kendi `MarshalJSON` / `UnmarshalJSON` metotları olan tiplerde sonuç o metotlara bağlıdır. Araç metodun iki sürümde farklı çağrıldığı durumu (`methods` kuralı) yakalar, ama metodun içindeki mantığı denetlemez.
+* Sınıflandırma açgözlü (greedy) çalışıyor. Aynı yoldaki bir fark iki ayrı seçenekle birlikte kapanıyorsa ilk denenen kurala yazılır.
+
+---
+
+👉 İstersen bir sonraki adımda aracı **canlı trafiğe** bağlayalım: HTTP sunucusuna eklenen küçük bir ara katman (middleware), gelen isteklerin belli bir yüzdesini hem v1 hem v2 ile çözüp farkları metrik olarak yayınlasın. Böylece korpus toplamaya gerek kalmaz. Bunu ister misin?
+*/
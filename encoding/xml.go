@@ -560,4 +560,2416 @@ Açıklama: BBC News - Home
 ---
 
 👉 İstersen ben şimdi bunun bir **podcast RSS parser** versiyonunu da yapabilirim (ör. `<enclosure>` ile ses dosyalarını da çekmek için). Onu da görmek ister misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Süper 👍 Ama podcast'i ayrı bir örnek olarak yazmak yerine bir adım daha ileri gidelim. Yukarıdaki okuyucu tek bir beslemeyi bir kez indirip ekrana basıyor. Gerçek bir **besleme toplayıcı (feed aggregator)** ise şunlarla uğraşmak zorunda:
+
+* **Üç format:** RSS 2.0, RSS 1.0 (RDF) ve Atom. Hepsinde "başlık", "link", "tarih" farklı yerde duruyor.
+* **Ad alanları (namespace):** `content:encoded` (tam içerik), `dc:creator`, `dc:date`. RSS 2.0 kanalında hem `<link>` hem `<atom:link>` bulunuyor. İkisi de `link` adlı olduğu için `xml:"link"` etiketi ikisini birden yakalıyor.
+* **Tarihler:** RSS, RFC 822 ister ama pratikte `Tues,`, iki haneli yıl, `EST` gibi bölge adları ve ISO 8601 hepsi bir arada görülür.
+* **Kimlik (GUID):** Aynı yazıyı her yoklamada "yeni" saymamak için kararlı bir kimlik gerekir. `guid` yoksa link, o da yoksa içerikten türetilir.
+* **Ekler (enclosure):** Podcast bölümünün ses dosyası. RSS'te `<enclosure>`, Atom'da `<link rel="enclosure">` içinde gelir.
+* **Nazik yoklama:** Her 15 dakikada bir tüm beslemeyi indirmek yerine `ETag` / `Last-Modified` ile **koşullu GET** yapılır. Değişiklik yoksa sunucu gövdesiz `304 Not Modified` döner.
+* **Tekrar ayıklama:** Aynı yazı iki beslemede (ör. "tümü" ve "teknoloji" kategorisi) çıkabilir.
+* **OPML:** Besleme okuyucuların abonelik listesini dışa/içe aktarma formatı.
+* **Çıktı:** Toplanan her şey tek bir **Atom** beslemesi olarak geri sunulur.
+
+Hepsi yalnızca standart kütüphaneyle yapılıyor: `encoding/xml`, `net/http`, `time`.
+
+---
+
+# 📦 Proje Yapısı
+
+```
+feedagg/
+├── go.mod              // module feedagg
+├── main.go             // HTTP sunucusu
+└── feed/
+    ├── feed.go         // ortak model + RSS 2.0 / RSS 1.0 / Atom çözümleme
+    ├── date.go         // tarih biçimleri ve karakter kodlamaları
+    ├── store.go        // tekrarsız öğe deposu
+    ├── poll.go         // koşullu GET ile yoklama
+    ├── opml.go         // OPML içe/dışa aktarma
+    ├── atom.go         // birleşik Atom çıktısı
+    ├── feed_test.go
+    └── testdata/       // rss2.xml, rss1.rdf, atom.xml, latin5.xml, subs.opml
+```
+
+---
+
+# 1️⃣ Ortak Model ve Çözümleme (`feed/feed.go`)
+
+Format, kök elemana bakılarak seçilir:
+
+| Kök eleman | Format |
+| --- | --- |
+| `<rss>` | RSS 2.0 |
+| `<rdf:RDF>` (ad alanı `http://www.w3.org/1999/02/22-rdf-syntax-ns#`) | RSS 1.0 |
+| `<feed>` (ad alanı `http://www.w3.org/2005/Atom`) | Atom |
+
+Bunun için önce `Decoder.Token()` ile ilk `StartElement` okunur, sonra `DecodeElement` ile doğru struct'a çözülür. Belge iki kez okunmaz.
+
+`encoding/xml` etiketlerinde ad alanı, yerel addan önce boşlukla yazılır: `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`. Ad alanı olmayan `xml:"link"` ise **her** ad alanındaki `link`'i yakalar. `rssLink` bu yüzden `XMLName` alanını da taşır; ad alanına göre hangisinin site linki, hangisinin `atom:link rel="self"` olduğu ayrılır.
+
+Atom metinleri üç tiptedir (`text`, `html`, `xhtml`). Modelde `Summary` ve `Content` her zaman HTML'dir. Düz metin kaçışlanır, `xhtml` ise ham XML olarak alınır. Bunun için `,chardata` ile `,innerxml` aynı struct'ta birlikte kullanılıyor.
+*/
+``go
+// Package feed, RSS 2.0, RSS 1.0 (RDF) ve Atom beslemelerini ortak bir
+// modele çevirir, abonelikleri koşullu GET ile yoklar, tekrar eden
+// öğeleri ayıklar ve birleşik beslemeyi Atom olarak yazar.
+package feed
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Feed, formattan bağımsız besleme modelidir.
+type Feed struct {
+	Format  string // "rss2", "rss1" ya da "atom"
+	ID      string
+	Title   string
+	Link    string // sitenin adresi
+	Self    string // beslemenin kendi adresi (varsa)
+	Updated time.Time
+	Items   []Item
+}
+
+// Item, tek bir haber/yazı/bölümdür. Summary ve Content HTML'dir; düz
+// metin girdiler kaçışlanarak HTML'e çevrilir.
+type Item struct {
+	ID         string // guid / atom:id; yoksa link ya da içerikten türetilir
+	Title      string
+	Link       string
+	Summary    string
+	Content    string
+	Author     string
+	Categories []string
+	Published  time.Time
+	Updated    time.Time
+	Enclosures []Enclosure
+
+	// Aşağıdakiler aggregator tarafından doldurulur.
+	FeedURL   string
+	FeedTitle string
+	Seen      time.Time // öğenin ilk görüldüğü an
+}
+
+// Enclosure, öğeye ekli dosyadır (podcast bölümünün ses dosyası gibi).
+type Enclosure struct {
+	URL    string
+	Type   string
+	Length int64
+}
+
+// Date, sıralamada kullanılan tarihtir: yayın, yoksa güncelleme, o da
+// yoksa ilk görülme zamanı.
+func (it *Item) Date() time.Time {
+	switch {
+	case !it.Published.IsZero():
+		return it.Published
+	case !it.Updated.IsZero():
+		return it.Updated
+	}
+	return it.Seen
+}
+
+const (
+	nsAtom = "http://www.w3.org/2005/Atom"
+	nsRDF  = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+)
+
+// ErrUnknownFormat, kök eleman tanınmadığında döner.
+var ErrUnknownFormat = errors.New("feed: tanınmayan besleme formatı")
+
+// Parse, r'deki beslemeyi kök elemanına bakarak çözer: <rss>, <rdf:RDF>
+// ya da Atom ad alanındaki <feed>.
+func Parse(r io.Reader) (*Feed, error) {
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+	dec.Entity = xml.HTMLEntity // &nbsp; gibi HTML varlıkları beslemelerde sık görülür
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("feed: kök eleman okunamadı: %w", err)
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		var f *Feed
+		switch {
+		case start.Name.Local == "rss":
+			var v rss2
+			if err = dec.DecodeElement(&v, &start); err == nil {
+				f = v.feed()
+			}
+		case start.Name.Space == nsRDF && start.Name.Local == "RDF":
+			var v rdf
+			if err = dec.DecodeElement(&v, &start); err == nil {
+				f = v.feed()
+			}
+		case start.Name.Space == nsAtom && start.Name.Local == "feed":
+			var v atomFeed
+			if err = dec.DecodeElement(&v, &start); err == nil {
+				f = v.feed()
+			}
+		default:
+			return nil, fmt.Errorf("%w: <%s>", ErrUnknownFormat, start.Name.Local)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("feed: %w", err)
+		}
+		for i := range f.Items {
+			it := &f.Items[i]
+			if it.ID == "" {
+				it.ID = fallbackID(it)
+			}
+		}
+		return f, nil
+	}
+}
+
+// fallbackID, kimliği olmayan öğe için kararlı bir kimlik üretir: önce
+// link, o da yoksa başlık ve içeriğin özeti.
+func fallbackID(it *Item) string {
+	if it.Link != "" {
+		return it.Link
+	}
+	h := sha1.Sum([]byte(it.Title + "\x00" + it.Summary + "\x00" + it.Content))
+	return "urn:sha1:" + hex.EncodeToString(h[:])
+}
+
+// ResolveLinks, göreli linkleri base'e (beslemenin indirildiği adres)
+// göre mutlak hale getirir.
+func (f *Feed) ResolveLinks(base *url.URL) {
+	resolve := func(s *string) {
+		if *s == "" {
+			return
+		}
+		if u, err := base.Parse(*s); err == nil {
+			*s = u.String()
+		}
+	}
+	resolve(&f.Link)
+	resolve(&f.Self)
+	for i := range f.Items {
+		it := &f.Items[i]
+		resolve(&it.Link)
+		for j := range it.Enclosures {
+			resolve(&it.Enclosures[j].URL)
+		}
+	}
+}
+
+// ---- RSS 2.0 ----
+
+type rss2 struct {
+	Channel struct {
+		Title         string     `xml:"title"`
+		Links         []rssLink  `xml:"link"`
+		Description   string     `xml:"description"`
+		LastBuildDate string     `xml:"lastBuildDate"`
+		PubDate       string     `xml:"pubDate"`
+		Items         []rss2Item `xml:"item"`
+	} `xml:"channel"`
+}
+
+// rssLink hem RSS'in <link>URL</link> elemanını hem de kanal içinde sık
+// kullanılan <atom:link rel="self" href=".."/> elemanını yakalar. Alan
+// adı tek olduğu için ikisi aynı dilime düşer, ad alanına göre ayrılır.
+type rssLink struct {
+	XMLName xml.Name
+	Href    string `xml:"href,attr"`
+	Rel     string `xml:"rel,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type rss2Item struct {
+	Title       string    `xml:"title"`
+	Links       []rssLink `xml:"link"`
+	Description string    `xml:"description"`
+	Encoded     string    `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	Author      string    `xml:"author"`
+	Creator     string    `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string  `xml:"category"`
+	PubDate     string    `xml:"pubDate"`
+	DCDate      string    `xml:"http://purl.org/dc/elements/1.1/ date"`
+	GUID        struct {
+		Value       string `xml:",chardata"`
+		IsPermaLink string `xml:"isPermaLink,attr"`
+	} `xml:"guid"`
+	Enclosures []struct {
+		URL    string `xml:"url,attr"`
+		Type   string `xml:"type,attr"`
+		Length string `xml:"length,attr"`
+	} `xml:"enclosure"`
+}
+
+func (v *rss2) feed() *Feed {
+	c := &v.Channel
+	f := &Feed{Format: "rss2", Title: text(c.Title)}
+	f.Link, f.Self = rssLinks(c.Links)
+	f.ID = f.Self
+	if f.ID == "" {
+		f.ID = f.Link
+	}
+	f.Updated = firstDate(c.LastBuildDate, c.PubDate)
+	for _, x := range c.Items {
+		it := Item{
+			ID:         strings.TrimSpace(x.GUID.Value),
+			Title:      text(x.Title),
+			Summary:    strings.TrimSpace(x.Description),
+			Content:    strings.TrimSpace(x.Encoded),
+			Author:     text(firstNonEmpty(x.Creator, x.Author)),
+			Categories: trimAll(x.Categories),
+			Published:  firstDate(x.PubDate, x.DCDate),
+		}
+		it.Link, _ = rssLinks(x.Links)
+		// isPermaLink varsayılanı true'dur: link yoksa guid linktir.
+		if it.Link == "" && it.ID != "" && x.GUID.IsPermaLink != "false" {
+			it.Link = it.ID
+		}
+		for _, e := range x.Enclosures {
+			n, _ := strconv.ParseInt(strings.TrimSpace(e.Length), 10, 64)
+			it.Enclosures = append(it.Enclosures, Enclosure{URL: strings.TrimSpace(e.URL), Type: e.Type, Length: n})
+		}
+		f.Items = append(f.Items, it)
+	}
+	return f
+}
+
+func rssLinks(links []rssLink) (link, self string) {
+	for _, l := range links {
+		switch {
+		case l.XMLName.Space == nsAtom && l.Rel == "self":
+			self = strings.TrimSpace(l.Href)
+		case l.XMLName.Space == "" && link == "":
+			link = strings.TrimSpace(l.Text)
+		}
+	}
+	return link, self
+}
+
+// ---- RSS 1.0 (RDF) ----
+
+// rdf, RSS 1.0 belgesidir. RSS 2.0'dan farkı, öğelerin <channel>'ın
+// içinde değil kardeşi olması ve her şeyin bir ad alanında olmasıdır.
+type rdf struct {
+	Channel struct {
+		About       string `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+		Title       string `xml:"http://purl.org/rss/1.0/ title"`
+		Link        string `xml:"http://purl.org/rss/1.0/ link"`
+		Description string `xml:"http://purl.org/rss/1.0/ description"`
+		Date        string `xml:"http://purl.org/dc/elements/1.1/ date"`
+	} `xml:"http://purl.org/rss/1.0/ channel"`
+	Items []struct {
+		About       string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+		Title       string   `xml:"http://purl.org/rss/1.0/ title"`
+		Link        string   `xml:"http://purl.org/rss/1.0/ link"`
+		Description string   `xml:"http://purl.org/rss/1.0/ description"`
+		Encoded     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+		Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+		Subjects    []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+		Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	} `xml:"http://purl.org/rss/1.0/ item"`
+}
+
+func (v *rdf) feed() *Feed {
+	c := &v.Channel
+	f := &Feed{
+		Format:  "rss1",
+		ID:      firstNonEmpty(c.About, c.Link),
+		Title:   text(c.Title),
+		Link:    strings.TrimSpace(c.Link),
+		Self:    strings.TrimSpace(c.About),
+		Updated: parseDate(c.Date),
+	}
+	for _, x := range v.Items {
+		f.Items = append(f.Items, Item{
+			ID:         strings.TrimSpace(x.About),
+			Title:      text(x.Title),
+			Link:       strings.TrimSpace(x.Link),
+			Summary:    strings.TrimSpace(x.Description),
+			Content:    strings.TrimSpace(x.Encoded),
+			Author:     text(x.Creator),
+			Categories: trimAll(x.Subjects),
+			Published:  parseDate(x.Date),
+		})
+	}
+	return f
+}
+
+// ---- Atom ----
+
+type atomFeed struct {
+	ID      string      `xml:"http://www.w3.org/2005/Atom id"`
+	Title   atomText    `xml:"http://www.w3.org/2005/Atom title"`
+	Updated string      `xml:"http://www.w3.org/2005/Atom updated"`
+	Links   []atomLink  `xml:"http://www.w3.org/2005/Atom link"`
+	Author  atomPerson  `xml:"http://www.w3.org/2005/Atom author"`
+	Entries []atomEntry `xml:"http://www.w3.org/2005/Atom entry"`
+}
+
+type atomEntry struct {
+	ID         string       `xml:"http://www.w3.org/2005/Atom id"`
+	Title      atomText     `xml:"http://www.w3.org/2005/Atom title"`
+	Links      []atomLink   `xml:"http://www.w3.org/2005/Atom link"`
+	Summary    atomText     `xml:"http://www.w3.org/2005/Atom summary"`
+	Content    atomText     `xml:"http://www.w3.org/2005/Atom content"`
+	Authors    []atomPerson `xml:"http://www.w3.org/2005/Atom author"`
+	Categories []struct {
+		Term  string `xml:"term,attr"`
+		Label string `xml:"label,attr"`
+	} `xml:"http://www.w3.org/2005/Atom category"`
+	Published string `xml:"http://www.w3.org/2005/Atom published"`
+	Updated   string `xml:"http://www.w3.org/2005/Atom updated"`
+}
+
+// atomText, Atom'un üç metin tipini taşır: text, html ve xhtml.
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// html, içeriği HTML olarak döndürür.
+func (t *atomText) html() string {
+	switch t.Type {
+	case "html", "text/html":
+		return strings.TrimSpace(t.Text)
+	case "xhtml":
+		// İçerik bir <div> ile sarılıdır; ham XML'i olduğu gibi kullan.
+		return strings.TrimSpace(t.Inner)
+	}
+	return html.EscapeString(strings.TrimSpace(t.Text))
+}
+
+// plain, içeriği düz metin olarak döndürür (başlıklar için).
+func (t *atomText) plain() string {
+	if t.Type == "xhtml" {
+		return text(stripTags(t.Inner))
+	}
+	if t.Type == "html" {
+		return text(html.UnescapeString(stripTags(t.Text)))
+	}
+	return text(t.Text)
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr"`
+	Type   string `xml:"type,attr"`
+	Length string `xml:"length,attr"`
+}
+
+type atomPerson struct {
+	Name string `xml:"http://www.w3.org/2005/Atom name"`
+}
+
+func (v *atomFeed) feed() *Feed {
+	f := &Feed{
+		Format:  "atom",
+		ID:      strings.TrimSpace(v.ID),
+		Title:   v.Title.plain(),
+		Updated: parseDate(v.Updated),
+	}
+	for _, l := range v.Links {
+		switch l.Rel {
+		case "", "alternate":
+			if f.Link == "" {
+				f.Link = l.Href
+			}
+		case "self":
+			f.Self = l.Href
+		}
+	}
+	for _, e := range v.Entries {
+		it := Item{
+			ID:        strings.TrimSpace(e.ID),
+			Title:     e.Title.plain(),
+			Summary:   e.Summary.html(),
+			Content:   e.Content.html(),
+			Published: parseDate(e.Published),
+			Updated:   parseDate(e.Updated),
+		}
+		// Yazar girdide yoksa beslemeninki geçerlidir (RFC 4287 4.2.1).
+		it.Author = v.Author.Name
+		if len(e.Authors) > 0 {
+			it.Author = e.Authors[0].Name
+		}
+		it.Author = text(it.Author)
+		for _, c := range e.Categories {
+			it.Categories = append(it.Categories, firstNonEmpty(c.Label, c.Term))
+		}
+		for _, l := range e.Links {
+			switch l.Rel {
+			case "", "alternate":
+				if it.Link == "" {
+					it.Link = l.Href
+				}
+			case "enclosure":
+				n, _ := strconv.ParseInt(l.Length, 10, 64)
+				it.Enclosures = append(it.Enclosures, Enclosure{URL: l.Href, Type: l.Type, Length: n})
+			}
+		}
+		f.Items = append(f.Items, it)
+	}
+	return f
+}
+
+// ---- yardımcılar ----
+
+// text, baştaki/sondaki boşlukları atar ve aradaki boşlukları teke indirir.
+func text(s string) string { return strings.Join(strings.Fields(s), " ") }
+
+func trimAll(ss []string) []string {
+	var out []string
+	for _, s := range ss {
+		if s = text(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func firstNonEmpty(ss ...string) string {
+	for _, s := range ss {
+		if s = strings.TrimSpace(s); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+func firstDate(ss ...string) time.Time {
+	for _, s := range ss {
+		if t := parseDate(s); !t.IsZero() {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// stripTags, başlıklar için kaba bir etiket temizleyicidir.
+func stripTags(s string) string {
+	var b strings.Builder
+	in := false
+	for _, r := range s {
+		switch {
+		case r == '<':
+			in = true
+		case r == '>':
+			in = false
+		case !in:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+``
+/*
+
+---
+
+# 2️⃣ Tarihler ve Karakter Kodlamaları (`feed/date.go`)
+
+İki tuzak var:
+
+1. `time.Parse`, tanımadığı bir bölge kısaltmasını (`EST`, `PDT`) **sıfır ofsetle** kabul eder ve hata vermez. Yani `08:30 EST` sessizce `08:30 UTC` olur, beş saatlik kayma oluşur. Bu yüzden RFC 822 kısaltmaları bir tabloda sabitlendi.
+2. `encoding/xml` yalnızca UTF-8'i bilir. `encoding="ISO-8859-9"` diyen eski bir Türkçe site için hata verir. `Decoder.CharsetReader` ile Latin-1, Latin-5 (ISO-8859-9) ve Windows-1252/1254 çözülür.
+*/
+``go
+package feed
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// dateLayouts, beslemelerde görülen tarih biçimleridir. RSS, RFC 822
+// ister; pratikte iki haneli yıl, saniyesiz saat, tam ay adı ve ISO 8601
+// de çıkar. Haftanın günü parseDate'te atılır: yanlış yazılmış ("Tues")
+// ya da tarihle uyuşmayan gün adları çok yaygındır.
+var dateLayouts = []string{
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 2006 15:04 MST",
+	"2 Jan 06 15:04:05 -0700",
+	"2 Jan 06 15:04:05 MST",
+	"2 Jan 06 15:04 -0700",
+	"2 Jan 06 15:04 MST",
+	"2 January 2006 15:04:05 -0700",
+	"2 January 2006 15:04:05 MST",
+	time.RFC3339, // kesirli saniyeyi de kabul eder
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+// zones, RFC 822'deki bölge kısaltmalarıdır. time.Parse bilmediği bir
+// kısaltmayı sıfır ofsetle kabul eder; "EST" beş saat kaymasın diye
+// ofsetler burada sabitlenir.
+var zones = map[string]int{
+	"UT": 0, "UTC": 0, "GMT": 0, "Z": 0,
+	"EST": -5, "EDT": -4, "CST": -6, "CDT": -5,
+	"MST": -7, "MDT": -6, "PST": -8, "PDT": -7,
+}
+
+// parseDate, tarihi çözemezse sıfır zaman döner; tarihi bozuk bir öğe
+// yine de kabul edilir, sıralamada ilk görülme zamanı kullanılır.
+func parseDate(s string) time.Time {
+	s = text(s)
+	if s == "" {
+		return time.Time{}
+	}
+	if i := strings.IndexByte(s, ','); i >= 0 && i <= 10 {
+		s = strings.TrimSpace(s[i+1:])
+	}
+	if strings.HasSuffix(s, " UT") {
+		s += "C"
+	}
+	for _, layout := range dateLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		if name, _ := t.Zone(); strings.Contains(layout, "MST") {
+			if h, ok := zones[name]; ok {
+				y, mo, d := t.Date()
+				t = time.Date(y, mo, d, t.Hour(), t.Minute(), t.Second(), 0, time.FixedZone(name, h*3600))
+			}
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// charsetReader, UTF-8 dışında sık rastlanan tek byte'lık kodlamaları
+// çözer. encoding/xml yalnızca UTF-8'i bilir; diğerleri için bu fonksiyon
+// çağrılır.
+func charsetReader(label string, r io.Reader) (io.Reader, error) {
+	var high *[128]rune
+	switch strings.ToLower(label) {
+	case "iso-8859-1", "latin1", "us-ascii":
+		high = &latin1
+	case "iso-8859-9", "latin5":
+		high = &latin5
+	case "windows-1254", "cp1254":
+		high = &cp1254
+	case "windows-1252", "cp1252":
+		high = &cp1252
+	default:
+		return nil, fmt.Errorf("feed: desteklenmeyen karakter kodlaması %q", label)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(data)+len(data)/8)
+	for _, c := range data {
+		if c < utf8.RuneSelf {
+			out = append(out, c)
+		} else {
+			out = utf8.AppendRune(out, high[c-0x80])
+		}
+	}
+	return strings.NewReader(string(out)), nil
+}
+
+var latin1, latin5, cp1252, cp1254 [128]rune
+
+func init() {
+	for i := range latin1 {
+		latin1[i] = rune(0x80 + i)
+	}
+	// ISO-8859-9, Latin-1'in altı harfini Türkçe harflerle değiştirir.
+	latin5 = latin1
+	for c, r := range map[byte]rune{0xD0: 'Ğ', 0xDD: 'İ', 0xDE: 'Ş', 0xF0: 'ğ', 0xFD: 'ı', 0xFE: 'ş'} {
+		latin5[c-0x80] = r
+	}
+	// Windows kod sayfaları 0x80-0x9F aralığına noktalama işaretleri koyar.
+	punct := []rune("€�‚ƒ„…†‡ˆ‰Š‹Œ�Ž��‘’“”•–—˜™š›œ�žŸ")
+	cp1252 = latin1
+	copy(cp1252[:], punct)
+	cp1254 = latin5
+	copy(cp1254[:], punct)
+	cp1254[0x8E-0x80], cp1254[0x9E-0x80] = utf8.RuneError, utf8.RuneError
+}
+``
+/*
+
+---
+
+# 3️⃣ Tekrar Ayıklama (`feed/store.go`)
+
+Öğenin anahtarı kimliğidir, ancak iki tür kimlik var:
+
+* **Küresel kimlikler:** `https://..`, `urn:..`, `tag:..`. Atom bunların evrensel olarak tekil olmasını şart koşar. Aynı kimlik iki beslemeden gelirse **tek öğe** sayılır.
+* **Yerel kimlikler:** `"123"`, `"gopher-42"`. İki farklı sitenin guid'i tesadüfen aynı olabilir. Bu yüzden besleme adresiyle birleştirilip `urn:sha1:..` yapılır.
+
+Aynı öğe tekrar geldiğinde yalnızca **görünür içerik** değiştiyse güncellenir. Bazı beslemeler her istekte `lastBuildDate`'i değiştirir ama öğelere dokunmaz. Her yoklamayı "güncelleme" saymak, okuyucuların aynı yazıyı tekrar tekrar "okunmamış" göstermesine yol açar.
+*/
+``go
+package feed
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Store, tüm aboneliklerden gelen öğeleri tekrarsız tutar.
+//
+// Anahtar, öğenin kimliğidir. Mutlak bir URI olan kimlikler (http://..,
+// urn:.., tag:..) küresel kabul edilir: aynı yazı iki beslemede (ör. hem
+// "tümü" hem "teknoloji" kategorisinde) yayınlanırsa bir kez saklanır.
+// "123" gibi yerel kimlikler ise besleme adresiyle birleştirilir; iki
+// sitenin guid'i tesadüfen aynı olabilir.
+type Store struct {
+	MaxAge time.Duration // bundan eski öğeler tutulmaz; 0 ise sınırsız
+
+	mu      sync.RWMutex
+	items   map[string]*Item
+	version uint64
+}
+
+// NewStore, boş bir Store döner.
+func NewStore(maxAge time.Duration) *Store {
+	return &Store{MaxAge: maxAge, items: map[string]*Item{}}
+}
+
+// Key, öğenin Store'daki anahtarıdır; aynı zamanda birleşik Atom
+// beslemesinde girdinin kimliği olarak kullanılır.
+func Key(feedURL, id string) string {
+	if isGlobalID(id) {
+		return id
+	}
+	h := sha1.Sum([]byte(feedURL + "\x00" + id))
+	return "urn:sha1:" + hex.EncodeToString(h[:])
+}
+
+func isGlobalID(id string) bool {
+	i := strings.IndexByte(id, ':')
+	if i <= 0 {
+		return false
+	}
+	switch strings.ToLower(id[:i]) {
+	case "http", "https":
+		return strings.HasPrefix(id[i:], "://") && len(id) > i+3
+	case "urn", "tag":
+		return len(id) > i+1
+	}
+	return false
+}
+
+// AddResult, Add'in özetidir.
+type AddResult struct {
+	New, Updated int
+}
+
+// Add, beslemenin öğelerini ekler. Daha önce görülen bir öğe yalnızca
+// içeriği değişmişse güncellenir; ilk görülme zamanı korunur.
+func (s *Store) Add(feedURL, feedTitle string, items []Item, now time.Time) AddResult {
+	var res AddResult
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, it := range items {
+		it.FeedURL, it.FeedTitle, it.Seen = feedURL, feedTitle, now
+		if s.tooOld(&it, now) {
+			continue
+		}
+		key := Key(feedURL, it.ID)
+		old, ok := s.items[key]
+		switch {
+		case !ok:
+			res.New++
+		case sameContent(old, &it):
+			continue
+		default:
+			// Aynı yazı başka bir beslemeden de gelebilir; ilk kaynağı koru.
+			it.FeedURL, it.FeedTitle, it.Seen = old.FeedURL, old.FeedTitle, old.Seen
+			res.Updated++
+		}
+		s.items[key] = &it
+	}
+	if res.New+res.Updated > 0 {
+		s.version++
+	}
+	s.prune(now)
+	return res
+}
+
+func (s *Store) tooOld(it *Item, now time.Time) bool {
+	return s.MaxAge > 0 && it.Date().Before(now.Add(-s.MaxAge))
+}
+
+func (s *Store) prune(now time.Time) {
+	for k, it := range s.items {
+		if s.tooOld(it, now) {
+			delete(s.items, k)
+			s.version++
+		}
+	}
+}
+
+// sameContent, görünür alanlardan biri değişmiş mi diye bakar. Bazı
+// beslemeler her istekte lastBuildDate'i değiştirir ama öğelere dokunmaz;
+// yalnızca tarih alanına bakmak gereksiz güncellemeye yol açardı.
+func sameContent(a, b *Item) bool {
+	return a.Title == b.Title && a.Link == b.Link && a.Summary == b.Summary &&
+		a.Content == b.Content && a.Updated.Equal(b.Updated) &&
+		slices.Equal(a.Enclosures, b.Enclosures)
+}
+
+// Items, en yeni n öğeyi (n <= 0 ise tümünü) tarihe göre azalan sırada
+// döner.
+func (s *Store) Items(n int) []Item {
+	s.mu.RLock()
+	out := make([]Item, 0, len(s.items))
+	for _, it := range s.items {
+		out = append(out, *it)
+	}
+	s.mu.RUnlock()
+	slices.SortFunc(out, func(a, b Item) int {
+		if c := b.Date().Compare(a.Date()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID) // aynı tarihte kararlı sıra
+	})
+	if n > 0 && len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
+
+// Len, saklanan öğe sayısıdır.
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.items)
+}
+
+// Version, her yeni ya da güncellenen öğede artar. HTTP'de ETag olarak
+// kullanılır.
+func (s *Store) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+``
+/*
+
+---
+
+# 4️⃣ Koşullu GET ile Yoklama (`feed/poll.go`)
+
+Akış şöyle:
+
+```
+1. istek:  GET /feed.xml
+           ← 200 OK, ETag: "abc", Last-Modified: ..., gövde
+2. istek:  GET /feed.xml
+           If-None-Match: "abc"
+           If-Modified-Since: ...
+           ← 304 Not Modified (gövde yok)
+```
+
+Dikkat edilen noktalar:
+
+* `200` yanıtında sunucu doğrulayıcı (`ETag`/`Last-Modified`) göndermediyse eskisi **silinir**. Aksi halde içerik değişse bile sonsuza kadar `304` istenirdi.
+* Hata olursa bekleme süresi ikiye katlanır (en fazla 1 gün). Sunucu `Retry-After` gönderdiyse ondan erken gidilmez.
+* Gövde `MaxBody` ile sınırlanır. `io.LimitReader` sınırda sessizce EOF döndüğü için belge kesilir ve hata "beklenmeyen EOF" gibi yanıltıcı olurdu. `maxReader` bunun yerine açık bir `ErrTooLarge` döner.
+* Yönlendirme sonrasında göreli linkler **son** adrese göre çözülür (`resp.Request.URL`).
+* `Run`, zamanı gelen abonelikleri en fazla `Concurrency` kadar eşzamanlı yoklar. Çalışırken eklenen abonelik (`Subscribe`) döngüyü hemen uyandırır.
+* Sırası gelen abonelik, yoklama başlamadan önce `polling` ile işaretlenir. `ctx` yoklamalar dağıtılırken iptal edilirse, sırada kalanların işareti `Run`'daki `defer` ile indirilir. Aksi halde bu abonelikler ne `Poll` ile ne de yeniden başlatılan bir `Run` ile bir daha yoklanabilirdi.
+*/
+``go
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Subscription, takip edilen bir beslemedir. ETag ile sonrası yoklama
+// durumudur; Aggregator tarafından güncellenir.
+type Subscription struct {
+	URL      string
+	Title    string
+	Category string // OPML'deki klasör yolu, ör. "Haber/Teknoloji"
+
+	ETag         string
+	LastModified string
+	LastPoll     time.Time
+	NextPoll     time.Time
+	Failures     int    // art arda başarısız yoklama
+	LastError    string // son hata, başarılıysa boş
+
+	polling bool
+}
+
+// PollResult, tek bir yoklamanın sonucudur.
+type PollResult struct {
+	Status      int
+	NotModified bool // 304: sunucu "değişiklik yok" dedi, gövde indirilmedi
+	AddResult
+}
+
+// Aggregator, abonelikleri yoklar ve öğeleri Store'a ekler.
+type Aggregator struct {
+	Client      *http.Client // nil ise zaman aşımı 30 sn olan bir istemci
+	Store       *Store
+	Interval    time.Duration // varsayılan 15 dk
+	MaxBody     int64         // besleme başına en fazla byte, varsayılan 10 MB
+	Concurrency int           // aynı anda en fazla yoklama, varsayılan 4
+	UserAgent   string
+	Logf        func(format string, args ...any) // nil ise sessiz
+
+	mu   sync.Mutex
+	subs map[string]*Subscription
+	wake chan struct{}
+}
+
+// Subscribe, abonelikleri ekler. Aynı URL ikinci kez eklenmez; ilk
+// yoklama hemen yapılır.
+func (a *Aggregator) Subscribe(subs ...Subscription) {
+	a.mu.Lock()
+	if a.subs == nil {
+		a.subs = map[string]*Subscription{}
+	}
+	for _, s := range subs {
+		if _, ok := a.subs[s.URL]; !ok {
+			a.subs[s.URL] = &s
+		}
+	}
+	a.mu.Unlock()
+	a.signal()
+}
+
+// Subscriptions, aboneliklerin anlık bir kopyasını döner.
+func (a *Aggregator) Subscriptions() []Subscription {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	out := make([]Subscription, 0, len(a.subs))
+	for _, s := range a.subs {
+		out = append(out, *s)
+	}
+	return out
+}
+
+func (a *Aggregator) signal() {
+	a.mu.Lock()
+	if a.wake == nil {
+		a.wake = make(chan struct{}, 1)
+	}
+	w := a.wake
+	a.mu.Unlock()
+	select {
+	case w <- struct{}{}:
+	default:
+	}
+}
+
+// Run, ctx iptal edilene kadar zamanı gelen abonelikleri yoklar.
+func (a *Aggregator) Run(ctx context.Context) error {
+	a.signal() // wake kanalını oluştur
+	sem := make(chan struct{}, a.concurrency())
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// pending, polling işaretlenmiş ama henüz başlatılmamış aboneliklerdir.
+	// Run ctx iptal edilip erken dönerse bayrakları burada indirilir; yoksa
+	// bu abonelikler ne Poll ile ne de sonraki bir Run ile yoklanabilir.
+	var pending []*Subscription
+	defer func() {
+		a.mu.Lock()
+		for _, s := range pending {
+			s.polling = false
+		}
+		a.mu.Unlock()
+	}()
+	for {
+		now := time.Now()
+		next := now.Add(a.interval())
+		a.mu.Lock()
+		var due []*Subscription
+		for _, s := range a.subs {
+			switch {
+			case s.polling:
+			case !s.NextPoll.After(now):
+				s.polling = true
+				due = append(due, s)
+			case s.NextPoll.Before(next):
+				next = s.NextPoll
+			}
+		}
+		wake := a.wake
+		a.mu.Unlock()
+
+		for pending = due; len(pending) > 0; {
+			s := pending[0]
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			pending = pending[1:]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				a.poll(ctx, s)
+			}()
+		}
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-wake:
+		case <-timer.C:
+		}
+		timer.Stop()
+	}
+}
+
+// Poll, url'deki aboneliği hemen yoklar. Abonelik yoksa hata döner.
+func (a *Aggregator) Poll(ctx context.Context, url string) (PollResult, error) {
+	a.mu.Lock()
+	s, ok := a.subs[url]
+	if ok && s.polling {
+		a.mu.Unlock()
+		return PollResult{}, errors.New("feed: bu abonelik zaten yoklanıyor")
+	}
+	if ok {
+		s.polling = true
+	}
+	a.mu.Unlock()
+	if !ok {
+		return PollResult{}, fmt.Errorf("feed: abonelik yok: %s", url)
+	}
+	return a.poll(ctx, s)
+}
+
+// poll, s'yi koşullu GET ile indirir. Çağıran s.polling'i true yapmış
+// olmalıdır; yoklama bitince sıradaki zaman hesaplanıp bayrak indirilir.
+func (a *Aggregator) poll(ctx context.Context, s *Subscription) (PollResult, error) {
+	a.mu.Lock()
+	url, etag, lastMod := s.URL, s.ETag, s.LastModified
+	a.mu.Unlock()
+
+	res, hdr, err := a.fetch(ctx, url, etag, lastMod)
+
+	now := time.Now()
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	s.polling = false
+	s.LastPoll = now
+	if err != nil {
+		s.Failures++
+		s.LastError = err.Error()
+		s.NextPoll = now.Add(a.backoff(s.Failures, hdr))
+		a.logf("%s: %v (%d. hata)", url, err, s.Failures)
+		return res, err
+	}
+	s.Failures, s.LastError = 0, ""
+	s.NextPoll = now.Add(a.interval())
+	if !res.NotModified {
+		// Sunucu doğrulayıcı göndermediyse eskisini de silmek gerekir;
+		// yoksa değişmiş içerik için hâlâ 304 isteriz.
+		s.ETag = hdr.Get("ETag")
+		s.LastModified = hdr.Get("Last-Modified")
+	}
+	a.logf("%s: %d, %d yeni, %d güncellenen", url, res.Status, res.New, res.Updated)
+	return res, nil
+}
+
+func (a *Aggregator) fetch(ctx context.Context, url, etag, lastMod string) (PollResult, http.Header, error) {
+	var res PollResult
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return res, nil, err
+	}
+	req.Header.Set("Accept", "application/atom+xml, application/rss+xml, application/rdf+xml;q=0.9, application/xml;q=0.8, text/xml;q=0.8")
+	if a.UserAgent != "" {
+		req.Header.Set("User-Agent", a.UserAgent)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	if lastMod != "" {
+		req.Header.Set("If-Modified-Since", lastMod)
+	}
+	resp, err := a.client().Do(req)
+	if err != nil {
+		return res, nil, err
+	}
+	defer resp.Body.Close()
+	res.Status = resp.StatusCode
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		res.NotModified = true
+		return res, resp.Header, nil
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // bağlantı tekrar kullanılabilsin
+		return res, resp.Header, fmt.Errorf("HTTP %s", resp.Status)
+	}
+
+	f, err := Parse(&maxReader{r: resp.Body, n: a.maxBody()})
+	if err != nil {
+		return res, resp.Header, err
+	}
+	// Yönlendirme olduysa göreli linkler son adrese göre çözülür.
+	f.ResolveLinks(resp.Request.URL)
+
+	a.mu.Lock()
+	title := a.subs[url].Title
+	if title == "" && f.Title != "" {
+		a.subs[url].Title = f.Title
+		title = f.Title
+	}
+	a.mu.Unlock()
+	res.AddResult = a.Store.Add(url, title, f.Items, time.Now())
+	return res, resp.Header, nil
+}
+
+// ErrTooLarge, besleme MaxBody sınırını aştığında döner.
+var ErrTooLarge = errors.New("feed: besleme boyut sınırını aşıyor")
+
+// maxReader, io.LimitReader gibidir ama sınırda sessizce EOF dönmek
+// yerine hata verir; yoksa kesilmiş bir belge "beklenmeyen EOF" diye
+// raporlanırdı.
+type maxReader struct {
+	r io.Reader
+	n int64
+}
+
+func (m *maxReader) Read(p []byte) (int, error) {
+	if m.n <= 0 {
+		// Tam sınırda biten gövde hata değildir.
+		var one [1]byte
+		if n, _ := m.r.Read(one[:]); n == 0 {
+			return 0, io.EOF
+		}
+		return 0, ErrTooLarge
+	}
+	if int64(len(p)) > m.n {
+		p = p[:m.n]
+	}
+	n, err := m.r.Read(p)
+	m.n -= int64(n)
+	return n, err
+}
+
+// backoff, art arda hatalarda bekleme süresini ikiye katlar (en fazla bir
+// gün). Sunucu Retry-After gönderdiyse ondan kısa beklenmez.
+func (a *Aggregator) backoff(failures int, hdr http.Header) time.Duration {
+	d := a.interval() << min(failures-1, 10)
+	d = min(d, 24*time.Hour)
+	if hdr != nil {
+		if sec, err := strconv.Atoi(hdr.Get("Retry-After")); err == nil {
+			d = max(d, time.Duration(sec)*time.Second)
+		} else if t, err := http.ParseTime(hdr.Get("Retry-After")); err == nil {
+			d = max(d, time.Until(t))
+		}
+	}
+	return d
+}
+
+func (a *Aggregator) client() *http.Client {
+	if a.Client != nil {
+		return a.Client
+	}
+	return defaultClient
+}
+
+var defaultClient = &http.Client{Timeout: 30 * time.Second}
+
+func (a *Aggregator) interval() time.Duration {
+	if a.Interval > 0 {
+		return a.Interval
+	}
+	return 15 * time.Minute
+}
+
+func (a *Aggregator) maxBody() int64 {
+	if a.MaxBody > 0 {
+		return a.MaxBody
+	}
+	return 10 << 20
+}
+
+func (a *Aggregator) concurrency() int {
+	if a.Concurrency > 0 {
+		return a.Concurrency
+	}
+	return 4
+}
+
+func (a *Aggregator) logf(format string, args ...any) {
+	if a.Logf != nil {
+		a.Logf(format, args...)
+	}
+}
+``
+/*
+
+---
+
+# 5️⃣ OPML (`feed/opml.go`)
+
+OPML'de abonelikler `xmlUrl` özniteliği olan `<outline>` elemanlarıdır. `xmlUrl`'si olmayan outline'lar klasördür ve iç içe olabilir. Klasör yolu `Category`'ye `"Haber/Yerel"` biçiminde yazılır.
+
+📌 Bazı araçlar `xmlUrl` yerine `xmlurl` yazıyor ve `encoding/xml` öznitelik adlarında büyük/küçük harf ayırıyor. Öznitelikler bu yüzden `xml:",any,attr"` ile toplu alınıp `strings.EqualFold` ile aranıyor.
+*/
+``go
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// OPML, besleme okuyucularının abonelik listesini dışa/içe aktarma
+// formatıdır. Abonelikler <outline xmlUrl=".."> elemanlarıdır; xmlUrl'si
+// olmayan outline'lar klasördür ve iç içe olabilir.
+type opml struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    struct {
+		Title string `xml:"title,omitempty"`
+	} `xml:"head"`
+	Body struct {
+		Outlines []outline `xml:"outline"`
+	} `xml:"body"`
+}
+
+// outline'ın öznitelikleri tek tek alan olarak değil, toplu alınır:
+// bazı araçlar "xmlUrl" yerine "xmlurl" ya da "XMLURL" yazıyor ve
+// encoding/xml öznitelik adlarında büyük/küçük harf ayırıyor.
+type outline struct {
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Outlines []outline  `xml:"outline"`
+}
+
+func (o *outline) attr(name string) string {
+	for _, a := range o.Attrs {
+		if strings.EqualFold(a.Name.Local, name) {
+			return strings.TrimSpace(a.Value)
+		}
+	}
+	return ""
+}
+
+// ParseOPML, OPML dosyasındaki abonelikleri döner. Klasör adları "/" ile
+// birleştirilip Category'ye yazılır. Aynı URL birden fazla klasörde
+// geçiyorsa ilki alınır.
+func ParseOPML(r io.Reader) ([]Subscription, error) {
+	var doc opml
+	dec := xml.NewDecoder(r)
+	dec.CharsetReader = charsetReader
+	dec.Entity = xml.HTMLEntity
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("opml: %w", err)
+	}
+	var subs []Subscription
+	seen := map[string]bool{}
+	var walk func(list []outline, category string)
+	walk = func(list []outline, category string) {
+		for i := range list {
+			o := &list[i]
+			name := firstNonEmpty(o.attr("title"), o.attr("text"))
+			if u := o.attr("xmlUrl"); u != "" {
+				if !seen[u] {
+					seen[u] = true
+					subs = append(subs, Subscription{URL: u, Title: name, Category: category})
+				}
+				continue
+			}
+			sub := name
+			if category != "" {
+				sub = category + "/" + name
+			}
+			walk(o.Outlines, sub)
+		}
+	}
+	walk(doc.Body.Outlines, "")
+	return subs, nil
+}
+
+// WriteOPML, abonelikleri kategorilerine göre klasörlenmiş OPML olarak
+// yazar.
+func WriteOPML(w io.Writer, title string, subs []Subscription) error {
+	var doc opml
+	doc.Version = "2.0"
+	doc.Head.Title = title
+	for _, s := range subs {
+		list := &doc.Body.Outlines
+		if s.Category != "" {
+			for _, name := range strings.Split(s.Category, "/") {
+				list = &folder(list, name).Outlines
+			}
+		}
+		*list = append(*list, outline{Attrs: []xml.Attr{
+			{Name: xml.Name{Local: "type"}, Value: "rss"},
+			{Name: xml.Name{Local: "text"}, Value: s.Title},
+			{Name: xml.Name{Local: "title"}, Value: s.Title},
+			{Name: xml.Name{Local: "xmlUrl"}, Value: s.URL},
+		}})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// folder, list içinde name adlı klasörü bulur, yoksa ekler.
+func folder(list *[]outline, name string) *outline {
+	for i := range *list {
+		o := &(*list)[i]
+		if o.attr("xmlUrl") == "" && o.attr("text") == name {
+			return o
+		}
+	}
+	*list = append(*list, outline{Attrs: []xml.Attr{{Name: xml.Name{Local: "text"}, Value: name}}})
+	return &(*list)[len(*list)-1]
+}
+``
+/*
+
+---
+
+# 6️⃣ Birleşik Atom Çıktısı (`feed/atom.go`)
+
+Okuma için kullanılan struct'lar her türlü girdiyi kabul edecek kadar gevşek. Yazma için ise RFC 4287'nin zorunlu alanlarını (`id`, `title`, `updated`, yazar) eksiksiz üreten ayrı tipler var.
+
+Her girdiye bir `<source>` eklenir. Böylece okuyucu, yazının hangi beslemeden geldiğini gösterebilir.
+*/
+``go
+package feed
+
+import (
+	"encoding/xml"
+	"io"
+	"strconv"
+	"time"
+)
+
+// AtomMeta, birleşik beslemenin kendi bilgileridir.
+type AtomMeta struct {
+	ID     string // kalıcı bir IRI; genelde Self ile aynı
+	Title  string
+	Self   string // beslemenin yayınlandığı adres
+	Author string
+}
+
+// Atom çıktısı için ayrı tipler: okuma tipleri her türlü girdiyi kabul
+// edecek şekilde gevşek, yazma tipleri ise RFC 4287'nin zorunlu
+// alanlarını eksiksiz üretecek şekilde sıkı.
+type atomOut struct {
+	XMLName xml.Name       `xml:"http://www.w3.org/2005/Atom feed"`
+	ID      string         `xml:"id"`
+	Title   string         `xml:"title"`
+	Updated string         `xml:"updated"`
+	Links   []atomLinkOut  `xml:"link"`
+	Author  *atomPersonOut `xml:"author"`
+	Entries []atomEntryOut `xml:"entry"`
+}
+
+type atomEntryOut struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published,omitempty"`
+	Links      []atomLinkOut  `xml:"link"`
+	Author     *atomPersonOut `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+	Summary    *atomTextOut   `xml:"summary"`
+	Content    *atomTextOut   `xml:"content"`
+	Source     *atomSource    `xml:"source"`
+}
+
+type atomLinkOut struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomPersonOut struct {
+	Name string `xml:"name"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+type atomTextOut struct {
+	Type string `xml:"type,attr"`
+	Body string `xml:",chardata"`
+}
+
+// atomSource, girdinin hangi beslemeden geldiğini söyler (RFC 4287
+// 4.2.11); birleşik beslemelerde okuyucu bunu kaynak adı olarak gösterir.
+type atomSource struct {
+	ID    string        `xml:"id"`
+	Title string        `xml:"title,omitempty"`
+	Links []atomLinkOut `xml:"link"`
+}
+
+// WriteAtom, öğeleri Atom 1.0 beslemesi olarak yazar. Girdi kimliği
+// Key'dir; böylece kimliği "123" olan iki farklı sitenin yazıları
+// çakışmaz ve okuyucu aynı yazıyı yoklamalar arasında tanır.
+func WriteAtom(w io.Writer, meta AtomMeta, items []Item) error {
+	out := atomOut{
+		ID:    meta.ID,
+		Title: meta.Title,
+		Links: []atomLinkOut{{Href: meta.Self, Rel: "self", Type: "application/atom+xml"}},
+	}
+	if meta.Author != "" {
+		out.Author = &atomPersonOut{Name: meta.Author}
+	}
+	var updated time.Time
+	for i := range items {
+		it := &items[i]
+		u := it.Updated
+		if u.IsZero() {
+			u = it.Date()
+		}
+		if u.After(updated) {
+			updated = u
+		}
+		e := atomEntryOut{
+			ID:      Key(it.FeedURL, it.ID),
+			Title:   it.Title,
+			Updated: atomTime(u),
+			Source: &atomSource{
+				ID:    it.FeedURL,
+				Title: it.FeedTitle,
+				Links: []atomLinkOut{{Href: it.FeedURL, Rel: "self"}},
+			},
+		}
+		if !it.Published.IsZero() {
+			e.Published = atomTime(it.Published)
+		}
+		if it.Link != "" {
+			e.Links = append(e.Links, atomLinkOut{Href: it.Link, Rel: "alternate", Type: "text/html"})
+		}
+		for _, enc := range it.Enclosures {
+			l := atomLinkOut{Href: enc.URL, Rel: "enclosure", Type: enc.Type}
+			if enc.Length > 0 {
+				l.Length = strconv.FormatInt(enc.Length, 10)
+			}
+			e.Links = append(e.Links, l)
+		}
+		// Beslemede yazar yoksa her girdinin yazarı olmalı (RFC 4287 4.1.1).
+		switch {
+		case it.Author != "":
+			e.Author = &atomPersonOut{Name: it.Author}
+		case meta.Author == "":
+			e.Author = &atomPersonOut{Name: firstNonEmpty(it.FeedTitle, it.FeedURL)}
+		}
+		for _, c := range it.Categories {
+			e.Categories = append(e.Categories, atomCategory{Term: c})
+		}
+		if it.Summary != "" {
+			e.Summary = &atomTextOut{Type: "html", Body: it.Summary}
+		}
+		if it.Content != "" {
+			e.Content = &atomTextOut{Type: "html", Body: it.Content}
+		}
+		out.Entries = append(out.Entries, e)
+	}
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	out.Updated = atomTime(updated)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(out); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func atomTime(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+``
+/*
+
+---
+
+# 7️⃣ Sunucu (`main.go`)
+
+Birleşik beslemenin ETag'i, Store'un sürüm numarasıdır. Bizi takip eden okuyucular da koşullu GET yapabilir: bir şey değişmediyse `304` döneriz.
+*/
+``go
+// feedagg, OPML'deki abonelikleri yoklar ve hepsini tek bir Atom
+// beslemesi olarak sunar.
+//
+//	GET  /feed.atom           birleşik besleme (?n=50)
+//	GET  /subscriptions.opml  abonelikleri dışa aktar
+//	POST /subscriptions.opml  OPML içe aktar
+//	GET  /status              yoklama durumu
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"feedagg/feed"
+)
+
+func main() {
+	var (
+		addr     = flag.String("addr", ":8080", "dinlenecek adres")
+		opmlPath = flag.String("opml", "", "başlangıçta içe aktarılacak OPML dosyası")
+		interval = flag.Duration("interval", 15*time.Minute, "yoklama aralığı")
+		maxAge   = flag.Duration("max-age", 30*24*time.Hour, "bundan eski öğeler tutulmaz")
+		public   = flag.String("public", "http://localhost:8080", "dışarıdan görünen adres (self link için)")
+	)
+	flag.Parse()
+
+	agg := &feed.Aggregator{
+		Store:     feed.NewStore(*maxAge),
+		Interval:  *interval,
+		UserAgent: "feedagg/1.0 (+" + *public + ")",
+		Logf:      log.Printf,
+	}
+	if *opmlPath != "" {
+		f, err := os.Open(*opmlPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		subs, err := feed.ParseOPML(f)
+		f.Close()
+		if err != nil {
+			log.Fatal(err)
+		}
+		agg.Subscribe(subs...)
+		log.Printf("%s: %d abonelik", *opmlPath, len(subs))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go agg.Run(ctx)
+
+	s := &server{agg: agg, public: strings.TrimSuffix(*public, "/")}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /feed.atom", s.feed)
+	mux.HandleFunc("GET /subscriptions.opml", s.exportOPML)
+	mux.HandleFunc("POST /subscriptions.opml", s.importOPML)
+	mux.HandleFunc("GET /status", s.status)
+
+	srv := &http.Server{Addr: *addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+	log.Printf("%s dinleniyor", *addr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		log.Fatal(err)
+	}
+}
+
+type server struct {
+	agg    *feed.Aggregator
+	public string
+}
+
+// feed, birleşik beslemeyi yazar. Store her değiştiğinde sürümü artar;
+// sürüm ETag olarak verilir ve okuyucular da bizi koşullu GET ile yoklar.
+func (s *server) feed(w http.ResponseWriter, r *http.Request) {
+	n := 50
+	if v := r.URL.Query().Get("n"); v != "" {
+		var err error
+		if n, err = strconv.Atoi(v); err != nil || n <= 0 || n > 1000 {
+			http.Error(w, "n 1..1000 olmalı", http.StatusBadRequest)
+			return
+		}
+	}
+	etag := fmt.Sprintf(`"v%d-%d"`, s.agg.Store.Version(), n)
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	self := s.public + "/feed.atom"
+	meta := feed.AtomMeta{ID: self, Title: "feedagg", Self: self}
+	if err := feed.WriteAtom(w, meta, s.agg.Store.Items(n)); err != nil {
+		log.Print(err)
+	}
+}
+
+func (s *server) exportOPML(w http.ResponseWriter, r *http.Request) {
+	subs := s.agg.Subscriptions()
+	slices.SortFunc(subs, func(a, b feed.Subscription) int {
+		return strings.Compare(a.Category+"/"+a.Title, b.Category+"/"+b.Title)
+	})
+	w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="subscriptions.opml"`)
+	feed.WriteOPML(w, "feedagg abonelikleri", subs)
+}
+
+func (s *server) importOPML(w http.ResponseWriter, r *http.Request) {
+	subs, err := feed.ParseOPML(http.MaxBytesReader(w, r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	before := len(s.agg.Subscriptions())
+	s.agg.Subscribe(subs...)
+	fmt.Fprintf(w, "%d abonelik okundu, %d yeni\n", len(subs), len(s.agg.Subscriptions())-before)
+}
+
+func (s *server) status(w http.ResponseWriter, r *http.Request) {
+	subs := s.agg.Subscriptions()
+	slices.SortFunc(subs, func(a, b feed.Subscription) int { return strings.Compare(a.URL, b.URL) })
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "%d öğe, sürüm %d\n\n", s.agg.Store.Len(), s.agg.Store.Version())
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BESLEME\tSON\tSONRAKİ\tHATA")
+	for _, sub := range subs {
+		last := "-"
+		if !sub.LastPoll.IsZero() {
+			last = sub.LastPoll.Format("15:04:05")
+		}
+		errText := sub.LastError
+		if sub.Failures > 0 {
+			errText = fmt.Sprintf("%d× %s", sub.Failures, sub.LastError)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", sub.Title, last, sub.NextPoll.Format("15:04:05"), errText)
+	}
+	tw.Flush()
+}
+``
+/*
+
+---
+
+# 8️⃣ Testler
+
+Testler internete çıkmaz. `httptest.Server`, `testdata` altındaki dosyaları içerikten türetilen bir ETag ile sunar ve koşullu istekleri `304` ile yanıtlar.
+
+## 📂 `feed/testdata/rss2.xml`
+
+Göreli link, `isPermaLink="false"` guid, `EST` bölgesi, `&nbsp;` ve iki haneli yıl içeren bir podcast beslemesi:
+*/
+``xml
+<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0"
+     xmlns:atom="http://www.w3.org/2005/Atom"
+     xmlns:content="http://purl.org/rss/1.0/modules/content/"
+     xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel>
+    <title>Gopher Podcast</title>
+    <link>https://podcast.example.com/</link>
+    <atom:link href="https://podcast.example.com/feed.xml" rel="self" type="application/rss+xml"/>
+    <description>Go&nbsp;üzerine sohbetler</description>
+    <lastBuildDate>Tue, 14 Oct 2025 09:00:00 GMT</lastBuildDate>
+    <item>
+      <title>Bölüm 42: Generics</title>
+      <link>/bolum/42</link>
+      <guid isPermaLink="false">gopher-42</guid>
+      <pubDate>Tue, 14 Oct 2025 08:30:00 EST</pubDate>
+      <dc:creator>Ayşe Yılmaz</dc:creator>
+      <category>go</category>
+      <category> generics </category>
+      <description><![CDATA[<p>Tip parametreleri &amp; kısıtlar</p>]]></description>
+      <content:encoded><![CDATA[<p>Uzun <b>notlar</b></p>]]></content:encoded>
+      <enclosure url="https://cdn.example.com/42.mp3" length="31457280" type="audio/mpeg"/>
+    </item>
+    <item>
+      <title>Bölüm 41: Context</title>
+      <guid>https://podcast.example.com/bolum/41</guid>
+      <pubDate>Thu, 9 Oct 25 10:00 +0300</pubDate>
+      <description>context.Context neden ilk parametre?</description>
+      <enclosure url="https://cdn.example.com/41.mp3" length="28000000" type="audio/mpeg"/>
+    </item>
+  </channel>
+</rss>
+``
+/*
+## 📂 `feed/testdata/rss1.rdf`
+*/
+``xml
+<?xml version="1.0" encoding="UTF-8"?>
+<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+         xmlns="http://purl.org/rss/1.0/"
+         xmlns:dc="http://purl.org/dc/elements/1.1/">
+  <channel rdf:about="https://haber.example.org/rss.rdf">
+    <title>Örnek Haber</title>
+    <link>https://haber.example.org/</link>
+    <description>RSS 1.0 örneği</description>
+    <dc:date>2025-10-14T07:00:00+03:00</dc:date>
+    <items>
+      <rdf:Seq>
+        <rdf:li rdf:resource="https://haber.example.org/2025/10/deprem-tatbikati"/>
+      </rdf:Seq>
+    </items>
+  </channel>
+  <item rdf:about="https://haber.example.org/2025/10/deprem-tatbikati">
+    <title>Okullarda deprem tatbikatı</title>
+    <link>https://haber.example.org/2025/10/deprem-tatbikati</link>
+    <description>Tatbikat saat 10.00'da yapılacak.</description>
+    <dc:creator>Haber Masası</dc:creator>
+    <dc:subject>eğitim</dc:subject>
+    <dc:date>2025-10-14T06:45:00+03:00</dc:date>
+  </item>
+</rdf:RDF>
+``
+/*
+## 📂 `feed/testdata/atom.xml`
+
+HTML başlık, `xhtml` içerik, beslemeden miras yazar ve video eki içeriyor:
+*/
+``xml
+<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom" xml:lang="tr">
+  <id>tag:blog.example.net,2025:feed</id>
+  <title type="html">Gopher &lt;em&gt;Günlüğü&lt;/em&gt;</title>
+  <updated>2025-10-13T18:00:00Z</updated>
+  <link rel="alternate" href="https://blog.example.net/"/>
+  <link rel="self" href="https://blog.example.net/atom.xml"/>
+  <author><name>Mehmet Demir</name></author>
+  <entry>
+    <id>tag:blog.example.net,2025:iter</id>
+    <title>Range-over-func ile iteratörler</title>
+    <link href="https://blog.example.net/iteratorler"/>
+    <published>2025-10-13T17:30:00.123+03:00</published>
+    <updated>2025-10-13T18:00:00Z</updated>
+    <category term="go" label="Go"/>
+    <summary>iter.Seq &amp; iter.Seq2</summary>
+    <content type="xhtml"><div xmlns="http://www.w3.org/1999/xhtml"><p>İlk <code>yield</code></p></div></content>
+  </entry>
+  <entry>
+    <id>tag:blog.example.net,2025:sunum</id>
+    <title>Sunum kaydı</title>
+    <link rel="alternate" type="text/html" href="https://blog.example.net/sunum"/>
+    <link rel="enclosure" type="video/mp4" length="1048576" href="https://blog.example.net/sunum.mp4"/>
+    <updated>2025-10-01T09:00:00Z</updated>
+    <author><name>Zeynep Kaya</name></author>
+    <content type="html">&lt;p&gt;Video ektedir.&lt;/p&gt;</content>
+  </entry>
+</feed>
+``
+/*
+## 📂 `feed/testdata/subs.opml`
+
+İç içe klasörler, küçük harfli `xmlurl` ve iki klasörde tekrar eden bir abonelik içeriyor:
+*/
+``xml
+<?xml version="1.0" encoding="UTF-8"?>
+<opml version="1.0">
+  <head><title>Aboneliklerim</title></head>
+  <body>
+    <outline text="Go">
+      <outline text="Gopher Podcast" type="rss" xmlUrl="https://podcast.example.com/feed.xml"/>
+      <outline text="Blog" title="Gopher Günlüğü" type="rss" xmlUrl="https://blog.example.net/atom.xml"/>
+    </outline>
+    <outline text="Haber">
+      <outline text="Yerel">
+        <outline text="Örnek Haber" type="rss" xmlurl="https://haber.example.org/rss.rdf"/>
+      </outline>
+      <outline text="Tekrar" type="rss" xmlUrl="https://podcast.example.com/feed.xml"/>
+    </outline>
+  </body>
+</opml>
+``
+/*
+## 📂 `feed/testdata/latin5.xml`
+
+Bu dosya ISO-8859-9 ile kodlandığı için `iconv` ile üretiliyor:
+
+```bash
+iconv -f UTF-8 -t ISO-8859-9 > testdata/latin5.xml <<'XML'
+<?xml version="1.0" encoding="ISO-8859-9"?>
+<rss version="2.0"><channel><title>Türkçe Şişli Ağ</title><link>https://eski.example.com.tr/</link>
+<item><title>İğne ile kuyu kazmak</title><link>https://eski.example.com.tr/1</link><pubDate>Mon, 13 Oct 2025 12:00:00 +0300</pubDate></item>
+</channel></rss>
+XML
+```
+
+## 🧪 `feed/feed_test.go`
+*/
+``go
+package feed
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func parseFile(t *testing.T, name string) *Feed {
+	t.Helper()
+	f, err := os.Open("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	feed, err := Parse(f)
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return feed
+}
+
+func TestParseFormats(t *testing.T) {
+	tests := []struct {
+		file, format, title, self string
+		items                     int
+		first                     Item
+	}{
+		{
+			file: "rss2.xml", format: "rss2", title: "Gopher Podcast",
+			self: "https://podcast.example.com/feed.xml", items: 2,
+			first: Item{
+				ID:         "gopher-42",
+				Title:      "Bölüm 42: Generics",
+				Link:       "/bolum/42", // göreli; ResolveLinks'te çözülür
+				Summary:    "<p>Tip parametreleri &amp; kısıtlar</p>",
+				Content:    "<p>Uzun <b>notlar</b></p>",
+				Author:     "Ayşe Yılmaz",
+				Categories: []string{"go", "generics"},
+				Published:  time.Date(2025, 10, 14, 13, 30, 0, 0, time.UTC), // EST = -5
+				Enclosures: []Enclosure{{URL: "https://cdn.example.com/42.mp3", Type: "audio/mpeg", Length: 31457280}},
+			},
+		},
+		{
+			file: "rss1.rdf", format: "rss1", title: "Örnek Haber",
+			self: "https://haber.example.org/rss.rdf", items: 1,
+			first: Item{
+				ID:         "https://haber.example.org/2025/10/deprem-tatbikati",
+				Title:      "Okullarda deprem tatbikatı",
+				Link:       "https://haber.example.org/2025/10/deprem-tatbikati",
+				Summary:    "Tatbikat saat 10.00'da yapılacak.",
+				Author:     "Haber Masası",
+				Categories: []string{"eğitim"},
+				Published:  time.Date(2025, 10, 14, 3, 45, 0, 0, time.UTC),
+			},
+		},
+		{
+			file: "atom.xml", format: "atom", title: "Gopher Günlüğü",
+			self: "https://blog.example.net/atom.xml", items: 2,
+			first: Item{
+				ID:         "tag:blog.example.net,2025:iter",
+				Title:      "Range-over-func ile iteratörler",
+				Link:       "https://blog.example.net/iteratorler",
+				Summary:    "iter.Seq &amp; iter.Seq2",
+				Content:    `<div xmlns="http://www.w3.org/1999/xhtml"><p>İlk <code>yield</code></p></div>`,
+				Author:     "Mehmet Demir", // beslemeden miras
+				Categories: []string{"Go"},
+				Published:  time.Date(2025, 10, 13, 14, 30, 0, 123e6, time.UTC),
+				Updated:    time.Date(2025, 10, 13, 18, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			f := parseFile(t, tt.file)
+			if f.Format != tt.format || f.Title != tt.title || f.Self != tt.self || len(f.Items) != tt.items {
+				t.Fatalf("besleme = %s %q %q, %d öğe", f.Format, f.Title, f.Self, len(f.Items))
+			}
+			got, want := f.Items[0], tt.first
+			if !got.Published.Equal(want.Published) || !got.Updated.Equal(want.Updated) {
+				t.Errorf("tarih = %v / %v, beklenen %v / %v", got.Published, got.Updated, want.Published, want.Updated)
+			}
+			got.Published, got.Updated, want.Published, want.Updated = time.Time{}, time.Time{}, time.Time{}, time.Time{}
+			if g, w := dump(got), dump(want); g != w {
+				t.Errorf("öğe\n got: %s\nwant: %s", g, w)
+			}
+		})
+	}
+}
+
+func dump(it Item) string {
+	var b strings.Builder
+	WriteAtom(&b, AtomMeta{}, []Item{it}) // alan karşılaştırması için okunaklı bir döküm
+	return b.String()
+}
+
+func TestRSS2Details(t *testing.T) {
+	f := parseFile(t, "rss2.xml")
+	// guid bir URL ve isPermaLink varsayılan (true): link yoksa guid kullanılır.
+	if it := f.Items[1]; it.Link != "https://podcast.example.com/bolum/41" {
+		t.Errorf("permalink guid: %q", it.Link)
+	}
+	want := time.Date(2025, 10, 9, 7, 0, 0, 0, time.UTC) // iki haneli yıl, saniyesiz
+	if got := f.Items[1].Published; !got.Equal(want) {
+		t.Errorf("tarih = %v, beklenen %v", got, want)
+	}
+	if f.Link != "https://podcast.example.com/" {
+		t.Errorf("kanal linki atom:link ile karıştı: %q", f.Link)
+	}
+}
+
+func TestCharset(t *testing.T) {
+	f := parseFile(t, "latin5.xml")
+	if f.Title != "Türkçe Şişli Ağ" || f.Items[0].Title != "İğne ile kuyu kazmak" {
+		t.Errorf("ISO-8859-9: %q / %q", f.Title, f.Items[0].Title)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	utc := func(s string) time.Time { t, _ := time.Parse(time.RFC3339, s); return t }
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"Tue, 14 Oct 2025 09:00:00 GMT", utc("2025-10-14T09:00:00Z")},
+		{"Tue, 14 Oct 2025 09:00:00 +0300", utc("2025-10-14T06:00:00Z")},
+		{"Tues, 14 Oct 2025 09:00:00 PDT", utc("2025-10-14T16:00:00Z")}, // hatalı gün adı
+		{"14 Oct 2025 09:00 EST", utc("2025-10-14T14:00:00Z")},
+		{"Tue, 14 Oct 25 09:00:00 UT", utc("2025-10-14T09:00:00Z")},
+		{"Tuesday, 14 October 2025 09:00:00 +0000", utc("2025-10-14T09:00:00Z")},
+		{"2025-10-14T09:00:00.5+02:00", utc("2025-10-14T07:00:00.5Z")},
+		{"2025-10-14T09:00+02:00", utc("2025-10-14T07:00:00Z")},
+		{"2025-10-14", utc("2025-10-14T00:00:00Z")},
+		{"dün", time.Time{}},
+		{"", time.Time{}},
+	}
+	for _, tt := range tests {
+		if got := parseDate(tt.in); !got.Equal(tt.want) {
+			t.Errorf("parseDate(%q) = %v, beklenen %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseOPML(t *testing.T) {
+	f, err := os.Open("testdata/subs.opml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	subs, err := ParseOPML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Subscription{
+		{URL: "https://podcast.example.com/feed.xml", Title: "Gopher Podcast", Category: "Go"},
+		{URL: "https://blog.example.net/atom.xml", Title: "Gopher Günlüğü", Category: "Go"},
+		{URL: "https://haber.example.org/rss.rdf", Title: "Örnek Haber", Category: "Haber/Yerel"},
+	}
+	if len(subs) != len(want) {
+		t.Fatalf("%d abonelik, beklenen %d: %+v", len(subs), len(want), subs)
+	}
+	for i := range want {
+		if subs[i] != want[i] {
+			t.Errorf("%d: %+v, beklenen %+v", i, subs[i], want[i])
+		}
+	}
+
+	// Yazıp geri okuyunca aynı liste çıkmalı.
+	var buf bytes.Buffer
+	if err := WriteOPML(&buf, "test", subs); err != nil {
+		t.Fatal(err)
+	}
+	again, err := ParseOPML(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range want {
+		if again[i] != want[i] {
+			t.Errorf("gidiş-dönüş %d: %+v", i, again[i])
+		}
+	}
+}
+
+// fixtureServer, testdata'daki dosyayı içerikten türetilen ETag ile sunar
+// ve koşullu istekleri 304 ile yanıtlar.
+type fixtureServer struct {
+	body     atomic.Pointer[[]byte]
+	requests atomic.Int32
+	full     atomic.Int32 // 200 ile yanıtlanan
+}
+
+func newFixtureServer(t *testing.T, name string) (*fixtureServer, *httptest.Server) {
+	fs := &fixtureServer{}
+	fs.set(t, name)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fs.requests.Add(1)
+		body := *fs.body.Load()
+		etag := fmt.Sprintf(`"%x"`, sha1.Sum(body))
+		w.Header().Set("ETag", etag)
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		fs.full.Add(1)
+		w.Header().Set("Content-Type", "application/rss+xml")
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return fs, srv
+}
+
+func (fs *fixtureServer) set(t *testing.T, name string) {
+	b, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.body.Store(&b)
+}
+
+func TestPollConditionalGET(t *testing.T) {
+	fs, srv := newFixtureServer(t, "rss2.xml")
+	store := NewStore(0)
+	agg := &Aggregator{Store: store}
+	agg.Subscribe(Subscription{URL: srv.URL + "/feed.xml"})
+	ctx := context.Background()
+
+	res, err := agg.Poll(ctx, srv.URL+"/feed.xml")
+	if err != nil || res.Status != 200 || res.New != 2 {
+		t.Fatalf("ilk yoklama: %+v, %v", res, err)
+	}
+	res, err = agg.Poll(ctx, srv.URL+"/feed.xml")
+	if err != nil || !res.NotModified || res.New != 0 {
+		t.Fatalf("ikinci yoklama 304 olmalıydı: %+v, %v", res, err)
+	}
+	if fs.requests.Load() != 2 || fs.full.Load() != 1 {
+		t.Errorf("istek %d, gövde %d", fs.requests.Load(), fs.full.Load())
+	}
+
+	items := store.Items(0)
+	if len(items) != 2 || items[0].ID != "gopher-42" {
+		t.Fatalf("öğeler: %+v", items)
+	}
+	// Göreli link, beslemenin adresine göre çözülmüş olmalı.
+	if want := srv.URL + "/bolum/42"; items[0].Link != want {
+		t.Errorf("link = %q, beklenen %q", items[0].Link, want)
+	}
+	if items[0].FeedTitle != "Gopher Podcast" {
+		t.Errorf("besleme adı = %q", items[0].FeedTitle)
+	}
+}
+
+func TestPollUpdatesAndDedupe(t *testing.T) {
+	fs, srv := newFixtureServer(t, "atom.xml")
+	store := NewStore(0)
+	agg := &Aggregator{Store: store}
+	mirror := srv.URL + "/ayna.xml" // aynı içerik başka bir adreste
+	agg.Subscribe(Subscription{URL: srv.URL + "/atom.xml"}, Subscription{URL: mirror})
+	ctx := context.Background()
+
+	if res, err := agg.Poll(ctx, srv.URL+"/atom.xml"); err != nil || res.New != 2 {
+		t.Fatalf("%+v, %v", res, err)
+	}
+	// Atom kimlikleri küresel (tag:): aynadaki kopyalar yeni sayılmamalı.
+	if res, err := agg.Poll(ctx, mirror); err != nil || res.New != 0 || res.Updated != 0 {
+		t.Fatalf("ayna: %+v, %v", res, err)
+	}
+
+	// Yazı güncellenirse (farklı ETag) öğe değişir ama tekrar etmez.
+	b := bytes.Replace(*fs.body.Load(), []byte("Sunum kaydı"), []byte("Sunum kaydı (altyazılı)"), 1)
+	fs.body.Store(&b)
+	res, err := agg.Poll(ctx, srv.URL+"/atom.xml")
+	if err != nil || res.New != 0 || res.Updated != 1 {
+		t.Fatalf("güncelleme: %+v, %v", res, err)
+	}
+	if store.Len() != 2 {
+		t.Fatalf("%d öğe, beklenen 2", store.Len())
+	}
+	for _, it := range store.Items(0) {
+		if it.FeedURL != srv.URL+"/atom.xml" {
+			t.Errorf("%s: kaynak %s, ilk görülen besleme olmalıydı", it.ID, it.FeedURL)
+		}
+	}
+}
+
+func TestLocalIDsDoNotCollide(t *testing.T) {
+	store := NewStore(0)
+	now := time.Now()
+	store.Add("https://a.example/feed", "A", []Item{{ID: "1", Title: "A'nın yazısı"}}, now)
+	store.Add("https://b.example/feed", "B", []Item{{ID: "1", Title: "B'nin yazısı"}}, now)
+	if store.Len() != 2 {
+		t.Fatalf("yerel guid'ler çakıştı: %d öğe", store.Len())
+	}
+}
+
+func TestPollErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		switch r.URL.Path {
+		case "/yok":
+			w.Header().Set("Retry-After", "7200")
+			http.Error(w, "bakımda", http.StatusServiceUnavailable)
+		case "/html":
+			w.Write([]byte("<html><body>besleme değil</body></html>"))
+		case "/buyuk":
+			w.Write([]byte(`<rss><channel><title>` + strings.Repeat("x", 4096) + `</title></channel></rss>`))
+		}
+	}))
+	defer srv.Close()
+	agg := &Aggregator{Store: NewStore(0), Interval: time.Minute, MaxBody: 1024}
+	for _, p := range []string{"/yok", "/html", "/buyuk"} {
+		agg.Subscribe(Subscription{URL: srv.URL + p})
+	}
+	ctx := context.Background()
+
+	before := time.Now()
+	if _, err := agg.Poll(ctx, srv.URL+"/yok"); err == nil {
+		t.Error("503 hata olmalıydı")
+	}
+	if _, err := agg.Poll(ctx, srv.URL+"/html"); err == nil || !strings.Contains(err.Error(), "tanınmayan") {
+		t.Errorf("html: %v", err)
+	}
+	if _, err := agg.Poll(ctx, srv.URL+"/buyuk"); err == nil || !strings.Contains(err.Error(), "sınır") {
+		t.Errorf("büyük: %v", err)
+	}
+	for _, s := range agg.Subscriptions() {
+		if s.Failures != 1 || s.LastError == "" {
+			t.Errorf("%s: %+v", s.URL, s)
+		}
+		if strings.HasSuffix(s.URL, "/yok") && s.NextPoll.Sub(before) < 2*time.Hour {
+			t.Errorf("Retry-After uygulanmadı: %v", s.NextPoll.Sub(before))
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	fs, srv := newFixtureServer(t, "rss1.rdf")
+	agg := &Aggregator{Store: NewStore(0), Interval: 50 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		agg.Subscribe(Subscription{URL: srv.URL}) // Run çalışırken eklenen abonelik
+	}()
+	if err := agg.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatal(err)
+	}
+	if n := fs.requests.Load(); n < 3 || fs.full.Load() != 1 {
+		t.Errorf("%d istek, %d tam yanıt", n, fs.full.Load())
+	}
+	if agg.Store.Len() != 1 {
+		t.Errorf("%d öğe", agg.Store.Len())
+	}
+}
+
+func TestRunCancelReleasesSubscriptions(t *testing.T) {
+	var hold atomic.Bool
+	hold.Store(true)
+	started := make(chan struct{}, 2)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if hold.Load() {
+			started <- struct{}{}
+			<-r.Context().Done() // Run iptal edilene kadar cevap yok
+			return
+		}
+		w.WriteHeader(http.StatusNotModified)
+	}))
+	defer srv.Close()
+
+	// Tek yoklama hakkı: ilk abonelik asılı kalırken ikincisi sırada bekler.
+	agg := &Aggregator{Store: NewStore(0), Concurrency: 1}
+	agg.Subscribe(Subscription{URL: srv.URL + "/a"}, Subscription{URL: srv.URL + "/b"})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- agg.Run(ctx) }()
+	<-started
+	cancel()
+	if err := <-done; err != context.Canceled {
+		t.Fatal(err)
+	}
+
+	// Sırada kalan abonelik "yoklanıyor" diye işaretli kalmamalı; yoksa
+	// ne Poll ne de sonraki bir Run onu bir daha yoklayabilir.
+	hold.Store(false)
+	for _, u := range []string{srv.URL + "/a", srv.URL + "/b"} {
+		if _, err := agg.Poll(context.Background(), u); err != nil {
+			t.Errorf("%s: %v", u, err)
+		}
+	}
+}
+
+func TestWriteAtomRoundTrip(t *testing.T) {
+	store := NewStore(0)
+	now := time.Date(2025, 10, 14, 12, 0, 0, 0, time.UTC)
+	for _, name := range []string{"rss2.xml", "rss1.rdf", "atom.xml"} {
+		f := parseFile(t, name)
+		store.Add("https://kaynak.example/"+name, f.Title, f.Items, now)
+	}
+	var buf bytes.Buffer
+	meta := AtomMeta{ID: "urn:example:birlesik", Title: "Birleşik", Self: "https://agg.example/feed.atom"}
+	if err := WriteAtom(&buf, meta, store.Items(0)); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Parse(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Format != "atom" || len(f.Items) != 5 {
+		t.Fatalf("%s, %d öğe", f.Format, len(f.Items))
+	}
+	// En yeni önce: rss2 bölüm 42 (13:30Z), atom iter (14:30Z 13 Ekim) ...
+	if f.Items[0].Title != "Bölüm 42: Generics" {
+		t.Errorf("ilk öğe %q", f.Items[0].Title)
+	}
+	for _, it := range f.Items {
+		if it.ID == "" || it.Author == "" || it.Updated.IsZero() {
+			t.Errorf("eksik zorunlu alan: %+v", it)
+		}
+	}
+	// Yerel guid ("gopher-42") urn'e çevrilmeli, enclosure korunmalı.
+	first := f.Items[0]
+	if !strings.HasPrefix(first.ID, "urn:sha1:") || len(first.Enclosures) != 1 || first.Enclosures[0].Length != 31457280 {
+		t.Errorf("%+v", first)
+	}
+}
+``
+/*
+
+```bash
+go test -race -v ./feed
+```
+*/
+``bash
+--- PASS: TestParseFormats (0.00s)
+    --- PASS: TestParseFormats/rss2.xml (0.00s)
+    --- PASS: TestParseFormats/rss1.rdf (0.00s)
+    --- PASS: TestParseFormats/atom.xml (0.00s)
+--- PASS: TestRSS2Details (0.00s)
+--- PASS: TestCharset (0.00s)
+--- PASS: TestParseDate (0.00s)
+--- PASS: TestParseOPML (0.00s)
+--- PASS: TestPollConditionalGET (0.00s)
+--- PASS: TestPollUpdatesAndDedupe (0.00s)
+--- PASS: TestLocalIDsDoNotCollide (0.00s)
+--- PASS: TestPollErrors (0.00s)
+--- PASS: TestRun (0.30s)
+--- PASS: TestRunCancelReleasesSubscriptions (0.00s)
+--- PASS: TestWriteAtomRoundTrip (0.01s)
+PASS
+ok  	feedagg/feed	1.347s
+``
+/*
+---
+
+# 9️⃣ Çalıştıralım
+
+Test dosyalarını yerel bir `http.FileServer` ile `127.0.0.1:9000` üzerinden sundum. `http.FileServer` ETag üretmez ama `Last-Modified` gönderir, böylece koşullu GET'in diğer yolu da denenmiş olur. Aboneliklerde `127.0.0.1:9000/...` adresleri olan bir `subs.opml` kullandım. Test beslemelerindeki tarihler bir yıldan eski olduğu için `-max-age 0` (sınırsız) verdim:
+
+```bash
+go build -o feedagg .
+./feedagg -opml subs.opml -interval 3s -max-age 0
+```
+*/
+``bash
+2026/10/18 20:57:34 subs.opml: 3 abonelik
+2026/10/18 20:57:34 :8080 dinleniyor
+2026/10/18 20:57:34 http://127.0.0.1:9000/rss2.xml: 200, 2 yeni, 0 güncellenen
+2026/10/18 20:57:34 http://127.0.0.1:9000/rss1.rdf: 200, 1 yeni, 0 güncellenen
+2026/10/18 20:57:34 http://127.0.0.1:9000/atom.xml: 200, 2 yeni, 0 güncellenen
+2026/10/18 20:57:37 http://127.0.0.1:9000/atom.xml: 304, 0 yeni, 0 güncellenen
+2026/10/18 20:57:37 http://127.0.0.1:9000/rss2.xml: 304, 0 yeni, 0 güncellenen
+2026/10/18 20:57:37 http://127.0.0.1:9000/rss1.rdf: 304, 0 yeni, 0 güncellenen
+``
+/*
+Dosya sunucusunun gördüğü istekler. İkinci turda `If-Modified-Since` gönderiliyor:
+*/
+``bash
+GET /rss2.xml INM="" IMS=""
+GET /rss2.xml INM="" IMS="Sun, 18 Oct 2026 20:57:27 GMT"
+``
+/*
+## ▶️ Birleşik besleme
+
+```bash
+curl -i 'localhost:8080/feed.atom?n=3'
+```
+*/
+``xml
+HTTP/1.1 200 OK
+Cache-Control: no-cache
+Content-Type: application/atom+xml; charset=utf-8
+Etag: "v3-3"
+
+<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <id>http://localhost:8080/feed.atom</id>
+  <title>feedagg</title>
+  <updated>2025-10-14T13:30:00Z</updated>
+  <link href="http://localhost:8080/feed.atom" rel="self" type="application/atom+xml"></link>
+  <entry>
+    <id>urn:sha1:e4da7eec98503ec8594ce72ad5252f6e13e7e98d</id>
+    <title>Bölüm 42: Generics</title>
+    <updated>2025-10-14T13:30:00Z</updated>
+    <published>2025-10-14T13:30:00Z</published>
+    <link href="http://127.0.0.1:9000/bolum/42" rel="alternate" type="text/html"></link>
+    <link href="https://cdn.example.com/42.mp3" rel="enclosure" type="audio/mpeg" length="31457280"></link>
+    <author>
+      <name>Ayşe Yılmaz</name>
+    </author>
+    <category term="go"></category>
+    <category term="generics"></category>
+    <summary type="html">&lt;p&gt;Tip parametreleri &amp;amp; kısıtlar&lt;/p&gt;</summary>
+    <content type="html">&lt;p&gt;Uzun &lt;b&gt;notlar&lt;/b&gt;&lt;/p&gt;</content>
+    <source>
+      <id>http://127.0.0.1:9000/rss2.xml</id>
+      <title>Gopher Podcast</title>
+      <link href="http://127.0.0.1:9000/rss2.xml" rel="self"></link>
+    </source>
+  </entry>
+  <entry>
+    <id>https://haber.example.org/2025/10/deprem-tatbikati</id>
+    <title>Okullarda deprem tatbikatı</title>
+    ...
+  </entry>
+  <entry>
+    <id>tag:blog.example.net,2025:iter</id>
+    <title>Range-over-func ile iteratörler</title>
+    ...
+  </entry>
+</feed>
+``
+/*
+Dikkat edilecekler:
+
+* `08:30 EST` → `13:30Z`. Bölge tablosu olmasaydı `08:30Z` olurdu.
+* Göreli `/bolum/42` linki beslemenin adresine göre çözüldü.
+* Yerel guid `gopher-42` küresel bir `urn:sha1:` kimliğine çevrildi.
+* Her girdi `<source>` ile geldiği beslemeyi söylüyor.
+
+📌 `encoding/xml` boş elemanları `<link/>` biçiminde kısaltmaz, `<link ...></link>` yazar. İkisi de geçerli XML'dir.
+
+## ▶️ Güncelleme ve koşullu GET
+
+`atom.xml` içinde bir başlığı değiştirdim (`Sunum kaydı` → `Sunum kaydı (altyazılı)`). Bir sonraki turda yalnızca o besleme `200` döndü ve öğe **güncellendi**, yeni öğe eklenmedi:
+*/
+``bash
+2026/10/18 20:57:43 http://127.0.0.1:9000/rss2.xml: 304, 0 yeni, 0 güncellenen
+2026/10/18 20:57:43 http://127.0.0.1:9000/atom.xml: 200, 0 yeni, 1 güncellenen
+2026/10/18 20:57:43 http://127.0.0.1:9000/rss1.rdf: 304, 0 yeni, 0 güncellenen
+``
+/*
+Bizim beslememiz de koşullu GET'i destekliyor:
+
+```bash
+curl -s -o /dev/null -w '%{http_code}\n' -H 'If-None-Match: "v4-50"' localhost:8080/feed.atom
+304
+```
+
+## ▶️ OPML içe aktarma ve durum
+
+Biri olmayan, diğeri zaten abone olunan iki besleme gönderiyoruz:
+
+```bash
+printf '<opml version="1.0"><body><outline text="Ölü" xmlUrl="http://127.0.0.1:9000/yok.xml"/><outline text="Tekrar" xmlUrl="http://127.0.0.1:9000/rss2.xml"/></body></opml>' \
+  | curl -s --data-binary @- localhost:8080/subscriptions.opml
+2 abonelik okundu, 1 yeni
+
+curl -s localhost:8080/status
+```
+*/
+``bash
+5 öğe, sürüm 4
+
+BESLEME         SON       SONRAKİ   HATA
+Gopher Günlüğü  20:57:46  20:57:49  
+Örnek Haber     20:57:46  20:57:49  
+Gopher Podcast  20:57:46  20:57:49  
+Ölü             20:57:46  20:57:49  1× HTTP 404 Not Found
+``
+/*
+Dışa aktarılan OPML, klasörleri koruyor:
+
+```bash
+curl -s localhost:8080/subscriptions.opml
+```
+*/
+``xml
+<?xml version="1.0" encoding="UTF-8"?>
+<opml version="2.0">
+  <head>
+    <title>feedagg abonelikleri</title>
+  </head>
+  <body>
+    <outline type="rss" text="Ölü" title="Ölü" xmlUrl="http://127.0.0.1:9000/yok.xml"></outline>
+    <outline text="Go">
+      <outline type="rss" text="Gopher Günlüğü" title="Gopher Günlüğü" xmlUrl="http://127.0.0.1:9000/atom.xml"></outline>
+      <outline type="rss" text="Gopher Podcast" title="Gopher Podcast" xmlUrl="http://127.0.0.1:9000/rss2.xml"></outline>
+    </outline>
+    <outline text="Haber">
+      <outline text="Yerel">
+        <outline type="rss" text="Örnek Haber" title="Örnek Haber" xmlUrl="http://127.0.0.1:9000/rss1.rdf"></outline>
+      </outline>
+    </outline>
+  </body>
+</opml>
+``
+/*
+---
+
+# ⚠️ Sınırlar
+
+* Öğeler bellekte tutuluyor. Program yeniden başlarsa her şey yeniden indirilir ve ETag'ler kaybolur. Kalıcılık için `Store` ve `Subscription` durumu bir dosyaya ya da veritabanına yazılmalı.
+* Tarihi olmayan öğeler için ilk görülme zamanı kullanılır. Böyle bir öğe `MaxAge` dolunca silinir. Besleme onu hâlâ listeliyorsa bir sonraki yoklamada tekrar "yeni" sayılır.
+* HTML içerik **temizlenmiyor** (sanitize). Birleşik besleme doğrudan bir web sayfasında gösterilecekse `<script>` gibi etiketler ayıklanmalı.
+* Karakter kodlaması olarak yalnızca UTF-8 ve tek byte'lık Latin/Windows kod sayfaları destekleniyor.
+* Kalıcı yönlendirmede (`301`) abonelik adresi güncellenmiyor. İstek her seferinde eski adrese gidip yönlendiriliyor.
+
+---
+
+👉 İstersen bir sonraki adımda `Store`'u **SQLite** (`database/sql`) ile kalıcı hale getirelim: öğeler, okundu/okunmadı bilgisi ve ETag'ler yeniden başlatmadan sonra da korunsun. Bunu ister misin?
+*/
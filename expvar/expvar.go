@@ -494,4 +494,725 @@ func main() {
 
 ✅ Böylece `expvar` paketinin **tüm API’sini** ve nasıl kullanıldığını gördük.
 Bu bilgiyi istersen ben sana **PDF olarak bir cheatsheet / özet** hazırlayıp verebilirim. İstiyor musun?
-*/
\ No newline at end of file
+*/
+
+/*
+---
+
+## 8. **Kendi Metrik Tiplerimiz: Histogram, Gauge, Etiketli Sayaç ve Prometheus Çıktısı**
+
+Yukarıdaki tam uygulama sadece `expvar.Int`, `expvar.Map` ve `expvar.Func` kullanıyor. Pratikte şunlara da ihtiyaç duyarız:
+
+* **Gauge** → yukarı/aşağı gidebilen anlık değer (aktif istek sayısı, kuyruk uzunluğu).
+* **Histogram** → gecikme gibi değerlerin dağılımı (p50/p99 hesaplamak için kovalar).
+* **Etiketli sayaç (CounterVec)** → `http_requests_total{route="/",code="200"}` gibi boyutlu sayaçlar.
+
+`expvar.Var` arayüzü sadece tek bir metot ister:
+
+``go
+type Var interface {
+	String() string // geçerli bir JSON değeri döndürmeli
+}
+``
+
+Yani bu metodu uygulayan **her tip** `expvar.Publish` ile yayınlanabilir ve `/debug/vars` çıktısında otomatik görünür.
+
+Dashboard’larımız ise expvar JSON’unu değil **Prometheus** formatını okuyor. Bu yüzden aynı değişkenleri `/metrics` altında Prometheus text formatında da sunacağız. Bunun için `expvar.Do` ile yayınlanmış tüm değişkenleri dolaşıp tiplerine göre yazıyoruz.
+*/
+``go
+expvar.Do(func(kv expvar.KeyValue) {
+	// kv.Key   → değişken adı
+	// kv.Value → expvar.Var
+})
+``
+/*
+---
+
+### Tam Kod
+*/
+``go
+package main
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// Metrik tipleri (hepsi expvar.Var arayüzünü uygular → /debug/vars'ta da görünür)
+// ---------------------------------------------------------------
+
+// promVar, kendini Prometheus text formatında yazabilen değişkenlerdir.
+type promVar interface {
+	expvar.Var
+	writeProm(w io.Writer, name string)
+}
+
+// help metinleri ve tipleri; Publish sırasında kaydedilir
+var helps sync.Map // name → string
+
+// Gauge, yukarı/aşağı gidebilen anlık bir değerdir (ör: aktif bağlantı sayısı).
+type Gauge struct {
+	bits atomic.Uint64
+}
+
+func NewGauge(name, help string) *Gauge {
+	g := new(Gauge)
+	publish(name, help, g)
+	return g
+}
+
+func (g *Gauge) Set(v float64) { g.bits.Store(math.Float64bits(v)) }
+
+func (g *Gauge) Add(delta float64) {
+	for {
+		old := g.bits.Load()
+		nv := math.Float64bits(math.Float64frombits(old) + delta)
+		if g.bits.CompareAndSwap(old, nv) {
+			return
+		}
+	}
+}
+
+func (g *Gauge) Value() float64 { return math.Float64frombits(g.bits.Load()) }
+
+func (g *Gauge) String() string {
+	b, _ := json.Marshal(jsonFloat(g.Value()))
+	return string(b)
+}
+
+func (g *Gauge) writeProm(w io.Writer, name string) {
+	fmt.Fprintf(w, "# TYPE %s gauge\n%s %s\n", name, name, formatFloat(g.Value()))
+}
+
+// Histogram, gözlemleri yapılandırılabilir kovalara dağıtır.
+type Histogram struct {
+	mu      sync.Mutex
+	buckets []float64 // üst sınırlar (artan sırada)
+	counts  []uint64  // her kovanın kendi sayısı (kümülatif değil)
+	sum     float64
+	count   uint64
+}
+
+// DefBuckets, HTTP gecikmeleri için makul varsayılan kovalardır (saniye).
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+func newHistogram(buckets []float64) *Histogram {
+	b := slices.Clone(buckets)
+	slices.Sort(b)
+	return &Histogram{buckets: b, counts: make([]uint64, len(b))}
+}
+
+func NewHistogram(name, help string, buckets []float64) *Histogram {
+	h := newHistogram(buckets)
+	publish(name, help, h)
+	return h
+}
+
+func (h *Histogram) Observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.sum += v
+	h.count++
+	// v'nin sığdığı ilk kova; hiçbirine sığmazsa sadece +Inf'e sayılır
+	if i, _ := slices.BinarySearch(h.buckets, v); i < len(h.buckets) {
+		h.counts[i]++
+	}
+}
+
+// snapshot, kümülatif kova sayılarını tutarlı bir anda okur.
+func (h *Histogram) snapshot() (cum []uint64, sum float64, count uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	cum = make([]uint64, len(h.counts))
+	var acc uint64
+	for i, c := range h.counts {
+		acc += c
+		cum[i] = acc
+	}
+	return cum, h.sum, h.count
+}
+
+func (h *Histogram) String() string {
+	cum, sum, count := h.snapshot()
+	buckets := make(map[string]uint64, len(cum))
+	for i, c := range cum {
+		buckets[formatFloat(h.buckets[i])] = c
+	}
+	b, _ := json.Marshal(map[string]any{"buckets": buckets, "sum": jsonFloat(sum), "count": count})
+	return string(b)
+}
+
+func (h *Histogram) writeSeries(w io.Writer, name, labels string) {
+	cum, sum, count := h.snapshot()
+	sep := ""
+	if labels != "" {
+		sep = ","
+	}
+	for i, c := range cum {
+		fmt.Fprintf(w, "%s_bucket{%s%sle=\"%s\"} %d\n", name, labels, sep, formatFloat(h.buckets[i]), c)
+	}
+	fmt.Fprintf(w, "%s_bucket{%s%sle=\"+Inf\"} %d\n", name, labels, sep, count)
+	fmt.Fprintf(w, "%s_sum%s %s\n", name, braces(labels), formatFloat(sum))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, braces(labels), count)
+}
+
+func (h *Histogram) writeProm(w io.Writer, name string) {
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	h.writeSeries(w, name, "")
+}
+
+// vec, etiket değerlerine göre alt metrikleri tutan ortak yapıdır.
+type vec[T any] struct {
+	mu     sync.RWMutex
+	labels []string
+	series map[string]*series[T]
+	newT   func() T
+}
+
+type series[T any] struct {
+	values []string
+	metric T
+}
+
+func (v *vec[T]) with(values ...string) T {
+	if len(values) != len(v.labels) {
+		panic(fmt.Sprintf("metrik: %d etiket bekleniyordu, %d verildi", len(v.labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	v.mu.RLock()
+	s, ok := v.series[key]
+	v.mu.RUnlock()
+	if ok {
+		return s.metric
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if s, ok := v.series[key]; ok {
+		return s.metric
+	}
+	s = &series[T]{values: slices.Clone(values), metric: v.newT()}
+	v.series[key] = s
+	return s.metric
+}
+
+// sorted, çıktının her seferinde aynı sırada olması için serileri sıralar.
+func (v *vec[T]) sorted() []*series[T] {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	keys := make([]string, 0, len(v.series))
+	for k := range v.series {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	out := make([]*series[T], len(keys))
+	for i, k := range keys {
+		out[i] = v.series[k]
+	}
+	return out
+}
+
+func (v *vec[T]) labelString(values []string) string {
+	parts := make([]string, len(values))
+	for i, val := range values {
+		parts[i] = fmt.Sprintf("%s=\"%s\"", v.labels[i], escapeLabel(val))
+	}
+	return strings.Join(parts, ",")
+}
+
+func (v *vec[T]) jsonString(value func(T) any) string {
+	var out []map[string]any
+	for _, s := range v.sorted() {
+		labels := make(map[string]string, len(v.labels))
+		for i, l := range v.labels {
+			labels[l] = s.values[i]
+		}
+		out = append(out, map[string]any{"labels": labels, "value": value(s.metric)})
+	}
+	b, _ := json.Marshal(out)
+	return string(b)
+}
+
+// CounterVec, etiketli sayaçlardır: http_requests_total{route="/",code="200"}.
+type CounterVec struct {
+	vec[*expvar.Int]
+}
+
+func NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{vec[*expvar.Int]{
+		labels: labels,
+		series: make(map[string]*series[*expvar.Int]),
+		newT:   func() *expvar.Int { return new(expvar.Int) },
+	}}
+	publish(name, help, c)
+	return c
+}
+
+func (c *CounterVec) WithLabelValues(values ...string) *expvar.Int { return c.with(values...) }
+
+func (c *CounterVec) String() string {
+	return c.jsonString(func(i *expvar.Int) any { return i.Value() })
+}
+
+func (c *CounterVec) writeProm(w io.Writer, name string) {
+	fmt.Fprintf(w, "# TYPE %s counter\n", name)
+	for _, s := range c.sorted() {
+		fmt.Fprintf(w, "%s{%s} %d\n", name, c.labelString(s.values), s.metric.Value())
+	}
+}
+
+// HistogramVec, etiketli histogramlardır.
+type HistogramVec struct {
+	vec[*Histogram]
+}
+
+func NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	h := &HistogramVec{vec[*Histogram]{
+		labels: labels,
+		series: make(map[string]*series[*Histogram]),
+		newT:   func() *Histogram { return newHistogram(buckets) },
+	}}
+	publish(name, help, h)
+	return h
+}
+
+func (h *HistogramVec) WithLabelValues(values ...string) *Histogram { return h.with(values...) }
+
+func (h *HistogramVec) String() string {
+	return h.jsonString(func(x *Histogram) any { return json.RawMessage(x.String()) })
+}
+
+func (h *HistogramVec) writeProm(w io.Writer, name string) {
+	fmt.Fprintf(w, "# TYPE %s histogram\n", name)
+	for _, s := range h.sorted() {
+		s.metric.writeSeries(w, name, h.labelString(s.values))
+	}
+}
+
+// ---------------------------------------------------------------
+// Yardımcılar
+// ---------------------------------------------------------------
+
+func publish(name, help string, v expvar.Var) {
+	expvar.Publish(name, v) // aynı isim iki kez kaydedilirse panic eder
+	helps.Store(name, help)
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// jsonFloat, JSON'da karşılığı olmayan NaN ve ±Inf'i Prometheus'taki gibi
+// string olarak ("NaN", "+Inf", "-Inf") döndürür. Aksi halde json.Marshal hata
+// verir ve /debug/vars çıktısı geçersiz JSON olur.
+func jsonFloat(f float64) any {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return formatFloat(f)
+	}
+	return f
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
+
+// HELP metninde sadece \ ve satır sonu kaçırılır; tırnak olduğu gibi kalır.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// promName, expvar isimlerini Prometheus kurallarına uydurur: [a-zA-Z_:][a-zA-Z0-9_:]*
+func promName(s string) string {
+	var b strings.Builder
+	for i, r := range s {
+		ok := r == '_' || r == ':' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') ||
+			(i > 0 && r >= '0' && r <= '9')
+		if ok {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// ---------------------------------------------------------------
+// /metrics: tüm expvar değişkenlerini Prometheus formatında yaz
+// ---------------------------------------------------------------
+
+func writeHelp(w io.Writer, expvarName, name string) {
+	if h, ok := helps.Load(expvarName); ok && h != "" {
+		fmt.Fprintf(w, "# HELP %s %s\n", name, helpEscaper.Replace(h.(string)))
+	}
+}
+
+func writeMemStats(w io.Writer) {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	gauges := []struct {
+		name, help string
+		value      float64
+	}{
+		{"go_memstats_alloc_bytes", "Heap'te ayrılmış ve hâlâ kullanılan byte sayısı.", float64(m.Alloc)},
+		{"go_memstats_sys_bytes", "İşletim sisteminden alınan toplam byte.", float64(m.Sys)},
+		{"go_memstats_heap_objects", "Heap'teki nesne sayısı.", float64(m.HeapObjects)},
+		{"go_memstats_heap_inuse_bytes", "Kullanımdaki heap span byte'ları.", float64(m.HeapInuse)},
+		{"go_memstats_next_gc_bytes", "Bir sonraki GC'nin tetikleneceği heap boyutu.", float64(m.NextGC)},
+		{"go_goroutines", "Çalışan goroutine sayısı.", float64(runtime.NumGoroutine())},
+	}
+	for _, g := range gauges {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %s\n", g.name, g.help, g.name, g.name, formatFloat(g.value))
+	}
+	fmt.Fprintf(w, "# HELP go_memstats_mallocs_total Toplam malloc sayısı.\n# TYPE go_memstats_mallocs_total counter\ngo_memstats_mallocs_total %d\n", m.Mallocs)
+	fmt.Fprintf(w, "# HELP go_gc_cycles_total Tamamlanan GC döngüsü sayısı.\n# TYPE go_gc_cycles_total counter\ngo_gc_cycles_total %d\n", m.NumGC)
+}
+
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	expvar.Do(func(kv expvar.KeyValue) {
+		name := promName(kv.Key)
+		switch v := kv.Value.(type) {
+		case promVar:
+			writeHelp(w, kv.Key, name)
+			v.writeProm(w, name)
+		case *expvar.Int:
+			writeHelp(w, kv.Key, name)
+			fmt.Fprintf(w, "# TYPE %s untyped\n%s %d\n", name, name, v.Value())
+		case *expvar.Float:
+			writeHelp(w, kv.Key, name)
+			fmt.Fprintf(w, "# TYPE %s untyped\n%s %s\n", name, name, formatFloat(v.Value()))
+		case *expvar.Map:
+			// expvar.Map → tek metrik, anahtar "key" etiketi olur
+			fmt.Fprintf(w, "# TYPE %s untyped\n", name)
+			v.Do(func(e expvar.KeyValue) {
+				switch n := e.Value.(type) {
+				case *expvar.Int:
+					fmt.Fprintf(w, "%s{key=\"%s\"} %d\n", name, escapeLabel(e.Key), n.Value())
+				case *expvar.Float:
+					fmt.Fprintf(w, "%s{key=\"%s\"} %s\n", name, escapeLabel(e.Key), formatFloat(n.Value()))
+				}
+			})
+		}
+		// expvar.String, expvar.Func, cmdline ve memstats (JSON) atlanır
+	})
+	writeMemStats(w)
+}
+
+// ---------------------------------------------------------------
+// HTTP middleware: route ve status başına gecikme + sayaç
+// ---------------------------------------------------------------
+
+var (
+	httpRequests = NewCounterVec("http_requests_total",
+		"Route ve status koduna göre toplam HTTP isteği.", "route", "code")
+	httpDuration = NewHistogramVec("http_request_duration_seconds",
+		"Route başına HTTP istek süresi (saniye).", DefBuckets, "route")
+	httpInFlight = NewGauge("http_requests_in_flight",
+		"Şu anda işlenmekte olan istek sayısı.")
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func Instrument(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		httpInFlight.Add(1)
+		defer httpInFlight.Add(-1)
+
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		// ServeMux eşleşen deseni r.Pattern'e yazar; ham path kullanmak
+		// /user/1, /user/2 ... gibi sınırsız etiket üretirdi.
+		route := r.Pattern
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(route, strconv.Itoa(rec.status)).Add(1)
+		httpDuration.WithLabelValues(route).Observe(time.Since(start).Seconds())
+	})
+}
+
+// ---------------------------------------------------------------
+// Uygulama (önceki "Tam Uygulama Örneği"nin metrikli hali)
+// ---------------------------------------------------------------
+
+var (
+	requests = expvar.NewInt("requests")
+	version  = expvar.NewString("version")
+	stats    = expvar.NewMap("stats")
+	start    = time.Now()
+
+	payloadSize = NewHistogram("payload_size_bytes",
+		"Yanıt gövdesi boyutu.", []float64{64, 256, 1024, 4096, 16384})
+)
+
+func main() {
+	version.Set("1.1.0")
+
+	expvar.Publish("uptime", expvar.Func(func() any {
+		return time.Since(start).String()
+	}))
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		stats.Add("hits", 1)
+		n, _ := fmt.Fprintln(w, "Hello, World!")
+		payloadSize.Observe(float64(n))
+	})
+
+	mux.HandleFunc("GET /user/{id}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		time.Sleep(20 * time.Millisecond) // yavaş bir işlem simülasyonu
+		fmt.Fprintln(w, "Kullanıcı:", r.PathValue("id"))
+	})
+
+	mux.HandleFunc("GET /error", func(w http.ResponseWriter, r *http.Request) {
+		stats.Add("errors", 1)
+		http.Error(w, "Something went wrong!", http.StatusInternalServerError)
+	})
+
+	// /debug/vars → expvar JSON (yeni tipler de burada görünür)
+	mux.Handle("GET /debug/vars", expvar.Handler())
+	// /metrics → Prometheus text formatı
+	mux.HandleFunc("GET /metrics", metricsHandler)
+
+	fmt.Println("Server running on http://localhost:8080")
+	http.ListenAndServe(":8080", Instrument(mux))
+}
+``
+/*
+---
+
+### 📝 Nasıl Çalışır?
+
+* **Gauge**
+  * Değer `float64` olduğu için `atomic.Uint64` içinde `math.Float64bits` ile saklanır.
+  * `Add` işlemi `CompareAndSwap` döngüsü ile kilitsiz yapılır (`expvar.Float.Add` da aynı yöntemi kullanır).
+* **Histogram**
+  * Kovalar yapılandırılabilir (`DefBuckets` veya kendi listen).
+  * Her gözlem `slices.BinarySearch` ile sığdığı **ilk** kovaya yazılır.
+  * Prometheus kovaları **kümülatif** ister; bu yüzden `snapshot` okurken toplamlar hesaplanır. `+Inf` kovası her zaman `count`’a eşittir.
+  * JSON’da `NaN` ve `±Inf` yoktur. Bir gözlem `sum`’ı sonsuz yaparsa `json.Marshal` hata verir, `String` boş döner ve tüm `/debug/vars` çıktısı bozulur. Bu yüzden `jsonFloat` bu değerleri `"NaN"`, `"+Inf"`, `"-Inf"` string’i olarak yazar. `Gauge.String` de aynı yolu kullanır.
+* **CounterVec / HistogramVec**
+  * Etiket değerleri `"\xff"` ile birleştirilip map anahtarı yapılır.
+  * Okuma yolu `RLock` ile hızlıdır; yeni bir seri ilk kez görüldüğünde `Lock` alınır (double-checked).
+  * Etiket sayısı yanlışsa `panic` eder. Bu bir programlama hatasıdır, çalışma zamanında düzeltilemez.
+* **Middleware (`Instrument`)**
+  * Route etiketi olarak **ham path değil**, `ServeMux`’un eşleştirdiği desen (`r.Pattern`, Go 1.22+) kullanılır. Böylece `/user/1`, `/user/2`, … ayrı ayrı seri üretmez (cardinality patlaması olmaz).
+  * Eşleşmeyen istekler `route="unmatched"` altında toplanır.
+* **`/metrics`**
+  * `promVar` arayüzünü uygulayan tipler kendilerini yazar.
+  * `# HELP` metninde `\` → `\\`, satır sonu → `\n` olarak kaçırılır; aksi halde help’teki bir satır sonu çıktıyı bozar. Etiket değerlerinde bunlara ek olarak `"` da kaçırılır.
+  * `expvar.Int` / `expvar.Float` → `untyped` metrik.
+  * `expvar.Map` → tek metrik, anahtarlar `key` etiketi olur.
+  * `expvar.String` ve `expvar.Func` atlanır, çünkü sayısal değiller.
+  * expvar’ın kendi `memstats` JSON’u yerine `runtime.ReadMemStats` ile standart `go_memstats_*` isimleri yazılır.
+  * Çıktı her seferinde aynı sırada olur (`expvar.Do` anahtarları sıralı dolaşır, seriler de sıralanır).
+
+---
+
+### 🧪 Testler
+
+`main_test.go`, JSON ve Prometheus çıktısının sınır durumlarını kontrol eder: sonsuz/NaN değerlerle `/debug/vars`’ın hâlâ geçerli JSON olması ve HELP/etiket kaçışları.
+*/
+``go
+package main
+
+import (
+	"encoding/json"
+	"expvar"
+	"math"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHistogramString(t *testing.T) {
+	h := newHistogram([]float64{1, 10})
+	for _, v := range []float64{0.5, 5, 50} {
+		h.Observe(v)
+	}
+	var got struct {
+		Buckets map[string]uint64
+		Sum     float64
+		Count   uint64
+	}
+	if err := json.Unmarshal([]byte(h.String()), &got); err != nil {
+		t.Fatalf("%s: %v", h.String(), err)
+	}
+	if got.Buckets["1"] != 1 || got.Buckets["10"] != 2 || got.Sum != 55.5 || got.Count != 3 {
+		t.Errorf("String = %s", h.String())
+	}
+}
+
+// JSON'da NaN ve ±Inf yoktur; bunlar string olarak yazılmalı, aksi halde
+// json.Marshal hata verir ve /debug/vars geçersiz JSON olur.
+func TestNonFiniteJSON(t *testing.T) {
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		h := newHistogram([]float64{1})
+		h.Observe(v)
+		var got struct{ Sum string }
+		if err := json.Unmarshal([]byte(h.String()), &got); err != nil || got.Sum != formatFloat(v) {
+			t.Errorf("Histogram(%v).String() = %q (%v)", v, h.String(), err)
+		}
+
+		g := new(Gauge)
+		g.Set(v)
+		if s := g.String(); s != `"`+formatFloat(v)+`"` {
+			t.Errorf("Gauge(%v).String() = %s", v, s)
+		}
+	}
+
+	NewHistogram("test_nonfinite_seconds", "", DefBuckets).Observe(math.Inf(1))
+	NewGauge("test_nonfinite_gauge", "").Set(math.NaN())
+	rec := httptest.NewRecorder()
+	expvar.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/debug/vars", nil))
+	if !json.Valid(rec.Body.Bytes()) {
+		t.Errorf("/debug/vars geçersiz JSON:\n%s", rec.Body)
+	}
+}
+
+func TestMetricsHelpEscape(t *testing.T) {
+	NewGauge("test_help_gauge", `C:\tmp altındaki`+"\n"+`dosya sayısı`).Set(3)
+	NewCounterVec("test_labels_total", "", "path").WithLabelValues(`a"b\c` + "\n").Add(1)
+
+	rec := httptest.NewRecorder()
+	metricsHandler(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body := rec.Body.String()
+	for _, want := range []string{
+		`# HELP test_help_gauge C:\\tmp altındaki\ndosya sayısı` + "\n# TYPE test_help_gauge gauge\ntest_help_gauge 3\n",
+		`test_labels_total{path="a\"b\\c\n"} 1` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics içinde yok:\n%s\n--- çıktı ---\n%s", want, body)
+		}
+	}
+	// Boş help satırı yazılmaz
+	if strings.Contains(body, "# HELP test_labels_total") {
+		t.Error("boş HELP yazıldı")
+	}
+}
+
+func TestPromName(t *testing.T) {
+	for in, want := range map[string]string{
+		"requests":        "requests",
+		"http.latency-ms": "http_latency_ms",
+		"9lives":          "_lives",
+		"a:b_c9":          "a:b_c9",
+	} {
+		if got := promName(in); got != want {
+			t.Errorf("promName(%q) = %q, beklenen %q", in, got, want)
+		}
+	}
+}
+``
+/*
+``bash
+$ go test -race -v .
+=== RUN   TestHistogramString
+--- PASS: TestHistogramString (0.00s)
+=== RUN   TestNonFiniteJSON
+--- PASS: TestNonFiniteJSON (0.01s)
+=== RUN   TestMetricsHelpEscape
+--- PASS: TestMetricsHelpEscape (0.00s)
+=== RUN   TestPromName
+--- PASS: TestPromName (0.00s)
+PASS
+ok  	metricsdemo	1.042s
+``
+
+---
+
+### 🔍 Çıktılar
+
+`curl http://localhost:8080/metrics`:
+
+``text
+# HELP http_request_duration_seconds Route başına HTTP istek süresi (saniye).
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{route="GET /user/{id}",le="0.005"} 0
+http_request_duration_seconds_bucket{route="GET /user/{id}",le="0.025"} 2
+...
+http_request_duration_seconds_bucket{route="GET /user/{id}",le="+Inf"} 2
+http_request_duration_seconds_sum{route="GET /user/{id}"} 0.0412
+http_request_duration_seconds_count{route="GET /user/{id}"} 2
+# HELP http_requests_total Route ve status koduna göre toplam HTTP isteği.
+# TYPE http_requests_total counter
+http_requests_total{route="GET /",code="200"} 5
+http_requests_total{route="GET /error",code="500"} 2
+http_requests_total{route="unmatched",code="404"} 1
+# TYPE requests untyped
+requests 7
+# TYPE stats untyped
+stats{key="errors"} 2
+stats{key="hits"} 5
+# HELP go_memstats_alloc_bytes Heap'te ayrılmış ve hâlâ kullanılan byte sayısı.
+# TYPE go_memstats_alloc_bytes gauge
+go_memstats_alloc_bytes 214536
+...
+``
+
+`curl http://localhost:8080/debug/vars` (aynı değişkenler JSON olarak):
+
+``json
+{
+  "http_requests_in_flight": 1,
+  "http_requests_total": [
+    {"labels": {"code": "200", "route": "GET /"}, "value": 5}
+  ],
+  "payload_size_bytes": {"buckets": {"64": 5, "256": 5, ...}, "count": 5, "sum": 70},
+  ...
+}
+``
+
+Prometheus tarafında örnek `scrape_config`:
+
+``yaml
+scrape_configs:
+  - job_name: "expvar-demo"
+    static_configs:
+      - targets: ["localhost:8080"]
+``
+
+---
+
+✅ Böylece `expvar` ile yayınlanan **her değişken** hem `/debug/vars` (JSON) hem de `/metrics` (Prometheus) üzerinden okunabilir hale geldi. Ek bir kütüphaneye de ihtiyaç duymadık.
+*/
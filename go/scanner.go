@@ -561,4 +561,2093 @@ Program çalıştırıldığında:
 ---
 
 👉 İstersen ben bunu **geliştirip değişken atamaları (ör: `x = 5; x * 2`) ve fonksiyonlar (ör: `max(3,5)`)** destekleyen mini bir dil haline getirebilirim. İster misin?
-*/
\ No newline at end of file
+EVET
+*/
+
+/*
+Harika 😄 Hadi bu hesap makinesini gerçekten kullanılabilir küçük bir dile çevirelim. Önce yukarıdaki sürümün sorunlarına bakalım:
+
+* `1 / 0` **panic** verir, program çöker. Aynı şekilde `"1 +"` ya da `"(3"` gibi hatalı girdiler de panic'e dönüşür.
+* Hata mesajları **konum** vermez: `Beklenmeyen token: "*"` hangi satırda, hangi sütunda?
+* Yalnızca `int` var. `7 / 2` **3** çıkar; `1.5` yazınca scanner `scanner.Float` döndürür ve parser onu tanımaz.
+* `-3` yazılamaz (tekli eksi yok), üs alma yok.
+* Değişken yok; her ifade tek başına hesaplanır.
+
+Bu bölümde yazacağımız `calc` paketi bunların hepsini çözüyor ve iki şekilde kullanılabiliyor:
+
+1. **REPL / komut satırı aracı** (`cmd/calc`): `>` isteminde ifade yazıp sonucu görürsünüz.
+2. **Kütüphane**: bir uygulama, değerleri hesaplanan ayar dosyalarını okuyabilir:
+
+```
+# server.calc
+let workers    = max(2, cpus * 2)
+let queue_size = workers * 64
+```
+
+---
+
+# 🔹 Dilin özellikleri
+
+| Özellik | Örnek | Sonuç |
+|---|---|---|
+| Kesin sayılar (`math/big`) | `0.1 + 0.2 == 0.3` | `true` |
+| Kesirler | `1/3 + 1/6` | `0.5` |
+| Büyük sayılar | `2^100` | `1267650600228229401496703205376` |
+| float | `float(1/3)`, `sqrt(2)` | `0.3333333333333333`, `1.4142135623730951` |
+| Tekli eksi, `+`, `!` | `-(2 + 3)`, `!true` | `-5`, `false` |
+| Üs (`^`, sağdan birleşir) | `2^3^2`, `-2^2`, `2^-1` | `512`, `-4`, `0.5` |
+| Karşılaştırma | `==  !=  <  <=  >  >=` | `true` / `false` |
+| Mantık (kısa devre) | `false && 1/0 > 0` | `false` (sağ taraf hesaplanmaz) |
+| Koşul | `x > 0 ? x : -x` | |
+| Değişken | `let r = 3/2; pi * r^2` | `7.0685834705770345` |
+| Fonksiyonlar | `sqrt  floor  round  min  max  ln  sin ...` | |
+| Yorumlar | `# ...`, `// ...` ve `/*` ile başlayan blok yorumlar | |
+| Konumlu hatalar | `1 / (r - 3/2)` | `1:3: sıfıra bölme` |
+
+Dilbilgisi (öncelik yukarıdan aşağıya artar):
+
+```
+program = { stmt ( ";" | satır sonu ) }
+stmt    = "let" ident "=" expr | expr
+expr    = or [ "?" expr ":" expr ]
+or      = and { "||" and }
+and     = cmp { "&&" cmp }
+cmp     = add [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) add ]
+add     = mul { ( "+" | "-" ) mul }
+mul     = unary { ( "*" | "/" ) unary }
+unary   = ( "-" | "+" | "!" ) unary | power
+power   = primary [ "^" unary ]
+primary = sayı | "true" | "false" | ident | ident "(" [ expr { "," expr } ] ")" | "(" expr ")"
+```
+
+Yukarıdaki `parseExpr → parseTerm → parseFactor` zincirinin aynısı, sadece daha uzun. Her öncelik seviyesi bir fonksiyon.
+
+Birkaç karar:
+
+* **`^` tekli eksiden önce gelir**: `-2^2 = -(2^2) = -4`. Matematikte ve Python'da da böyledir. Üssün sağ tarafı yine `unary` olduğu için `2^-1` yazılabilir.
+* **Karşılaştırmalar zincirlenemez.** `1 < x < 3` çoğu dilde ya hata verir ya da beklenmeyen bir şey hesaplar (`(1 < x) < 3`). Burada açık bir hata veriyor: `karşılaştırmalar zincirlenemez; && kullanın`.
+* **Atama `let` ile yapılır.** `x = 3` yazılırsa `atama için let kullanın: let x = ...` hatası döner. Böylece `x == 3` yerine yanlışlıkla `x = 3` yazmak sessizce değişken tanımlamaz.
+* **Satır sonu ifadeyi bitirir**, ama parantez içindeyken yok sayılır. Uzun bir ifade parantezle birden fazla satıra bölünebilir.
+
+---
+
+# 🔹 Sayılar: kesin mi, float mı?
+
+Eski sürümde her şey `int` idi. Sadece `float64`'e geçseydik de şunu görürdük:
+
+```
+0.1 + 0.2        = 0.30000000000000004
+0.1 + 0.2 == 0.3 = false
+```
+
+Bir hesap makinesinde (hele ayar dosyasında) bu kabul edilemez. Bu yüzden **bütün sayı sabitleri `*big.Rat` olarak okunur**: `0.1` tam olarak `1/10`'dur. `+ - * /` ve tam sayı üsler kesin kalır.
+
+`sqrt(2)`, `sin(1)`, `2^0.5` gibi sonucu kesir olarak yazılamayan işlemler `float64` döndürür. Sonuç ayrıca belli olsun diye float'lar her zaman ondalık noktayla yazılır (`6.0`, `6` değil). Bir işlemde float varsa sonuç da float olur.
+
+| İfade | Tür | Yazılışı |
+|---|---|---|
+| `42` | kesin | `42` |
+| `7/2` | kesin | `3.5` (sonlu ondalık açılımı var) |
+| `1/3` | kesin | `1/3` (sonsuz açılım, kesir olarak yazılır) |
+| `sqrt(16/9)` | kesin | `4/3` (pay ve payda tam kare) |
+| `sqrt(2)` | float | `1.4142135623730951` |
+| `float(6)` | float | `6.0` |
+| `rat(0.5 * float(1))` | kesin | `0.5` |
+
+Kesin sayılar sınırsız büyüyebilir. `2^1000000000` ya da `let a = a * a` zinciri belleği tüketmesin diye sonucun payı ve paydası **65.536 bit** (≈ 20.000 basamak) ile sınırlı. Sınır aşılırsa `sonuç çok büyük` hatası döner. `1e1000000` gibi sabitler de ayrıştırılırken reddedilir (üs en çok ±1000).
+
+---
+
+# 🔹 text/scanner'ı nasıl kullanıyoruz?
+
+Bu paketteki asıl ders, `text/scanner`ın bir dil için nasıl ayarlandığı:
+
+* **`Mode`**: `ScanIdents | ScanInts | ScanFloats | ScanComments | SkipComments`. Stringler ve karakterler (`'a'`) istenmiyor; onlar tek karakterli token olarak gelir ve parser `beklenmeyen "'"` der. `SkipComments` sayesinde `//` ve blok yorumları parser'a hiç ulaşmaz.
+* **`Whitespace`**: `scanner.GoWhitespace &^ (1 << '\n')`. Satır sonunu boşluk saymıyoruz; böylece `'\n'` bir token olarak gelir ve ifade ayırıcı olur.
+* **İki karakterli operatörler**: scanner `==`, `<=`, `&&` gibi operatörleri tanımaz, `'='` ve `'='` diye iki token verir. `next()`, ilk karakterden sonra `s.Peek()` ile ikinciye bakar ve `s.Next()` ile tüketir. `"= ="` (arada boşluk) iki ayrı token kalır.
+* **`#` yorumları**: scanner yalnızca Go yorumlarını bilir. `#` gelince satır sonuna kadar `Peek`/`Next` ile atlıyoruz.
+* **`s.Error`**: bitmemiş bir yorum (`1 /* x`) ya da basamaksız bir sayı (`0x`) gibi scanner hataları varsayılan olarak stderr'e yazılır. Kendi fonksiyonumuzu vererek bunları da konumlu `*Error`'a çeviriyoruz.
+* **`s.Position`**: son token'ın dosya adını, satırını ve sütununu taşır. Her düğüm kendi operatörünün konumunu saklar. Hesaplama hatası (sıfıra bölme gibi) ayrıştırmadan sonra olsa bile doğru sütunu gösterir.
+
+Ayrıştırıcı ilk hatada durur. Hata `p.err`'e yazılır ve `panic(bailout{})` ile yığın boşaltılır; `Parse` bunu `recover` ile yakalayıp normal `error` olarak döndürür. `encoding/json` ve `text/template` de aynı yöntemi kullanır. Başka bir panic (gerçek bir hata) yeniden fırlatılır.
+
+---
+
+# 📂 Dizin yapısı
+
+```
+calc/
+├── go.mod
+├── value.go                  // Value: kesin (big.Rat), float, bool
+├── error.go                  // Error: scanner.Position + mesaj, Caret
+├── env.go                    // Env: değişkenler, fonksiyonlar, Int/Float/Bool
+├── parser.go                 // text/scanner + recursive descent
+├── eval.go                   // ağacın hesaplanması, Eval/Exec/ExecFile
+├── builtins.go               // sqrt, floor, min, max, sin, ...
+├── calc_test.go
+├── cmd/calc/main.go          // REPL, -e, -D, dosya
+└── examples/config/
+    ├── main.go               // ayar dosyasını struct'a okuma
+    └── server.calc
+```
+
+---
+
+## 📌 `go.mod`
+*/
+``go
+module calc
+
+go 1.24.0
+``
+/*
+---
+
+## 📌 `value.go`
+
+`Value` üç türden birini tutar. Kesin değer `*big.Rat`'tır; sıfır değeri (`Value{}`) 0 olsun diye `nil` kesir 0 sayılır. `String`, `1/2`'yi `0.5` diye, `1/3`'ü `1/3` diye yazar: paydanın 2 ve 5 dışında asal çarpanı yoksa ondalık açılım sonludur.
+*/
+``go
+// Package calc, text/scanner üzerine kurulmuş küçük bir ifade dilidir:
+// tam sayılar ve kesirler math/big ile kesin, sqrt gibi fonksiyonların
+// sonuçları float64 olarak hesaplanır. Karşılaştırma ve mantık
+// operatörleri, let bağlamaları ve math paketinden fonksiyonlar vardır.
+// Hatalar scanner.Position ile satır ve sütun taşır.
+//
+//	env := calc.NewEnv()
+//	v, err := calc.Eval("let r = 2; pi * r^2", env)
+//
+// Ayar dosyaları da birer let listesidir; bkz. Exec ve Env.Int.
+package calc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// Kind, bir değerin türüdür.
+type Kind int
+
+const (
+	KindRat   Kind = iota // kesin: tam sayı ya da kesir (*big.Rat)
+	KindFloat             // yaklaşık: float64
+	KindBool
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindRat:
+		return "sayı"
+	case KindFloat:
+		return "float"
+	case KindBool:
+		return "bool"
+	}
+	return fmt.Sprintf("Kind(%d)", int(k))
+}
+
+// Value, bir ifadenin sonucudur. Sıfır değeri 0'dır (KindRat).
+//
+// Sayı sabitleri, ondalıklı olanlar da dahil, kesin tutulur: 0.1 + 0.2
+// tam olarak 3/10'dur. float yalnızca kesin sonucu olmayan işlemlerden
+// (sqrt, sin, kesirli üs...) ya da float() çağrısından çıkar ve bir kez
+// float'a geçen hesap float kalır.
+type Value struct {
+	kind Kind
+	r    *big.Rat // KindRat; nil ise 0
+	f    float64
+	b    bool
+}
+
+// Rat, r'nin kopyasını tutan kesin bir değer döndürür.
+func Rat(r *big.Rat) Value { return Value{kind: KindRat, r: new(big.Rat).Set(r)} }
+
+// Int, kesin bir tam sayı değeri döndürür.
+func Int(i int64) Value { return Value{kind: KindRat, r: new(big.Rat).SetInt64(i)} }
+
+// Float, yaklaşık bir değer döndürür.
+func Float(f float64) Value { return Value{kind: KindFloat, f: f} }
+
+// Bool, bir mantık değeri döndürür.
+func Bool(b bool) Value { return Value{kind: KindBool, b: b} }
+
+func (v Value) Kind() Kind { return v.kind }
+
+// rat, KindRat değerin kesrini döndürür; sonucu değiştirilmemelidir.
+func (v Value) rat() *big.Rat {
+	if v.r == nil {
+		return new(big.Rat)
+	}
+	return v.r
+}
+
+// Rat, kesin değerin bir kopyasını döndürür. ok, değer KindRat değilse
+// false'tur.
+func (v Value) Rat() (r *big.Rat, ok bool) {
+	if v.kind != KindRat {
+		return nil, false
+	}
+	return new(big.Rat).Set(v.rat()), true
+}
+
+// Float64, sayıyı float64 olarak döndürür; kesin değerler en yakın
+// float64'e yuvarlanır. ok, değer sayı değilse false'tur.
+func (v Value) Float64() (f float64, ok bool) {
+	switch v.kind {
+	case KindRat:
+		f, _ = v.rat().Float64()
+		return f, true
+	case KindFloat:
+		return v.f, true
+	}
+	return 0, false
+}
+
+// Int64, değer int64'e sığan kesin bir tam sayıysa onu döndürür.
+func (v Value) Int64() (i int64, ok bool) {
+	if v.kind != KindRat || !v.rat().IsInt() || !v.rat().Num().IsInt64() {
+		return 0, false
+	}
+	return v.rat().Num().Int64(), true
+}
+
+// Bool, mantık değerini döndürür. ok, değer KindBool değilse false'tur.
+func (v Value) Bool() (b, ok bool) { return v.b, v.kind == KindBool }
+
+// String, değeri yeniden okunabilecek biçimde yazar: tam sayılar "42",
+// sonlu ondalık açılımı olan kesirler "0.3", diğerleri "1/3".
+func (v Value) String() string {
+	switch v.kind {
+	case KindBool:
+		if v.b {
+			return "true"
+		}
+		return "false"
+	case KindFloat:
+		s := fmt.Sprint(v.f)
+		// 2.0 gibi tam float'lar kesin sayılarla karışmasın diye ".0" alır.
+		if !strings.ContainsAny(s, ".eE") && !math.IsInf(v.f, 0) && !math.IsNaN(v.f) {
+			s += ".0"
+		}
+		return s
+	}
+	r := v.rat()
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	if n, exact := decimalDigits(r.Denom()); exact && n <= 30 {
+		return r.FloatString(n)
+	}
+	return r.String()
+}
+
+// decimalDigits, 1/d'nin kaç ondalık basamakta bittiğini döndürür; d'nin
+// 2 ve 5 dışında asal çarpanı varsa açılım sonsuzdur.
+func decimalDigits(d *big.Int) (n int, exact bool) {
+	d = new(big.Int).Set(d)
+	var twos, fives int
+	two, five := big.NewInt(2), big.NewInt(5)
+	var m big.Int
+	for m.Mod(d, two).Sign() == 0 {
+		d.Quo(d, two)
+		twos++
+	}
+	for m.Mod(d, five).Sign() == 0 {
+		d.Quo(d, five)
+		fives++
+	}
+	return max(twos, fives), d.IsInt64() && d.Int64() == 1
+}
+``
+/*
+---
+
+## 📌 `error.go`
+
+Bütün ayrıştırma ve hesaplama hataları `*Error`'dır; `errors.As` ile konuma ulaşılır. `Caret`, REPL'in ve CLI'ın hatanın altına koyduğu `^` işaretini üretir.
+*/
+``go
+package calc
+
+import (
+	"fmt"
+	"strings"
+	"text/scanner"
+)
+
+// Error, konumlu bir ayrıştırma ya da hesaplama hatasıdır. Pos, hatalı
+// token'ın ya da işlemin başladığı yerdir.
+type Error struct {
+	Pos scanner.Position
+	Msg string
+}
+
+// Error, "dosya:satır:sütun: mesaj" biçimindedir; dosya adı yoksa
+// (Eval) "satır:sütun: mesaj".
+func (e *Error) Error() string {
+	switch {
+	case !e.Pos.IsValid():
+		return e.Msg
+	case e.Pos.Filename == "":
+		return fmt.Sprintf("%d:%d: %s", e.Pos.Line, e.Pos.Column, e.Msg)
+	}
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+func errorf(pos scanner.Position, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Caret, hatalı satırı ve altında hatanın sütununu gösteren bir işaret
+// döndürür. src, hatanın alındığı kaynağın tamamıdır.
+//
+//	let x = 1 +* 2
+//	           ^
+func (e *Error) Caret(src string) string {
+	lines := strings.Split(src, "\n")
+	if e.Pos.Line < 1 || e.Pos.Line > len(lines) {
+		return ""
+	}
+	line := lines[e.Pos.Line-1]
+	// Column bayt değil karakter sayar; sekmeler hizayı bozmasın diye korunur.
+	var pad strings.Builder
+	for i, r := range []rune(line) {
+		if i >= e.Pos.Column-1 {
+			break
+		}
+		if r == '\t' {
+			pad.WriteByte('\t')
+		} else {
+			pad.WriteByte(' ')
+		}
+	}
+	return line + "\n" + pad.String() + "^"
+}
+``
+/*
+---
+
+## 📌 `env.go`
+
+`Env` hem değişkenleri hem de uygulamanın eklediği fonksiyonları tutar. `Int`, `Float` ve `Bool`, ayar dosyasından değer okumak için yazıldı: tür uymazsa hata değerle birlikte döner (`calc: ratio = 16/3 tam sayı değil`).
+*/
+``go
+package calc
+
+import (
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+)
+
+// Func, ifadelerden çağrılabilen bir fonksiyondur. Argüman sayısı ve
+// türleri fonksiyonun kendisi tarafından kontrol edilir; dönen hata
+// çağrının konumuyla sarılır.
+type Func func(args []Value) (Value, error)
+
+// Env, değişkenleri ve kullanıcı fonksiyonlarını tutar. let bağlamaları
+// Env'e yazılır; aynı Env ile çalışan sonraki ifadeler onları görür.
+// Env eşzamanlı kullanım için güvenli değildir.
+type Env struct {
+	vars  map[string]Value
+	funcs map[string]Func
+}
+
+// NewEnv, pi, e ve phi sabitleriyle yeni bir Env döndürür. Yerleşik
+// fonksiyonlar (sqrt, min, ...) her Env'de vardır.
+func NewEnv() *Env {
+	return &Env{
+		vars: map[string]Value{
+			"pi":  Float(math.Pi),
+			"e":   Float(math.E),
+			"phi": Float(math.Phi),
+		},
+		funcs: map[string]Func{},
+	}
+}
+
+// Set, bir değişken tanımlar ya da değiştirir. Uygulamanın ayar dosyasına
+// verdiği değerler (ör. CPU sayısı) böyle eklenir.
+func (e *Env) Set(name string, v Value) { e.vars[name] = v }
+
+// Lookup, değişkenin değerini döndürür.
+func (e *Env) Lookup(name string) (Value, bool) {
+	v, ok := e.vars[name]
+	return v, ok
+}
+
+// SetFunc, bir fonksiyon tanımlar. Aynı adlı yerleşik fonksiyonu gizler.
+func (e *Env) SetFunc(name string, f Func) { e.funcs[name] = f }
+
+func (e *Env) fn(name string) (Func, bool) {
+	if f, ok := e.funcs[name]; ok {
+		return f, true
+	}
+	f, ok := builtins[name]
+	return f, ok
+}
+
+// Names, tanımlı değişkenlerin adlarını sıralı döndürür.
+func (e *Env) Names() []string { return slices.Sorted(maps.Keys(e.vars)) }
+
+// Int, değişkenin int64 değerini döndürür. Değişken yoksa, kesin bir tam
+// sayı değilse ya da int64'e sığmıyorsa hata döner.
+func (e *Env) Int(name string) (int64, error) {
+	v, err := e.get(name)
+	if err != nil {
+		return 0, err
+	}
+	i, ok := v.Int64()
+	if !ok {
+		return 0, fmt.Errorf("calc: %s = %s tam sayı değil", name, v)
+	}
+	return i, nil
+}
+
+// Float, değişkenin sayısal değerini float64 olarak döndürür.
+func (e *Env) Float(name string) (float64, error) {
+	v, err := e.get(name)
+	if err != nil {
+		return 0, err
+	}
+	f, ok := v.Float64()
+	if !ok {
+		return 0, fmt.Errorf("calc: %s = %s sayı değil", name, v)
+	}
+	return f, nil
+}
+
+// Bool, değişkenin mantık değerini döndürür.
+func (e *Env) Bool(name string) (bool, error) {
+	v, err := e.get(name)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.Bool()
+	if !ok {
+		return false, fmt.Errorf("calc: %s = %s bool değil", name, v)
+	}
+	return b, nil
+}
+
+func (e *Env) get(name string) (Value, error) {
+	v, ok := e.vars[name]
+	if !ok {
+		return Value{}, fmt.Errorf("calc: %s tanımlı değil", name)
+	}
+	return v, nil
+}
+``
+/*
+---
+
+## 📌 `parser.go`
+
+Ayrıştırıcı. `next()` scanner'dan token alır, `#` yorumlarını ve parantez içindeki satır sonlarını atlar, iki karakterli operatörleri birleştirir. `maxNest`, `((((...))))` gibi girdilerin yığını taşırmasını önler.
+*/
+``go
+package calc
+
+import (
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+	"text/scanner"
+)
+
+// Dil:
+//
+//	program = { stmt ( ";" | satır sonu ) }
+//	stmt    = "let" ident "=" expr | expr
+//	expr    = or [ "?" expr ":" expr ]
+//	or      = and { "||" and }
+//	and     = cmp { "&&" cmp }
+//	cmp     = add [ ( "==" | "!=" | "<" | "<=" | ">" | ">=" ) add ]
+//	add     = mul { ( "+" | "-" ) mul }
+//	mul     = unary { ( "*" | "/" ) unary }
+//	unary   = ( "-" | "+" | "!" ) unary | power
+//	power   = primary [ "^" unary ]
+//	primary = sayı | "true" | "false" | ident | ident "(" [ expr { "," expr } ] ")" | "(" expr ")"
+//
+// ^ sağdan birleşir ve tekli eksiden önce gelir: -2^2 = -4, 2^-1 = 1/2,
+// 2^3^2 = 512. Karşılaştırmalar zincirlenemez (1 < x < 3 hatadır).
+// Satır sonu parantez içinde yok sayılır; uzun ifadeler parantezle
+// bölünebilir. # ve // satır sonuna kadar, /* */ arası yorumdur.
+
+// İki karakterli operatörler. text/scanner bunları tek tek döndürür;
+// next onları birleştirir. Değerler scanner'ın token'larıyla çakışmaz.
+const (
+	tokEq rune = -(iota + 20)
+	tokNe
+	tokLe
+	tokGe
+	tokAnd
+	tokOr
+)
+
+var tokNames = map[rune]string{
+	tokEq: "==", tokNe: "!=", tokLe: "<=", tokGe: ">=", tokAnd: "&&", tokOr: "||",
+}
+
+// pairs, iki karakterli operatörün ilk karakterinden ikinci karakterine ve
+// token'ına gider.
+var pairs = map[rune][2]rune{
+	'=': {'=', tokEq}, '!': {'=', tokNe}, '<': {'=', tokLe}, '>': {'=', tokGe},
+	'&': {'&', tokAnd}, '|': {'|', tokOr},
+}
+
+func tokString(tok rune) string {
+	if s, ok := tokNames[tok]; ok {
+		return s
+	}
+	return string(tok)
+}
+
+const (
+	maxNest     = 200  // iç içe parantez/operatör sınırı; yığın taşmasını önler
+	maxExponent = 1000 // 1e1000'den büyük sabitler reddedilir
+)
+
+// bailout, ilk hatada ayrıştırmayı durdurmak için kullanılır; Parse onu
+// yakalar. Hata p.err'dedir.
+type bailout struct{}
+
+type parser struct {
+	s     scanner.Scanner
+	tok   rune
+	lit   string
+	pos   scanner.Position
+	depth int // açık parantez sayısı; içeride satır sonu yok sayılır
+	nest  int
+	err   *Error
+}
+
+// Program, ayrıştırılmış bir ifade listesidir. Aynı Program farklı Env'lerle
+// tekrar tekrar çalıştırılabilir.
+type Program struct {
+	stmts []node
+}
+
+// Parse, r'deki programı ayrıştırır. filename hata konumlarında görünür;
+// boş olabilir. Dönen hata *Error'dır.
+func Parse(filename string, r io.Reader) (prog *Program, err error) {
+	p := &parser{}
+	p.s.Init(r)
+	p.s.Filename = filename
+	p.s.Mode = scanner.ScanIdents | scanner.ScanInts | scanner.ScanFloats | scanner.ScanComments | scanner.SkipComments
+	p.s.Whitespace = scanner.GoWhitespace &^ (1 << '\n')
+	p.s.Error = func(s *scanner.Scanner, msg string) {
+		if p.err == nil {
+			pos := s.Position
+			if !pos.IsValid() {
+				pos = s.Pos()
+			}
+			p.err = &Error{Pos: pos, Msg: msg}
+		}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(bailout); !ok {
+				panic(r)
+			}
+			prog, err = nil, p.err
+		}
+	}()
+	p.next()
+	prog = &Program{stmts: p.program()}
+	return prog, nil
+}
+
+// ParseString, Parse'ın string alan kısaltmasıdır.
+func ParseString(src string) (*Program, error) {
+	return Parse("", strings.NewReader(src))
+}
+
+// Defines, programın let ile tanımladığı adları sırayla döndürür.
+func (prog *Program) Defines() []string {
+	var names []string
+	for _, s := range prog.stmts {
+		if l, ok := s.(*letStmt); ok {
+			names = append(names, l.name)
+		}
+	}
+	return names
+}
+
+// HasResult, programın son deyimi let değil bir ifadeyse true döner. REPL
+// bunu "let x = 2" satırında değeri ikinci kez yazmamak için kullanır.
+func (prog *Program) HasResult() bool {
+	if len(prog.stmts) == 0 {
+		return false
+	}
+	_, isLet := prog.stmts[len(prog.stmts)-1].(*letStmt)
+	return !isLet
+}
+
+func (p *parser) fail(pos scanner.Position, format string, args ...any) {
+	if p.err == nil {
+		p.err = errorf(pos, format, args...)
+	}
+	panic(bailout{})
+}
+
+// desc, geçerli token'ı hata mesajı için tanımlar.
+func (p *parser) desc() string {
+	switch p.tok {
+	case scanner.EOF:
+		return "girdinin sonu"
+	case '\n':
+		return "satır sonu"
+	}
+	return strconv.Quote(p.lit)
+}
+
+func (p *parser) next() {
+	for {
+		p.tok = p.s.Scan()
+		p.pos = p.s.Position
+		p.lit = p.s.TokenText()
+		if p.err != nil {
+			panic(bailout{})
+		}
+		switch {
+		case p.tok == '#':
+			for c := p.s.Peek(); c != '\n' && c != scanner.EOF; c = p.s.Peek() {
+				p.s.Next()
+			}
+			continue
+		case p.tok == '\n' && p.depth > 0:
+			continue
+		}
+		break
+	}
+	// Operatörün ikinci karakteri hemen ardından gelmeli: "= =" iki ayrı '='dir.
+	if pair, ok := pairs[p.tok]; ok && p.s.Peek() == pair[0] {
+		p.s.Next()
+		p.tok = pair[1]
+		p.lit = tokNames[p.tok]
+	}
+}
+
+func (p *parser) expect(tok rune) {
+	if p.tok != tok {
+		p.fail(p.pos, "%q bekleniyor, %s bulundu", tokString(tok), p.desc())
+	}
+	p.next()
+}
+
+func (p *parser) program() []node {
+	var stmts []node
+	for {
+		for p.tok == ';' || p.tok == '\n' {
+			p.next()
+		}
+		if p.tok == scanner.EOF {
+			return stmts
+		}
+		stmts = append(stmts, p.stmt())
+		if p.tok != ';' && p.tok != '\n' && p.tok != scanner.EOF {
+			p.fail(p.pos, "beklenmeyen %s", p.desc())
+		}
+	}
+}
+
+var keywords = map[string]bool{"let": true, "true": true, "false": true}
+
+func (p *parser) stmt() node {
+	if p.tok == scanner.Ident && p.lit == "let" {
+		pos := p.pos
+		p.next()
+		if p.tok != scanner.Ident || keywords[p.lit] {
+			p.fail(p.pos, "let'ten sonra değişken adı bekleniyor, %s bulundu", p.desc())
+		}
+		name := p.lit
+		p.next()
+		p.expect('=')
+		return &letStmt{pos: pos, name: name, x: p.expr()}
+	}
+	x := p.expr()
+	if id, ok := x.(*ident); ok && p.tok == '=' {
+		p.fail(p.pos, "atama için let kullanın: let %s = ...", id.name)
+	}
+	return x
+}
+
+func (p *parser) expr() node {
+	p.nest++
+	if p.nest > maxNest {
+		p.fail(p.pos, "ifade çok derin (en çok %d seviye)", maxNest)
+	}
+	defer func() { p.nest-- }()
+
+	x := p.or()
+	if p.tok == '?' {
+		pos := p.pos
+		p.next()
+		a := p.expr()
+		p.expect(':')
+		b := p.expr()
+		x = &cond{pos: pos, c: x, a: a, b: b}
+	}
+	return x
+}
+
+func (p *parser) or() node {
+	x := p.and()
+	for p.tok == tokOr {
+		pos := p.pos
+		p.next()
+		x = &binary{pos: pos, op: tokOr, x: x, y: p.and()}
+	}
+	return x
+}
+
+func (p *parser) and() node {
+	x := p.cmp()
+	for p.tok == tokAnd {
+		pos := p.pos
+		p.next()
+		x = &binary{pos: pos, op: tokAnd, x: x, y: p.cmp()}
+	}
+	return x
+}
+
+func isCmp(tok rune) bool {
+	switch tok {
+	case tokEq, tokNe, '<', tokLe, '>', tokGe:
+		return true
+	}
+	return false
+}
+
+func (p *parser) cmp() node {
+	x := p.add()
+	if isCmp(p.tok) {
+		op, pos := p.tok, p.pos
+		p.next()
+		x = &binary{pos: pos, op: op, x: x, y: p.add()}
+		if isCmp(p.tok) {
+			p.fail(p.pos, "karşılaştırmalar zincirlenemez; && kullanın")
+		}
+	}
+	return x
+}
+
+func (p *parser) add() node {
+	x := p.mul()
+	for p.tok == '+' || p.tok == '-' {
+		op, pos := p.tok, p.pos
+		p.next()
+		x = &binary{pos: pos, op: op, x: x, y: p.mul()}
+	}
+	return x
+}
+
+func (p *parser) mul() node {
+	x := p.unary()
+	for p.tok == '*' || p.tok == '/' {
+		op, pos := p.tok, p.pos
+		p.next()
+		x = &binary{pos: pos, op: op, x: x, y: p.unary()}
+	}
+	return x
+}
+
+func (p *parser) unary() node {
+	switch p.tok {
+	case '-', '+', '!':
+		op, pos := p.tok, p.pos
+		p.nest++
+		if p.nest > maxNest {
+			p.fail(p.pos, "ifade çok derin (en çok %d seviye)", maxNest)
+		}
+		defer func() { p.nest-- }()
+		p.next()
+		return &unary{pos: pos, op: op, x: p.unary()}
+	}
+	return p.power()
+}
+
+func (p *parser) power() node {
+	x := p.primary()
+	if p.tok == '^' {
+		pos := p.pos
+		p.next()
+		// Sağ taraf unary: 2^-1 ve 2^3^2 (= 2^(3^2)) yazılabilsin.
+		x = &binary{pos: pos, op: '^', x: x, y: p.unary()}
+	}
+	return x
+}
+
+func (p *parser) primary() node {
+	pos := p.pos
+	switch p.tok {
+	case scanner.Int, scanner.Float:
+		v := p.number()
+		p.next()
+		return &lit{pos: pos, v: v}
+	case scanner.Ident:
+		name := p.lit
+		p.next()
+		switch name {
+		case "true", "false":
+			return &lit{pos: pos, v: Bool(name == "true")}
+		case "let":
+			p.fail(pos, "let yalnızca satır başında kullanılabilir")
+		}
+		if p.tok != '(' {
+			return &ident{pos: pos, name: name}
+		}
+		p.next()
+		p.depth++
+		c := &call{pos: pos, name: name}
+		if p.tok != ')' {
+			c.args = append(c.args, p.expr())
+			for p.tok == ',' {
+				p.next()
+				c.args = append(c.args, p.expr())
+			}
+		}
+		p.depth--
+		p.expect(')')
+		return c
+	case '(':
+		p.next()
+		p.depth++
+		x := p.expr()
+		p.depth--
+		p.expect(')')
+		return x
+	}
+	p.fail(pos, "beklenmeyen %s", p.desc())
+	return nil
+}
+
+// number, sayı sabitini kesin olarak okur: "0.1" tam olarak 1/10'dur.
+// big.Rat, Go'nun bütün sayı biçimlerini (0x, 0b, 1_000, 1e3, 0x1p-2) okur.
+func (p *parser) number() Value {
+	if p.tok == scanner.Float {
+		// 1e1000000 gibi bir sabit, SetString'e milyon basamaklı bir sayı
+		// hesaplatırdı.
+		lit := strings.ToLower(p.lit)
+		sep := "e"
+		if strings.HasPrefix(lit, "0x") {
+			sep = "p"
+		}
+		if _, exp, ok := strings.Cut(lit, sep); ok {
+			if n, err := strconv.Atoi(strings.ReplaceAll(exp, "_", "")); err != nil || n > maxExponent || n < -maxExponent {
+				p.fail(p.pos, "üs çok büyük: %s (en çok ±%d)", p.lit, maxExponent)
+			}
+		}
+	}
+	r, ok := new(big.Rat).SetString(p.lit)
+	if !ok {
+		p.fail(p.pos, "geçersiz sayı %s", p.lit)
+	}
+	return Value{kind: KindRat, r: r}
+}
+``
+/*
+---
+
+## 📌 `eval.go`
+
+Her düğüm kendini hesaplar. Kesin işlemlerden sonra sonucun boyutu, float işlemlerden sonra sonlu olup olmadığı kontrol edilir. `ln(0)` ya da `asin(2)` sessizce `+Inf`/`NaN` döndürüp sonraki hesapları bozmak yerine hata verir.
+*/
+``go
+package calc
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"os"
+	"strings"
+	"text/scanner"
+)
+
+// maxBits, kesin bir sonucun payının ya da paydasının en çok kaç bit
+// olabileceğidir (yaklaşık 20.000 ondalık basamak). 2^1e9 gibi bir ifade
+// belleği tüketmek yerine hata verir.
+const maxBits = 1 << 16
+
+// node, ifade ağacının bir düğümüdür.
+type node interface {
+	eval(env *Env) (Value, error)
+}
+
+type (
+	lit struct {
+		pos scanner.Position
+		v   Value
+	}
+	ident struct {
+		pos  scanner.Position
+		name string
+	}
+	unary struct {
+		pos scanner.Position
+		op  rune
+		x   node
+	}
+	binary struct {
+		pos  scanner.Position
+		op   rune
+		x, y node
+	}
+	cond struct {
+		pos     scanner.Position
+		c, a, b node
+	}
+	call struct {
+		pos  scanner.Position
+		name string
+		args []node
+	}
+	letStmt struct {
+		pos  scanner.Position
+		name string
+		x    node
+	}
+)
+
+// Exec, programı sırayla çalıştırır ve son ifadenin değerini döndürür. let
+// bağlamaları env'e yazılır; hata olursa o ana kadarki bağlamalar kalır.
+// Dönen hata *Error'dır.
+func (prog *Program) Exec(env *Env) (Value, error) {
+	var v Value
+	for _, s := range prog.stmts {
+		var err error
+		if v, err = s.eval(env); err != nil {
+			return Value{}, err
+		}
+	}
+	return v, nil
+}
+
+// Eval, src'yi ayrıştırıp env ile çalıştırır. env nil ise NewEnv kullanılır.
+func Eval(src string, env *Env) (Value, error) {
+	return Exec("", strings.NewReader(src), env)
+}
+
+// Exec, r'deki programı ayrıştırıp env ile çalıştırır.
+func Exec(filename string, r io.Reader, env *Env) (Value, error) {
+	prog, err := Parse(filename, r)
+	if err != nil {
+		return Value{}, err
+	}
+	if env == nil {
+		env = NewEnv()
+	}
+	return prog.Exec(env)
+}
+
+// ExecFile, bir ayar dosyasını çalıştırır; tanımladığı değerler env'den
+// okunur (bkz. Env.Int).
+func ExecFile(path string, env *Env) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = Exec(path, f, env)
+	return err
+}
+
+func (n *lit) eval(*Env) (Value, error) { return n.v, nil }
+
+func (n *ident) eval(env *Env) (Value, error) {
+	if v, ok := env.Lookup(n.name); ok {
+		return v, nil
+	}
+	if _, ok := env.fn(n.name); ok {
+		return Value{}, errorf(n.pos, "%s bir fonksiyon; %s(...) olarak çağırın", n.name, n.name)
+	}
+	return Value{}, errorf(n.pos, "tanımsız değişken %s", n.name)
+}
+
+func (n *letStmt) eval(env *Env) (Value, error) {
+	v, err := n.x.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	env.Set(n.name, v)
+	return v, nil
+}
+
+func (n *cond) eval(env *Env) (Value, error) {
+	c, err := n.c.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	b, ok := c.Bool()
+	if !ok {
+		return Value{}, errorf(n.pos, "?: koşulu bool olmalı, %s verildi", c.kind)
+	}
+	if b {
+		return n.a.eval(env)
+	}
+	return n.b.eval(env)
+}
+
+func (n *call) eval(env *Env) (Value, error) {
+	f, ok := env.fn(n.name)
+	if !ok {
+		return Value{}, errorf(n.pos, "tanımsız fonksiyon %s", n.name)
+	}
+	args := make([]Value, len(n.args))
+	for i, a := range n.args {
+		var err error
+		if args[i], err = a.eval(env); err != nil {
+			return Value{}, err
+		}
+	}
+	v, err := f(args)
+	if err == nil {
+		err = checkFloat(v)
+	}
+	if err != nil {
+		return Value{}, errorf(n.pos, "%s: %v", n.name, err)
+	}
+	return v, nil
+}
+
+func (n *unary) eval(env *Env) (Value, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	switch {
+	case n.op == '!' && x.kind == KindBool:
+		return Bool(!x.b), nil
+	case n.op == '!':
+		return Value{}, errorf(n.pos, "'!' bool ister, %s verildi", x.kind)
+	case x.kind == KindBool:
+		return Value{}, errorf(n.pos, "%q sayı ister, bool verildi", n.op)
+	case n.op == '+':
+		return x, nil
+	case x.kind == KindFloat:
+		return Float(-x.f), nil
+	}
+	return Value{kind: KindRat, r: new(big.Rat).Neg(x.rat())}, nil
+}
+
+func (n *binary) eval(env *Env) (Value, error) {
+	x, err := n.x.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	// && ve || kısa devre yapar: false && 1/0 hata vermez.
+	if n.op == tokAnd || n.op == tokOr {
+		b, ok := x.Bool()
+		if !ok {
+			return Value{}, errorf(n.pos, "%q bool ister, %s verildi", tokString(n.op), x.kind)
+		}
+		if b == (n.op == tokOr) {
+			return x, nil
+		}
+		y, err := n.y.eval(env)
+		if err != nil {
+			return Value{}, err
+		}
+		if y.kind != KindBool {
+			return Value{}, errorf(n.pos, "%q bool ister, %s verildi", tokString(n.op), y.kind)
+		}
+		return y, nil
+	}
+	y, err := n.y.eval(env)
+	if err != nil {
+		return Value{}, err
+	}
+	var v Value
+	switch n.op {
+	case '+', '-', '*', '/':
+		v, err = arith(n.op, x, y)
+	case '^':
+		v, err = pow(x, y)
+	default:
+		v, err = compare(n.op, x, y)
+	}
+	if err == nil {
+		err = checkFloat(v)
+	}
+	if err != nil {
+		return Value{}, &Error{Pos: n.pos, Msg: err.Error()}
+	}
+	return v, nil
+}
+
+var (
+	errDivZero = errors.New("sıfıra bölme")
+	errTooBig  = errors.New("sonuç çok büyük")
+)
+
+// checkFloat, float sonuçların sonlu olduğunu denetler. Sonsuz ya da NaN bir
+// değer sessizce sonraki hesaplara yayılacağı yerde işlemin konumunda hata
+// verir.
+func checkFloat(v Value) error {
+	if v.kind != KindFloat {
+		return nil
+	}
+	switch {
+	case math.IsNaN(v.f):
+		return errors.New("sonuç tanımsız (NaN)")
+	case math.IsInf(v.f, 0):
+		return errors.New("sonuç sonsuz")
+	}
+	return nil
+}
+
+// numbers, iki işlenenin de sayı olduğunu denetler.
+func numbers(op rune, x, y Value) error {
+	if x.kind == KindBool || y.kind == KindBool {
+		return errors.New("'" + tokString(op) + "' sayılar için tanımlı, bool verildi")
+	}
+	return nil
+}
+
+func arith(op rune, x, y Value) (Value, error) {
+	if err := numbers(op, x, y); err != nil {
+		return Value{}, err
+	}
+	if x.kind == KindRat && y.kind == KindRat {
+		a, b, r := x.rat(), y.rat(), new(big.Rat)
+		switch op {
+		case '+':
+			r.Add(a, b)
+		case '-':
+			r.Sub(a, b)
+		case '*':
+			r.Mul(a, b)
+		case '/':
+			if b.Sign() == 0 {
+				return Value{}, errDivZero
+			}
+			r.Quo(a, b)
+		}
+		// Tek işlem sınırı en çok iki katına çıkarır; let a = a*a zinciri
+		// burada durur.
+		if r.Num().BitLen() > maxBits || r.Denom().BitLen() > maxBits {
+			return Value{}, errTooBig
+		}
+		return Value{kind: KindRat, r: r}, nil
+	}
+	a, _ := x.Float64()
+	b, _ := y.Float64()
+	switch op {
+	case '+':
+		return Float(a + b), nil
+	case '-':
+		return Float(a - b), nil
+	case '*':
+		return Float(a * b), nil
+	}
+	if b == 0 {
+		return Value{}, errDivZero
+	}
+	return Float(a / b), nil
+}
+
+// pow, x^y'yi hesaplar. Üs tam sayıysa ve taban kesinse sonuç da kesindir:
+// (2/3)^-2 = 9/4. Diğer durumlarda math.Pow kullanılır.
+func pow(x, y Value) (Value, error) {
+	if err := numbers('^', x, y); err != nil {
+		return Value{}, err
+	}
+	if x.kind == KindRat && y.kind == KindRat && y.rat().IsInt() {
+		base, exp := x.rat(), y.rat().Num()
+		if base.Sign() == 0 && exp.Sign() < 0 {
+			return Value{}, errDivZero
+		}
+		e := new(big.Int).Abs(exp)
+		// Sonucun bit sayısı yaklaşık (taban bitleri - 1) * üs; 0, 1 ve -1
+		// tabanlarında üs ne kadar büyük olursa olsun sonuç küçüktür.
+		bits := int64(max(base.Num().BitLen(), base.Denom().BitLen()) - 1)
+		if bits > 0 && (!e.IsInt64() || e.Int64() > maxBits || bits*e.Int64() > maxBits) {
+			return Value{}, errTooBig
+		}
+		num := new(big.Int).Exp(base.Num(), e, nil)
+		den := new(big.Int).Exp(base.Denom(), e, nil)
+		if exp.Sign() < 0 {
+			num, den = den, num
+		}
+		return Value{kind: KindRat, r: new(big.Rat).SetFrac(num, den)}, nil
+	}
+	a, _ := x.Float64()
+	b, _ := y.Float64()
+	if a == 0 && b < 0 {
+		return Value{}, errDivZero
+	}
+	if a < 0 && b != math.Trunc(b) {
+		return Value{}, errors.New("negatif sayının kesirli kuvveti tanımsız")
+	}
+	return Float(math.Pow(a, b)), nil
+}
+
+// cmp, iki sayıyı karşılaştırır. Biri float ise ikisi de float64 olarak
+// karşılaştırılır.
+func cmp(x, y Value) int {
+	if x.kind == KindRat && y.kind == KindRat {
+		return x.rat().Cmp(y.rat())
+	}
+	a, _ := x.Float64()
+	b, _ := y.Float64()
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func compare(op rune, x, y Value) (Value, error) {
+	if x.kind == KindBool || y.kind == KindBool {
+		if x.kind != y.kind {
+			return Value{}, errors.New(x.kind.String() + " ile " + y.kind.String() + " karşılaştırılamaz")
+		}
+		switch op {
+		case tokEq:
+			return Bool(x.b == y.b), nil
+		case tokNe:
+			return Bool(x.b != y.b), nil
+		}
+		return Value{}, errors.New("'" + tokString(op) + "' bool için tanımlı değil")
+	}
+	c := cmp(x, y)
+	switch op {
+	case tokEq:
+		return Bool(c == 0), nil
+	case tokNe:
+		return Bool(c != 0), nil
+	case '<':
+		return Bool(c < 0), nil
+	case tokLe:
+		return Bool(c <= 0), nil
+	case '>':
+		return Bool(c > 0), nil
+	}
+	return Bool(c >= 0), nil
+}
+``
+/*
+---
+
+## 📌 `builtins.go`
+
+`abs`, `floor`, `ceil`, `round`, `trunc`, `sqrt`, `min`, `max` ve `pow` kesin girdide kesin sonuç verir; diğerleri `math` paketini çağırır. Uygulama `Env.SetFunc` ile kendi fonksiyonunu ekleyebilir ya da bir yerleşik fonksiyonu gizleyebilir.
+*/
+``go
+package calc
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// builtins, her Env'de bulunan fonksiyonlardır. abs, floor, ceil, round,
+// trunc, sqrt, min ve max kesin girdide kesin sonuç verir; diğerleri
+// math paketini çağırır ve float döndürür.
+var builtins = map[string]Func{
+	"abs":   exact1(new(big.Rat).Abs, math.Abs),
+	"floor": exact1(ratFloor, math.Floor),
+	"ceil":  exact1(ratCeil, math.Ceil),
+	"round": exact1(ratRound, math.Round),
+	"trunc": exact1(ratTrunc, math.Trunc),
+	"sqrt":  sqrt,
+	"cbrt":  float1(math.Cbrt),
+	"exp":   float1(math.Exp),
+	"ln":    float1(math.Log),
+	"log2":  float1(math.Log2),
+	"log10": float1(math.Log10),
+	"sin":   float1(math.Sin),
+	"cos":   float1(math.Cos),
+	"tan":   float1(math.Tan),
+	"asin":  float1(math.Asin),
+	"acos":  float1(math.Acos),
+	"atan":  float1(math.Atan),
+	"atan2": float2(math.Atan2),
+	"hypot": float2(math.Hypot),
+	"pow": func(args []Value) (Value, error) {
+		if err := arity(args, 2); err != nil {
+			return Value{}, err
+		}
+		return pow(args[0], args[1])
+	},
+	"min":   extremum(-1),
+	"max":   extremum(1),
+	"float": toFloat,
+	"rat":   toRat,
+	"num":   ratPart((*big.Rat).Num),
+	"den":   ratPart((*big.Rat).Denom),
+}
+
+func arity(args []Value, n int) error {
+	if len(args) != n {
+		return fmt.Errorf("%d argüman bekleniyor, %d verildi", n, len(args))
+	}
+	return nil
+}
+
+// number, i'inci argümanın sayı olduğunu denetler.
+func number(args []Value, i int) error {
+	if args[i].kind == KindBool {
+		return fmt.Errorf("%d. argüman sayı olmalı, bool verildi", i+1)
+	}
+	return nil
+}
+
+func exact1(fr func(*big.Rat) *big.Rat, ff func(float64) float64) Func {
+	return func(args []Value) (Value, error) {
+		if err := arity(args, 1); err != nil {
+			return Value{}, err
+		}
+		if err := number(args, 0); err != nil {
+			return Value{}, err
+		}
+		if args[0].kind == KindFloat {
+			return Float(ff(args[0].f)), nil
+		}
+		return Value{kind: KindRat, r: fr(new(big.Rat).Set(args[0].rat()))}, nil
+	}
+}
+
+func float1(f func(float64) float64) Func {
+	return func(args []Value) (Value, error) {
+		if err := arity(args, 1); err != nil {
+			return Value{}, err
+		}
+		if err := number(args, 0); err != nil {
+			return Value{}, err
+		}
+		x, _ := args[0].Float64()
+		return Float(f(x)), nil
+	}
+}
+
+func float2(f func(float64, float64) float64) Func {
+	return func(args []Value) (Value, error) {
+		if err := arity(args, 2); err != nil {
+			return Value{}, err
+		}
+		for i := range args {
+			if err := number(args, i); err != nil {
+				return Value{}, err
+			}
+		}
+		x, _ := args[0].Float64()
+		y, _ := args[1].Float64()
+		return Float(f(x, y)), nil
+	}
+}
+
+// ratFloor, r'yi aşağı yuvarlar. big.Int.Div Öklid bölmesidir; payda her
+// zaman pozitif olduğu için sonuç tabana yuvarlamadır.
+func ratFloor(r *big.Rat) *big.Rat {
+	return r.SetInt(new(big.Int).Div(r.Num(), r.Denom()))
+}
+
+func ratCeil(r *big.Rat) *big.Rat {
+	return r.Neg(ratFloor(r.Neg(r)))
+}
+
+func ratTrunc(r *big.Rat) *big.Rat {
+	return r.SetInt(new(big.Int).Quo(r.Num(), r.Denom()))
+}
+
+// ratRound, math.Round gibi yarımları sıfırdan uzağa yuvarlar.
+func ratRound(r *big.Rat) *big.Rat {
+	neg := r.Sign() < 0
+	r.Abs(r)
+	r.Add(r, big.NewRat(1, 2))
+	ratFloor(r)
+	if neg {
+		r.Neg(r)
+	}
+	return r
+}
+
+// sqrt, pay ve payda tam kare ise kesin sonuç verir: sqrt(9/4) = 3/2.
+func sqrt(args []Value) (Value, error) {
+	if err := arity(args, 1); err != nil {
+		return Value{}, err
+	}
+	if err := number(args, 0); err != nil {
+		return Value{}, err
+	}
+	if r := args[0].rat(); args[0].kind == KindRat && r.Sign() >= 0 {
+		n, d := new(big.Int).Sqrt(r.Num()), new(big.Int).Sqrt(r.Denom())
+		if new(big.Int).Mul(n, n).Cmp(r.Num()) == 0 && new(big.Int).Mul(d, d).Cmp(r.Denom()) == 0 {
+			return Value{kind: KindRat, r: new(big.Rat).SetFrac(n, d)}, nil
+		}
+	}
+	x, _ := args[0].Float64()
+	return Float(math.Sqrt(x)), nil
+}
+
+// extremum, sign 1 ise max, -1 ise min fonksiyonunu döndürür. Sonuç,
+// argümanlardan biridir; türü değişmez.
+func extremum(sign int) Func {
+	return func(args []Value) (Value, error) {
+		if len(args) == 0 {
+			return Value{}, fmt.Errorf("en az 1 argüman bekleniyor")
+		}
+		best := args[0]
+		for i := range args {
+			if err := number(args, i); err != nil {
+				return Value{}, err
+			}
+			if cmp(args[i], best)*sign > 0 {
+				best = args[i]
+			}
+		}
+		return best, nil
+	}
+}
+
+func toFloat(args []Value) (Value, error) {
+	if err := arity(args, 1); err != nil {
+		return Value{}, err
+	}
+	if err := number(args, 0); err != nil {
+		return Value{}, err
+	}
+	f, _ := args[0].Float64()
+	return Float(f), nil
+}
+
+// toRat, float'ı tam değerine çevirir: rat(0.5) = 1/2. float64'ler ikili
+// kesir olduğu için rat(float(0.1)) 1/10 değil, ona en yakın ikili kesirdir.
+func toRat(args []Value) (Value, error) {
+	if err := arity(args, 1); err != nil {
+		return Value{}, err
+	}
+	if err := number(args, 0); err != nil {
+		return Value{}, err
+	}
+	if args[0].kind == KindRat {
+		return args[0], nil
+	}
+	return Value{kind: KindRat, r: new(big.Rat).SetFloat64(args[0].f)}, nil
+}
+
+func ratPart(part func(*big.Rat) *big.Int) Func {
+	return func(args []Value) (Value, error) {
+		if err := arity(args, 1); err != nil {
+			return Value{}, err
+		}
+		if args[0].kind != KindRat {
+			return Value{}, fmt.Errorf("kesin sayı bekleniyor, %s verildi", args[0].kind)
+		}
+		return Value{kind: KindRat, r: new(big.Rat).SetInt(part(args[0].rat()))}, nil
+	}
+}
+``
+/*
+---
+
+## 📌 `cmd/calc/main.go`
+
+Argümansız çalışınca REPL açılır. `:vars` tanımlı değişkenleri, `:q` çıkışı yapar; son sonuç `_` değişkenindedir. `-e` tek bir ifadeyi, dosya argümanları ayar dosyalarını çalıştırır. `-D ad=ifade` dosyalardan önce değişken tanımlar ve tekrarlanabilir (`flag.Func`).
+*/
+``go
+// calc, calc paketinin komut satırı aracıdır.
+//
+//	calc                          # REPL
+//	calc -e '2^64 / 3'            # tek ifade
+//	calc -D cpus=8 server.calc    # dosyayı çalıştırıp tanımlarını yazar
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"calc"
+)
+
+func main() {
+	env := calc.NewEnv()
+	expr := flag.String("e", "", "hesaplanacak ifade")
+	flag.Func("D", "`ad=ifade`: dosyalardan önce tanımlanacak değişken (tekrarlanabilir)", func(s string) error {
+		name, src, ok := strings.Cut(s, "=")
+		if !ok {
+			return errors.New("ad=ifade bekleniyor")
+		}
+		v, err := calc.Eval(src, env)
+		if err != nil {
+			return err
+		}
+		env.Set(strings.TrimSpace(name), v)
+		return nil
+	})
+	flag.Parse()
+
+	switch {
+	case *expr != "":
+		v, err := calc.Eval(*expr, env)
+		if err != nil {
+			report(os.Stderr, *expr, err, false)
+			os.Exit(1)
+		}
+		fmt.Println(v)
+	case flag.NArg() > 0:
+		for _, path := range flag.Args() {
+			if !runFile(path, env) {
+				os.Exit(1)
+			}
+		}
+	default:
+		repl(os.Stdin, os.Stdout, env)
+	}
+}
+
+// runFile, dosyayı çalıştırır ve tanımladığı adları dosyadaki sırayla yazar.
+func runFile(path string, env *calc.Env) bool {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
+	}
+	prog, err := calc.Parse(path, strings.NewReader(string(src)))
+	if err == nil {
+		_, err = prog.Exec(env)
+	}
+	if err != nil {
+		report(os.Stderr, string(src), err, false)
+		return false
+	}
+	seen := map[string]bool{}
+	for _, name := range prog.Defines() {
+		if !seen[name] {
+			seen[name] = true
+			v, _ := env.Lookup(name)
+			fmt.Printf("%s = %v\n", name, v)
+		}
+	}
+	return true
+}
+
+// repl, satır satır okur ve her satırı aynı Env'de çalıştırır. Son sonuç _
+// değişkenindedir.
+func repl(in io.Reader, out io.Writer, env *calc.Env) {
+	sc := bufio.NewScanner(in)
+	fmt.Fprint(out, prompt)
+	for sc.Scan() {
+		line := sc.Text()
+		switch strings.TrimSpace(line) {
+		case "":
+		case ":q", ":quit":
+			return
+		case ":vars":
+			for _, name := range env.Names() {
+				v, _ := env.Lookup(name)
+				fmt.Fprintf(out, "%s = %v\n", name, v)
+			}
+		default:
+			evalLine(out, line, env)
+		}
+		fmt.Fprint(out, prompt)
+	}
+	fmt.Fprintln(out)
+}
+
+func evalLine(out io.Writer, line string, env *calc.Env) {
+	prog, err := calc.ParseString(line)
+	if err != nil {
+		report(out, line, err, true)
+		return
+	}
+	v, err := prog.Exec(env)
+	if err != nil {
+		report(out, line, err, true)
+		return
+	}
+	if !prog.HasResult() {
+		names := prog.Defines()
+		fmt.Fprintf(out, "%s = %v\n", names[len(names)-1], v)
+		return
+	}
+	env.Set("_", v)
+	fmt.Fprintln(out, v)
+}
+
+const prompt = "> "
+
+// report, konumlu hatayı kaynak satırı ve işaretle birlikte yazar. REPL'de
+// (echoed) satır zaten ekranda olduğu için yalnızca işaret, istemin
+// genişliği kadar kaydırılarak yazılır.
+func report(w io.Writer, src string, err error, echoed bool) {
+	var cerr *calc.Error
+	if errors.As(err, &cerr) {
+		if caret := cerr.Caret(src); caret != "" {
+			if echoed {
+				_, caret, _ = strings.Cut(caret, "\n")
+				caret = strings.Repeat(" ", len(prompt)) + caret
+			}
+			fmt.Fprintln(w, caret)
+		}
+	}
+	fmt.Fprintln(w, "hata:", err)
+}
+``
+/*
+---
+
+## 📌 `examples/config/server.calc`
+
+`cpus` ve `mem_gb` dosyada tanımlı değil; onları uygulama verir.
+*/
+``
+# Sunucu ayarları. cpus ve mem_gb uygulamadan gelir.
+let workers    = max(2, cpus * 2)
+let queue_size = workers * 64
+let cache_mb   = floor(mem_gb * 1024 / 4)   // belleğin dörtte biri
+let timeout    = 1.5 * (workers > 8 ? 2 : 1)
+let debug      = false
+``
+/*
+---
+
+## 📌 `examples/config/main.go`
+
+Uygulama tarafı: `Env`'e ortam değerlerini koy, dosyayı çalıştır, sonuçları tür kontrolüyle oku. Eksik ya da yanlış türde anahtarların hepsi tek seferde raporlanır (`errors.Join`).
+*/
+``go
+// config, hesaplanmış değerler içeren bir ayar dosyasını okur. Uygulama
+// cpus ve mem_gb'yi verir; dosya diğer değerleri onlardan hesaplar.
+//
+//	go run ./examples/config examples/config/server.calc
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"runtime"
+
+	"calc"
+)
+
+type Config struct {
+	Workers   int64
+	QueueSize int64
+	CacheMB   int64
+	Timeout   float64
+	Debug     bool
+}
+
+func load(path string) (Config, error) {
+	env := calc.NewEnv()
+	env.Set("cpus", calc.Int(int64(runtime.NumCPU())))
+	env.Set("mem_gb", calc.Int(16))
+
+	var c Config
+	if err := calc.ExecFile(path, env); err != nil {
+		return c, err
+	}
+	// Hataları toplayıp birlikte döndürür; eksik bir anahtar diğerlerini
+	// gizlemez.
+	var errs []error
+	get := func(err error) { errs = append(errs, err) }
+	var err error
+	c.Workers, err = env.Int("workers")
+	get(err)
+	c.QueueSize, err = env.Int("queue_size")
+	get(err)
+	c.CacheMB, err = env.Int("cache_mb")
+	get(err)
+	c.Timeout, err = env.Float("timeout")
+	get(err)
+	c.Debug, err = env.Bool("debug")
+	get(err)
+	return c, errors.Join(errs...)
+}
+
+func main() {
+	log.SetFlags(0)
+	path := "examples/config/server.calc"
+	if len(os.Args) > 1 {
+		path = os.Args[1]
+	}
+	c, err := load(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%+v\n", c)
+}
+``
+/*
+---
+
+# 🧪 Testler
+
+`calc_test.go`, yukarıdaki eski hesap makinesinin dört testini de içeriyor; sonuçları değişmedi. Hata testleri mesajla birlikte **satır:sütun** konumunu da kontrol ediyor.
+*/
+``go
+package calc
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string
+	}{
+		// Eski hesap makinesinin testleri aynen geçer.
+		{"3 + 5 * 2", "13"},
+		{"(3 + 5) * 2", "16"},
+		{"10 - 4 / 2", "8"},
+		{"8 / 2 * (2 + 2)", "16"},
+
+		// Kesin aritmetik
+		{"7 / 2", "3.5"},
+		{"1 / 3", "1/3"},
+		{"1/3 + 1/6", "0.5"},
+		{"0.1 + 0.2", "0.3"},
+		{"0.1 + 0.2 == 0.3", "true"},
+		{"2^100", "1267650600228229401496703205376"},
+		{"(2/3)^-2", "2.25"},
+		{"(2/3)^3", "8/27"},
+		{"0x1p-2 + 1_000", "1000.25"},
+
+		// Öncelik
+		{"-2^2", "-4"},
+		{"(-2)^2", "4"},
+		{"2^-1", "0.5"},
+		{"2^3^2", "512"},
+		{"1 + 2 * 3 > 6 && !false", "true"},
+		{"1 > 2 || 2 > 1", "true"},
+		{"2 > 1 ? 10 : 20", "10"},
+
+		// Float
+		{"float(1/3)", "0.3333333333333333"},
+		{"2.0 * float(3)", "6.0"},
+		{"float(1) == 1", "true"},
+		{"rat(float(0.5))", "0.5"},
+
+		// Fonksiyonlar
+		{"sqrt(16/9)", "4/3"},
+		{"sqrt(2)", "1.4142135623730951"},
+		{"floor(-7/2)", "-4"},
+		{"ceil(7/2)", "4"},
+		{"round(-5/2)", "-3"},
+		{"trunc(-7/2)", "-3"},
+		{"abs(-3/4)", "0.75"},
+		{"max(1, 5/2, 2)", "2.5"},
+		{"min(3, float(2))", "2.0"},
+		{"num(6/4) + den(6/4)", "5"},
+		{"pow(2, 10)", "1024"},
+		{"hypot(3, 4)", "5.0"},
+
+		// let ve çok satır
+		{"let r = 2; pi * r^2 > 12", "true"},
+		{"let a = 1\nlet b = a + 1 # yorum\n(a +\n b) * 2", "6"},
+		{"// yorum\nlet x = 3 /* iç */ * 2; x", "6"},
+
+		// Kısa devre: sağ taraf hiç hesaplanmaz.
+		{"false && 1/0 > 0", "false"},
+		{"true || undefined", "true"},
+		{"1 > 0 ? 1 : 1/0", "1"},
+	}
+	for _, tt := range tests {
+		v, err := Eval(tt.src, nil)
+		if err != nil {
+			t.Errorf("Eval(%q): %v", tt.src, err)
+			continue
+		}
+		if got := v.String(); got != tt.want {
+			t.Errorf("Eval(%q) = %s, istenen %s", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		src  string
+		want string // "satır:sütun: mesaj"ın başı
+	}{
+		{"1 / 0", "1:3: sıfıra bölme"},
+		{"1 + 2 / (3 - 3)", "1:7: sıfıra bölme"},
+		{"0^-1", "1:2: sıfıra bölme"},
+		{"1 < 2 < 3", "1:7: karşılaştırmalar zincirlenemez"},
+		{"1 +* 2", "1:4: beklenmeyen \"*\""},
+		{"1 + (2", "1:7: \")\" bekleniyor, girdinin sonu"},
+		{"x * 2", "1:1: tanımsız değişken x"},
+		{"x = 3", "1:3: atama için let kullanın"},
+		{"let true = 1", "1:5: let'ten sonra değişken adı"},
+		{"sqrt", "1:1: sqrt bir fonksiyon"},
+		{"foo(1)", "1:1: tanımsız fonksiyon foo"},
+		{"sqrt(1, 2)", "1:1: sqrt: 1 argüman bekleniyor, 2 verildi"},
+		{"ln(0)", "1:1: ln: sonuç sonsuz"},
+		{"asin(2)", "1:1: asin: sonuç tanımsız (NaN)"},
+		{"(-8)^(1/3)", "1:5: negatif sayının kesirli kuvveti"},
+		{"1 + true", "1:3: '+' sayılar için tanımlı, bool verildi"},
+		{"1 ? 2 : 3", "1:3: ?: koşulu bool olmalı"},
+		{"1 && true", "1:3: \"&&\" bool ister"},
+		{"2^100000", "1:2: sonuç çok büyük"},
+		{"1e2000", "1:1: üs çok büyük"},
+		{"let a = 1\nlet b = a +\n", "2:12: beklenmeyen satır sonu"},
+		{"let a = 1\n\n  a / 0", "3:5: sıfıra bölme"},
+		{strings.Repeat("(", 300) + "1" + strings.Repeat(")", 300), "ifade çok derin"},
+	}
+	for _, tt := range tests {
+		_, err := Eval(tt.src, nil)
+		var cerr *Error
+		if !errors.As(err, &cerr) {
+			t.Errorf("Eval(%q) hatası = %v, *Error istenen", tt.src, err)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Eval(%q) hatası = %q, %q içermeli", tt.src, err, tt.want)
+		}
+	}
+}
+
+func TestLetChainTooBig(t *testing.T) {
+	src := "let a = 3" + strings.Repeat("; let a = a * a", 20)
+	if _, err := Eval(src, nil); err == nil || !strings.Contains(err.Error(), "sonuç çok büyük") {
+		t.Fatalf("hata = %v, sonuç çok büyük istenen", err)
+	}
+}
+
+func TestEnv(t *testing.T) {
+	env := NewEnv()
+	env.Set("cpus", Int(8))
+	env.SetFunc("double", func(args []Value) (Value, error) {
+		if len(args) != 1 {
+			return Value{}, errors.New("1 argüman bekleniyor")
+		}
+		r, ok := args[0].Rat()
+		if !ok {
+			return Value{}, errors.New("kesin sayı bekleniyor")
+		}
+		return Rat(r.Mul(r, big.NewRat(2, 1))), nil
+	})
+	src := `
+		let workers = double(cpus)
+		let ratio   = workers / 3
+		let timeout = 1.5
+		let debug   = workers > 10
+	`
+	prog, err := Parse("server.calc", strings.NewReader(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prog.Exec(env); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := strings.Join(prog.Defines(), ","), "workers,ratio,timeout,debug"; got != want {
+		t.Errorf("Defines = %s, istenen %s", got, want)
+	}
+	if n, err := env.Int("workers"); err != nil || n != 16 {
+		t.Errorf("Int(workers) = %d, %v; istenen 16", n, err)
+	}
+	if f, err := env.Float("timeout"); err != nil || f != 1.5 {
+		t.Errorf("Float(timeout) = %v, %v; istenen 1.5", f, err)
+	}
+	if b, err := env.Bool("debug"); err != nil || !b {
+		t.Errorf("Bool(debug) = %v, %v; istenen true", b, err)
+	}
+	if _, err := env.Int("ratio"); err == nil || err.Error() != "calc: ratio = 16/3 tam sayı değil" {
+		t.Errorf("Int(ratio) hatası = %v", err)
+	}
+	if _, err := env.Int("missing"); err == nil {
+		t.Error("Int(missing) hata vermedi")
+	}
+
+	// Kullanıcı fonksiyonunun hatası çağrının konumuyla döner.
+	_, err = Exec("server.calc", strings.NewReader("let x = 1\nlet y = double(true)"), env)
+	if err == nil || err.Error() != "server.calc:2:9: double: kesin sayı bekleniyor" {
+		t.Errorf("hata = %v", err)
+	}
+}
+
+func TestCaret(t *testing.T) {
+	src := "let a = 1\nlet b = a +* 2"
+	_, err := Eval(src, nil)
+	var cerr *Error
+	if !errors.As(err, &cerr) {
+		t.Fatalf("hata = %v, *Error istenen", err)
+	}
+	want := "let b = a +* 2\n           ^"
+	if got := cerr.Caret(src); got != want {
+		t.Errorf("Caret =\n%s\nistenen\n%s", got, want)
+	}
+}
+``
+/*
+Çıktı:
+*/
+``bash
+$ go vet ./... && go test -v ./...
+=== RUN   TestEval
+--- PASS: TestEval (0.00s)
+=== RUN   TestErrors
+--- PASS: TestErrors (0.00s)
+=== RUN   TestLetChainTooBig
+--- PASS: TestLetChainTooBig (0.00s)
+=== RUN   TestEnv
+--- PASS: TestEnv (0.00s)
+=== RUN   TestCaret
+--- PASS: TestCaret (0.00s)
+PASS
+ok  	calc	0.006s
+?   	calc/cmd/calc	[no test files]
+?   	calc/examples/config	[no test files]
+``
+/*
+---
+
+# ▶️ Çalıştırma
+
+## REPL
+*/
+``bash
+$ go run ./cmd/calc
+> 3 + 5 * 2
+13
+> 7 / 2
+3.5
+> 1/3 + 1/6
+0.5
+> 0.1 + 0.2 == 0.3
+true
+> 2^100
+1267650600228229401496703205376
+> -2^2
+-4
+> let r = 3/2
+r = 1.5
+> pi * r^2
+7.0685834705770345
+> sqrt(16/9)
+4/3
+> floor(-7/2)
+-4
+> let fiyat = 1250; fiyat * 18/100
+225
+> _ + fiyat
+1475
+> r > 1 && !(fiyat < 1000) ? 100 : 0
+100
+> 1 +* 2
+     ^
+hata: 1:4: beklenmeyen "*"
+> 1 / (r - 3/2)
+    ^
+hata: 1:3: sıfıra bölme
+> x * 2
+  ^
+hata: 1:1: tanımsız değişken x
+> ln(0)
+  ^
+hata: 1:1: ln: sonuç sonsuz
+> 1 < r < 2
+        ^
+hata: 1:7: karşılaştırmalar zincirlenemez; && kullanın
+> x = 5
+    ^
+hata: 1:3: atama için let kullanın: let x = ...
+> :vars
+_ = 100
+e = 2.718281828459045
+fiyat = 1250
+phi = 1.618033988749895
+pi = 3.141592653589793
+r = 1.5
+> :q
+``
+/*
+Hesaplama hataları da (sıfıra bölme, `ln(0)`) ayrıştırma hataları gibi operatörün ya da fonksiyonun konumunu gösteriyor. Hatadan sonra REPL çalışmaya devam ediyor; önceki `let` bağlamaları korunuyor.
+
+## Tek ifade (`-e`)
+*/
+``bash
+$ go run ./cmd/calc -e '2^64 / 3'
+18446744073709551616/3
+$ go run ./cmd/calc -e 'float(2^64 / 3)'
+6.148914691236517e+18
+$ go run ./cmd/calc -e '1 < 2 < 3'
+1 < 2 < 3
+      ^
+hata: 1:7: karşılaştırmalar zincirlenemez; && kullanın
+exit status 1
+``
+/*
+## Ayar dosyası
+
+`-D` ile verilen değerler dosyadan önce tanımlanır; araç dosyanın tanımladığı her değeri sırayla yazar. Bir ayar dosyasının ne hesapladığını görmenin en kolay yolu budur:
+*/
+``bash
+$ go run ./cmd/calc -D cpus=8 -D mem_gb=32 examples/config/server.calc
+workers = 16
+queue_size = 1024
+cache_mb = 8192
+timeout = 3
+debug = false
+``
+/*
+Dosyada hata varsa mesaj **dosya adını** da içerir. `cpus = 8` iken:
+*/
+``bash
+$ cat bad.calc
+let workers = cpus * 2
+let timeout = workers / (cpus - 8)
+$ go run ./cmd/calc -D cpus=8 bad.calc
+let timeout = workers / (cpus - 8)
+                      ^
+hata: bad.calc:2:23: sıfıra bölme
+exit status 1
+``
+/*
+Uygulamadan okuma (bu makinede `runtime.NumCPU()` = 1, `mem_gb` = 16):
+*/
+``bash
+$ go run ./examples/config
+{Workers:2 QueueSize:128 CacheMB:4096 Timeout:1.5 Debug:false}
+``
+/*
+---
+
+# ⚠️ Notlar
+
+* **`7 / 2` artık `3.5`.** Eski sürüm tam sayı bölmesi yapıyordu. Tam sayı bölmesi gerekirse `floor(7/2)` (= 3) ya da negatif sayılarda sıfıra doğru yuvarlayan `trunc(-7/2)` (= -3) kullanın. Eski dört test aynı sonucu veriyor, çünkü oradaki bölmelerin hepsi tam bölünüyor.
+* **`//` bölme değil, yorumdur.** Python alışkanlığıyla yazılan `7 // 2` hata vermez, `7` döndürür. Yorum söz dizimini `text/scanner`'dan hazır aldığımız için bu bilinçli bir tercih.
+* **Float bulaşıcıdır.** `0.1 + 0.2 == 0.3` true'dur, ama `0.1 + float(0.2) == 0.3` false'tur, çünkü hesap float'a geçmiştir (`0.30000000000000004`). Float değerleri `==` ile karşılaştırmak yerine `abs(a - b) < 1e-9` yazın. `pi`, `e` ve `phi` sabitleri de float'tır.
+* **Sınırlar:** kesin sonuçların payı/paydası en çok 65.536 bit, sayı sabitlerinde üs en çok ±1000, iç içe parantez/operatör en çok 200 seviye. Bunlar, güvenilmeyen bir kaynaktan gelen ifadenin sunucuyu kilitlemesini ya da yığını taşırmasını önler. Ama ifade hesaplama süresini sınırlamaz; kullanıcı girdisini çalıştıran bir sunucu yine de istek başına zaman aşımı koymalı.
+* **`Env` eşzamanlı kullanım için güvenli değil.** Bir ayar dosyası için tek Env yeterli. Aynı anda birden fazla goroutine'de hesap yapılacaksa her biri kendi `NewEnv()`'ini kullanmalı.
+* REPL her satırı ayrı ayrıştırır; bu yüzden REPL'deki hatalar hep `1:sütun` konumundadır. Dosyalarda satır numarası gerçek satırdır.
+* Scanner'ın kendi hata mesajları İngilizcedir (`comment not terminated`, `hexadecimal literal has no digits`). Konumları yine doğrudur.
+* Ayar dosyası bir programdır ama döngü ve kullanıcı tanımlı fonksiyon yoktur. Hesaplama her zaman biter ve dosya yalnızca uygulamanın verdiği değişkenleri ve fonksiyonları görebilir (dosya sistemi, ağ vb. erişimi yok).
+
+---
+
+# 🚀 Özet
+
+* `text/scanner`ı `Mode`, `Whitespace`, `Peek`/`Next` ve `Error` ile kendi dilimize göre ayarladık.
+* Eski `Expr → Term → Factor` zincirini karşılaştırma, mantık, üs ve koşul seviyeleriyle genişlettik.
+* `math/big` ile kesin, gerektiğinde `float64` ile yaklaşık hesap yaptık.
+* `panic` yerine `scanner.Position` taşıyan hatalar döndürdük; REPL ve CLI hatanın altına `^` koyuyor.
+* Aynı paketi hem REPL olarak hem de hesaplanmış değerli ayar dosyalarını okumak için kullandık ✅
+
+---
+
+👉 İstersen bir sonraki adımda dile **kullanıcı tanımlı fonksiyonlar** (`let kare(x) = x * x`) ve **birimler** (`512 MB + 1 GB`, `1h30m`) ekleyebiliriz. Ayar dosyaları için çok kullanışlı olur. Bunu ister misin?
+*/
@@ -480,3 +480,1336 @@ konularını bir arada görmüş oluyorsun ✅
 Bunu yapayım mı?
 */
 
+
+/*
+Hazırlamadan önce bir eksikliği kapatalım: şimdiye kadarki tüm örneklerde `cookiejar.New(nil)` kullandık. Bunun iki sonucu var:
+
+* Cookie’ler **sadece bellekte** tutulur. Program kapanınca oturum kaybolur, her çalıştırmada tekrar login gerekir.
+* `nil` verildiği için **public suffix listesi** uygulanmaz. Kötü niyetli bir site `Domain=co.uk` diyerek tüm `*.co.uk` sitelerine cookie yazabilir.
+
+Standart `cookiejar.Jar` içeriğini dışarı da vermez. Sadece `Cookies(u)` ile belirli bir URL için sorgulanabilir, “hangi cookie’ler var?” diye listelenemez.
+
+---
+
+# 🌐 CookieJar Mini Proje – Kalıcı ve İncelenebilir Cookie Jar
+
+### 1️⃣ Amaç
+
+* `http.CookieJar` arayüzünü (`SetCookies`, `Cookies`) **kendimiz** uygulamak.
+* Cookie’leri **JSON** veya **Netscape `cookies.txt`** (curl / wget uyumlu) formatında diske kaydetmek.
+* `Expires` / `Max-Age`, `Secure`, `HttpOnly`, `SameSite`, `__Secure-` / `__Host-` kurallarını doğru uygulamak.
+* Public suffix listesini **yerel bir dosyadan** (`public_suffix_list.dat`) yüklemek.
+* Cookie’leri listeleyen, silen, içe ve dışa aktaran bir **CLI** (`cookiectl`) yazmak.
+
+---
+
+### 2️⃣ Proje Yapısı
+*/
+``
+cookiedemo/
+├── go.mod                      (module cookiedemo)
+├── persistjar/
+│   ├── jar.go                  → http.CookieJar uygulaması
+│   ├── psl.go                  → dosyadan PublicSuffixList
+│   ├── format.go               → JSON / cookies.txt okuma-yazma
+│   └── jar_test.go
+├── cmd/
+│   ├── cookiectl/main.go       → list / delete / import / export / check
+│   └── scraper/main.go         → oturumu koruyan örnek istemci
+└── public_suffix_list.dat      → https://publicsuffix.org/list/public_suffix_list.dat
+``
+/*
+---
+
+### 3️⃣ `persistjar/jar.go`
+*/
+``go
+// Package persistjar, diske kaydedilebilen ve içeriği incelenebilen bir
+// http.CookieJar uygulamasıdır. Cookie'ler JSON veya Netscape cookies.txt
+// formatında saklanır, domain kuralları için PublicSuffixList kullanılır.
+package persistjar
+
+import (
+	"errors"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Entry, jar içinde saklanan tek bir cookie'dir.
+type Entry struct {
+	Name       string    `json:"name"`
+	Value      string    `json:"value"`
+	Domain     string    `json:"domain"` // nokta olmadan, küçük harf
+	Path       string    `json:"path"`
+	HostOnly   bool      `json:"host_only"` // Domain niteliği yoksa true → alt domainlere gönderilmez
+	Secure     bool      `json:"secure"`
+	HttpOnly   bool      `json:"http_only"`
+	SameSite   string    `json:"same_site,omitempty"` // "Strict", "Lax", "None"
+	Persistent bool      `json:"persistent"`          // false → oturum cookie'si
+	Expires    time.Time `json:"expires,omitzero"`
+	Created    time.Time `json:"created"`
+	LastAccess time.Time `json:"last_access"`
+}
+
+func (e *Entry) id() string { return e.Domain + ";" + e.Path + ";" + e.Name }
+
+func (e *Entry) expired(now time.Time) bool {
+	return e.Persistent && !e.Expires.After(now)
+}
+
+// Options, Jar davranışını ayarlar.
+type Options struct {
+	// PublicSuffixList nil ise "co.uk" gibi suffix'lere cookie yazılmasına
+	// karşı koruma olmaz (cookiejar.New(nil) ile aynı).
+	PublicSuffixList PublicSuffixList
+
+	// Filename boş değilse New bu dosyadan yükler, Save buraya yazar.
+	// Uzantı .txt ise Netscape, değilse JSON formatı kullanılır.
+	Filename string
+
+	// KeepSessionCookies true ise Expires/Max-Age'i olmayan cookie'ler de kaydedilir.
+	KeepSessionCookies bool
+}
+
+// Jar, http.CookieJar arayüzünü uygular.
+type Jar struct {
+	psl  PublicSuffixList
+	opts Options
+
+	mu      sync.Mutex
+	entries map[string]*Entry
+	now     func() time.Time
+}
+
+var _ http.CookieJar = (*Jar)(nil)
+
+// New, yeni bir Jar oluşturur. opts.Filename varsa ve dosya mevcutsa yüklenir.
+func New(opts *Options) (*Jar, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	j := &Jar{
+		psl:     opts.PublicSuffixList,
+		opts:    *opts,
+		entries: make(map[string]*Entry),
+		now:     time.Now,
+	}
+	if opts.Filename != "" {
+		if err := j.Load(opts.Filename); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// ---------------------------------------------------------------
+// http.CookieJar
+// ---------------------------------------------------------------
+
+// SetCookies, sunucudan gelen Set-Cookie'leri RFC 6265 kurallarına göre saklar.
+func (j *Jar) SetCookies(u *url.URL, cookies []*http.Cookie) {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return
+	}
+	host, err := canonicalHost(u.Host)
+	if err != nil {
+		return
+	}
+	now := j.now()
+	secureScheme := u.Scheme == "https"
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	for _, c := range cookies {
+		e, remove, ok := j.newEntry(c, u, host, secureScheme, now)
+		if !ok {
+			continue
+		}
+		old, exists := j.entries[e.id()]
+		if remove {
+			delete(j.entries, e.id())
+			continue
+		}
+		if exists {
+			e.Created = old.Created // RFC 6265 5.3.11.3: oluşturma zamanı korunur
+		}
+		j.entries[e.id()] = e
+	}
+}
+
+// newEntry, bir http.Cookie'yi doğrulayıp Entry'ye çevirir.
+// remove=true → cookie silinmeli (Max-Age<0 veya geçmiş Expires).
+func (j *Jar) newEntry(c *http.Cookie, u *url.URL, host string, secureScheme bool, now time.Time) (e *Entry, remove, ok bool) {
+	if c.Name == "" && c.Value == "" {
+		return nil, false, false
+	}
+	// Secure cookie'yi sadece https yazabilir (modern tarayıcı davranışı)
+	if c.Secure && !secureScheme {
+		return nil, false, false
+	}
+
+	e = &Entry{
+		Name:       c.Name,
+		Value:      c.Value,
+		Secure:     c.Secure,
+		HttpOnly:   c.HttpOnly,
+		SameSite:   sameSiteString(c.SameSite),
+		Created:    now,
+		LastAccess: now,
+	}
+
+	// Path
+	e.Path = c.Path
+	if e.Path == "" || e.Path[0] != '/' {
+		e.Path = defaultPath(u.Path)
+	}
+
+	// Domain
+	domain, hostOnly, ok := j.domainAndType(host, c.Domain)
+	if !ok {
+		return nil, false, false
+	}
+	e.Domain, e.HostOnly = domain, hostOnly
+
+	// __Secure- ve __Host- önekleri
+	if strings.HasPrefix(c.Name, "__Secure-") && !c.Secure {
+		return nil, false, false
+	}
+	if strings.HasPrefix(c.Name, "__Host-") && (!c.Secure || !hostOnly || e.Path != "/") {
+		return nil, false, false
+	}
+
+	// SameSite=None sadece Secure ile geçerlidir
+	if c.SameSite == http.SameSiteNoneMode && !c.Secure {
+		return nil, false, false
+	}
+
+	// http, aynı isimli bir Secure cookie'nin üzerine yazamaz ve onu silemez
+	// (RFC 6265bis 5.6)
+	if !secureScheme && j.shadowsSecure(e) {
+		return nil, false, false
+	}
+
+	// Ömür: Max-Age, Expires'tan önceliklidir
+	switch {
+	case c.MaxAge < 0:
+		return e, true, true
+	case c.MaxAge > 0:
+		e.Persistent = true
+		e.Expires = now.Add(time.Duration(c.MaxAge) * time.Second)
+	case !c.Expires.IsZero():
+		if !c.Expires.After(now) {
+			return e, true, true
+		}
+		e.Persistent = true
+		e.Expires = c.Expires.UTC()
+	}
+	return e, false, true
+}
+
+// shadowsSecure, e ile aynı isimde, domain'leri birbirini kapsayan ve path'i
+// e'nin path'ini kapsayan bir Secure cookie var mı diye bakar. j.mu tutulmalıdır.
+func (j *Jar) shadowsSecure(e *Entry) bool {
+	for _, old := range j.entries {
+		if old.Secure && old.Name == e.Name &&
+			(domainMatch(e.Domain, old.Domain) || domainMatch(old.Domain, e.Domain)) &&
+			pathMatch(e.Path, old.Path) {
+			return true
+		}
+	}
+	return false
+}
+
+// domainAndType, Domain niteliğini doğrular.
+func (j *Jar) domainAndType(host, domain string) (string, bool, bool) {
+	if domain == "" {
+		return host, true, true // host-only cookie
+	}
+	if net.ParseIP(host) != nil {
+		// IP adreslerinde Domain niteliği sadece aynı IP olabilir
+		return host, true, host == domain
+	}
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+	if domain == "" || strings.HasSuffix(domain, ".") {
+		return "", false, false
+	}
+
+	// "co.uk" gibi bir public suffix'e cookie yazılamaz.
+	// İstisna: host'un kendisi bir suffix ise host-only olarak kabul edilir.
+	if j.psl != nil && j.psl.PublicSuffix(domain) == domain {
+		if host == domain {
+			return host, true, true
+		}
+		return "", false, false
+	}
+
+	if !domainMatch(host, domain) {
+		return "", false, false
+	}
+	return domain, false, true
+}
+
+// Cookies, u adresine gönderilecek cookie'leri döndürür.
+func (j *Jar) Cookies(u *url.URL) []*http.Cookie {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil
+	}
+	host, err := canonicalHost(u.Host)
+	if err != nil {
+		return nil
+	}
+	path := u.Path
+	if path == "" {
+		path = "/"
+	}
+	now := j.now()
+	https := u.Scheme == "https"
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	var selected []*Entry
+	for id, e := range j.entries {
+		if e.expired(now) {
+			delete(j.entries, id)
+			continue
+		}
+		if e.Secure && !https {
+			continue
+		}
+		if e.HostOnly && host != e.Domain || !e.HostOnly && !domainMatch(host, e.Domain) {
+			continue
+		}
+		if !pathMatch(path, e.Path) {
+			continue
+		}
+		e.LastAccess = now
+		selected = append(selected, e)
+	}
+
+	// RFC 6265 5.4: uzun path önce, eşitse eski cookie önce
+	slices.SortFunc(selected, func(a, b *Entry) int {
+		if d := len(b.Path) - len(a.Path); d != 0 {
+			return d
+		}
+		return a.Created.Compare(b.Created)
+	})
+
+	out := make([]*http.Cookie, len(selected))
+	for i, e := range selected {
+		out[i] = &http.Cookie{Name: e.Name, Value: e.Value}
+	}
+	return out
+}
+
+// ---------------------------------------------------------------
+// İnceleme API'si (CLI bunları kullanır)
+// ---------------------------------------------------------------
+
+// All, süresi dolmamış tüm cookie'lerin kopyalarını domain/path/isim sırasıyla döndürür.
+func (j *Jar) All() []Entry {
+	now := j.now()
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	out := make([]Entry, 0, len(j.entries))
+	for _, e := range j.entries {
+		if !e.expired(now) {
+			out = append(out, *e)
+		}
+	}
+	slices.SortFunc(out, func(a, b Entry) int { return strings.Compare(a.id(), b.id()) })
+	return out
+}
+
+// Delete, domain'e (ve name boş değilse isme) uyan cookie'leri siler, silinen sayısını döndürür.
+func (j *Jar) Delete(domain, name string) int {
+	domain = strings.ToLower(strings.TrimPrefix(domain, "."))
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	n := 0
+	for id, e := range j.entries {
+		if (e.Domain == domain || domainMatch(e.Domain, domain)) && (name == "" || e.Name == name) {
+			delete(j.entries, id)
+			n++
+		}
+	}
+	return n
+}
+
+// Add, bir Entry'yi doğrudan ekler (içe aktarma için).
+func (j *Jar) Add(e Entry) {
+	if e.Created.IsZero() {
+		e.Created = j.now()
+	}
+	if e.LastAccess.IsZero() {
+		e.LastAccess = e.Created
+	}
+	e.Domain = strings.ToLower(strings.TrimPrefix(e.Domain, "."))
+	j.mu.Lock()
+	j.entries[e.id()] = &e
+	j.mu.Unlock()
+}
+
+// ---------------------------------------------------------------
+// Yardımcılar
+// ---------------------------------------------------------------
+
+func canonicalHost(host string) (string, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return "", errors.New("persistjar: boş host")
+	}
+	return host, nil
+}
+
+// domainMatch: host == domain veya host, "."+domain ile bitiyor (IP değilse).
+func domainMatch(host, domain string) bool {
+	if host == domain {
+		return true
+	}
+	return strings.HasSuffix(host, "."+domain) && net.ParseIP(host) == nil
+}
+
+// pathMatch, RFC 6265 5.1.4'teki path eşleşmesidir.
+func pathMatch(reqPath, cookiePath string) bool {
+	if reqPath == cookiePath {
+		return true
+	}
+	if strings.HasPrefix(reqPath, cookiePath) {
+		return strings.HasSuffix(cookiePath, "/") || reqPath[len(cookiePath)] == '/'
+	}
+	return false
+}
+
+// defaultPath, RFC 6265 5.1.4'teki varsayılan path'tir: son '/'e kadar olan kısım.
+func defaultPath(p string) string {
+	if p == "" || p[0] != '/' {
+		return "/"
+	}
+	i := strings.LastIndex(p, "/")
+	if i == 0 {
+		return "/"
+	}
+	return p[:i]
+}
+
+func sameSiteString(s http.SameSite) string {
+	switch s {
+	case http.SameSiteStrictMode:
+		return "Strict"
+	case http.SameSiteLaxMode:
+		return "Lax"
+	case http.SameSiteNoneMode:
+		return "None"
+	}
+	return ""
+}
+``
+/*
+---
+
+### 4️⃣ `persistjar/psl.go`
+*/
+``go
+package persistjar
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// PublicSuffixList, net/http/cookiejar.PublicSuffixList ile aynı arayüzdür.
+// Bu sayede aynı liste hem standart jar'a hem bu jar'a verilebilir.
+type PublicSuffixList interface {
+	PublicSuffix(domain string) string
+	String() string
+}
+
+// FileList, https://publicsuffix.org/list/public_suffix_list.dat formatındaki
+// bir dosyadan yüklenen public suffix listesidir.
+//
+// Kurallar:
+//
+//	com        → normal kural
+//	*.ck       → joker: ck altındaki her etiket bir suffix'tir
+//	!www.ck    → istisna: www.ck bir suffix DEĞİLDİR
+type FileList struct {
+	name       string
+	rules      map[string]bool
+	wildcards  map[string]bool
+	exceptions map[string]bool
+}
+
+// LoadPublicSuffixList, dosyayı okuyup bir FileList döndürür.
+func LoadPublicSuffixList(path string) (*FileList, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParsePublicSuffixList(path, f)
+}
+
+func ParsePublicSuffixList(name string, r io.Reader) (*FileList, error) {
+	l := &FileList{
+		name:       name,
+		rules:      make(map[string]bool),
+		wildcards:  make(map[string]bool),
+		exceptions: make(map[string]bool),
+	}
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		// Satırdaki ilk boşluğa kadar olan kısım kuraldır
+		rule := strings.ToLower(strings.Fields(line)[0])
+		switch {
+		case strings.HasPrefix(rule, "!"):
+			l.exceptions[rule[1:]] = true
+		case strings.HasPrefix(rule, "*."):
+			l.wildcards[rule[2:]] = true
+		default:
+			l.rules[rule] = true
+		}
+	}
+	return l, sc.Err()
+}
+
+// PublicSuffix, domain'in en uzun eşleşen public suffix'ini döndürür.
+// Hiçbir kural eşleşmezse varsayılan kural "*" uygulanır (son etiket).
+func (l *FileList) PublicSuffix(domain string) string {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	labels := strings.Split(domain, ".")
+
+	// Soldan sağa kısalan her aday için kontrol: ilk eşleşme en uzunudur
+	for i := range labels {
+		candidate := strings.Join(labels[i:], ".")
+		if l.exceptions[candidate] {
+			// İstisna kuralında suffix, istisnanın bir etiket kısasıdır
+			return strings.Join(labels[i+1:], ".")
+		}
+		if l.rules[candidate] {
+			return candidate
+		}
+		if i+1 < len(labels) && l.wildcards[strings.Join(labels[i+1:], ".")] {
+			return candidate
+		}
+	}
+	return labels[len(labels)-1]
+}
+
+func (l *FileList) String() string { return "file:" + l.name }
+``
+/*
+---
+
+### 5️⃣ `persistjar/format.go`
+*/
+``go
+package persistjar
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format, dosya formatını seçer.
+type Format int
+
+const (
+	JSON     Format = iota
+	Netscape        // curl / wget / tarayıcı eklentilerinin kullandığı cookies.txt
+)
+
+// FormatFor, dosya uzantısına göre formatı seçer.
+func FormatFor(path string) Format {
+	if strings.EqualFold(filepath.Ext(path), ".txt") {
+		return Netscape
+	}
+	return JSON
+}
+
+// Save, jar'ı Options.Filename dosyasına yazar.
+func (j *Jar) Save() error {
+	if j.opts.Filename == "" {
+		return fmt.Errorf("persistjar: Options.Filename boş")
+	}
+	return j.SaveAs(j.opts.Filename, FormatFor(j.opts.Filename))
+}
+
+// SaveAs, önce geçici dosyaya yazar sonra rename eder; böylece yazma
+// sırasında program çökse bile eski dosya bozulmaz.
+func (j *Jar) SaveAs(path string, format Format) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".cookies-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // rename başarılıysa zaten yok
+
+	if err := tmp.Chmod(0o600); err != nil { // cookie'ler oturum anahtarıdır
+		tmp.Close()
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	switch format {
+	case Netscape:
+		err = j.WriteNetscape(w)
+	default:
+		err = j.WriteJSON(w)
+	}
+	if err == nil {
+		err = w.Flush()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load, dosyadaki cookie'leri jar'a ekler (mevcutların üzerine yazar).
+func (j *Jar) Load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if FormatFor(path) == Netscape {
+		return j.ReadNetscape(f)
+	}
+	return j.ReadJSON(f)
+}
+
+// saved, kaydedilecek cookie'leri seçer: süresi dolanlar hiç, oturum
+// cookie'leri ise sadece KeepSessionCookies açıksa yazılır.
+func (j *Jar) saved() []Entry {
+	var out []Entry
+	for _, e := range j.All() {
+		if e.Persistent || j.opts.KeepSessionCookies {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (j *Jar) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(j.saved())
+}
+
+func (j *Jar) ReadJSON(r io.Reader) error {
+	var entries []Entry
+	if err := json.NewDecoder(r).Decode(&entries); err != nil {
+		return fmt.Errorf("persistjar: json: %w", err)
+	}
+	now := j.now()
+	for _, e := range entries {
+		if !e.expired(now) {
+			j.Add(e)
+		}
+	}
+	return nil
+}
+
+// WriteNetscape, cookies.txt formatında yazar. Her satır TAB ile ayrılmış 7 alandır:
+//
+//	domain  includeSubdomains  path  secure  expiry(unix)  name  value
+//
+// HttpOnly cookie'lerin satırı "#HttpOnly_" önekiyle başlar (curl uyumlu).
+//
+// Format SameSite ve oluşturma zamanı için alan içermez ve curl fazladan alan
+// içeren satırları reddeder. Bu yüzden ikisi de yazılmaz: ReadNetscape ile geri
+// okunan cookie'lerde SameSite boştur, Created ise okuma zamanıdır (aynı path'li
+// cookie'lerin Cookies'teki sırası korunmaz). Bu bilgiler gerekiyorsa JSON kullanın.
+func (j *Jar) WriteNetscape(w io.Writer) error {
+	fmt.Fprintln(w, "# Netscape HTTP Cookie File")
+	fmt.Fprintln(w, "# Bu dosya persistjar tarafından oluşturuldu. Elle düzenlemeyin.")
+	fmt.Fprintln(w)
+	for _, e := range j.saved() {
+		domain := e.Domain
+		if !e.HostOnly {
+			domain = "." + domain
+		}
+		if e.HttpOnly {
+			domain = "#HttpOnly_" + domain
+		}
+		var expiry int64
+		if e.Persistent {
+			expiry = e.Expires.Unix()
+		}
+		_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			domain, boolTF(!e.HostOnly), e.Path, boolTF(e.Secure), expiry, e.Name, e.Value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *Jar) ReadNetscape(r io.Reader) error {
+	now := j.now()
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimRight(sc.Text(), "\r")
+		httpOnly := false
+		if rest, ok := strings.CutPrefix(line, "#HttpOnly_"); ok {
+			line, httpOnly = rest, true
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f := strings.Split(line, "\t")
+		if len(f) != 7 {
+			return fmt.Errorf("persistjar: cookies.txt satır %d: 7 alan bekleniyordu, %d bulundu", n, len(f))
+		}
+		expiry, err := strconv.ParseInt(f[4], 10, 64)
+		if err != nil {
+			return fmt.Errorf("persistjar: cookies.txt satır %d: geçersiz expiry: %w", n, err)
+		}
+		e := Entry{
+			Domain:   f[0],
+			HostOnly: !strings.EqualFold(f[1], "TRUE"),
+			Path:     f[2],
+			Secure:   strings.EqualFold(f[3], "TRUE"),
+			Name:     f[5],
+			Value:    f[6],
+			HttpOnly: httpOnly,
+		}
+		if expiry > 0 {
+			e.Persistent = true
+			e.Expires = time.Unix(expiry, 0).UTC()
+		}
+		if !e.expired(now) {
+			j.Add(e)
+		}
+	}
+	return sc.Err()
+}
+
+func boolTF(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+``
+/*
+---
+
+### 6️⃣ `cmd/cookiectl/main.go`
+*/
+``go
+// cookiectl, persistjar dosyalarını listeler, siler, içe ve dışa aktarır.
+//
+//	cookiectl -jar cookies.json list [domain]
+//	cookiectl -jar cookies.json delete <domain> [isim]
+//	cookiectl -jar cookies.json import <cookies.txt|cookies.json>
+//	cookiectl -jar cookies.json export <cookies.txt|cookies.json>
+//	cookiectl -jar cookies.json check <url>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"cookiedemo/persistjar"
+)
+
+func main() {
+	jarFile := flag.String("jar", "cookies.json", "jar dosyası (.json veya .txt)")
+	pslFile := flag.String("psl", "", "public_suffix_list.dat dosyası (opsiyonel)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "kullanım: cookiectl [-jar dosya] [-psl dosya] list|delete|import|export|check ...")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	opts := &persistjar.Options{Filename: *jarFile, KeepSessionCookies: true}
+	if *pslFile != "" {
+		psl, err := persistjar.LoadPublicSuffixList(*pslFile)
+		if err != nil {
+			fatal(err)
+		}
+		opts.PublicSuffixList = psl
+	}
+	jar, err := persistjar.New(opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	args := flag.Args()
+	switch args[0] {
+	case "list":
+		filter := ""
+		if len(args) > 1 {
+			filter = strings.ToLower(args[1])
+		}
+		list(jar, filter)
+
+	case "delete":
+		if len(args) < 2 {
+			fatal(fmt.Errorf("delete: domain gerekli"))
+		}
+		name := ""
+		if len(args) > 2 {
+			name = args[2]
+		}
+		n := jar.Delete(args[1], name)
+		fmt.Printf("%d cookie silindi\n", n)
+		save(jar)
+
+	case "import":
+		if len(args) < 2 {
+			fatal(fmt.Errorf("import: dosya gerekli"))
+		}
+		before := len(jar.All())
+		if err := jar.Load(args[1]); err != nil {
+			fatal(err)
+		}
+		fmt.Printf("%d cookie içe aktarıldı\n", len(jar.All())-before)
+		save(jar)
+
+	case "export":
+		if len(args) < 2 {
+			fatal(fmt.Errorf("export: dosya gerekli"))
+		}
+		if err := jar.SaveAs(args[1], persistjar.FormatFor(args[1])); err != nil {
+			fatal(err)
+		}
+		fmt.Println("yazıldı:", args[1])
+
+	case "check":
+		// Bu URL'ye istek atılsa hangi cookie'ler gönderilirdi?
+		if len(args) < 2 {
+			fatal(fmt.Errorf("check: url gerekli"))
+		}
+		u, err := url.Parse(args[1])
+		if err != nil {
+			fatal(err)
+		}
+		for _, c := range jar.Cookies(u) {
+			fmt.Printf("%s=%s\n", c.Name, c.Value)
+		}
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+func list(jar *persistjar.Jar, filter string) {
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "DOMAIN\tPATH\tİSİM\tDEĞER\tBİTİŞ\tBAYRAKLAR")
+	for _, e := range jar.All() {
+		if filter != "" && e.Domain != filter && !strings.HasSuffix(e.Domain, "."+filter) {
+			continue
+		}
+		domain := e.Domain
+		if !e.HostOnly {
+			domain = "." + domain
+		}
+		expires := "oturum"
+		if e.Persistent {
+			expires = e.Expires.Local().Format(time.DateTime)
+		}
+		var flags []string
+		if e.Secure {
+			flags = append(flags, "Secure")
+		}
+		if e.HttpOnly {
+			flags = append(flags, "HttpOnly")
+		}
+		if e.SameSite != "" {
+			flags = append(flags, "SameSite="+e.SameSite)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			domain, e.Path, e.Name, truncate(e.Value, 24), expires, strings.Join(flags, ","))
+	}
+	tw.Flush()
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n-1] + "…"
+}
+
+func save(jar *persistjar.Jar) {
+	if err := jar.Save(); err != nil {
+		fatal(err)
+	}
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "cookiectl:", err)
+	os.Exit(1)
+}
+``
+/*
+---
+
+### 7️⃣ `cmd/scraper/main.go`
+*/
+``go
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"cookiedemo/persistjar"
+)
+
+func main() {
+	// Oturum cookie'leri de saklansın: giriş yapılmış oturum bir sonraki çalıştırmada devam eder
+	opts := &persistjar.Options{Filename: "cookies.json", KeepSessionCookies: true}
+
+	// public_suffix_list.dat: https://publicsuffix.org/list/public_suffix_list.dat
+	if psl, err := persistjar.LoadPublicSuffixList("public_suffix_list.dat"); err == nil {
+		opts.PublicSuffixList = psl
+	} else {
+		log.Println("uyarı: public suffix listesi yüklenemedi, koruma kapalı:", err)
+	}
+
+	// Dosya varsa önceki oturumun cookie'leri yüklenir
+	jar, err := persistjar.New(opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client := &http.Client{Jar: jar}
+
+	// Ctrl-C ile çıkılsa bile cookie'ler kaydedilsin
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt)
+	go func() {
+		<-sig
+		jar.Save()
+		os.Exit(130)
+	}()
+	defer func() {
+		if err := jar.Save(); err != nil {
+			log.Println("cookie'ler kaydedilemedi:", err)
+		}
+	}()
+
+	// 1️⃣ İlk çalıştırmada cookie'yi al (httpbin oturum cookie'si döner)
+	if len(jar.All()) == 0 {
+		fmt.Println("İlk çalıştırma: cookie alınıyor...")
+		get(client, "https://httpbin.org/cookies/set?session=abc123")
+	} else {
+		fmt.Printf("Önceki oturumdan %d cookie yüklendi\n", len(jar.All()))
+	}
+
+	// 2️⃣ Cookie otomatik gönderilir
+	fmt.Println(get(client, "https://httpbin.org/cookies"))
+}
+
+func get(client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	return string(body)
+}
+``
+/*
+---
+
+### 8️⃣ Açıklama
+
+1. **`SetCookies`** her cookie’yi RFC 6265’e göre süzer:
+   * `Domain` yoksa cookie **host-only** olur, alt domainlere gönderilmez.
+   * `Domain` bir public suffix ise (`co.uk`, `github.io`…) cookie **reddedilir**.
+   * `Domain`, isteğin host’unu kapsamıyorsa reddedilir (`a.com`, `b.com` için cookie yazamaz).
+   * `Secure` cookie’yi sadece `https` yazabilir.
+   * `http` üzerinden gelen bir cookie, aynı isimli ve path’i onunkini kapsayan bir `Secure` cookie’nin üzerine yazamaz, onu silemez de (RFC 6265bis 5.6). Aksi halde ağdaki bir saldırgan düz `http` yanıtıyla `https` oturum cookie’sini değiştirebilirdi.
+   * `__Host-` önekli cookie’ler Secure + host-only + `Path=/` olmak zorundadır.
+   * `SameSite=None`, `Secure` olmadan kabul edilmez.
+   * `Max-Age` her zaman `Expires`’tan önceliklidir. `Max-Age<0` veya geçmiş `Expires` cookie’yi **siler**.
+2. **`Cookies`** gönderilecek cookie’leri seçer:
+   * Süresi dolanları jar’dan temizler.
+   * `Secure` cookie’leri sadece `https` isteklere ekler.
+   * Domain ve path eşleşmesine bakar (`/a` cookie’si `/a/b`’ye gider, `/ab`’ye gitmez).
+   * Sonucu uzun path önce, eşitse eski cookie önce şeklinde sıralar.
+3. **`SameSite`** saklanır ve dışa aktarılır. Ancak `http.CookieJar` arayüzü isteğin hangi siteden tetiklendiğini bilmez. Bu yüzden (standart jar’da olduğu gibi) gönderim kararını etkilemez.
+4. **Kaydetme** önce geçici dosyaya yazar, sonra `os.Rename` yapar. Yazma sırasında program çökerse eski dosya bozulmaz. Dosya izni `0600`’dür, çünkü cookie’ler oturum anahtarıdır.
+5. **Oturum cookie’leri** (Expires/Max-Age yok) varsayılan olarak kaydedilmez. Scraper’da `KeepSessionCookies: true` ile oturumun bir sonraki çalıştırmada devam etmesi sağlanır.
+6. **Netscape formatı** `SameSite` bilgisini ve oluşturma zamanını taşıyamaz. curl fazladan alan içeren satırları reddettiği için bunlar ek alan olarak da yazılmaz. Geri okunan cookie’lerde `SameSite` boş, `Created` ise okuma zamanı olur. Bu yüzden aynı path’li cookie’lerin gönderim sırası korunmaz. Bu bilgileri korumak istiyorsan JSON kullan.
+7. **`PublicSuffixList`** arayüzü `net/http/cookiejar` ile birebir aynıdır. Yani aynı `FileList`, `cookiejar.New(&cookiejar.Options{PublicSuffixList: psl})` ile standart jar’a da verilebilir.
+
+---
+
+### 9️⃣ Test
+*/
+``bash
+# Public suffix listesini bir kez indir
+curl -o public_suffix_list.dat https://publicsuffix.org/list/public_suffix_list.dat
+
+# İlk çalıştırma: cookie alınır ve cookies.json'a kaydedilir
+go run ./cmd/scraper
+# İlk çalıştırma: cookie alınıyor...
+# {"cookies": {"session": "abc123"}}
+
+# İkinci çalıştırma: login yok, cookie dosyadan gelir
+go run ./cmd/scraper
+# Önceki oturumdan 1 cookie yüklendi
+# {"cookies": {"session": "abc123"}}
+
+# İçeriği incele
+go run ./cmd/cookiectl -jar cookies.json list
+# DOMAIN       PATH  İSİM     DEĞER   BİTİŞ   BAYRAKLAR
+# httpbin.org  /     session  abc123  oturum
+
+# curl ile paylaş
+go run ./cmd/cookiectl -jar cookies.json export cookies.txt
+curl -b cookies.txt https://httpbin.org/cookies
+
+# Tarayıcı eklentisinden alınan cookies.txt'yi içe aktar
+go run ./cmd/cookiectl -jar cookies.json -psl public_suffix_list.dat import browser-cookies.txt
+
+# Bu URL'ye hangi cookie'ler gider?
+go run ./cmd/cookiectl -jar cookies.json check https://httpbin.org/get
+
+# Sil
+go run ./cmd/cookiectl -jar cookies.json delete httpbin.org session
+``
+/*
+Örnek `cookies.txt` çıktısı:
+*/
+``
+# Netscape HTTP Cookie File
+# Bu dosya persistjar tarafından oluşturuldu. Elle düzenlemeyin.
+
+#HttpOnly_.example.co.uk	TRUE	/a	FALSE	1792352089	b	2
+www.example.co.uk	FALSE	/	TRUE	0	__Host-x	4
+``
+/*
+---
+
+### 🧪 Testler (`persistjar/jar_test.go`)
+
+Testler küçük bir public suffix listesi ve `Jar.now` alanına verilen sahte bir saat kullanır. Ağ bağlantısı gerekmez. Public suffix reddi, `__Secure-` / `__Host-` önekleri, `http`’nin `Secure` cookie’yi ezememesi, süre dolumu ve iki formatta kaydet/yükle döngüsü test edilir.
+*/
+``go
+package persistjar
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPSL = `
+// test listesi
+com
+uk
+co.uk
+*.ck
+!www.ck
+github.io
+`
+
+var t0 = time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func newTestJar(t *testing.T, opts *Options) (*Jar, *time.Time) {
+	t.Helper()
+	psl, err := ParsePublicSuffixList("test", strings.NewReader(testPSL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	opts.PublicSuffixList = psl
+	j, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := t0
+	j.now = func() time.Time { return now }
+	return j, &now
+}
+
+func mustURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+// sent, u'ya gönderilecek cookie'leri "a=1 b=2" biçiminde döndürür.
+func sent(j *Jar, u string) string {
+	var s []string
+	for _, c := range j.Cookies(mustURL(u)) {
+		s = append(s, c.Name+"="+c.Value)
+	}
+	return strings.Join(s, " ")
+}
+
+func TestPublicSuffix(t *testing.T) {
+	psl, _ := ParsePublicSuffixList("test", strings.NewReader(testPSL))
+	for domain, want := range map[string]string{
+		"www.example.co.uk": "co.uk",
+		"example.com":       "com",
+		"a.b.ck":            "b.ck",
+		"www.ck":            "ck",
+		"user.github.io":    "github.io",
+		"example.test":      "test", // kural yok: son etiket
+	} {
+		if got := psl.PublicSuffix(domain); got != want {
+			t.Errorf("PublicSuffix(%q) = %q, beklenen %q", domain, got, want)
+		}
+	}
+}
+
+func TestDomainRejected(t *testing.T) {
+	j, _ := newTestJar(t, nil)
+	u := mustURL("https://www.example.co.uk/")
+	j.SetCookies(u, []*http.Cookie{
+		{Name: "suffix", Value: "1", Domain: "co.uk"},
+		{Name: "suffix2", Value: "1", Domain: ".uk"},
+		{Name: "other", Value: "1", Domain: "other.co.uk"},
+		{Name: "ok", Value: "1", Domain: "example.co.uk"},
+	})
+	if got := sent(j, "https://example.co.uk/"); got != "ok=1" {
+		t.Errorf("example.co.uk: %q", got)
+	}
+	if got := sent(j, "https://other.co.uk/"); got != "" {
+		t.Errorf("başka site cookie aldı: %q", got)
+	}
+
+	// Host'un kendisi bir suffix ise cookie host-only olarak kabul edilir
+	j.SetCookies(mustURL("https://github.io/"), []*http.Cookie{{Name: "h", Value: "1", Domain: "github.io"}})
+	if got := sent(j, "https://user.github.io/"); got != "" {
+		t.Errorf("suffix host'un cookie'si alt domaine gitti: %q", got)
+	}
+	if got := sent(j, "https://github.io/"); got != "h=1" {
+		t.Errorf("github.io: %q", got)
+	}
+}
+
+func TestPrefixesAndSecure(t *testing.T) {
+	j, now := newTestJar(t, nil)
+	https := mustURL("https://www.example.com/app/x")
+	j.SetCookies(https, []*http.Cookie{
+		{Name: "__Secure-a", Value: "1"},                                               // Secure yok
+		{Name: "__Secure-b", Value: "2", Secure: true},                                 // geçerli
+		{Name: "__Host-c", Value: "3", Secure: true},                                   // Path=/ yok
+		{Name: "__Host-d", Value: "4", Secure: true, Path: "/", Domain: "example.com"}, // host-only değil
+		{Name: "__Host-e", Value: "5", Secure: true, Path: "/"},                        // geçerli
+		{Name: "none", Value: "6", SameSite: http.SameSiteNoneMode},                    // SameSite=None, Secure yok
+	})
+	if got := sent(j, "https://www.example.com/app/y"); got != "__Secure-b=2 __Host-e=5" {
+		t.Errorf("https: %q", got)
+	}
+	if got := sent(j, "http://www.example.com/app/y"); got != "" {
+		t.Errorf("Secure cookie http'ye gitti: %q", got)
+	}
+
+	// http, Secure cookie yazamaz ve aynı isimli Secure cookie'yi ezemez/silemez
+	http1 := mustURL("http://www.example.com/app/x")
+	*now = t0.Add(time.Second)
+	j.SetCookies(https, []*http.Cookie{{Name: "sid", Value: "secure", Secure: true, Domain: "example.com"}})
+	j.SetCookies(http1, []*http.Cookie{
+		{Name: "s", Value: "1", Secure: true},
+		{Name: "sid", Value: "evil"},
+		{Name: "sid", Value: "evil", Path: "/app"},
+		{Name: "sid", MaxAge: -1, Domain: "example.com"},
+	})
+	if got := sent(j, "https://www.example.com/app/y"); got != "__Secure-b=2 sid=secure __Host-e=5" {
+		t.Errorf("http Secure cookie'yi değiştirdi: %q", got)
+	}
+	// Secure cookie'nin path'i yeni cookie'nin path'ini kapsamıyorsa http yazabilir
+	j.SetCookies(mustURL("http://example.com/"), []*http.Cookie{{Name: "sid", Value: "plain", Path: "/"}})
+	*now = t0.Add(2 * time.Second)
+	j.SetCookies(mustURL("http://example.com/"), []*http.Cookie{{Name: "lang", Value: "tr"}})
+	if got := sent(j, "http://example.com/"); got != "sid=plain lang=tr" {
+		t.Errorf("http: %q", got)
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	j, now := newTestJar(t, nil)
+	u := mustURL("https://example.com/")
+	j.SetCookies(u, []*http.Cookie{
+		{Name: "session", Value: "1", Path: "/a/b/c"},
+		{Name: "maxage", Value: "2", Path: "/a/b", MaxAge: 60, Expires: t0.Add(time.Hour)}, // Max-Age öncelikli
+		{Name: "expires", Value: "3", Path: "/a", Expires: t0.Add(2 * time.Minute)},
+		{Name: "past", Value: "4", Expires: t0.Add(-time.Second)},
+	})
+	if got := sent(j, "https://example.com/a/b/c"); got != "session=1 maxage=2 expires=3" {
+		t.Fatalf("başlangıç: %q", got)
+	}
+
+	*now = t0.Add(90 * time.Second)
+	if got := sent(j, "https://example.com/a/b/c"); got != "session=1 expires=3" {
+		t.Errorf("90 sn sonra: %q", got)
+	}
+
+	// Max-Age<0 ve geçmiş Expires cookie'yi siler
+	j.SetCookies(u, []*http.Cookie{
+		{Name: "session", Path: "/a/b/c", MaxAge: -1},
+		{Name: "expires", Path: "/a", Expires: t0},
+	})
+	if got := sent(j, "https://example.com/a/b/c"); got != "" {
+		t.Errorf("silme: %q", got)
+	}
+	if n := len(j.All()); n != 0 {
+		t.Errorf("All: %d cookie", n)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"cookies.json", "cookies.txt"} {
+		path := filepath.Join(dir, name)
+		j, now := newTestJar(t, &Options{Filename: path})
+		j.SetCookies(mustURL("https://www.example.co.uk/a/x"), []*http.Cookie{
+			{Name: "a", Value: "1", Path: "/a/x", MaxAge: 3600, Secure: true, SameSite: http.SameSiteLaxMode},
+			{Name: "b", Value: "2", MaxAge: 3600, Domain: "example.co.uk", HttpOnly: true, Path: "/a"},
+			{Name: "session", Value: "3"}, // KeepSessionCookies kapalı: yazılmaz
+		})
+		*now = t0.Add(time.Minute)
+		sent(j, "https://www.example.co.uk/a/x") // LastAccess güncellenir
+		if err := j.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		loaded, lnow := newTestJar(t, nil)
+		*lnow = t0.Add(time.Minute)
+		if err := loaded.Load(path); err != nil {
+			t.Fatal(err)
+		}
+		got, want := loaded.All(), slicesWithout(j.All(), "session")
+		if FormatFor(path) == Netscape {
+			// Netscape formatı SameSite, Created ve LastAccess taşımaz
+			for i := range want {
+				want[i].SameSite = ""
+				want[i].Created, want[i].LastAccess = got[i].Created, got[i].LastAccess
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s:\n got  %+v\n want %+v", name, got, want)
+		}
+		if s := sent(loaded, "https://www.example.co.uk/a/x"); s != "a=1 b=2" {
+			t.Errorf("%s: yüklenen jar %q gönderdi", name, s)
+		}
+
+		// Süresi dolan cookie'ler yüklenmez
+		expired, enow := newTestJar(t, nil)
+		*enow = t0.Add(2 * time.Hour)
+		if err := expired.Load(path); err != nil || len(expired.All()) != 0 {
+			t.Errorf("%s: süresi dolan cookie yüklendi: %v %v", name, err, expired.All())
+		}
+	}
+}
+
+func TestNetscapeFormat(t *testing.T) {
+	j, _ := newTestJar(t, nil)
+	j.SetCookies(mustURL("https://www.example.co.uk/a/x"), []*http.Cookie{
+		{Name: "b", Value: "2", MaxAge: 3600, Domain: "example.co.uk", HttpOnly: true},
+		{Name: "__Host-x", Value: "4", Secure: true, Path: "/", MaxAge: 60},
+	})
+	var buf bytes.Buffer
+	if err := j.WriteNetscape(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "#HttpOnly_.example.co.uk\tTRUE\t/a\tFALSE\t1735736400\tb\t2\n" +
+		"www.example.co.uk\tFALSE\t/\tTRUE\t1735732860\t__Host-x\t4\n"
+	if _, got, _ := strings.Cut(buf.String(), "\n\n"); got != want {
+		t.Errorf("WriteNetscape:\n%s", buf.String())
+	}
+
+	for _, line := range []string{"a\tb\tc", "x.com\tFALSE\t/\tFALSE\tyarın\tn\tv"} {
+		if err := j.ReadNetscape(strings.NewReader(line)); err == nil {
+			t.Errorf("%q için hata bekleniyordu", line)
+		}
+	}
+}
+
+func slicesWithout(entries []Entry, name string) []Entry {
+	var out []Entry
+	for _, e := range entries {
+		if e.Name != name {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+``
+/*
+Çıktı:
+
+``bash
+$ go test -race -v ./persistjar
+=== RUN   TestPublicSuffix
+--- PASS: TestPublicSuffix (0.00s)
+=== RUN   TestDomainRejected
+--- PASS: TestDomainRejected (0.00s)
+=== RUN   TestPrefixesAndSecure
+--- PASS: TestPrefixesAndSecure (0.00s)
+=== RUN   TestExpiry
+--- PASS: TestExpiry (0.00s)
+=== RUN   TestSaveLoadRoundTrip
+--- PASS: TestSaveLoadRoundTrip (0.01s)
+=== RUN   TestNetscapeFormat
+--- PASS: TestNetscapeFormat (0.00s)
+PASS
+ok  	cookiedemo/persistjar	1.024s
+``
+
+---
+
+Bu proje ile artık:
+
+* Cookie’ler programlar arasında **kalıcı**,
+* Public suffix kuralları **yerel dosyadan** uygulanıyor,
+* Jar içeriği CLI ile **listelenip düzenlenebiliyor**,
+* `cookies.txt` sayesinde curl, wget ve tarayıcı eklentileriyle **aynı dosya** paylaşılabiliyor ✅
+*/
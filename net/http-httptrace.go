@@ -267,4 +267,922 @@ Bu proje ile artık:
 İstersen bir sonraki adımda **bunu PDF veya Excel tablosu** hâline getirip, tüm callback’leri ve örnek çıktılarıyla görselleştirebilirim.
 
 Bunu yapayım mı?
-*/
\ No newline at end of file
+*/
+
+/*
+---
+
+# 🌐 `httptrace` ile HTTP Zamanlama Profiler'ı (`curl -w` benzeri CLI)
+
+Yukarıdaki proje, tek bir istek için callback olaylarını **ham** olarak ekrana basıyor.
+Gerçek bir performans incelemesinde ise şunları bilmek isteriz:
+
+* Her **fazın süresi**: DNS, TCP bağlantısı, TLS, isteğin gönderilmesi, sunucunun düşünmesi (TTFB), gövdenin aktarımı
+* Bağlantı **yeniden kullanıldı mı** (keep-alive)?
+* **Yönlendirme** (redirect) varsa her adımın ayrı zamanlaması
+* Aynı isteği **N kez**, belirli bir **eşzamanlılıkla** atınca dağılım: p50, p90, p99
+* Sonucu göz ile okumak için **waterfall**, başka araçlara vermek için **JSON**
+
+Bu bölümde bunları yapan küçük bir CLI yazıyoruz: `httpprof`.
+
+---
+
+## 📂 Proje Yapısı
+*/
+``
+httpprof/
+│── go.mod                 (module httpprof)
+│── main.go                → CLI: bayraklar, çıktı
+└── timing/
+    ├── trace.go           → ClientTrace → fazlar, Measure, Run
+    ├── stats.go           → yüzdelikler (p50/p90/p99)
+    ├── report.go          → ASCII waterfall + özet tablo
+    └── timing_test.go     → httptest.NewTLSServer ile testler
+``
+/*
+---
+
+## ⏱️ Fazlar ve Callback'ler
+
+| Faz        | Başlangıç callback'i     | Bitiş callback'i         | Anlamı                                  |
+| ---------- | ------------------------ | ------------------------ | --------------------------------------- |
+| `dns`      | `DNSStart`               | `DNSDone`                | İsim çözümleme                          |
+| `connect`  | `ConnectStart`           | `ConnectDone`            | TCP bağlantısı                          |
+| `tls`      | `TLSHandshakeStart`      | `TLSHandshakeDone`       | TLS el sıkışması                        |
+| `send`     | `GotConn`                | `WroteRequest`           | Başlıklar + gövdenin yazılması          |
+| `wait`     | `WroteRequest`           | `GotFirstResponseByte`   | Sunucu işlem süresi (TTFB)              |
+| `transfer` | `GotFirstResponseByte`   | gövde sonuna kadar okundu | Yanıt gövdesinin indirilmesi           |
+
+Bağlantı havuzdan geldiyse (`GotConnInfo.Reused == true`) `dns`, `connect` ve `tls` fazları **hiç yaşanmaz**.
+
+---
+
+## 📄 `timing/trace.go`
+*/
+``go
+// Package timing, httptrace.ClientTrace olaylarını istek başına fazlara
+// (DNS, bağlantı, TLS, gönderim, bekleme, aktarım) dönüştürür.
+package timing
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+// Fazlar, waterfall ve özet tablosundaki sırayla.
+const (
+	PhaseDNS      = "dns"
+	PhaseConnect  = "connect"
+	PhaseTLS      = "tls"
+	PhaseSend     = "send"     // bağlantı alındı → istek yazıldı
+	PhaseWait     = "wait"     // istek yazıldı → ilk byte (sunucu süresi, TTFB)
+	PhaseTransfer = "transfer" // ilk byte → gövdenin sonu
+)
+
+// Phases, tüm fazların sıralı listesidir.
+var Phases = []string{PhaseDNS, PhaseConnect, PhaseTLS, PhaseSend, PhaseWait, PhaseTransfer}
+
+// Span, isteğin başlangıcına göre bir fazın başlangıç ve bitişidir.
+// End == 0 ise faz hiç yaşanmamıştır (ör: yeniden kullanılan bağlantıda DNS).
+type Span struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Duration, fazın süresidir.
+func (s Span) Duration() time.Duration {
+	if s.End == 0 {
+		return 0
+	}
+	return s.End - s.Start
+}
+
+// MarshalJSON, süreleri milisaniye olarak yazar; yaşanmamış faz null olur.
+func (s Span) MarshalJSON() ([]byte, error) {
+	if s.End == 0 {
+		return []byte("null"), nil
+	}
+	return json.Marshal(struct {
+		StartMs float64 `json:"start_ms"`
+		DurMs   float64 `json:"dur_ms"`
+	}{ms(s.Start), ms(s.Duration())})
+}
+
+func ms(d time.Duration) float64 { return float64(d.Microseconds()) / 1000 }
+
+// Hop, bir yönlendirme zincirindeki tek bir HTTP isteğidir.
+type Hop struct {
+	Method     string `json:"method"`
+	URL        string `json:"url"`
+	Status     int    `json:"status"`
+	Proto      string `json:"proto,omitempty"`
+	Addr       string `json:"addr,omitempty"`
+	Reused     bool   `json:"reused"`
+	TLSVersion string `json:"tls_version,omitempty"`
+
+	Start time.Duration `json:"-"`
+	End   time.Duration `json:"-"`
+
+	DNS      Span `json:"dns"`
+	Connect  Span `json:"connect"`
+	TLS      Span `json:"tls"`
+	Send     Span `json:"send"`
+	Wait     Span `json:"wait"`
+	Transfer Span `json:"transfer"`
+
+	gotConn bool
+}
+
+// Phase, isme göre fazın Span'ini döndürür.
+func (h *Hop) Phase(name string) Span {
+	switch name {
+	case PhaseDNS:
+		return h.DNS
+	case PhaseConnect:
+		return h.Connect
+	case PhaseTLS:
+		return h.TLS
+	case PhaseSend:
+		return h.Send
+	case PhaseWait:
+		return h.Wait
+	case PhaseTransfer:
+		return h.Transfer
+	}
+	return Span{}
+}
+
+// Result, yönlendirmeler dahil tek bir ölçümün sonucudur.
+type Result struct {
+	Start  time.Time     `json:"start"`
+	Total  time.Duration `json:"-"`
+	Status int           `json:"status"`
+	Bytes  int64         `json:"bytes"`
+	Err    string        `json:"error,omitempty"`
+	Hops   []*Hop        `json:"hops"`
+}
+
+// MarshalJSON, Total'ı milisaniye olarak ekler.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	type plain Result
+	return json.Marshal(struct {
+		*plain
+		TotalMs float64 `json:"total_ms"`
+	}{(*plain)(r), ms(r.Total)})
+}
+
+// PhaseTotal, fazın tüm hop'lardaki toplam süresini ve fazın en az bir kez
+// yaşanıp yaşanmadığını döndürür.
+func (r *Result) PhaseTotal(name string) (time.Duration, bool) {
+	var d time.Duration
+	seen := false
+	for _, h := range r.Hops {
+		if s := h.Phase(name); s.End != 0 {
+			d += s.Duration()
+			seen = true
+		}
+	}
+	return d, seen
+}
+
+// recorder, ClientTrace callback'lerini Result'a yazar. Callback'ler farklı
+// goroutine'lerden çağrılabildiği için (ör: Happy Eyeballs) mutex gerekir.
+type recorder struct {
+	mu    sync.Mutex
+	start time.Time
+	res   *Result
+	hop   *Hop
+}
+
+func (r *recorder) now() time.Duration { return time.Since(r.start) }
+
+// closeHop, açık hop'u verilen anda kapatır.
+func (r *recorder) closeHop(at time.Duration) {
+	if r.hop == nil {
+		return
+	}
+	if r.hop.Transfer.Start != 0 {
+		r.hop.Transfer.End = at
+	}
+	r.hop.End = at
+	r.hop = nil
+}
+
+func (r *recorder) trace() *httptrace.ClientTrace {
+	// lock, callback gövdesini kilit altında ve açık bir hop varsa çalıştırır.
+	lock := func(f func(h *Hop, now time.Duration)) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.hop != nil {
+			f(r.hop, r.now())
+		}
+	}
+	return &httptrace.ClientTrace{
+		// Her GetConn yeni bir hop başlatır: yönlendirmede client aynı context ile
+		// yeni bir istek yapar, trace callback'leri tekrar tetiklenir.
+		GetConn: func(hostPort string) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			now := r.now()
+			r.closeHop(now)
+			r.hop = &Hop{Addr: hostPort, Start: now}
+			r.res.Hops = append(r.res.Hops, r.hop)
+		},
+		// Bağlantı alındıktan sonra gelen dial olayları başka bir isteğe gidecek
+		// bağlantıya aittir (havuza düşer); sayılmaz.
+		DNSStart: func(httptrace.DNSStartInfo) {
+			lock(func(h *Hop, now time.Duration) {
+				if !h.gotConn {
+					h.DNS.Start = now
+				}
+			})
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			lock(func(h *Hop, now time.Duration) {
+				if !h.gotConn {
+					h.DNS.End = now
+				}
+			})
+		},
+		ConnectStart: func(network, addr string) {
+			lock(func(h *Hop, now time.Duration) {
+				if !h.gotConn && h.Connect.Start == 0 {
+					h.Connect.Start = now
+				}
+			})
+		},
+		ConnectDone: func(network, addr string, err error) {
+			lock(func(h *Hop, now time.Duration) {
+				if !h.gotConn && err == nil {
+					h.Connect.End = now
+				}
+			})
+		},
+		TLSHandshakeStart: func() {
+			lock(func(h *Hop, now time.Duration) {
+				if !h.gotConn {
+					h.TLS.Start = now
+				}
+			})
+		},
+		TLSHandshakeDone: func(state tls.ConnectionState, err error) {
+			lock(func(h *Hop, now time.Duration) {
+				if !h.gotConn && err == nil {
+					h.TLS.End = now
+					h.TLSVersion = tls.VersionName(state.Version)
+				}
+			})
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			lock(func(h *Hop, now time.Duration) {
+				h.gotConn = true
+				h.Reused = info.Reused
+				h.Addr = info.Conn.RemoteAddr().String()
+				h.Send.Start = now
+			})
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			lock(func(h *Hop, now time.Duration) {
+				h.Send.End = now
+				h.Wait.Start = now
+			})
+		},
+		GotFirstResponseByte: func() {
+			lock(func(h *Hop, now time.Duration) {
+				h.Wait.End = now
+				h.Transfer.Start = now
+			})
+		},
+	}
+}
+
+// Measure, isteği client ile gönderir, gövdeyi sonuna kadar okur ve her fazın
+// zamanlamasını döndürür. İstek hatası Result.Err'e yazılır.
+func Measure(client *http.Client, req *http.Request) *Result {
+	rec := &recorder{start: time.Now(), res: &Result{}}
+	rec.res.Start = rec.start
+	ctx := httptrace.WithClientTrace(req.Context(), rec.trace())
+
+	resp, err := client.Do(req.WithContext(ctx))
+	if err == nil {
+		rec.res.Bytes, err = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	rec.res.Total = rec.now()
+	rec.closeHop(rec.res.Total)
+	if err != nil {
+		rec.res.Err = err.Error()
+	}
+	if resp != nil {
+		rec.res.Status = resp.StatusCode
+		label(rec.res.Hops, resp)
+	}
+	return rec.res
+}
+
+// label, yönlendirme zincirini sondan başa yürüyerek hop'lara method, URL ve
+// durum kodunu yazar: resp.Request.Response, o isteğe yol açan önceki yanıttır.
+func label(hops []*Hop, resp *http.Response) {
+	for i := len(hops) - 1; i >= 0 && resp != nil; i-- {
+		h := hops[i]
+		h.Method = resp.Request.Method
+		h.URL = resp.Request.URL.String()
+		h.Status = resp.StatusCode
+		h.Proto = resp.Proto
+		resp = resp.Request.Response
+	}
+}
+
+// Run, newReq ile üretilen istekleri n kez, en fazla concurrency tanesi aynı
+// anda olacak şekilde ölçer. Sonuçlar başlama sırasıyla döner.
+func Run(ctx context.Context, client *http.Client, newReq func(context.Context) (*http.Request, error), n, concurrency int) []*Result {
+	results := make([]*Result, n)
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, min(concurrency, n)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Her worker isteklerini sırayla yapar: ilk isteği bağlantı kurar,
+			// sonrakiler keep-alive ile bağlantıyı yeniden kullanır.
+			for i := range next {
+				req, err := newReq(ctx)
+				if err != nil {
+					results[i] = &Result{Start: time.Now(), Err: err.Error()}
+					continue
+				}
+				results[i] = Measure(client, req)
+			}
+		}()
+	}
+	for i := range n {
+		if ctx.Err() != nil {
+			break
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	// İptal edildiyse hiç başlamamış ölçümleri at
+	done := results[:0]
+	for _, r := range results {
+		if r != nil {
+			done = append(done, r)
+		}
+	}
+	return done
+}
+``
+/*
+---
+
+### 🔎 Dikkat Edilen Noktalar
+
+1. **Yönlendirmeler** → `http.Client` yönlendirmede aynı context ile yeni bir istek yapar; trace callback'leri **tekrar** tetiklenir. Her `GetConn` yeni bir `Hop` başlatır. Method, URL ve durum kodu istek bitince `resp.Request.Response` zinciri sondan başa yürünerek doldurulur.
+2. **Eşzamanlı callback'ler** → Happy Eyeballs (IPv4/IPv6 yarışı) sırasında `ConnectStart`/`ConnectDone` farklı goroutine'lerden gelebilir. Bu yüzden `recorder` bir mutex kullanır.
+3. **Başka isteğe giden dial** → İstek bir dial başlattıktan sonra havuzdan boş bir bağlantı alabilir. Dial yine de tamamlanır ve bağlantı havuza düşer. `GotConn`'dan sonra gelen dial olayları bu yüzden sayılmaz.
+4. **Transfer fazı** → `GotFirstResponseByte` sadece ilk byte'ı bildirir. Gövdenin sonu için `Measure` gövdeyi `io.Discard`'a kopyalar ve bitiş anını kendisi kaydeder.
+5. **Run** → Her worker isteklerini **sırayla** yapar. İlk isteği bağlantı kurar, sonrakiler keep-alive ile bağlantıyı yeniden kullanır. `-c 4 -n 20` → 4 soğuk, 16 sıcak istek.
+
+---
+
+## 📄 `timing/stats.go`
+*/
+``go
+package timing
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"time"
+)
+
+// Stat, bir fazın tüm ölçümlerdeki dağılımıdır.
+type Stat struct {
+	Phase string
+	Count int // fazın yaşandığı ölçüm sayısı
+	Min   time.Duration
+	Mean  time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// MarshalJSON, süreleri Span gibi milisaniye olarak yazar.
+func (s Stat) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"phase":   s.Phase,
+		"count":   s.Count,
+		"min_ms":  ms(s.Min),
+		"mean_ms": ms(s.Mean),
+		"p50_ms":  ms(s.P50),
+		"p90_ms":  ms(s.P90),
+		"p99_ms":  ms(s.P99),
+		"max_ms":  ms(s.Max),
+	})
+}
+
+// Summarize, başarılı ölçümlerden her faz ve toplam süre için istatistik
+// üretir. Bir faz bir ölçümde birden çok hop'ta yaşandıysa süreler toplanır.
+func Summarize(results []*Result) []Stat {
+	var stats []Stat
+	for _, phase := range Phases {
+		var ds []time.Duration
+		for _, r := range results {
+			if r.Err != "" {
+				continue
+			}
+			if d, ok := r.PhaseTotal(phase); ok {
+				ds = append(ds, d)
+			}
+		}
+		stats = append(stats, newStat(phase, ds))
+	}
+
+	var totals []time.Duration
+	for _, r := range results {
+		if r.Err == "" {
+			totals = append(totals, r.Total)
+		}
+	}
+	return append(stats, newStat("total", totals))
+}
+
+func newStat(phase string, ds []time.Duration) Stat {
+	s := Stat{Phase: phase, Count: len(ds)}
+	if len(ds) == 0 {
+		return s
+	}
+	slices.Sort(ds)
+	var sum time.Duration
+	for _, d := range ds {
+		sum += d
+	}
+	s.Min, s.Max = ds[0], ds[len(ds)-1]
+	s.Mean = sum / time.Duration(len(ds))
+	s.P50 = Percentile(ds, 50)
+	s.P90 = Percentile(ds, 90)
+	s.P99 = Percentile(ds, 99)
+	return s
+}
+
+// Percentile, sıralı dilimde "nearest-rank" yöntemiyle p. yüzdeliği döndürür:
+// değerlerin en az p%'i dönen değere eşit ya da ondan küçüktür.
+func Percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	rank = min(max(rank, 1), len(sorted))
+	return sorted[rank-1]
+}
+``
+/*
+---
+
+> 📌 **Nearest-rank** yöntemi, ölçülmüş gerçek bir değeri döndürür (iki ölçüm arasında enterpolasyon yapmaz).
+> `n = 20` iken p99, en yavaş ölçümün kendisidir. Az örnekle yüksek yüzdelikler bu yüzden max'a eşit çıkar.
+
+---
+
+## 📄 `timing/report.go`
+*/
+``go
+package timing
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// WriteWaterfall, bir ölçümün her hop'unu ve fazını ortak bir zaman ekseninde
+// çubuk olarak çizer. width, çubuk alanının karakter genişliğidir.
+//
+//	GET https://example.com/ → 200 HTTP/1.1 (yeni bağlantı 93.184.215.14:443, TLS 1.3)
+//	  dns       |██                                      |   4.1ms
+//	  connect   |  ████                                  |  11.8ms
+func WriteWaterfall(w io.Writer, r *Result, width int) {
+	if r.Err != "" {
+		fmt.Fprintf(w, "HATA: %s\n", r.Err)
+	}
+	if r.Total <= 0 {
+		return
+	}
+	col := func(d time.Duration) int {
+		return min(int(int64(d)*int64(width)/int64(r.Total)), width)
+	}
+
+	for _, h := range r.Hops {
+		conn := "yeni bağlantı"
+		if h.Reused {
+			conn = "yeniden kullanıldı"
+		}
+		if h.Addr != "" {
+			conn += " " + h.Addr
+		}
+		if h.TLSVersion != "" {
+			conn += ", " + h.TLSVersion
+		}
+		fmt.Fprintf(w, "%s %s → %d %s (%s)\n", h.Method, h.URL, h.Status, h.Proto, conn)
+
+		for _, name := range Phases {
+			s := h.Phase(name)
+			if s.End == 0 {
+				continue
+			}
+			from := col(s.Start)
+			to := max(col(s.End), from+1) // çok kısa fazlar da en az 1 karakter
+			if to > width {
+				from, to = width-1, width
+			}
+			bar := strings.Repeat(" ", from) + strings.Repeat("█", to-from) + strings.Repeat(" ", width-to)
+			fmt.Fprintf(w, "  %-9s |%s| %9s\n", name, bar, round(s.Duration()))
+		}
+	}
+	fmt.Fprintf(w, "  %-9s  %s  %9s  (%d byte)\n", "total", strings.Repeat(" ", width), round(r.Total), r.Bytes)
+}
+
+// WriteSummary, Summarize çıktısını tablo olarak yazar.
+func WriteSummary(w io.Writer, stats []Stat) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "faz\tn\tmin\tort\tp50\tp90\tp99\tmax\t")
+	for _, s := range stats {
+		if s.Count == 0 {
+			fmt.Fprintf(tw, "%s\t0\t-\t-\t-\t-\t-\t-\t\n", s.Phase)
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t\n", s.Phase, s.Count,
+			round(s.Min), round(s.Mean), round(s.P50), round(s.P90), round(s.P99), round(s.Max))
+	}
+	tw.Flush()
+}
+
+// round, süreyi okunabilir bir hassasiyete yuvarlar (1.234567ms → 1.23ms).
+func round(d time.Duration) time.Duration {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond)
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond)
+	default:
+		return d.Round(time.Microsecond)
+	}
+}
+``
+/*
+---
+
+## 📄 `main.go`
+*/
+``go
+// httpprof, bir URL'ye yapılan isteklerin DNS, bağlantı, TLS, bekleme ve
+// aktarım sürelerini ölçer (curl -w gibi, ama tekrar ve eşzamanlılıkla).
+//
+//	httpprof -n 50 -c 5 https://example.com/
+//	httpprof -X POST -d '{"a":1}' -H 'Content-Type: application/json' -json https://httpbin.org/post
+package main
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"time"
+
+	"httpprof/timing"
+)
+
+func main() {
+	headers := http.Header{}
+	n := flag.Int("n", 1, "istek sayısı")
+	c := flag.Int("c", 1, "eşzamanlı istek sayısı")
+	method := flag.String("X", http.MethodGet, "HTTP metodu")
+	data := flag.String("d", "", "istek gövdesi")
+	insecure := flag.Bool("k", false, "TLS sertifikasını doğrulama")
+	noReuse := flag.Bool("no-reuse", false, "keep-alive kapalı: her istek yeni bağlantı")
+	noRedirect := flag.Bool("no-redirect", false, "yönlendirmeleri takip etme")
+	timeout := flag.Duration("timeout", 30*time.Second, "istek başına zaman aşımı")
+	width := flag.Int("w", 50, "waterfall genişliği")
+	asJSON := flag.Bool("json", false, "sonuçları JSON olarak yaz")
+	flag.Func("H", "başlık ekle, ör: -H 'Accept: text/html' (tekrarlanabilir)", func(s string) error {
+		k, v, ok := strings.Cut(s, ":")
+		if !ok {
+			return fmt.Errorf("başlık 'İsim: değer' biçiminde olmalı: %q", s)
+		}
+		headers.Add(strings.TrimSpace(k), strings.TrimSpace(v))
+		return nil
+	})
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "kullanım: httpprof [seçenekler] URL")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || *n < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	target := flag.Arg(0)
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.DisableKeepAlives = *noReuse
+	tr.MaxIdleConnsPerHost = *c // her worker kendi bağlantısını havuzda tutabilsin
+	if *insecure {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	client := &http.Client{Transport: tr, Timeout: *timeout}
+	if *noRedirect {
+		client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		var body io.Reader
+		if *data != "" {
+			body = strings.NewReader(*data)
+		}
+		req, err := http.NewRequestWithContext(ctx, *method, target, body)
+		if err != nil {
+			return nil, err
+		}
+		req.Header = headers.Clone()
+		return req, nil
+	}
+
+	// Ctrl-C: yeni istek başlatma, o ana kadarkileri raporla
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	results := timing.Run(ctx, client, newReq, *n, *c)
+	if len(results) == 0 {
+		os.Exit(1)
+	}
+	stats := timing.Summarize(results)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(map[string]any{"results": results, "summary": stats})
+		return
+	}
+
+	// İlk ölçüm genelde "soğuk"tur (DNS + bağlantı + TLS); en yavaşı ise
+	// tek tek bakmaya değer olandır.
+	fmt.Println("▶ İlk istek")
+	timing.WriteWaterfall(os.Stdout, results[0], *width)
+
+	if len(results) > 1 {
+		slowest := slices.MaxFunc(results, func(a, b *timing.Result) int {
+			return cmp.Compare(a.Total, b.Total)
+		})
+		if slowest != results[0] {
+			fmt.Println("\n▶ En yavaş istek")
+			timing.WriteWaterfall(os.Stdout, slowest, *width)
+		}
+
+		failed, reused := 0, 0
+		for _, r := range results {
+			if r.Err != "" {
+				failed++
+			}
+			if len(r.Hops) > 0 && r.Hops[0].Reused {
+				reused++
+			}
+		}
+		fmt.Printf("\n▶ Özet: %d istek, %d hata, %d yeniden kullanılan bağlantı\n", len(results), failed, reused)
+		timing.WriteSummary(os.Stdout, stats)
+	}
+}
+``
+/*
+---
+
+## 📄 `timing/timing_test.go`
+
+Testler internete çıkmaz: `httptest.NewTLSServer` yerel bir HTTPS sunucusu açar, `srv.Client()` onun test sertifikasına güvenen bir client verir.
+*/
+``go
+package timing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(5 * time.Millisecond) // ölçülebilir bir "wait" fazı
+		w.Write([]byte("merhaba"))
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+	srv := httptest.NewTLSServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestMeasureTLS(t *testing.T) {
+	srv := newServer(t)
+	client := srv.Client() // test sertifikasına güvenen client
+
+	req, _ := http.NewRequest("GET", srv.URL+"/", nil)
+	r := Measure(client, req)
+	if r.Err != "" {
+		t.Fatal(r.Err)
+	}
+	if len(r.Hops) != 1 {
+		t.Fatalf("hop sayısı = %d, beklenen 1", len(r.Hops))
+	}
+	h := r.Hops[0]
+	if h.Reused {
+		t.Error("ilk istek yeni bağlantı kullanmalı")
+	}
+	// httptest adresi IP olduğu için DNS fazı yok; bağlantı ve TLS var
+	for _, name := range []string{PhaseConnect, PhaseTLS, PhaseSend, PhaseWait, PhaseTransfer} {
+		if h.Phase(name).End == 0 {
+			t.Errorf("%s fazı kaydedilmedi", name)
+		}
+	}
+	if h.Wait.Duration() < 5*time.Millisecond {
+		t.Errorf("wait = %v, en az 5ms beklenirdi", h.Wait.Duration())
+	}
+	if h.TLSVersion == "" || h.Status != 200 || r.Bytes != int64(len("merhaba")) {
+		t.Errorf("hop = %+v, bytes = %d", h, r.Bytes)
+	}
+	// Fazlar sıralı ve toplam sürenin içinde olmalı
+	if !(h.Connect.End <= h.TLS.Start && h.TLS.End <= h.Send.Start && h.Wait.End <= r.Total) {
+		t.Errorf("faz sırası bozuk: %+v (total %v)", h, r.Total)
+	}
+}
+
+func TestConnectionReuse(t *testing.T) {
+	srv := newServer(t)
+	newReq := func(ctx context.Context) (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", srv.URL+"/", nil)
+	}
+
+	// Tek worker: ilk istek bağlantı kurar, sonrakiler onu kullanır
+	results := Run(context.Background(), srv.Client(), newReq, 5, 1)
+	if len(results) != 5 {
+		t.Fatalf("sonuç sayısı = %d", len(results))
+	}
+	for i, r := range results {
+		h := r.Hops[0]
+		if want := i > 0; h.Reused != want {
+			t.Errorf("istek %d: reused = %v, beklenen %v", i, h.Reused, want)
+		}
+		if h.Reused && (h.Connect.End != 0 || h.TLS.End != 0) {
+			t.Errorf("istek %d: yeniden kullanılan bağlantıda connect/tls fazı olmamalı", i)
+		}
+	}
+
+	stats := Summarize(results)
+	byPhase := map[string]Stat{}
+	for _, s := range stats {
+		byPhase[s.Phase] = s
+	}
+	if got := byPhase[PhaseTLS].Count; got != 1 {
+		t.Errorf("tls count = %d, beklenen 1", got)
+	}
+	if got := byPhase["total"].Count; got != 5 {
+		t.Errorf("total count = %d, beklenen 5", got)
+	}
+}
+
+func TestRedirectHops(t *testing.T) {
+	srv := newServer(t)
+	req, _ := http.NewRequest("GET", srv.URL+"/redirect", nil)
+	r := Measure(srv.Client(), req)
+	if len(r.Hops) != 2 {
+		t.Fatalf("hop sayısı = %d, beklenen 2", len(r.Hops))
+	}
+	first, second := r.Hops[0], r.Hops[1]
+	if first.Status != http.StatusFound || second.Status != http.StatusOK {
+		t.Errorf("durumlar = %d, %d", first.Status, second.Status)
+	}
+	if first.URL != srv.URL+"/redirect" || second.URL != srv.URL+"/" {
+		t.Errorf("URL'ler = %s, %s", first.URL, second.URL)
+	}
+	// Yönlendirme aynı sunucuya: ikinci hop ilk bağlantıyı kullanır
+	if !second.Reused {
+		t.Error("ikinci hop bağlantıyı yeniden kullanmalı")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	ds := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for p, want := range map[float64]time.Duration{50: 5, 90: 9, 99: 10, 100: 10, 0: 1} {
+		if got := Percentile(ds, p); got != want {
+			t.Errorf("p%v = %v, beklenen %v", p, got, want)
+		}
+	}
+}
+``
+/*
+---
+
+## ▶️ Çalıştırma
+*/
+``bash
+go test -race ./...
+go run . -n 20 -c 4 https://localhost:8443/r      # /r → / yönlendirmesi yapan yerel bir sunucu
+go run . -json https://example.com/ | jq '.summary'
+``
+/*
+Örnek çıktı (yerel TLS sunucusu, `/r` → `/` yönlendirmesi, 100 KB gövde):
+*/
+``
+▶ İlk istek
+GET https://localhost:38147/r → 302 HTTP/1.1 (yeni bağlantı 127.0.0.1:38147, TLS 1.3)
+  dns       |█                                                 |     566µs
+  connect   | ███                                              |    2.27ms
+  tls       |    ███████                                       |    4.33ms
+  send      |           █                                      |     723µs
+  wait      |            ███████████████████████               |   15.59ms
+  transfer  |                                   █              |      85µs
+GET https://localhost:38147/ → 200 HTTP/1.1 (yeniden kullanıldı 127.0.0.1:38147)
+  send      |                                   █              |      10µs
+  wait      |                                   █████████████  |     8.5ms
+  transfer  |                                                ██|     950µs
+  total                                                            33.49ms  (100000 byte)
+
+▶ Özet: 20 istek, 0 hata, 17 yeniden kullanılan bağlantı
+       faz   n     min      ort     p50      p90      p99      max
+       dns   4    60µs    225µs   101µs    566µs    566µs    566µs
+   connect   4  2.27ms    4.7ms  5.12ms   5.88ms   5.88ms   5.88ms
+       tls   4  3.92ms   5.62ms  4.33ms    8.7ms    8.7ms    8.7ms
+      send  20    14µs     96µs    31µs    138µs    733µs    733µs
+      wait  20  3.83ms   7.22ms  5.05ms  12.01ms   24.1ms   24.1ms
+  transfer  20    89µs    495µs   343µs   1.04ms   1.93ms   1.93ms
+     total  20  4.21ms  10.61ms  5.39ms  31.48ms  33.58ms  33.58ms
+``
+/*
+Çıktıdan okunanlar:
+
+* Yönlendirmenin ikinci adımı **aynı bağlantıyı** kullandı; DNS, connect ve TLS yalnızca bir kez ödendi
+* `dns`, `connect`, `tls` satırlarında `n = 4` var: 4 worker'ın her biri bir kez bağlantı kurdu, kalan istekler keep-alive ile geldi
+* `-no-reuse` ile her istek yeni bağlantı açar; aradaki fark keep-alive'ın kazancıdır
+
+JSON çıktısında her faz `{"start_ms", "dur_ms"}` olarak, yaşanmamış fazlar `null` olarak yazılır:
+*/
+``json
+{
+  "method": "GET",
+  "url": "https://localhost:38147/",
+  "status": 200,
+  "reused": false,
+  "tls_version": "TLS 1.3",
+  "dns": { "start_ms": 0.176, "dur_ms": 0.276 },
+  "connect": { "start_ms": 0.461, "dur_ms": 0.587 },
+  "tls": { "start_ms": 1.058, "dur_ms": 3.544 },
+  "send": { "start_ms": 4.617, "dur_ms": 0.088 },
+  "wait": { "start_ms": 4.706, "dur_ms": 3.851 },
+  "transfer": { "start_ms": 8.557, "dur_ms": 0.468 }
+}
+``
+/*
+---
+
+## 📌 Özet
+
+* `httptrace.ClientTrace`, bir isteğin **her fazını** ayrı ayrı ölçmeyi sağlar
+* Yönlendirmelerde callback'ler tekrar tetiklenir; `GetConn` yeni adımın başlangıcıdır
+* `GotConnInfo.Reused`, keep-alive'ın gerçekten çalışıp çalışmadığını gösterir
+* Tekrarlı ölçüm + yüzdelikler, tek bir ölçümün yanıltıcı olabileceği durumları ortaya çıkarır
+* `httptest.NewTLSServer` ile bu ölçümler internete çıkmadan test edilebilir ✅
+
+---
+
+İstersen bir sonraki adımda bu aracı **HTTP/2** ile karşılaştırmalı çalıştırıp (tek bağlantı üzerinde çoğullama) waterfall farkını inceleyebiliriz.
+
+Bunu yapalım mı?
+*/
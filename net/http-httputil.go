@@ -681,4 +681,1002 @@ func main() {
 ---
 
 İstersen ben sana bu load balancer’a **health check (sağlıklı olmayan backend’i devre dışı bırakma)** özelliğini de ekleyebilirim. Bunu ister misin?
-*/
\ No newline at end of file
+*/
+
+/*
+---
+
+## 5. **Middleware Zinciri ile Gateway (Log + Rate Limit + Cache + Streaming Rewrite)**
+
+Yukarıdaki “Tam Özelleştirilmiş Proxy” örneğinde her şey `Director`, `ModifyResponse` ve `ErrorHandler` içine gömülü. Ayrıca `ModifyResponse`, `io.ReadAll` ile **tüm gövdeyi belleğe alıyor**; büyük bir dosya geçtiğinde proxy’nin belleği şişer.
+
+Gerçek bir gateway’de bu işleri **birbirinden bağımsız middleware’lere** bölmek daha doğru:
+
+* **AccessLog** → `log/slog` ile yapılandırılmış (JSON) erişim logu. Yanıt `5xx` ise `httputil.DumpRequest` ile ham istek de loga eklenir.
+* **RateLimit** → istemci başına **token bucket**. Anahtar olarak `netip.Addr` kullanılır (string değil, karşılaştırılabilir ve ucuz).
+* **Cache** → sadece `GET` istekleri için, RFC 9111’deki `Cache-Control` (`max-age`, `s-maxage`, `no-store`, `private`, `no-cache`) ve `Expires` kurallarına göre basit bir yanıt önbelleği.
+* **Streaming rewrite** → `"httpbin"` değişimi artık `io.ReadAll` ile değil, parça parça okuyan bir `io.ReadCloser` ile yapılır.
+
+Middleware tipi klasik Go kalıbıdır:
+
+``go
+type Middleware func(http.Handler) http.Handler
+``
+
+`Chain(h, A, B, C)` çağrısı `A(B(C(h)))` üretir; yani istek önce `A`’dan geçer.
+*/
+``go
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/netip"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Middleware, bir handler'ı saran ve yeni bir handler döndüren fonksiyondur.
+type Middleware func(http.Handler) http.Handler
+
+// Chain, middleware'leri verilen sırayla uygular.
+// Chain(h, A, B, C) → A(B(C(h))) yani istek önce A'dan geçer.
+func Chain(h http.Handler, mws ...Middleware) http.Handler {
+	for i := len(mws) - 1; i >= 0; i-- {
+		h = mws[i](h)
+	}
+	return h
+}
+
+// statusRecorder, yazılan status kodunu ve byte sayısını yakalar.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Flush, streaming yanıtların ara belleğe takılmaması için gereklidir.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// ---------------------------------------------------------------
+// 1. Erişim logu (log/slog) + hata durumunda istek dökümü
+// ---------------------------------------------------------------
+
+// redactedHeaders, hata logundaki istek dökümünde değeri gizlenen başlıklardır.
+var redactedHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie"}
+
+func AccessLog(logger *slog.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// Body okunmadan önce dump alınır; body false → gövde tüketilmez.
+			// Kimlik bilgileri loga düşmesin diye dump, başlıkları gizlenmiş
+			// bir kopyadan alınır; handler'a giden istek değişmez.
+			redacted := r.Clone(r.Context())
+			for _, h := range redactedHeaders {
+				if _, ok := redacted.Header[h]; ok {
+					redacted.Header.Set(h, "[gizlendi]")
+				}
+			}
+			dump, _ := httputil.DumpRequest(redacted, false)
+
+			start := time.Now()
+			rec := &statusRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+			if rec.status == 0 {
+				// Handler hiç yazmadıysa net/http 200 gönderir
+				rec.status = http.StatusOK
+			}
+
+			attrs := []any{
+				slog.String("method", r.Method),
+				slog.String("path", r.URL.Path),
+				slog.Int("status", rec.status),
+				slog.Int("bytes", rec.bytes),
+				slog.Duration("sure", time.Since(start)),
+				slog.String("client", r.RemoteAddr),
+				slog.String("cache", w.Header().Get("X-Cache")),
+			}
+			if rec.status >= 500 {
+				// Sadece hata durumunda ham isteği loga ekle
+				logger.Error("istek başarısız", append(attrs, slog.String("dump", string(dump)))...)
+				return
+			}
+			logger.Info("istek", attrs...)
+		})
+	}
+}
+
+// ---------------------------------------------------------------
+// 2. İstemci başına token-bucket rate limiting (netip.Addr anahtarlı)
+// ---------------------------------------------------------------
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// maxBuckets aşılınca dolmuş kovalar silinir; sahte IP'lerle belleği
+// şişirmek böylece sınırlı kalır.
+const maxBuckets = 10_000
+
+type RateLimiter struct {
+	mu         sync.Mutex
+	rate       float64 // saniyede eklenen token
+	burst      float64 // kova kapasitesi
+	buckets    map[netip.Addr]*bucket
+	maxBuckets int
+}
+
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		rate:       rate,
+		burst:      float64(burst),
+		buckets:    make(map[netip.Addr]*bucket),
+		maxBuckets: maxBuckets,
+	}
+}
+
+func (rl *RateLimiter) refill(b *bucket, now time.Time) {
+	b.tokens += now.Sub(b.last).Seconds() * rl.rate
+	b.tokens = min(b.tokens, rl.burst)
+	b.last = now
+}
+
+// Allow, istemcinin kovasından bir token harcamaya çalışır.
+func (rl *RateLimiter) Allow(addr netip.Addr, now time.Time) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	b, ok := rl.buckets[addr]
+	if !ok {
+		if len(rl.buckets) >= rl.maxBuckets {
+			rl.sweep(now)
+		}
+		b = &bucket{tokens: rl.burst, last: now}
+		rl.buckets[addr] = b
+	}
+	// Geçen süre kadar token ekle (kapasiteyi aşmadan)
+	rl.refill(b, now)
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// sweep, dolmuş kovaları siler: silinen istemci geri geldiğinde yine dolu
+// bir kovayla başlar, yani hiçbir şey kaybetmez. Kovaların hepsi kullanımdaysa
+// en uzun süredir istek göndermeyen istemcinin kovası silinir.
+func (rl *RateLimiter) sweep(now time.Time) {
+	var oldest netip.Addr
+	var oldestAt time.Time
+	for addr, b := range rl.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*rl.rate >= rl.burst {
+			delete(rl.buckets, addr)
+			continue
+		}
+		if !oldest.IsValid() || b.last.Before(oldestAt) {
+			oldest, oldestAt = addr, b.last
+		}
+	}
+	if len(rl.buckets) >= rl.maxBuckets {
+		delete(rl.buckets, oldest)
+	}
+}
+
+// clientAddr, RemoteAddr'den netip.Addr üretir.
+// IPv4-mapped IPv6 adresleri (::ffff:1.2.3.4) tek anahtara indirgenir.
+func clientAddr(r *http.Request) (netip.Addr, bool) {
+	ap, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ap.Addr().Unmap(), true
+}
+
+func RateLimit(rl *RateLimiter) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			addr, ok := clientAddr(r)
+			if ok && !rl.Allow(addr, time.Now()) {
+				w.Header().Set("Retry-After", "1")
+				http.Error(w, "Çok fazla istek", http.StatusTooManyRequests)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ---------------------------------------------------------------
+// 3. GET yanıtları için RFC 9111 tarzı önbellek
+// ---------------------------------------------------------------
+
+type cacheEntry struct {
+	status  int
+	header  http.Header
+	body    []byte
+	stored  time.Time
+	expires time.Time
+	vary    map[string]string // Vary'deki her başlık için saklayan isteğin değeri
+}
+
+// matches, r'nin Vary başlıklarında yanıtı saklayan istekle aynı değerleri
+// taşıyıp taşımadığını söyler (RFC 9111 4.1). Vary yoksa her istek eşleşir.
+func (e *cacheEntry) matches(r *http.Request) bool {
+	for name, v := range e.vary {
+		if strings.Join(r.Header.Values(name), ", ") != v {
+			return false
+		}
+	}
+	return true
+}
+
+// maxCacheEntries aşılınca önce süresi dolmuş girişler silinir; yetmezse
+// süresi en erken dolacak olan.
+const maxCacheEntries = 1_000
+
+type Cache struct {
+	mu         sync.RWMutex
+	entries    map[string][]*cacheEntry // URL → Vary değerlerine göre varyantlar
+	n          int                      // toplam varyant sayısı
+	maxEntries int
+	maxBody    int // bu boyuttan büyük yanıtlar önbelleğe alınmaz
+	now        func() time.Time
+}
+
+func NewCache(maxBody int) *Cache {
+	return &Cache{
+		entries:    make(map[string][]*cacheEntry),
+		maxEntries: maxCacheEntries,
+		maxBody:    maxBody,
+		now:        time.Now,
+	}
+}
+
+// lookup, key için r ile eşleşen ve hâlâ taze olan varyantı bulur.
+func (c *Cache) lookup(key string, r *http.Request, now time.Time) *cacheEntry {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, e := range c.entries[key] {
+		if e.matches(r) && now.Before(e.expires) {
+			return e
+		}
+	}
+	return nil
+}
+
+// store, e'yi key altına koyar. Aynı Vary değerlerine sahip eski varyantın
+// yerine geçer; yeni bir varyant sınırı aşacaksa önce yer açılır.
+func (c *Cache) store(key string, r *http.Request, e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	variants := c.entries[key]
+	for i, old := range variants {
+		if old.matches(r) {
+			variants[i] = e
+			return
+		}
+	}
+	if c.n >= c.maxEntries {
+		c.evict(e.stored)
+		variants = c.entries[key]
+	}
+	c.entries[key] = append(variants, e)
+	c.n++
+}
+
+// evict, süresi dolmuş varyantları siler. Hiçbiri dolmamışsa süresi en
+// erken dolacak olanı siler.
+func (c *Cache) evict(now time.Time) {
+	var soonestKey string
+	var soonest *cacheEntry
+	for key, variants := range c.entries {
+		kept := variants[:0]
+		for _, e := range variants {
+			switch {
+			case !now.Before(e.expires):
+				c.n--
+				continue
+			case soonest == nil || e.expires.Before(soonest.expires):
+				soonestKey, soonest = key, e
+			}
+			kept = append(kept, e)
+		}
+		c.setVariants(key, kept)
+	}
+	if c.n >= c.maxEntries && soonest != nil {
+		c.setVariants(soonestKey, slices.DeleteFunc(c.entries[soonestKey], func(e *cacheEntry) bool { return e == soonest }))
+		c.n--
+	}
+}
+
+func (c *Cache) setVariants(key string, variants []*cacheEntry) {
+	if len(variants) == 0 {
+		delete(c.entries, key)
+		return
+	}
+	c.entries[key] = variants
+}
+
+// varyNames, yanıtın Vary başlığındaki alan adlarını kanonik biçimde döndürür.
+func varyNames(h http.Header) []string {
+	var names []string
+	for _, v := range h.Values("Vary") {
+		for _, name := range strings.Split(v, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, http.CanonicalHeaderKey(name))
+			}
+		}
+	}
+	return names
+}
+
+// parseCacheControl, "max-age=60, no-store" gibi değerleri map'e çevirir.
+func parseCacheControl(v string) map[string]string {
+	out := make(map[string]string)
+	for _, part := range strings.Split(v, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, val, _ := strings.Cut(part, "=")
+		out[strings.ToLower(k)] = strings.Trim(val, `"`)
+	}
+	return out
+}
+
+// freshness, yanıtın ne kadar süre taze kalacağını hesaplar.
+// s-maxage > max-age > Expires sırası izlenir (paylaşılan önbellek).
+func freshness(h http.Header, now time.Time) (time.Duration, bool) {
+	cc := parseCacheControl(h.Get("Cache-Control"))
+	if _, ok := cc["no-store"]; ok {
+		return 0, false
+	}
+	if _, ok := cc["private"]; ok {
+		return 0, false
+	}
+	if _, ok := cc["no-cache"]; ok {
+		return 0, false
+	}
+	if slices.Contains(varyNames(h), "*") || h.Get("Set-Cookie") != "" {
+		return 0, false
+	}
+	for _, key := range []string{"s-maxage", "max-age"} {
+		if v, ok := cc[key]; ok {
+			secs, err := strconv.Atoi(v)
+			if err != nil || secs <= 0 {
+				return 0, false
+			}
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	if exp := h.Get("Expires"); exp != "" {
+		t, err := http.ParseTime(exp)
+		if err != nil || !t.After(now) {
+			return 0, false
+		}
+		return t.Sub(now), true
+	}
+	return 0, false
+}
+
+// cacheWriter, yanıtı istemciye yazarken aynı anda kopyasını tutar.
+type cacheWriter struct {
+	*statusRecorder
+	buf      bytes.Buffer
+	overflow bool
+	limit    int
+}
+
+func (cw *cacheWriter) Write(b []byte) (int, error) {
+	if !cw.overflow {
+		if cw.buf.Len()+len(b) > cw.limit {
+			cw.overflow = true
+			cw.buf.Reset()
+		} else {
+			cw.buf.Write(b)
+		}
+	}
+	return cw.statusRecorder.Write(b)
+}
+
+func (c *Cache) Middleware() Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || r.Header.Get("Authorization") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			reqCC := parseCacheControl(r.Header.Get("Cache-Control"))
+			key := r.URL.String()
+			now := c.now()
+
+			if _, noCache := reqCC["no-cache"]; !noCache {
+				if e := c.lookup(key, r, now); e != nil {
+					for k, v := range e.header {
+						w.Header()[k] = v
+					}
+					w.Header().Set("Age", strconv.Itoa(int(now.Sub(e.stored).Seconds())))
+					w.Header().Set("X-Cache", "HIT")
+					w.WriteHeader(e.status)
+					w.Write(e.body)
+					return
+				}
+			}
+
+			w.Header().Set("X-Cache", "MISS")
+			cw := &cacheWriter{statusRecorder: &statusRecorder{ResponseWriter: w}, limit: c.maxBody}
+			next.ServeHTTP(cw, r)
+
+			if _, noStore := reqCC["no-store"]; noStore || cw.overflow {
+				return
+			}
+			if cw.status != http.StatusOK && cw.status != http.StatusNotFound && cw.status != http.StatusMovedPermanently {
+				return
+			}
+			ttl, ok := freshness(w.Header(), now)
+			if !ok {
+				return
+			}
+			header := w.Header().Clone()
+			header.Del("X-Cache")
+			vary := make(map[string]string)
+			for _, name := range varyNames(header) {
+				vary[name] = strings.Join(r.Header.Values(name), ", ")
+			}
+			c.store(key, r, &cacheEntry{
+				status:  cw.status,
+				header:  header,
+				body:    bytes.Clone(cw.buf.Bytes()),
+				stored:  now,
+				expires: now.Add(ttl),
+				vary:    vary,
+			})
+		})
+	}
+}
+
+// ---------------------------------------------------------------
+// 4. Belleğe almadan (streaming) gövde değiştirme
+// ---------------------------------------------------------------
+
+// replaceReader, kaynağı parça parça okuyup old → new değişimini yapar.
+// Parça sınırına denk gelen eşleşmeleri kaçırmamak için
+// len(old)-1 byte'lık bir kuyruk bir sonraki tura taşınır.
+type replaceReader struct {
+	src      io.ReadCloser
+	old, new []byte
+	chunk    []byte // kaynaktan okuma tamponu; her Read'de yeniden kullanılır
+	pending  []byte // henüz işlenmemiş (kuyruk dahil) veri
+	out      []byte // okunmaya hazır çıktı
+	eof      bool
+}
+
+func NewReplaceReader(src io.ReadCloser, old, new string) io.ReadCloser {
+	return &replaceReader{src: src, old: []byte(old), new: []byte(new), chunk: make([]byte, 32*1024)}
+}
+
+func (r *replaceReader) Read(p []byte) (int, error) {
+	for len(r.out) == 0 {
+		if r.eof {
+			return 0, io.EOF
+		}
+		n, err := r.src.Read(r.chunk)
+		r.pending = append(r.pending, r.chunk[:n]...)
+		if err == io.EOF {
+			r.eof = true
+		} else if err != nil {
+			return 0, err
+		}
+
+		// Tamamen görünen eşleşmeleri değiştir
+		buf := r.pending
+		for {
+			i := bytes.Index(buf, r.old)
+			if i < 0 {
+				break
+			}
+			r.out = append(r.out, buf[:i]...)
+			r.out = append(r.out, r.new...)
+			buf = buf[i+len(r.old):]
+		}
+
+		// Sonda kalan ve eşleşmenin başı olabilecek kısmı bir sonraki tura bırak
+		keep := 0
+		if !r.eof {
+			keep = min(len(r.old)-1, len(buf))
+		}
+		r.out = append(r.out, buf[:len(buf)-keep]...)
+		r.pending = append(r.pending[:0], buf[len(buf)-keep:]...)
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+func (r *replaceReader) Close() error { return r.src.Close() }
+
+// ---------------------------------------------------------------
+// Proxy + zincir
+// ---------------------------------------------------------------
+
+func newProxy(target *url.URL, logger *slog.Logger) *httputil.ReverseProxy {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+
+	originalDirector := proxy.Director
+	proxy.Director = func(req *http.Request) {
+		originalDirector(req)
+		req.Host = target.Host
+		req.Header.Set("X-Custom-Header", "ProxyKullaniyorum")
+	}
+
+	proxy.ModifyResponse = func(resp *http.Response) error {
+		ct := resp.Header.Get("Content-Type")
+		// Sadece metin yanıtlar ve sıkıştırılmamış gövdeler değiştirilir
+		if !strings.HasPrefix(ct, "text/") && !strings.Contains(ct, "json") {
+			return nil
+		}
+		if resp.Header.Get("Content-Encoding") != "" {
+			return nil
+		}
+		resp.Body = NewReplaceReader(resp.Body, "httpbin", "PROXY_DEGISTIRDI")
+		// Uzunluk artık bilinmiyor → chunked transfer kullanılır
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+		return nil
+	}
+
+	proxy.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+		logger.Error("proxy hatası", slog.String("hata", err.Error()))
+		http.Error(w, "Proxy üzerinden erişim hatası.", http.StatusBadGateway)
+	}
+	return proxy
+}
+
+func main() {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	target, _ := url.Parse("https://httpbin.org")
+	proxy := newProxy(target, logger)
+
+	handler := Chain(proxy,
+		AccessLog(logger),                // en dışta: her şeyi loglar (429 ve HIT dahil)
+		RateLimit(NewRateLimiter(5, 10)), // istemci başına 5 istek/sn, 10 burst
+		NewCache(1<<20).Middleware(),     // 1 MB'a kadar yanıtları önbelleğe al
+	)
+
+	srv := &http.Server{
+		Addr:    ":8080",
+		Handler: handler,
+	}
+	logger.Info("gateway çalışıyor", slog.String("addr", srv.Addr))
+	if err := srv.ListenAndServe(); err != nil {
+		logger.Error("sunucu durdu", slog.String("hata", err.Error()))
+	}
+}
+``
+/*
+---
+
+### 📝 Nasıl Çalışır?
+
+* **Sıralama önemli:**
+  * `AccessLog` en dışta olduğu için `429 Too Many Requests` ve önbellekten dönen (`X-Cache: HIT`) yanıtlar da loglanır.
+  * `RateLimit`, cache’ten **önce** çalışır; yani önbellekten dönen yanıtlar da limite dahildir.
+  * `Cache`, proxy’nin hemen önündedir; `HIT` durumunda backend’e hiç gidilmez.
+* **Erişim logu:**
+  * Handler hiçbir şey yazmazsa `net/http` istemciye `200` gönderir; log da bu durumda `status=0` yerine `200` yazar.
+  * `5xx` yanıtlarda istek dökümü loga eklenir. `Authorization`, `Proxy-Authorization` ve `Cookie` değerleri dökümde `[gizlendi]` olarak görünür; token ve oturum bilgisi log sistemine sızmaz. Gizleme isteğin bir kopyasında yapılır, handler orijinal başlıkları görür.
+* **Token bucket:**
+  * Her istemcinin kovası `burst` (10) token ile başlar.
+  * Her saniye `rate` (5) token eklenir, kapasite aşılmaz.
+  * Token kalmadıysa `429` ve `Retry-After: 1` döner.
+  * Kova sayısı `maxBuckets` (10 000) sınırına gelince dolmuş kovalar silinir. Silinen istemci geri geldiğinde yine dolu bir kovayla başlar, yani bir şey kaybetmez. Kovaların hepsi kullanımdaysa en uzun süredir istek göndermeyen istemcinin kovası silinir. Sahte kaynak adresleriyle belleği şişirmek böylece mümkün olmaz.
+  * `ap.Addr().Unmap()` sayesinde `::ffff:127.0.0.1` ve `127.0.0.1` aynı kovayı kullanır.
+* **Cache kuralları:**
+  * `Authorization` başlıklı istekler ve `Set-Cookie` / `Vary: *` içeren yanıtlar önbelleğe alınmaz.
+  * `Vary` başlığı olan bir yanıt, saklanırken isteğin o başlıklardaki değerlerini de yanına alır. Aynı URL için her değer kümesi ayrı bir **varyant** olarak saklanır. Sonraki istek yalnızca değerleri aynı olan varyanttan döner (RFC 9111 4.1). Böylece `Accept-Language: tr` için saklanan yanıt `en` isteyen istemciye gitmez.
+  * Toplam varyant sayısı `maxCacheEntries` (1 000) ile sınırlıdır. Sınıra gelince önce süresi dolmuş girişler silinir; yer açılmazsa süresi en erken dolacak giriş gider.
+  * Tazelik süresi `s-maxage` → `max-age` → `Expires` sırasıyla belirlenir.
+  * İstemci `Cache-Control: no-cache` gönderirse önbellek atlanır, `no-store` gönderirse yanıt saklanmaz.
+  * `maxBody` sınırını aşan yanıtlar istemciye akmaya devam eder ama saklanmaz.
+  * Önbellekten dönen yanıtlara `Age` başlığı eklenir.
+* **Streaming rewrite:**
+  * `replaceReader`, her turda kaynaktan en fazla 32 KB okur. Okuma tamponu bir kez ayrılır ve her `Read`'de yeniden kullanılır; kuyruk da aynı `pending` dilimine geri kopyalanır.
+  * Parça sınırına denk gelen bir `"httpbin"` kaçmasın diye son `len(old)-1` byte bir sonraki tura taşınır.
+  * Gövde uzunluğu artık önceden bilinmediği için `ContentLength = -1` yapılır ve `Content-Length` silinir; Go otomatik olarak **chunked** transfer kullanır.
+  * `Content-Encoding` (örneğin gzip) olan yanıtlara dokunulmaz, çünkü sıkıştırılmış byte’lar üzerinde metin değişimi yapılamaz.
+
+---
+
+### 🔍 Test
+
+``bash
+# Aynı isteği iki kez at: ilki MISS, ikincisi HIT olmalı (httpbin /cache/60 → max-age=60)
+curl -i http://localhost:8080/cache/60
+curl -i http://localhost:8080/cache/60
+
+# Rate limit: 10’dan sonrası 429 dönmeye başlar
+for i in $(seq 1 20); do curl -s -o /dev/null -w "%{http_code}\n" http://localhost:8080/get; done
+
+# Büyük yanıt: bellek kullanımı sabit kalır
+curl -s http://localhost:8080/stream/1000 | grep -c PROXY_DEGISTIRDI
+``
+
+Örnek log satırı:
+
+``json
+{"time":"...","level":"INFO","msg":"istek","method":"GET","path":"/cache/60","status":200,"bytes":312,"sure":"1.2ms","client":"127.0.0.1:53422","cache":"HIT"}
+``
+
+---
+
+### 🧪 Testler (`main_test.go`)
+
+Testler backend olarak sayaçlı bir `http.Handler`, log için `bytes.Buffer`'a yazan bir JSON `slog.Logger`, saat olarak da `Cache.now` alanına verilen sahte bir saat kullanır. Ağ bağlantısı ya da bekleme gerekmez.
+*/
+``go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestChainOrder(t *testing.T) {
+	var trace []string
+	mw := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				trace = append(trace, name+">")
+				next.ServeHTTP(w, r)
+				trace = append(trace, "<"+name)
+			})
+		}
+	}
+	h := Chain(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trace = append(trace, "h")
+	}), mw("A"), mw("B"), mw("C"))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/", nil))
+	if got := strings.Join(trace, " "); got != "A> B> C> h <C <B <A" {
+		t.Errorf("sıra = %s", got)
+	}
+}
+
+func TestAccessLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+	var gotAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/bos", func(w http.ResponseWriter, r *http.Request) {}) // hiçbir şey yazmaz
+	mux.HandleFunc("/hata", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		http.Error(w, "patladı", http.StatusBadGateway)
+	})
+	h := AccessLog(logger)(mux)
+
+	entry := func() map[string]any {
+		t.Helper()
+		var m map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &m); err != nil {
+			t.Fatalf("%v: %s", err, buf.String())
+		}
+		buf.Reset()
+		return m
+	}
+
+	// Handler yazmadıysa istemci 200 alır; log da 200 demeli
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/bos", nil))
+	if e := entry(); e["status"] != 200.0 || e["level"] != "INFO" {
+		t.Errorf("boş yanıt logu: %v", e)
+	}
+
+	// 5xx'te istek dökülür ama kimlik bilgileri gizlenir
+	req := httptest.NewRequest("GET", "/hata", nil)
+	req.Header.Set("Authorization", "Bearer gizli-token")
+	req.Header.Set("Proxy-Authorization", "Basic cHJveHk6c2lmcmU=")
+	req.Header.Set("Cookie", "session=gizli-oturum")
+	req.Header.Set("X-Request-Id", "abc")
+	h.ServeHTTP(httptest.NewRecorder(), req)
+	e := entry()
+	dump, _ := e["dump"].(string)
+	if e["status"] != 502.0 || !strings.Contains(dump, "X-Request-Id: abc") {
+		t.Fatalf("hata logu: %v", e)
+	}
+	for _, secret := range []string{"gizli-token", "cHJveHk6c2lmcmU=", "gizli-oturum"} {
+		if strings.Contains(dump, secret) {
+			t.Errorf("dump %q içeriyor:\n%s", secret, dump)
+		}
+	}
+	if !strings.Contains(dump, "Authorization: [gizlendi]") || !strings.Contains(dump, "Cookie: [gizlendi]") {
+		t.Errorf("gizlenen başlıklar dump'ta yok:\n%s", dump)
+	}
+	// Handler'a giden istek değişmemeli
+	if gotAuth != "Bearer gizli-token" {
+		t.Errorf("handler Authorization = %q", gotAuth)
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	rl := NewRateLimiter(1, 2) // saniyede 1, en fazla 2
+	a, b := netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("10.0.0.2")
+	t0 := time.Unix(0, 0)
+
+	for i, want := range []bool{true, true, false} {
+		if got := rl.Allow(a, t0); got != want {
+			t.Errorf("istek %d: Allow = %v", i+1, got)
+		}
+	}
+	if !rl.Allow(b, t0) {
+		t.Error("başka istemcinin kovası etkilendi")
+	}
+	if !rl.Allow(a, t0.Add(time.Second)) || rl.Allow(a, t0.Add(time.Second)) {
+		t.Error("1 saniyede tam 1 token eklenmeli")
+	}
+
+	// Middleware: IPv4-mapped IPv6 adresi aynı kovayı kullanır
+	h := RateLimit(NewRateLimiter(1, 1))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	for i, remote := range []string{"192.0.2.7:1000", "[::ffff:192.0.2.7]:2000"} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = remote
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if want := []int{200, 429}[i]; rec.Code != want {
+			t.Errorf("%s: %d, beklenen %d", remote, rec.Code, want)
+		}
+		if i == 1 && rec.Header().Get("Retry-After") != "1" {
+			t.Error("Retry-After yok")
+		}
+	}
+}
+
+func TestRateLimiterBounded(t *testing.T) {
+	rl := NewRateLimiter(1, 5)
+	rl.maxBuckets = 3
+	t0 := time.Unix(0, 0)
+	addr := func(i int) netip.Addr { return netip.AddrFrom4([4]byte{10, 0, 0, byte(i)}) }
+
+	// Hepsi kullanımda: yeni istemci en eski kovayı siler
+	for i := range 5 {
+		rl.Allow(addr(i), t0.Add(time.Duration(i)*time.Millisecond))
+	}
+	if len(rl.buckets) != 3 {
+		t.Fatalf("%d kova, en fazla 3 bekleniyordu", len(rl.buckets))
+	}
+	if _, ok := rl.buckets[addr(0)]; ok {
+		t.Error("en eski kova silinmedi")
+	}
+
+	// Kovalar dolunca (5 sn) hepsi tek seferde silinir
+	rl.Allow(addr(9), t0.Add(10*time.Second))
+	if len(rl.buckets) != 1 {
+		t.Errorf("dolmuş kovalar silinmedi: %d kova", len(rl.buckets))
+	}
+}
+
+// backend, çağrı sayısını tutan ve yanıt başlıklarını test belirleyen bir
+// handler'dır.
+type backend struct {
+	calls  atomic.Int32
+	header http.Header
+}
+
+func (b *backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	b.calls.Add(1)
+	for k, v := range b.header {
+		w.Header()[k] = v
+	}
+	fmt.Fprintf(w, "%s lang=%s", r.URL.Path, r.Header.Get("Accept-Language"))
+}
+
+type clock struct{ t time.Time }
+
+func (c *clock) now() time.Time { return c.t }
+
+func newTestCache(header http.Header) (*Cache, *backend, http.Handler, *clock) {
+	clk := &clock{time.Unix(1_700_000_000, 0)}
+	c := NewCache(1 << 10)
+	c.now = clk.now
+	b := &backend{header: header}
+	return c, b, c.Middleware()(b), clk
+}
+
+func do(h http.Handler, path string, hdr ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	for i := 0; i+1 < len(hdr); i += 2 {
+		req.Header.Set(hdr[i], hdr[i+1])
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestCacheHitAndExpiry(t *testing.T) {
+	_, b, h, clk := newTestCache(http.Header{"Cache-Control": {"max-age=60"}})
+
+	if rec := do(h, "/a"); rec.Header().Get("X-Cache") != "MISS" {
+		t.Fatalf("ilk istek: %s", rec.Header().Get("X-Cache"))
+	}
+	clk.t = clk.t.Add(30 * time.Second)
+	rec := do(h, "/a")
+	if rec.Header().Get("X-Cache") != "HIT" || rec.Header().Get("Age") != "30" || rec.Body.String() != "/a lang=" {
+		t.Errorf("ikinci istek: %v %q", rec.Header(), rec.Body)
+	}
+	if do(h, "/a", "Cache-Control", "no-cache").Header().Get("X-Cache") != "MISS" {
+		t.Error("no-cache isteği önbellekten döndü")
+	}
+	clk.t = clk.t.Add(61 * time.Second)
+	if do(h, "/a").Header().Get("X-Cache") != "MISS" {
+		t.Error("süresi dolan yanıt döndü")
+	}
+	if n := b.calls.Load(); n != 3 {
+		t.Errorf("backend %d kez çağrıldı, 3 bekleniyordu", n)
+	}
+}
+
+func TestCacheVary(t *testing.T) {
+	_, b, h, _ := newTestCache(http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept-Language"}})
+
+	for _, step := range []struct{ lang, cache string }{
+		{"tr", "MISS"}, {"en", "MISS"}, {"tr", "HIT"}, {"en", "HIT"}, {"", "MISS"},
+	} {
+		rec := do(h, "/v", "Accept-Language", step.lang)
+		if rec.Header().Get("X-Cache") != step.cache || rec.Body.String() != "/v lang="+step.lang {
+			t.Errorf("lang=%q: %s %q, beklenen %s", step.lang, rec.Header().Get("X-Cache"), rec.Body, step.cache)
+		}
+	}
+	if n := b.calls.Load(); n != 3 {
+		t.Errorf("backend %d kez çağrıldı, 3 bekleniyordu", n)
+	}
+}
+
+func TestCacheNotStored(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		req    []string
+	}{
+		{"no-store", http.Header{"Cache-Control": {"no-store"}}, nil},
+		{"private", http.Header{"Cache-Control": {"private, max-age=60"}}, nil},
+		{"Set-Cookie", http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"a=b"}}, nil},
+		{"Vary *", http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept, *"}}, nil},
+		{"tazelik yok", http.Header{}, nil},
+		{"Authorization", http.Header{"Cache-Control": {"max-age=60"}}, []string{"Authorization", "Bearer x"}},
+		{"istek no-store", http.Header{"Cache-Control": {"max-age=60"}}, []string{"Cache-Control", "no-store"}},
+	}
+	for _, tt := range tests {
+		c, _, h, _ := newTestCache(tt.header)
+		do(h, "/x", tt.req...)
+		if c.n != 0 {
+			t.Errorf("%s: yanıt saklandı", tt.name)
+		}
+	}
+
+	// maxBody'yi aşan yanıt istemciye tam gider ama saklanmaz
+	c := NewCache(4)
+	h := c.Middleware()(&backend{header: http.Header{"Cache-Control": {"max-age=60"}}})
+	if rec := do(h, "/uzun"); rec.Body.String() != "/uzun lang=" || c.n != 0 {
+		t.Errorf("büyük yanıt: %q, %d giriş", rec.Body, c.n)
+	}
+}
+
+func TestCacheBounded(t *testing.T) {
+	c, b, h, clk := newTestCache(http.Header{})
+	c.maxEntries = 2
+	get := func(path string, maxAge int) {
+		b.header.Set("Cache-Control", fmt.Sprintf("max-age=%d", maxAge))
+		do(h, path)
+	}
+
+	get("/60", 60)
+	get("/10", 10)
+	get("/30", 30) // yer yok: süresi en erken dolacak /10 silinir
+	if c.n != 2 || c.entries["/10"] != nil || c.entries["/60"] == nil {
+		t.Fatalf("sınır: %d giriş, %v", c.n, c.entries)
+	}
+
+	clk.t = clk.t.Add(45 * time.Second) // /30 doldu
+	get("/90", 90)
+	if c.n != 2 || c.entries["/30"] != nil || c.entries["/60"] == nil {
+		t.Errorf("süresi dolan giriş silinmedi: %d giriş, %v", c.n, c.entries)
+	}
+}
+
+func TestReplaceReader(t *testing.T) {
+	src := strings.Repeat("abc httpbin xyz http", 1000) + "httpbin"
+	want := strings.ReplaceAll(src, "httpbin", "PROXY")
+	for name, wrap := range map[string]func(io.Reader) io.Reader{
+		"tam":      func(r io.Reader) io.Reader { return r },
+		"tek bayt": iotest.OneByteReader,
+		"yarım":    iotest.HalfReader,
+	} {
+		r := NewReplaceReader(io.NopCloser(wrap(strings.NewReader(src))), "httpbin", "PROXY")
+		got, err := io.ReadAll(iotest.OneByteReader(r))
+		if err != nil || string(got) != want {
+			t.Errorf("%s: %v, %d bayt (beklenen %d)", name, err, len(got), len(want))
+		}
+	}
+}
+
+func TestReplaceReaderReusesBuffer(t *testing.T) {
+	r := NewReplaceReader(io.NopCloser(iotest.OneByteReader(strings.NewReader(strings.Repeat("x", 1<<16)))), "httpbin", "PROXY")
+	p := make([]byte, 1)
+	r.Read(p) // tamponlar ısınsın
+	allocs := testing.AllocsPerRun(1000, func() { r.Read(p) })
+	if allocs > 0 {
+		t.Errorf("Read başına %.1f tahsis", allocs)
+	}
+}
+``
+/*
+Çıktı:
+
+``bash
+$ go test -race -v .
+=== RUN   TestChainOrder
+--- PASS: TestChainOrder (0.00s)
+=== RUN   TestAccessLog
+--- PASS: TestAccessLog (0.00s)
+=== RUN   TestRateLimiter
+--- PASS: TestRateLimiter (0.00s)
+=== RUN   TestRateLimiterBounded
+--- PASS: TestRateLimiterBounded (0.00s)
+=== RUN   TestCacheHitAndExpiry
+--- PASS: TestCacheHitAndExpiry (0.00s)
+=== RUN   TestCacheVary
+--- PASS: TestCacheVary (0.00s)
+=== RUN   TestCacheNotStored
+--- PASS: TestCacheNotStored (0.00s)
+=== RUN   TestCacheBounded
+--- PASS: TestCacheBounded (0.00s)
+=== RUN   TestReplaceReader
+--- PASS: TestReplaceReader (0.02s)
+=== RUN   TestReplaceReaderReusesBuffer
+--- PASS: TestReplaceReaderReusesBuffer (0.00s)
+PASS
+ok  	gateway	1.044s
+``
+
+---
+
+✅ Böylece proxy, her biri tek bir işi yapan ve istenirse başka handler’larda da tekrar kullanılabilen **middleware zinciri** ile bir API gateway’e dönüşmüş oldu.
+*/
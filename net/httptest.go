@@ -416,7 +416,7 @@ func main() {
 
 1. **FastCGI handler** → Form verisini alır ve cookie ekler.
 2. **`httptest` GET/POST** → Handler’ı test eder, yanıtı ve body’yi doğrular.
-3. **FastCGI client** → TCP üzerinden FastCGI sunucusuna POST isteği gönderir ve cookie yönetimini gösterir.
+3. **FastCGI client** → TCP üzerinden FastCGI sunucusuna POST isteği gönderir ve cookie yönetimini gösterir. (Düz `http.Client` FastCGI konuşamaz; çalışan sürüm için `Transport` olarak `fcgiclient.Transport` kullanılmalıdır, bkz. `net/http-fcgi.go`.)
 4. **CookieJar** → Client cookie’lerini otomatik olarak saklar ve sonraki isteklere ekler.
 
 ---
@@ -562,6 +562,12 @@ func main() {
 ---
 
 ## 6️⃣ FastCGI Client ile Test ve Cookie Yönetimi
+
+> ⚠️ `fcgi.Serve` ile açılan port **düz HTTP konuşmaz**; ikili FastCGI kayıtları bekler.
+> Bu yüzden `http.Client{}` ile `client.PostForm("http://127.0.0.1:9000", ...)` çağrısı yanıt alamaz.
+> Standart kütüphanede yalnızca FastCGI **sunucu** tarafı vardır. İstemci tarafını
+> `net/http-fcgi.go` dosyasındaki **`fcgiclient.Transport`** (`http.RoundTripper`) sağlar:
+> `http.Client` aynı kalır, sadece `Transport` değişir.
 */
 
 ``go
@@ -569,24 +575,34 @@ package main
 
 import (
     "fmt"
-    "io/ioutil"
+    "io"
     "net/http"
     "net/http/cookiejar"
     "net/url"
+
+    "fcgidemo/fcgiclient"
 )
 
 func main() {
     jar, _ := cookiejar.New(nil)
-    client := &http.Client{Jar: jar}
+    client := &http.Client{
+        Jar: jar,
+        // İstekler FastCGI kayıtlarına çevrilip 127.0.0.1:9000'e gönderilir
+        Transport: &fcgiclient.Transport{Addr: "127.0.0.1:9000"},
+    }
 
     form := url.Values{}
     form.Set("name", "Ayşe")
-    resp, _ := client.PostForm("http://127.0.0.1:9000", form)
-    bodyClient, _ := ioutil.ReadAll(resp.Body)
+    resp, err := client.PostForm("http://uygulama.local/", form)
+    if err != nil {
+        fmt.Println("Hata:", err)
+        return
+    }
+    bodyClient, _ := io.ReadAll(resp.Body)
     resp.Body.Close()
     fmt.Println("FastCGI Client Response:", string(bodyClient))
 
-    u, _ := url.Parse("http://127.0.0.1:9000")
+    u, _ := url.Parse("http://uygulama.local/")
     for _, c := range jar.Cookies(u) {
         fmt.Println("Client Cookie:", c.Name, "=", c.Value)
     }
@@ -691,4 +691,1154 @@ destekliyor 🎉
 Örn: `timeout 3 sleep 10` → 3 saniye sonra işlemi öldürür.
 
 Bunu da ekleyeyim mi?
-*/
\ No newline at end of file
+*/
+
+/*
+Tamam 👍 Ama timeout’tan önce shell’in temelini sağlamlaştıralım. Şu ana kadarki sürümlerin ortak sorunları:
+
+* `strings.Split(input, " ")` / `strings.Fields` → `echo "merhaba dünya"` iki ayrı argüman olur, tırnaklar komuta aynen gider.
+* `|` işareti tırnak içinde bile pipe sanılır (`echo "a|b"`).
+* `cd` çalışmaz, çünkü `exec.Command("cd", ...)` ayrı bir süreçte çalışır ve shell’in dizinini değiştiremez.
+* `Ctrl-C` basınca çalışan komutla birlikte **shell de kapanır**.
+* `&` ile başlatılan işler terminalden gelen `Ctrl-C` ile ölür.
+
+Bu sürümde shell’i üç katmana ayırıyoruz:
+
+1. **Tokenizer** → satırı kelimelere ve operatörlere böler. Tırnakları ve kaçış karakterlerini çözer.
+2. **Parser** → token’lardan `Job → AndOr → Pipeline → Command` ağacı kurar.
+3. **Executor** → builtin’leri shell içinde, diğer komutları `exec.Cmd` ile çalıştırır.
+
+---
+
+# 🔹 Tam Go Shell (Tırnak + Pipe + Yönlendirme + && / || + $VAR + Builtin + Job Kontrolü)
+
+Desteklenen sözdizimi:
+
+| Sözdizimi | Anlamı |
+| --- | --- |
+| `'...'` | Olduğu gibi al, `$` genişletme |
+| `"..."` | `$VAR` genişlet, `\"` `\\` `\$` kaçışları |
+| `\x` | Tek karakteri kaçır (`a\ b` → tek argüman) |
+| `a \| b` | `a`’nın stdout’u `b`’nin stdin’i (`cmd.StdoutPipe`) |
+| `< f`, `> f`, `>> f` | Dosyadan oku / dosyaya yaz / dosyaya ekle |
+| `a && b`, `a \|\| b` | `a` başarılıysa / başarısızsa `b` |
+| `a ; b` | Sırayla çalıştır |
+| `a &` | Arka planda çalıştır |
+| `$VAR`, `${VAR}`, `$?` | Ortam değişkeni, son çıkış kodu |
+| `# ...` | Yorum |
+
+Builtin’ler: `cd`, `export`, `history`, `jobs`, `wait`, `exit`.
+
+> Not: Arka plan işleri için `syscall.SysProcAttr{Setpgid: true}` kullanıldığından bu kod **Linux / macOS** içindir.
+*/
+``go
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// ---------------------------------------------------------------
+// 1. Tokenizer: tırnaklar, kaçış karakterleri, operatörler, $VAR
+// ---------------------------------------------------------------
+
+type tokenKind int
+
+const (
+	tWord   tokenKind = iota
+	tPipe             // |
+	tOr               // ||
+	tAmp              // &
+	tAnd              // &&
+	tSemi             // ;
+	tLess             // <
+	tGreat            // >
+	tDGreat           // >>
+)
+
+var tokenNames = map[tokenKind]string{
+	tPipe: "|", tOr: "||", tAmp: "&", tAnd: "&&", tSemi: ";",
+	tLess: "<", tGreat: ">", tDGreat: ">>",
+}
+
+type token struct {
+	kind tokenKind
+	text string // operatörler ve hata mesajları için ham metin
+	word Word
+	pos  int // satırdaki sütun (hata mesajları için)
+}
+
+// wordPart, bir kelimenin tek parçasıdır. $VAR genişletmesi parse anında
+// değil çalıştırma anında yapılır; böylece "export X=1; echo $X" ve
+// "false; echo $?" doğru değeri görür.
+type wordPart struct {
+	text    string
+	literal bool // tek tırnak veya \ kaçışı → genişletilmez
+}
+
+type Word struct {
+	parts  []wordPart
+	quoted bool // kelimenin bir kısmı tırnaklı mı? ("" boş argüman olabilmeli)
+}
+
+// Expand, kelimeyi verilen fonksiyonla genişletir. Tırnaksız ve sonucu boş
+// olan kelimeler ($TANIMSIZ) ok=false döner ve argüman listesinden atılır.
+func (w Word) Expand(expand func(string) string) (s string, ok bool) {
+	var b strings.Builder
+	for _, p := range w.parts {
+		if p.literal {
+			b.WriteString(p.text)
+		} else {
+			b.WriteString(expand(p.text))
+		}
+	}
+	return b.String(), b.Len() > 0 || w.quoted
+}
+
+// tokenize, satırı kelime ve operatörlere böler.
+//
+//   - 'tek tırnak'  → içerik olduğu gibi alınır, $ genişletilmez
+//   - "çift tırnak" → $VAR genişletilir, \" \\ \$ kaçışları çalışır
+//   - \x            → tırnak dışında sonraki karakter olduğu gibi alınır
+//   - # ile başlayan kelime satırın geri kalanını yorum yapar
+func tokenize(line string) ([]token, error) {
+	var (
+		tokens []token
+		word   Word
+		raw    strings.Builder // henüz genişletilmemiş ($ içerebilen) parça
+		inWord bool
+		start  int
+	)
+	runes := []rune(line)
+
+	flush := func() {
+		if raw.Len() > 0 {
+			word.parts = append(word.parts, wordPart{text: raw.String()})
+			raw.Reset()
+		}
+	}
+	literal := func(s string) {
+		flush()
+		word.parts = append(word.parts, wordPart{text: s, literal: true})
+	}
+	beginWord := func(i int) {
+		if !inWord {
+			inWord, start = true, i
+		}
+	}
+	endWord := func(end int) {
+		flush()
+		if inWord {
+			tokens = append(tokens, token{kind: tWord, text: string(runes[start:end]), word: word, pos: start + 1})
+		}
+		word = Word{}
+		inWord = false
+	}
+	op := func(kind tokenKind, i int) {
+		endWord(i)
+		tokens = append(tokens, token{kind: kind, text: tokenNames[kind], pos: i + 1})
+	}
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+
+		switch {
+		case r == ' ' || r == '\t':
+			endWord(i)
+		case r == '#' && !inWord:
+			endWord(i)
+			return tokens, nil
+		case r == '\\':
+			beginWord(i)
+			if i+1 < len(runes) {
+				i++
+				literal(string(runes[i]))
+			}
+		case r == '\'':
+			beginWord(i)
+			word.quoted = true
+			end := indexRune(runes, i+1, '\'')
+			if end < 0 {
+				return nil, fmt.Errorf("sütun %d: kapanmamış tek tırnak", i+1)
+			}
+			literal(string(runes[i+1 : end]))
+			i = end
+		case r == '"':
+			beginWord(i)
+			word.quoted = true
+			flush()
+			j := i + 1
+			for ; j < len(runes) && runes[j] != '"'; j++ {
+				if runes[j] == '\\' && j+1 < len(runes) && strings.ContainsRune(`"\$`, runes[j+1]) {
+					j++
+					literal(string(runes[j]))
+					continue
+				}
+				raw.WriteRune(runes[j])
+			}
+			if j >= len(runes) {
+				return nil, fmt.Errorf("sütun %d: kapanmamış çift tırnak", i+1)
+			}
+			flush()
+			i = j
+		case r == '|' && next == '|':
+			op(tOr, i)
+			i++
+		case r == '|':
+			op(tPipe, i)
+		case r == '&' && next == '&':
+			op(tAnd, i)
+			i++
+		case r == '&':
+			op(tAmp, i)
+		case r == ';':
+			op(tSemi, i)
+		case r == '<':
+			op(tLess, i)
+		case r == '>' && next == '>':
+			op(tDGreat, i)
+			i++
+		case r == '>':
+			op(tGreat, i)
+		default:
+			beginWord(i)
+			raw.WriteRune(r)
+		}
+	}
+	endWord(len(runes))
+	return tokens, nil
+}
+
+func indexRune(runes []rune, from int, r rune) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// ---------------------------------------------------------------
+// 2. Parser: komut → pipeline → && / || zinciri → ; / & listesi
+// ---------------------------------------------------------------
+
+type Command struct {
+	Args   []Word
+	Stdin  *Word // < dosya
+	Stdout *Word // > veya >> dosya
+	Append bool  // >> mi?
+}
+
+type Pipeline struct {
+	Cmds []*Command
+}
+
+// AndOr, "a && b || c" gibi koşullu zincirdir. Ops[i], Pipes[i] ile Pipes[i+1] arasındadır.
+type AndOr struct {
+	Pipes []*Pipeline
+	Ops   []tokenKind
+	Text  string
+}
+
+type Job struct {
+	List       *AndOr
+	Background bool
+}
+
+type parser struct {
+	tokens []token
+	i      int
+}
+
+func (p *parser) peek() *token {
+	if p.i < len(p.tokens) {
+		return &p.tokens[p.i]
+	}
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	pos := "satır sonu"
+	if t := p.peek(); t != nil {
+		pos = fmt.Sprintf("sütun %d", t.pos)
+	}
+	return fmt.Errorf("sözdizimi hatası (%s): %s", pos, fmt.Sprintf(format, args...))
+}
+
+func parse(tokens []token) ([]Job, error) {
+	p := &parser{tokens: tokens}
+	var jobs []Job
+	for p.peek() != nil {
+		from := p.i
+		list, err := p.andOr()
+		if err != nil {
+			return nil, err
+		}
+		list.Text = joinTokens(tokens[from:p.i])
+		job := Job{List: list}
+		if t := p.peek(); t != nil {
+			switch t.kind {
+			case tAmp:
+				job.Background = true
+				p.i++
+			case tSemi:
+				p.i++
+			default:
+				return nil, p.errorf("beklenmeyen %q", t.text)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (p *parser) andOr() (*AndOr, error) {
+	first, err := p.pipeline()
+	if err != nil {
+		return nil, err
+	}
+	list := &AndOr{Pipes: []*Pipeline{first}}
+	for t := p.peek(); t != nil && (t.kind == tAnd || t.kind == tOr); t = p.peek() {
+		p.i++
+		next, err := p.pipeline()
+		if err != nil {
+			return nil, err
+		}
+		list.Ops = append(list.Ops, t.kind)
+		list.Pipes = append(list.Pipes, next)
+	}
+	return list, nil
+}
+
+func (p *parser) pipeline() (*Pipeline, error) {
+	pl := &Pipeline{}
+	for {
+		cmd, err := p.command()
+		if err != nil {
+			return nil, err
+		}
+		pl.Cmds = append(pl.Cmds, cmd)
+		if t := p.peek(); t == nil || t.kind != tPipe {
+			return pl, nil
+		}
+		p.i++
+	}
+}
+
+func (p *parser) command() (*Command, error) {
+	cmd := &Command{}
+	for t := p.peek(); t != nil; t = p.peek() {
+		switch t.kind {
+		case tWord:
+			cmd.Args = append(cmd.Args, t.word)
+			p.i++
+			continue
+		case tLess, tGreat, tDGreat:
+			p.i++
+			target := p.peek()
+			if target == nil || target.kind != tWord {
+				return nil, p.errorf("%q sonrası dosya adı bekleniyordu", t.text)
+			}
+			p.i++
+			if t.kind == tLess {
+				cmd.Stdin = &target.word
+			} else {
+				cmd.Stdout, cmd.Append = &target.word, t.kind == tDGreat
+			}
+			continue
+		}
+		break
+	}
+	if len(cmd.Args) == 0 {
+		return nil, p.errorf("komut bekleniyordu")
+	}
+	return cmd, nil
+}
+
+func joinTokens(tokens []token) string {
+	parts := make([]string, len(tokens))
+	for i, t := range tokens {
+		parts[i] = t.text
+	}
+	return strings.Join(parts, " ")
+}
+
+// ---------------------------------------------------------------
+// 3. Shell: builtin'ler, pipeline çalıştırma, job kontrolü, sinyaller
+// ---------------------------------------------------------------
+
+type job struct {
+	id   int
+	text string
+}
+
+type Shell struct {
+	history    []string
+	lastStatus int
+
+	mu       sync.Mutex
+	jobs     map[int]*job
+	nextJob  int
+	finished []string       // bir sonraki prompt'ta basılacak "[1] Bitti" mesajları
+	fg       []*exec.Cmd    // Ctrl-C'nin iletileceği ön plan süreçleri
+	bg       sync.WaitGroup // wait builtin'i için
+}
+
+func NewShell() *Shell {
+	return &Shell{jobs: make(map[int]*job)}
+}
+
+// expander, os.ExpandEnv ile aynıdır; ek olarak $? verilen çıkış kodunu
+// döndürür. Kod s.lastStatus'tan değil çağırandan gelir: arka plan işi
+// başladığı andaki kodun kopyasını taşır, ön plandaki döngüyle paylaşmaz.
+func expander(status int) func(string) string {
+	lookup := func(name string) string {
+		if name == "?" {
+			return strconv.Itoa(status)
+		}
+		return os.Getenv(name)
+	}
+	return func(text string) string { return os.Expand(text, lookup) }
+}
+
+// forwardSignals, Ctrl-C'de shell'in kapanmasını engeller. Terminalin
+// process group'undaki ön plan süreçleri SIGINT'i çekirdekten zaten alır;
+// onlara ikinci kez gönderilmez. Sinyal yalnızca kendi process group'unda
+// (Setpgid) çalışan ön plan süreçlerine iletilir. Arka plan işleri ön plan
+// listesinde olmadığı için Ctrl-C onlara hiç ulaşmaz.
+func (s *Shell) forwardSignals() {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt)
+	go func() {
+		for range sigCh {
+			s.mu.Lock()
+			fg := s.fg
+			s.mu.Unlock()
+			if len(fg) == 0 {
+				fmt.Println() // prompt'tayken Ctrl-C: shell kapanmaz
+				continue
+			}
+			for _, cmd := range fg {
+				if cmd.Process == nil || cmd.SysProcAttr == nil || !cmd.SysProcAttr.Setpgid {
+					continue
+				}
+				syscall.Kill(-cmd.Process.Pid, syscall.SIGINT) // bütün gruba
+			}
+		}
+	}()
+}
+
+var builtins = map[string]func(s *Shell, args []string, out io.Writer) int{
+	"cd": func(s *Shell, args []string, out io.Writer) int {
+		dir := os.Getenv("HOME")
+		if len(args) > 1 {
+			dir = args[1]
+		}
+		if dir == "-" {
+			dir = os.Getenv("OLDPWD")
+			fmt.Fprintln(out, dir)
+		}
+		old, _ := os.Getwd()
+		if err := os.Chdir(dir); err != nil {
+			fmt.Fprintln(os.Stderr, "cd:", err)
+			return 1
+		}
+		os.Setenv("OLDPWD", old)
+		if wd, err := os.Getwd(); err == nil {
+			os.Setenv("PWD", wd)
+		}
+		return 0
+	},
+	"export": func(s *Shell, args []string, out io.Writer) int {
+		if len(args) == 1 {
+			for _, kv := range os.Environ() {
+				fmt.Fprintln(out, "export", kv)
+			}
+			return 0
+		}
+		status := 0
+		for _, arg := range args[1:] {
+			key, value, ok := strings.Cut(arg, "=")
+			if !ok {
+				value = os.Getenv(key) // "export X" → mevcut değeri koru
+			}
+			if err := os.Setenv(key, value); err != nil {
+				fmt.Fprintln(os.Stderr, "export:", err)
+				status = 1
+			}
+		}
+		return status
+	},
+	"history": func(s *Shell, args []string, out io.Writer) int {
+		if len(args) > 1 && args[1] == "-c" {
+			s.history = nil
+			return 0
+		}
+		for i, line := range s.history {
+			fmt.Fprintf(out, "%5d  %s\n", i+1, line)
+		}
+		return 0
+	},
+	"jobs": func(s *Shell, args []string, out io.Writer) int {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for id := 1; id < s.nextJob+1; id++ {
+			if j, ok := s.jobs[id]; ok {
+				fmt.Fprintf(out, "[%d]  Çalışıyor   %s &\n", j.id, j.text)
+			}
+		}
+		return 0
+	},
+	"wait": func(s *Shell, args []string, out io.Writer) int {
+		s.bg.Wait()
+		return 0
+	},
+	"exit": func(s *Shell, args []string, out io.Writer) int {
+		code := s.lastStatus
+		if len(args) > 1 {
+			code, _ = strconv.Atoi(args[1])
+		}
+		s.bg.Wait()
+		os.Exit(code)
+		return code
+	},
+}
+
+// expandArgs, komutun kelimelerini çalıştırma anındaki değişkenlerle genişletir.
+func expandArgs(c *Command, expand func(string) string) []string {
+	var args []string
+	for _, w := range c.Args {
+		if arg, ok := w.Expand(expand); ok {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// openRedirects, komutun < ve > dosyalarını açar.
+func openRedirects(c *Command, expand func(string) string) (in, out *os.File, err error) {
+	if c.Stdin != nil {
+		name, _ := c.Stdin.Expand(expand)
+		if in, err = os.Open(name); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.Stdout != nil {
+		name, _ := c.Stdout.Expand(expand)
+		flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if c.Append {
+			flags = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+		}
+		if out, err = os.OpenFile(name, flags, 0o644); err != nil {
+			if in != nil {
+				in.Close()
+			}
+			return nil, nil, err
+		}
+	}
+	return in, out, nil
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		// Sinyalle ölen süreçler için bash gibi 128+sinyal döndür (Ctrl-C → 130)
+		if ws, ok := exitErr.Sys().(syscall.WaitStatus); ok && ws.Signaled() {
+			return 128 + int(ws.Signal())
+		}
+		return exitErr.ExitCode()
+	}
+	return 127 // komut bulunamadı / başlatılamadı
+}
+
+// changesState, builtin'in shell'in durumunu (dizin, ortam, geçmiş, süreç)
+// değiştirip değiştirmediğini söyler. Bash pipeline'daki builtin'i bir alt
+// shell'de çalıştırır ve değişiklik kaybolur; burada alt shell olmadığı için
+// bunlar pipeline'da reddedilir.
+func changesState(args []string) bool {
+	switch args[0] {
+	case "cd", "exit":
+		return true
+	case "export":
+		return len(args) > 1
+	case "history":
+		return len(args) > 1 && args[1] == "-c"
+	}
+	return false
+}
+
+// runPipeline, pipeline'ı çalıştırır ve çıkış kodunu döndürür. status, $?'in
+// değeridir.
+func (s *Shell) runPipeline(p *Pipeline, background bool, status int) int {
+	expand := expander(status)
+	argv := make([][]string, len(p.Cmds))
+	for i, c := range p.Cmds {
+		if argv[i] = expandArgs(c, expand); len(argv[i]) == 0 {
+			if len(p.Cmds) == 1 {
+				return 0 // ör: sadece "$TANIMSIZ"
+			}
+			argv[i] = []string{"true"} // boş komut pipeline'ı bozmasın
+		}
+		if _, ok := builtins[argv[i][0]]; !ok {
+			continue
+		}
+		// Builtin'ler shell sürecinin içinde çalışır. Arka planda cd shell'in
+		// dizinini değiştirir, exit shell'i kapatır, wait kendini bekler.
+		switch {
+		case background:
+			fmt.Fprintf(os.Stderr, "go-shell: %s: builtin'ler arka planda (&) çalıştırılamaz\n", argv[i][0])
+			return 1
+		case len(p.Cmds) > 1 && changesState(argv[i]):
+			fmt.Fprintf(os.Stderr, "go-shell: %s: bu builtin pipeline'da çalıştırılamaz\n", argv[i][0])
+			return 1
+		}
+	}
+
+	// Tek başına builtin: shell sürecinin içinde çalışmalı (cd, export state değiştirir)
+	if fn, ok := builtins[argv[0][0]]; ok && len(p.Cmds) == 1 {
+		_, out, err := openRedirects(p.Cmds[0], expand)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "go-shell:", err)
+			return 1
+		}
+		var w io.Writer = os.Stdout
+		if out != nil {
+			defer out.Close()
+			w = out
+		}
+		return fn(s, argv[0], w)
+	}
+
+	var (
+		cmds    []*exec.Cmd
+		files   []*os.File
+		prevOut io.ReadCloser
+
+		// Pipeline'daki builtin'ler goroutine'de çalışır ve io.Pipe'a yazar.
+		readers       []*io.PipeReader
+		builtinWG     sync.WaitGroup
+		builtinStatus int // son aşama builtin ise onun çıkış kodu
+	)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	// stopBuiltins, okunmayan io.Pipe'ları kapatır. "history | head -1"de
+	// head çıktıktan sonra history'nin yazması hata döner (SIGPIPE gibi).
+	stopBuiltins := func() {
+		for _, r := range readers {
+			r.CloseWithError(io.ErrClosedPipe)
+		}
+		builtinWG.Wait()
+	}
+	killAll := func() {
+		for _, cmd := range cmds {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}
+		stopBuiltins()
+	}
+
+	for i, c := range p.Cmds {
+		args := argv[i]
+		last := i == len(p.Cmds)-1
+
+		in, out, err := openRedirects(c, expand)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "go-shell:", err)
+			killAll()
+			return 1
+		}
+
+		if fn, ok := builtins[args[0]]; ok {
+			// Builtin stdin okumaz; önceki aşamanın çıktısını kapat ki yazan
+			// taraf beklemede kalmasın.
+			if in != nil {
+				files = append(files, in)
+			}
+			if prevOut != nil {
+				prevOut.Close()
+			}
+			var w io.Writer = os.Stdout
+			var nextIn io.ReadCloser
+			switch {
+			case out != nil:
+				files = append(files, out)
+				w = out
+				if !last {
+					nextIn = io.NopCloser(strings.NewReader(""))
+				}
+			case !last:
+				pr, pw := io.Pipe()
+				readers = append(readers, pr)
+				w, nextIn = pw, pr
+			}
+			builtinWG.Add(1)
+			go func() {
+				defer builtinWG.Done()
+				code := fn(s, args, w)
+				if pw, ok := w.(*io.PipeWriter); ok {
+					pw.Close()
+				}
+				if last {
+					builtinStatus = code
+				}
+			}()
+			prevOut = nextIn
+			continue
+		}
+
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stderr = os.Stderr
+
+		// stdin: < dosya > önceki komutun pipe'ı > terminal (arka planda /dev/null)
+		switch {
+		case in != nil:
+			files = append(files, in)
+			cmd.Stdin = in
+		case prevOut != nil:
+			cmd.Stdin = prevOut
+		case background:
+			cmd.Stdin = nil // exec.Cmd nil stdin'i /dev/null'a bağlar
+		default:
+			cmd.Stdin = os.Stdin
+		}
+
+		// stdout: > dosya > sonraki komuta pipe > terminal
+		var nextIn io.ReadCloser
+		switch {
+		case out != nil:
+			files = append(files, out)
+			cmd.Stdout = out
+			if !last {
+				nextIn = io.NopCloser(strings.NewReader("")) // "a > f | b": b boş girdi alır
+			}
+		case !last:
+			if nextIn, err = cmd.StdoutPipe(); err != nil {
+				fmt.Fprintln(os.Stderr, "go-shell:", err)
+				killAll()
+				return 1
+			}
+		default:
+			cmd.Stdout = os.Stdout
+		}
+
+		if background {
+			// Kendi process group'u: terminaldeki Ctrl-C arka plan işini öldürmez
+			cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+		}
+
+		if err := cmd.Start(); err != nil {
+			fmt.Fprintln(os.Stderr, "go-shell:", err)
+			killAll()
+			return 127
+		}
+		cmds = append(cmds, cmd)
+
+		// Okuma ucu artık çocuk süreçte; ebeveyndeki kopyayı kapat ki
+		// "yes | head -1" gibi durumlarda yazan taraf SIGPIPE alabilsin.
+		if f, ok := prevOut.(*os.File); ok {
+			f.Close()
+		}
+		prevOut = nextIn
+	}
+
+	if !background {
+		s.mu.Lock()
+		s.fg = cmds
+		s.mu.Unlock()
+		defer func() {
+			s.mu.Lock()
+			s.fg = nil
+			s.mu.Unlock()
+		}()
+	}
+
+	status = 0
+	for _, cmd := range cmds {
+		status = exitCode(cmd.Wait()) // pipeline'ın durumu son komutunkidir
+	}
+	stopBuiltins()
+	if _, ok := builtins[argv[len(argv)-1][0]]; ok {
+		status = builtinStatus // stopBuiltins goroutine'i bekledi
+	}
+	return status
+}
+
+// runAndOr, && ve || kurallarına göre pipeline'ları sırayla çalıştırır.
+// status, listeden önceki çıkış kodudur; her pipeline $? olarak bir
+// öncekinin kodunu görür.
+func (s *Shell) runAndOr(list *AndOr, background bool, status int) int {
+	status = s.runPipeline(list.Pipes[0], background, status)
+	for i, op := range list.Ops {
+		if (op == tAnd && status != 0) || (op == tOr && status == 0) {
+			continue
+		}
+		status = s.runPipeline(list.Pipes[i+1], background, status)
+	}
+	return status
+}
+
+// startBackground, listeyi arka planda çalıştırır. status, işin başladığı
+// andaki $? değeridir; iş s.lastStatus'u hiç okumaz.
+func (s *Shell) startBackground(list *AndOr, status int) {
+	s.mu.Lock()
+	s.nextJob++
+	j := &job{id: s.nextJob, text: list.Text}
+	s.jobs[j.id] = j
+	s.mu.Unlock()
+
+	fmt.Printf("[%d] %s &\n", j.id, j.text)
+	s.bg.Add(1)
+	go func() {
+		defer s.bg.Done()
+		status := s.runAndOr(list, true, status)
+
+		s.mu.Lock()
+		delete(s.jobs, j.id)
+		if len(s.jobs) == 0 {
+			s.nextJob = 0
+		}
+		s.finished = append(s.finished, fmt.Sprintf("[%d]+ Bitti (%d)   %s", j.id, status, j.text))
+		s.mu.Unlock()
+	}()
+}
+
+// Execute, tek bir girdi satırını çalıştırır.
+func (s *Shell) Execute(line string) {
+	tokens, err := tokenize(line)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "go-shell:", err)
+		s.lastStatus = 2
+		return
+	}
+	jobs, err := parse(tokens)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "go-shell:", err)
+		s.lastStatus = 2
+		return
+	}
+	for _, j := range jobs {
+		if j.Background {
+			s.startBackground(j.List, s.lastStatus)
+			s.lastStatus = 0
+			continue
+		}
+		s.lastStatus = s.runAndOr(j.List, false, s.lastStatus)
+	}
+}
+
+func (s *Shell) reportFinished() {
+	s.mu.Lock()
+	msgs := s.finished
+	s.finished = nil
+	s.mu.Unlock()
+	for _, m := range msgs {
+		fmt.Println(m)
+	}
+}
+
+func main() {
+	sh := NewShell()
+	sh.forwardSignals()
+
+	// go-shell -c "komut"   → tek satır çalıştır
+	// go-shell script.gosh  → dosyadaki satırları sırayla çalıştır
+	var input io.Reader = os.Stdin
+	interactive := true
+	switch {
+	case len(os.Args) > 2 && os.Args[1] == "-c":
+		sh.Execute(os.Args[2])
+		sh.bg.Wait()
+		os.Exit(sh.lastStatus)
+	case len(os.Args) > 1:
+		f, err := os.Open(os.Args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "go-shell:", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+		input, interactive = f, false
+	}
+
+	if interactive {
+		fmt.Println("🔹 Go Shell (pipe, yönlendirme, &&/||, $VAR, job kontrolü)")
+		fmt.Println("Komut girin (çıkmak için 'exit' yazın)")
+	}
+
+	scanner := bufio.NewScanner(input)
+	for {
+		if interactive {
+			sh.reportFinished()
+			fmt.Print("go-shell> ")
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if interactive {
+			sh.history = append(sh.history, line)
+		}
+		sh.Execute(line)
+	}
+
+	sh.bg.Wait()
+	sh.reportFinished()
+	os.Exit(sh.lastStatus)
+}
+``
+/*
+---
+
+## 📝 Nasıl Çalışır?
+
+### Tokenizer
+
+* Tek tırnak içi `literal` parça olarak saklanır.
+* Tırnaksız ve çift tırnaklı metin `raw` (genişletilecek) parça olarak saklanır.
+* Genişletme **parse anında değil, komut çalışacağı anda** yapılır:
+  * `export FOO=bar; echo $FOO` → `bar` yazar.
+  * `false; echo $?` → `1` yazar.
+  * `false || echo $?` → `1` yazar. `$?` her pipeline'da bir önceki pipeline'ın kodudur.
+* Arka plan işi, başladığı andaki `$?`'in **kopyasını** alır. `false; sleep 1 && echo $? &` → `0` yazar (sleep'in kodu). Ön planda sonradan çalışan komutların kodunu görmez; `s.lastStatus`'u yalnızca ön plan döngüsü okur ve yazar.
+* Genişletme `os.Expand` ile yapılır. `os.ExpandEnv`’den tek farkı, `$?` için shell’in son çıkış kodunu döndürmesidir.
+* Tırnaksız bir kelime genişletme sonucu boş kalırsa (`$TANIMSIZ`) argüman listesinden atılır. `""` ise boş bir argüman olarak kalır.
+* Hatalar sütun numarası ile raporlanır: `sütun 6: kapanmamış çift tırnak`.
+
+### Parser
+
+Gramer şu şekilde (yukarıdan aşağı öncelik):
+
+``text
+liste    := andor ( (';' | '&') andor )* [';' | '&']
+andor    := pipeline ( ('&&' | '||') pipeline )*
+pipeline := komut ( '|' komut )*
+komut    := ( kelime | '<' kelime | '>' kelime | '>>' kelime )+
+``
+
+### Executor
+
+* **Builtin’ler** shell sürecinin içinde çalışır. Bu yüzden `cd` ve `export` kalıcıdır. Builtin’lerin çıktısı da `>` ile yönlendirilebilir (`history > h.txt`).
+* Pipeline’daki builtin bir **goroutine**’de çalışır ve çıktısını bir `io.Pipe`’a yazar; sonraki komutun `Stdin`’i o pipe’ın okuma ucudur. `history | head -1` böyle çalışır. Okuyan taraf erken çıkarsa okuma ucu `CloseWithError` ile kapatılır, builtin’in yazması hata döner ve goroutine biter.
+* Bash pipeline’daki builtin’i bir alt shell’de çalıştırır; `cd / | cat` shell’in dizinini değiştirmez. Bizde alt shell yok. Bu yüzden shell’in durumunu değiştiren builtin’ler (`cd`, `exit`, argümanlı `export`, `history -c`) pipeline’da **reddedilir**.
+* Arka plan işlerinde builtin’ler **hiç çalıştırılmaz**: `cd /tmp &` shell’in dizinini değiştirir, `exit &` shell’i bir goroutine’den kapatır, `wait &` kendini bekler. Bunların yerine `go-shell: cd: builtin'ler arka planda (&) çalıştırılamaz` hatası verilir ve iş `1` ile biter.
+* **Pipe’lar** `cmd.StdoutPipe()` ile kurulur ve dönen `*os.File` doğrudan bir sonraki komutun `Stdin`’i yapılır. Arada kopyalama yapan bir goroutine yoktur.
+* Bir sonraki komut başladıktan sonra okuma ucunun **ebeveyndeki kopyası kapatılır**. Aksi halde `yes | head -1` sonsuza kadar asılı kalır, çünkü `yes` SIGPIPE alamaz.
+* Pipeline’ın çıkış kodu **son komutun** çıkış kodudur (bash ile aynı).
+* Komut bulunamazsa çıkış kodu `127` olur.
+* `&&` / `||` soldan sağa değerlendirilir: `false && echo A || echo B` → `B`.
+
+### Sinyaller ve Job Kontrolü
+
+* `signal.Notify(sigCh, os.Interrupt)` ile shell `Ctrl-C`’yi kendisi yakalar, yani **kapanmaz**.
+* Ön plandaki komutlar shell ile **aynı process group**’tadır. Terminal Ctrl-C’de SIGINT’i bu grubun tamamına gönderir; komutlar sinyali çekirdekten zaten alır. Shell onlara ikinci kez göndermez (yoksa her komut Ctrl-C’yi iki kez alır).
+* Sinyal yalnızca **kendi process group’unda** (`Setpgid`) çalışan ön plan süreçlerine iletilir, o da `syscall.Kill(-pid, SIGINT)` ile bütün gruba.
+* Arka plan işleri `Setpgid: true` ile **kendi process group’larında** başlatılır. Terminalin gönderdiği SIGINT onlara ulaşmaz.
+* Arka plan işlerinin stdin’i `/dev/null`’a bağlanır. Böylece terminalden okumaya çalışıp ön plandaki komutla çakışmazlar.
+* Biten işler bir sonraki prompt’ta `[1]+ Bitti (0)   sleep 3` şeklinde bildirilir.
+
+### Script Modu
+
+``bash
+go-shell -c 'echo $HOME && ls | wc -l'
+go-shell deploy.gosh     # her satırı sırayla çalıştırır, çıkış kodu son komutunkidir
+``
+
+---
+
+## 🧪 Testler (`main_test.go`)
+
+Testler komutların çıktısını shell’in kendi `>` yönlendirmesiyle dosyaya yazar. Arka plan testleri `-race` ile çalıştırılmalı.
+*/
+``go
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// run, satırı çalıştırır ve arka plan işlerini bekler. Komutların çıktısı
+// shell'in kendi yönlendirmesiyle dosyaya yazılır.
+func run(t *testing.T, sh *Shell, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		sh.Execute(line)
+	}
+	sh.bg.Wait()
+}
+
+func readFile(t *testing.T, name string) string {
+	t.Helper()
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func TestStatusInAndOr(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	sh := NewShell()
+	run(t, sh, "false || echo $? > "+out)
+	if got := readFile(t, out); got != "1\n" {
+		t.Errorf("$? = %q, istenen %q", got, "1\n")
+	}
+}
+
+// Arka plan işi $?'i başladığı andaki koddan ve kendi pipeline'larından
+// alır; ön plandaki komutların kodunu görmez. -race ile çalıştırın.
+func TestBackgroundStatusSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	first, second := filepath.Join(dir, "first"), filepath.Join(dir, "second")
+	sh := NewShell()
+	run(t, sh,
+		"false",
+		"echo $? > "+first+"; sleep 0.2 && echo $? > "+second+" &",
+		"false", "true", "false",
+	)
+	if got := readFile(t, first); got != "1\n" {
+		t.Errorf("ilk $? = %q, istenen %q", got, "1\n")
+	}
+	if got := readFile(t, second); got != "0\n" {
+		t.Errorf("sleep sonrası $? = %q, istenen %q", got, "0\n")
+	}
+}
+
+func TestBuiltinRejectedInBackground(t *testing.T) {
+	wd, _ := os.Getwd()
+	sh := NewShell()
+	run(t, sh, "cd / &", "history &", "wait &")
+	if now, _ := os.Getwd(); now != wd {
+		t.Errorf("cd & shell'in dizinini değiştirdi: %s", now)
+	}
+	if len(sh.finished) != 3 {
+		t.Fatalf("biten iş sayısı = %d, istenen 3", len(sh.finished))
+	}
+	if !slices.ContainsFunc(sh.finished, func(msg string) bool {
+		return strings.HasSuffix(msg, "Bitti (1)   cd /")
+	}) {
+		t.Errorf("cd & 1 ile bitmedi: %q", sh.finished)
+	}
+}
+
+func TestBuiltinInPipeline(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	sh := NewShell()
+	sh.history = []string{"echo a", "echo b", "echo c"}
+	run(t, sh, "history | head -1 > "+out)
+	if got, want := readFile(t, out), "    1  echo a\n"; got != want {
+		t.Errorf("history | head -1 = %q, istenen %q", got, want)
+	}
+	if sh.lastStatus != 0 {
+		t.Errorf("çıkış kodu = %d, istenen 0", sh.lastStatus)
+	}
+
+	// Okuyan taraf erken çıksa da builtin goroutine'i takılmaz.
+	for i := range 10000 {
+		sh.history = append(sh.history, "echo "+string(rune('a'+i%26)))
+	}
+	run(t, sh, "history | true")
+
+	wd, _ := os.Getwd()
+	run(t, sh, "cd / | cat")
+	if now, _ := os.Getwd(); now != wd || sh.lastStatus != 1 {
+		t.Errorf("cd / | cat: dizin %s, çıkış kodu %d", now, sh.lastStatus)
+	}
+}
+``
+/*
+Çıktı:
+*/
+``bash
+$ go test -race -v .
+=== RUN   TestStatusInAndOr
+--- PASS: TestStatusInAndOr (0.00s)
+=== RUN   TestBackgroundStatusSnapshot
+[1] sleep 0.2 && echo $? > /tmp/TestBackgroundStatusSnapshot2744409140/001/second &
+--- PASS: TestBackgroundStatusSnapshot (0.21s)
+=== RUN   TestBuiltinRejectedInBackground
+[1] cd / &
+[2] history &
+[3] wait &
+go-shell: cd: builtin'ler arka planda (&) çalıştırılamaz
+go-shell: history: builtin'ler arka planda (&) çalıştırılamaz
+go-shell: wait: builtin'ler arka planda (&) çalıştırılamaz
+--- PASS: TestBuiltinRejectedInBackground (0.00s)
+=== RUN   TestBuiltinInPipeline
+go-shell: cd: bu builtin pipeline'da çalıştırılamaz
+--- PASS: TestBuiltinInPipeline (0.06s)
+PASS
+ok  	go-shell	1.291s
+``
+/*
+
+---
+
+## 🖥 Kullanım Örneği
+*/
+``
+go-shell> echo "merhaba   dünya" 'tek $HOME' $HOME
+merhaba   dünya tek $HOME /home/kullanici
+
+go-shell> export NAME=gopher; echo "selam $NAME"
+selam gopher
+
+go-shell> ls /yok || echo "bulunamadı"
+ls: cannot access '/yok': No such file or directory
+bulunamadı
+
+go-shell> ps aux | grep go | wc -l > sayi.txt; cat < sayi.txt
+3
+
+go-shell> sleep 3 &
+[1] sleep 3 &
+
+go-shell> jobs
+[1]  Çalışıyor   sleep 3 &
+
+go-shell> sleep 100
+^C
+go-shell> echo $?
+130
+
+go-shell>
+[1]+ Bitti (0)   sleep 3
+
+go-shell> cd /tmp && pwd
+/tmp
+
+go-shell> history
+    1  echo "merhaba   dünya" 'tek $HOME' $HOME
+    2  export NAME=gopher; echo "selam $NAME"
+    ...
+``
+/*
+---
+
+✅ Artık shell’in tırnakları, kaçış karakterlerini, pipe’ları, yönlendirmeleri, koşullu zincirleri, değişkenleri, builtin’leri ve arka plan işlerini destekliyor. `Ctrl-C` da sadece çalışan komutu durduruyor 🎉
+*/
@@ -533,3 +533,1030 @@ Bu teknik, özellikle **Nginx, Apache, Docker daemon, Go microservices** gibi ya
 
 👉 İstiyor musun, ben bu projeyi daha da ileri götürüp **birden fazla config dosyasını izleyen (hot-reload)** bir sürüm hazırlayayım?
 */
+
+/*
+Tamam 👍 Ama birden fazla dosyaya geçmeden önce mevcut yapının zayıf noktalarını düzeltelim:
+
+* `loadConfig` her alan için elle `switch key` yazıyor. Yeni bir ayar eklemek = hem struct’a hem switch’e dokunmak.
+* Her şey `string`. `APP_PORT=abc` veya `APP_PORT=99999` yazsan bile config “başarıyla” yüklenir.
+* `LOG_LEVEL` satırı silinirse alan sessizce boş kalır.
+* `APP_PORT` değişse bile sunucu eski portta dinlemeye devam eder. Değişiklik aslında **yok sayılır**.
+* Config’i kullanan her yer global `config` ve `mu` değişkenlerine bağımlı.
+
+Bu yüzden config işini **yeniden kullanılabilir küçük bir pakete** taşıyoruz:
+
+* `key=value` (`.txt`, `.env`), **JSON** ve **TOML benzeri** (`[bölüm]` başlıklı) dosyaları okur.
+* Değerleri `reflect` ile **etiketli struct’a** doldurur: `config:"APP_PORT" default:"8080" validate:"min=1,max=65535"`.
+* **Doğrulama** yapar: `required`, `min`, `max`, `oneof`.
+* Hatalı bir reload’u **reddeder** ve eski config’i korur.
+* Değişiklikleri abonelere **kanal** üzerinden bildirir: `Change{Old, New, Fields}`.
+* `APP_PORT` değişince sunucu **yeni portta dinlemeye başlar** ve eski dinleyiciyi graceful olarak kapatır.
+
+---
+
+# 🔹 Örnek: Tipli Config Paketi + Doğrulama + Abonelik + Port Değişiminde Listener Swap
+
+## 📂 Proje Yapısı
+
+``
+configdemo/
+├── go.mod            (module configdemo)
+├── config/
+│   ├── config.go
+│   └── config_test.go
+├── main.go
+└── config.txt        (veya config.json / config.toml)
+``
+
+## 📄 `config/config.go`
+*/
+``go
+// Package config, key=value, JSON ve TOML benzeri dosyaları etiketli
+// struct'lara yükler, doğrular ve değişiklikleri abonelere bildirir.
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ---------------------------------------------------------------
+// 1. Dosyayı düz (flat) bir map'e çevirme: "server.port" → "8080"
+// ---------------------------------------------------------------
+
+// Parse, içeriği formatına göre okuyup noktalı anahtarlı bir map döndürür.
+// format: "json", "toml" veya "env" (key=value).
+//
+// Değerler string'dir; TOML ve JSON dizileri ise []string olarak, eleman
+// eleman gelir. Diziler virgülle birleştirilmediği için ["a,b"] tek eleman
+// olarak kalır.
+func Parse(r io.Reader, format string) (map[string]any, error) {
+	switch format {
+	case "json":
+		return parseJSON(r)
+	case "toml":
+		return parseTOML(r)
+	case "env", "":
+		return parseEnv(r)
+	}
+	return nil, fmt.Errorf("config: bilinmeyen format %q", format)
+}
+
+// FormatOf, dosya uzantısından formatı tahmin eder.
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	}
+	return "env" // .txt, .env, .conf ...
+}
+
+func parseEnv(r io.Reader) (map[string]any, error) {
+	out := make(map[string]any)
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("config: satır %d: '=' bekleniyordu", n)
+		}
+		out[strings.TrimSpace(key)] = unquote(strings.TrimSpace(value))
+	}
+	return out, sc.Err()
+}
+
+// parseTOML, TOML'un küçük bir alt kümesini okur:
+// [bölüm] başlıkları, key = "string" | sayı | bool | ["dizi", "elemanları"] ve # yorumlar.
+func parseTOML(r io.Reader) (map[string]any, error) {
+	out := make(map[string]any)
+	section := ""
+	sc := bufio.NewScanner(r)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("config: satır %d: kapanmamış bölüm başlığı", n)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("config: satır %d: '=' bekleniyordu", n)
+		}
+		key = strings.TrimSpace(key)
+		if section != "" {
+			key = section + "." + key
+		}
+		value = strings.TrimSpace(value)
+		if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+			out[key] = splitArray(value[1 : len(value)-1])
+		} else {
+			out[key] = unquote(value)
+		}
+	}
+	return out, sc.Err()
+}
+
+// splitArray, dizi içeriğini tırnak dışındaki virgüllerden böler:
+// `"a,b", "c"` → [a,b c].
+func splitArray(s string) []string {
+	var items []string
+	add := func(item string) {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, unquote(item))
+		}
+	}
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == ',':
+			add(s[start:i])
+			start = i + 1
+		}
+	}
+	add(s[start:])
+	return items
+}
+
+// stripComment, tırnak dışındaki ilk # karakterinden sonrasını atar.
+func stripComment(line string) string {
+	inQuote := false
+	for i, r := range line {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+		case r == '#' && !inQuote:
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		if u, err := strconv.Unquote(`"` + s[1:len(s)-1] + `"`); err == nil {
+			return u
+		}
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func parseJSON(r io.Reader) (map[string]any, error) {
+	var raw map[string]any
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("config: json: %w", err)
+	}
+	out := make(map[string]any)
+	flatten("", raw, out)
+	return out, nil
+}
+
+func flatten(prefix string, v any, out map[string]any) {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flatten(key, child, out)
+		}
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = fmt.Sprint(item)
+		}
+		out[prefix] = items
+	case nil:
+		out[prefix] = ""
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+// ---------------------------------------------------------------
+// 2. Map → struct (reflect) ve doğrulama
+// ---------------------------------------------------------------
+
+// Decode, düz map'i dst struct'ına doldurur ve doğrular.
+//
+// Desteklenen etiketler:
+//
+//	config:"APP_PORT"                 anahtar adı (yoksa alan adı kullanılır)
+//	default:"8080"                    anahtar dosyada yoksa kullanılacak değer
+//	validate:"required,min=1,max=10"  doğrulama kuralları
+//	validate:"oneof=DEBUG|INFO"       izin verilen değerler
+//
+// İç içe struct alanlarında config etiketi önek olur: Server.Port → "server.port".
+// time.Time alanları RFC 3339 biçiminde okunur (2025-01-02T15:04:05Z).
+func Decode(values map[string]any, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return errors.New("config: dst bir struct pointer'ı olmalı")
+	}
+	var errs []error
+	decodeStruct(values, "", rv.Elem(), &errs)
+	return errors.Join(errs...)
+}
+
+// FieldError, hangi alanın neden geçersiz olduğunu söyler.
+type FieldError struct {
+	Key string
+	Err error
+}
+
+func (e *FieldError) Error() string { return e.Key + ": " + e.Err.Error() }
+func (e *FieldError) Unwrap() error { return e.Err }
+
+func decodeStruct(values map[string]any, prefix string, v reflect.Value, errs *[]error) {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key := f.Tag.Get("config")
+		if key == "-" {
+			continue
+		}
+		if key == "" {
+			key = f.Name
+		}
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		fv := v.Field(i)
+
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeFor[time.Time]() {
+			decodeStruct(values, key, fv, errs)
+			continue
+		}
+
+		raw, present := values[key]
+		if !present {
+			raw, present = f.Tag.Lookup("default")
+		}
+		if present {
+			if err := setValue(fv, raw); err != nil {
+				*errs = append(*errs, &FieldError{key, err})
+				continue
+			}
+		}
+		if err := validate(fv, present, f.Tag.Get("validate")); err != nil {
+			*errs = append(*errs, &FieldError{key, err})
+		}
+	}
+}
+
+// setValue, raw'ı v'nin tipine çevirir. raw bir string ya da (TOML/JSON
+// dizisi için) []string'dir. key=value dosyalarında dizi sözdizimi
+// olmadığından orada []string alanına gelen string virgüllerden bölünür.
+func setValue(v reflect.Value, raw any) error {
+	if list, ok := raw.([]string); ok {
+		if v.Kind() != reflect.Slice || v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("dizi, %s tipine atanamaz", v.Type())
+		}
+		v.Set(reflect.ValueOf(slices.Clone(list)))
+		return nil
+	}
+	s, ok := raw.(string)
+	if !ok {
+		return fmt.Errorf("desteklenmeyen değer %T", raw)
+	}
+	return setString(v, s)
+}
+
+func setString(v reflect.Value, raw string) error {
+	switch v.Type() {
+	case reflect.TypeFor[time.Duration]():
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	case reflect.TypeFor[time.Time]():
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("desteklenmeyen dilim tipi %s", v.Type())
+		}
+		var items []string
+		for _, s := range strings.Split(raw, ",") {
+			if s = strings.TrimSpace(s); s != "" {
+				items = append(items, s)
+			}
+		}
+		v.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("desteklenmeyen tip %s", v.Type())
+	}
+	return nil
+}
+
+func validate(v reflect.Value, present bool, rules string) error {
+	if rules == "" {
+		return nil
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if !present || v.IsZero() {
+				return errors.New("zorunlu alan eksik")
+			}
+		case "min", "max":
+			limit, err := strconv.ParseFloat(arg, 64)
+			if err != nil {
+				return fmt.Errorf("geçersiz kural %q", rule)
+			}
+			n, ok := number(v)
+			if !ok {
+				continue
+			}
+			if name == "min" && n < limit {
+				return fmt.Errorf("%v, en az %v olmalı", v.Interface(), arg)
+			}
+			if name == "max" && n > limit {
+				return fmt.Errorf("%v, en fazla %v olmalı", v.Interface(), arg)
+			}
+		case "oneof":
+			allowed := strings.Split(arg, "|")
+			if v.Kind() == reflect.String && !slices.Contains(allowed, v.String()) {
+				return fmt.Errorf("%q geçersiz, izin verilenler: %s", v.String(), strings.Join(allowed, ", "))
+			}
+		default:
+			return fmt.Errorf("bilinmeyen kural %q", name)
+		}
+	}
+	return nil
+}
+
+// number, sayısal alanlar için değeri; string ve dilimler için uzunluğu döndürür.
+func number(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	case reflect.String, reflect.Slice:
+		return float64(v.Len()), true
+	}
+	return 0, false
+}
+
+// ---------------------------------------------------------------
+// 3. Manager: yükleme, güvenli yeniden yükleme ve abonelik
+// ---------------------------------------------------------------
+
+// Change, başarılı bir yeniden yüklemeden sonra abonelere gönderilir.
+type Change[T any] struct {
+	Old, New *T
+	Fields   []string // değişen alanların adları (ör: "AppPort")
+}
+
+// Has, verilen alanın değişip değişmediğini söyler.
+func (c Change[T]) Has(field string) bool { return slices.Contains(c.Fields, field) }
+
+type Manager[T any] struct {
+	path    string
+	current atomic.Pointer[T]
+
+	mu   sync.Mutex // Reload ve abonelik listesi için
+	subs []chan Change[T]
+}
+
+// Load, dosyayı ilk kez yükler. İlk yükleme başarısızsa Manager oluşmaz.
+func Load[T any](path string) (*Manager[T], error) {
+	m := &Manager[T]{path: path}
+	cfg, err := m.read()
+	if err != nil {
+		return nil, err
+	}
+	m.current.Store(cfg)
+	return m, nil
+}
+
+func (m *Manager[T]) read() (*T, error) {
+	f, err := os.Open(m.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	values, err := Parse(f, FormatOf(m.path))
+	if err != nil {
+		return nil, err
+	}
+	cfg := new(T)
+	if err := Decode(values, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Get, geçerli config'i döndürür. Dönen değer değiştirilmemelidir;
+// her reload yeni bir *T üretir, bu yüzden kilitsiz okunabilir.
+func (m *Manager[T]) Get() *T { return m.current.Load() }
+
+// Reload, dosyayı yeniden okur. Okuma veya doğrulama hatasında eski config
+// yerinde kalır ve hata döner. Değişiklik yoksa abonelere bildirim gitmez.
+func (m *Manager[T]) Reload() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	next, err := m.read()
+	if err != nil {
+		return err
+	}
+	old := m.current.Load()
+	fields := diff(old, next)
+	if len(fields) == 0 {
+		return nil
+	}
+	m.current.Store(next)
+
+	change := Change[T]{Old: old, New: next, Fields: fields}
+	for _, ch := range m.subs {
+		select {
+		case ch <- change:
+		default:
+			// Abone yetişemiyorsa bekleyen eski bildirimi at, en yenisini koy.
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- change
+		}
+	}
+	return nil
+}
+
+// Subscribe, her başarılı değişiklikte Change alan bir kanal döndürür.
+func (m *Manager[T]) Subscribe() <-chan Change[T] {
+	ch := make(chan Change[T], 1)
+	m.mu.Lock()
+	m.subs = append(m.subs, ch)
+	m.mu.Unlock()
+	return ch
+}
+
+// diff, iki struct arasında farklı olan alanları (iç içe alanlar "A.B" şeklinde) listeler.
+func diff(a, b any) []string {
+	var out []string
+	diffValue("", reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem(), &out)
+	return out
+}
+
+func diffValue(prefix string, a, b reflect.Value, out *[]string) {
+	t := a.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Name
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		if f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeFor[time.Time]() {
+			diffValue(name, a.Field(i), b.Field(i), out)
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			*out = append(*out, name)
+		}
+	}
+}
+``
+/*
+---
+
+## 📄 `main.go`
+*/
+``go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"configdemo/config"
+)
+
+// AppConfig, config.txt / config.json / config.toml'dan doldurulur.
+type AppConfig struct {
+	AppName  string `config:"APP_NAME" validate:"required"`
+	AppPort  int    `config:"APP_PORT" default:"8080" validate:"min=1,max=65535"`
+	LogLevel string `config:"LOG_LEVEL" default:"INFO" validate:"oneof=DEBUG|INFO|WARN|ERROR"`
+
+	ShutdownTimeout time.Duration `config:"SHUTDOWN_TIMEOUT" default:"5s" validate:"min=0"`
+	AllowedOrigins  []string      `config:"ALLOWED_ORIGINS"`
+}
+
+// server, çalışan http.Server'ı tutar ve port değişince yenisiyle değiştirir.
+type server struct {
+	mu      sync.Mutex
+	srv     *http.Server
+	handler http.Handler
+}
+
+// listen, yeni portta dinlemeye başlar; eski sunucu varsa yeni dinleyici
+// başarıyla açıldıktan SONRA graceful olarak kapatılır. Böylece yeni port
+// açılamazsa (ör: kullanımda) eski sunucu çalışmaya devam eder.
+func (s *server) listen(port int, timeout time.Duration) error {
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		return err
+	}
+	next := &http.Server{Addr: ln.Addr().String(), Handler: s.handler}
+	go func() {
+		if err := next.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fmt.Println("Sunucu hatası:", err)
+		}
+	}()
+	fmt.Printf("🌐 Sunucu çalışıyor http://localhost:%d\n", port)
+
+	s.mu.Lock()
+	old := s.srv
+	s.srv = next
+	s.mu.Unlock()
+
+	if old != nil {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			// Shutdown yeni bağlantı kabul etmeyi bırakır, açık istekleri bitirir
+			if err := old.Shutdown(ctx); err != nil {
+				fmt.Println("Eski sunucu kapatılamadı:", err)
+				old.Close()
+			}
+			fmt.Println("🔌 Eski dinleyici kapatıldı:", old.Addr)
+		}()
+	}
+	return nil
+}
+
+func (s *server) shutdown(timeout time.Duration) {
+	s.mu.Lock()
+	srv := s.srv
+	s.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	srv.Shutdown(ctx)
+}
+
+func main() {
+	path := "config.txt"
+	if len(os.Args) > 1 {
+		path = os.Args[1] // config.json veya config.toml da olabilir
+	}
+
+	cfgs, err := config.Load[AppConfig](path)
+	if err != nil {
+		fmt.Println("Config okunamadı:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("🔹 Config yüklendi: %+v\n", *cfgs.Get())
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		cfg := cfgs.Get() // kilit yok: her reload yeni bir *AppConfig üretir
+		fmt.Fprintf(w, "App: %s\nPort: %d\nLogLevel: %s\nOrigins: %v\n",
+			cfg.AppName, cfg.AppPort, cfg.LogLevel, cfg.AllowedOrigins)
+	})
+
+	srv := &server{handler: mux}
+	if err := srv.listen(cfgs.Get().AppPort, cfgs.Get().ShutdownTimeout); err != nil {
+		fmt.Println("Dinlenemedi:", err)
+		os.Exit(1)
+	}
+
+	// Abone 1: port değişirse dinleyiciyi değiştir
+	portChanges := cfgs.Subscribe()
+	go func() {
+		for change := range portChanges {
+			if !change.Has("AppPort") {
+				continue
+			}
+			fmt.Printf("🔁 Port değişti: %d → %d\n", change.Old.AppPort, change.New.AppPort)
+			if err := srv.listen(change.New.AppPort, change.New.ShutdownTimeout); err != nil {
+				fmt.Println("❌ Yeni port açılamadı, eski dinleyici korunuyor:", err)
+			}
+		}
+	}()
+
+	// Abone 2: sadece değişiklikleri logla
+	logChanges := cfgs.Subscribe()
+	go func() {
+		for change := range logChanges {
+			fmt.Println("📝 Değişen alanlar:", change.Fields)
+		}
+	}()
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+
+	for sig := range sigChan {
+		switch sig {
+		case syscall.SIGHUP:
+			fmt.Println("🔄 SIGHUP alındı → config yeniden yükleniyor...")
+			if err := cfgs.Reload(); err != nil {
+				// Hatalı config reddedilir, eski config geçerli kalır
+				fmt.Printf("❌ Yeni config reddedildi:\n%v\n", err)
+				continue
+			}
+			fmt.Printf("✅ Geçerli config: %+v\n", *cfgs.Get())
+		case syscall.SIGINT, syscall.SIGTERM:
+			fmt.Println("🔴 Program kapanıyor (sinyal:", sig, ")")
+			srv.shutdown(cfgs.Get().ShutdownTimeout)
+			return
+		}
+	}
+}
+``
+/*
+---
+
+## 📄 `config.txt`
+*/
+``
+APP_NAME=MyApp
+APP_PORT=8080
+LOG_LEVEL=DEBUG
+SHUTDOWN_TIMEOUT=10s
+ALLOWED_ORIGINS=example.com, api.example.com
+``
+/*
+Aynı ayarlar JSON olarak (`config.json`):
+*/
+``json
+{
+  "APP_NAME": "MyApp",
+  "APP_PORT": 8080,
+  "LOG_LEVEL": "DEBUG",
+  "SHUTDOWN_TIMEOUT": "10s",
+  "ALLOWED_ORIGINS": ["example.com", "api.example.com"]
+}
+``
+/*
+veya TOML benzeri (`config.toml`). `[server]` gibi bir bölüm kullanırsan, struct’ta `config:"server"` etiketli iç içe bir struct alanı tanımla. Bu durumda içindeki `config:"port"` alanının anahtarı `server.port` olur.
+*/
+``toml
+APP_NAME = "MyApp"   # yorum
+APP_PORT = 8080
+LOG_LEVEL = "DEBUG"
+ALLOWED_ORIGINS = ["example.com", "api.example.com"]
+``
+/*
+---
+
+## 📝 Nasıl Çalışır?
+
+* **Parse → Decode ayrımı:** Her format önce noktalı anahtarlı düz bir `map[string]any`’ye çevrilir. Değerler `string`’dir; TOML/JSON dizileri ise `[]string` olarak taşınır, yani `["a,b", "c"]` yeniden birleştirilip bölünmez ve iki eleman olarak kalır. Struct doldurma ve doğrulama formattan bağımsızdır. Yeni bir format eklemek = sadece yeni bir `parseXxx` fonksiyonu.
+* **Reflect ile doldurma:** `decodeStruct` alanları dolaşır ve her alanın tipine göre (`string`, `bool`, `int*`, `uint*`, `float*`, `time.Duration`, RFC 3339 biçiminde `time.Time`, `[]string`) dönüştürme yapar. `.env` dosyasında dizi sözdizimi olmadığı için oradaki `[]string` değerleri virgülden bölünür.
+* **Hataların hepsi birden:** `errors.Join` sayesinde tek bir reload denemesinde **tüm** hatalı alanlar raporlanır. Her hata `*FieldError` olduğundan `errors.As` ile alan adına ulaşılabilir.
+* **Kilitsiz okuma:** `Manager.Get()` bir `atomic.Pointer[T]` okur. Her reload **yeni** bir `*T` oluşturduğu için handler’lar `RWMutex` olmadan okuyabilir. Global `mu` değişkenine gerek kalmaz.
+* **Reddetme:** `Reload` önce yeni config’i okuyup doğrular. Ancak **başarılıysa** `current.Store` yapılır.
+* **Bildirim:** Her abone 1 elemanlık tamponlu bir kanal alır. Abone yavaşsa eski bildirim atılır ve **en son** değişiklik bırakılır. `Reload` asla bir aboneyi beklerken kilitlenmez.
+* **Listener swap:**
+  1. Yeni port için `net.Listen` **önce** açılır.
+  2. Açılamazsa (port kullanımda, yetki yok…) hata loglanır ve eski sunucu çalışmaya devam eder.
+  3. Açılırsa yeni `http.Server` başlar, eskisi `Shutdown(ctx)` ile kapatılır. Böylece açık istekler tamamlanır, bağlantı kopmaz.
+
+---
+
+## 🖥 Kullanım
+
+``bash
+go run . config.txt
+``
+
+``
+🔹 Config yüklendi: {AppName:MyApp AppPort:8080 LogLevel:DEBUG ShutdownTimeout:10s AllowedOrigins:[example.com api.example.com]}
+🌐 Sunucu çalışıyor http://localhost:8080
+``
+
+Hatalı bir config yazıp `kill -HUP <pid>` gönder (`APP_NAME=`, `APP_PORT=99999`, `LOG_LEVEL=TRACE`):
+
+``
+🔄 SIGHUP alındı → config yeniden yükleniyor...
+❌ Yeni config reddedildi:
+APP_NAME: zorunlu alan eksik
+APP_PORT: 99999, en fazla 65535 olmalı
+LOG_LEVEL: "TRACE" geçersiz, izin verilenler: DEBUG, INFO, WARN, ERROR
+``
+
+Sunucu eski config ile (`:8080`) çalışmaya devam eder. Şimdi geçerli bir değişiklik yap (`APP_PORT=8081`, `LOG_LEVEL=INFO`) ve tekrar `kill -HUP <pid>`:
+
+``
+🔄 SIGHUP alındı → config yeniden yükleniyor...
+✅ Geçerli config: {AppName:MyApp AppPort:8081 LogLevel:INFO ShutdownTimeout:10s AllowedOrigins:[example.com api.example.com]}
+📝 Değişen alanlar: [AppPort LogLevel]
+🔁 Port değişti: 8080 → 8081
+🌐 Sunucu çalışıyor http://localhost:8081
+🔌 Eski dinleyici kapatıldı: [::]:8080
+``
+
+---
+
+### 🧪 Testler (`config/config_test.go`)
+
+Testler ayrıştırmayı (dizi elemanlarındaki virgül ve `#` dahil), tüm tiplerin dönüştürülmesini, doğrulama hatalarını, hatalı reload’un reddedilmesini ve abonelere giden bildirimleri kapsar. `Manager` testleri geçici bir dizine yazılan dosyayla çalışır.
+*/
+``go
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		format, src string
+		want        map[string]any
+	}{
+		{"env", `
+# yorum
+NAME = "My App"
+ORIGINS=a.com, b.com
+`, map[string]any{"NAME": "My App", "ORIGINS": "a.com, b.com"}},
+		{"toml", `
+NAME = "My App"   # yorum
+TAGS = ["a,b", 'c', "d # değil yorum"]
+EMPTY = []
+[server]
+port = 8080
+`, map[string]any{
+			"NAME":        "My App",
+			"TAGS":        []string{"a,b", "c", "d # değil yorum"},
+			"EMPTY":       []string(nil),
+			"server.port": "8080",
+		}},
+		{"json", `{"NAME": "My App", "TAGS": ["a,b", 3], "server": {"port": 8080, "tls": null}}`,
+			map[string]any{
+				"NAME":        "My App",
+				"TAGS":        []string{"a,b", "3"},
+				"server.port": "8080",
+				"server.tls":  "",
+			}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(strings.NewReader(tt.src), tt.format)
+		if err != nil {
+			t.Errorf("%s: %v", tt.format, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n got  %#v\n want %#v", tt.format, got, tt.want)
+		}
+	}
+
+	for format, src := range map[string]string{
+		"env":  "NAME",
+		"toml": "[server\nport = 1",
+		"json": "{",
+		"yaml": "a: b",
+	} {
+		if _, err := Parse(strings.NewReader(src), format); err == nil {
+			t.Errorf("%s: %q için hata bekleniyordu", format, src)
+		}
+	}
+}
+
+type testConfig struct {
+	Name    string        `config:"NAME" validate:"required"`
+	Port    int           `config:"PORT" default:"8080" validate:"min=1,max=65535"`
+	Level   string        `config:"LEVEL" default:"INFO" validate:"oneof=DEBUG|INFO"`
+	Timeout time.Duration `config:"TIMEOUT" default:"5s"`
+	Tags    []string      `config:"TAGS"`
+	Since   time.Time     `config:"SINCE"`
+	Server  struct {
+		Host string `config:"host" default:"localhost"`
+	} `config:"server"`
+}
+
+func TestDecode(t *testing.T) {
+	values := map[string]any{
+		"NAME":        "app",
+		"TAGS":        []string{"a,b", "c"},
+		"SINCE":       "2025-03-14T09:26:53+03:00",
+		"server.host": "0.0.0.0",
+	}
+	var cfg testConfig
+	if err := Decode(values, &cfg); err != nil {
+		t.Fatal(err)
+	}
+	since := time.Date(2025, 3, 14, 6, 26, 53, 0, time.UTC)
+	if cfg.Name != "app" || cfg.Port != 8080 || cfg.Level != "INFO" || cfg.Timeout != 5*time.Second ||
+		!reflect.DeepEqual(cfg.Tags, []string{"a,b", "c"}) || !cfg.Since.Equal(since) || cfg.Server.Host != "0.0.0.0" {
+		t.Errorf("Decode = %+v", cfg)
+	}
+
+	// env dosyasında dizi sözdizimi yok: string virgülden bölünür
+	cfg = testConfig{}
+	if err := Decode(map[string]any{"NAME": "app", "TAGS": "a, b"}, &cfg); err != nil || !reflect.DeepEqual(cfg.Tags, []string{"a", "b"}) {
+		t.Errorf("env dizisi: %v %q", err, cfg.Tags)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	values := map[string]any{
+		"PORT":  "99999",
+		"LEVEL": "TRACE",
+		"SINCE": "dün",
+		"NAME":  []string{"a"},
+	}
+	err := Decode(values, &testConfig{})
+	if err == nil {
+		t.Fatal("hata bekleniyordu")
+	}
+	// Hataların hepsi birden raporlanır, her biri *FieldError
+	var keys []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("%v bir FieldError değil", e)
+		}
+		keys = append(keys, fe.Key)
+	}
+	if got := strings.Join(keys, " "); got != "NAME PORT LEVEL SINCE" {
+		t.Errorf("hatalı alanlar = %s\n%v", got, err)
+	}
+	if err := Decode(map[string]any{}, testConfig{}); err == nil {
+		t.Error("pointer olmayan dst kabul edildi")
+	}
+}
+
+type reloadConfig struct {
+	Name string `config:"NAME" validate:"required"`
+	Port int    `config:"PORT" validate:"min=1"`
+}
+
+func writeConfig(t *testing.T, path, src string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.toml")
+	writeConfig(t, path, "NAME = \"a\"\nPORT = 1")
+	m, err := Load[reloadConfig](path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := m.Get()
+	sub1, sub2 := m.Subscribe(), m.Subscribe()
+
+	// Geçersiz config reddedilir, eski değer yerinde kalır, bildirim gitmez
+	writeConfig(t, path, "NAME = \"\"\nPORT = 0")
+	if err := m.Reload(); err == nil {
+		t.Fatal("geçersiz config kabul edildi")
+	}
+	if m.Get() != first {
+		t.Error("reddedilen reload config'i değiştirdi")
+	}
+
+	// Değişiklik yoksa bildirim yok
+	writeConfig(t, path, "NAME = \"a\"   # yorum\nPORT = 1")
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case c := <-sub1:
+		t.Fatalf("değişiklik yokken bildirim: %v", c.Fields)
+	default:
+	}
+
+	// İki değişiklik: abone okumasa da en yenisini alır
+	writeConfig(t, path, "NAME = \"b\"\nPORT = 1")
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig(t, path, "NAME = \"b\"\nPORT = 2")
+	if err := m.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	for i, sub := range []<-chan Change[reloadConfig]{sub1, sub2} {
+		c := <-sub
+		if c.New != m.Get() || c.New.Port != 2 || !c.Has("Port") || c.Has("Name") {
+			t.Errorf("abone %d: %+v → %+v %v", i+1, c.Old, c.New, c.Fields)
+		}
+		select {
+		case c := <-sub:
+			t.Errorf("abone %d: fazladan bildirim %v", i+1, c.Fields)
+		default:
+		}
+	}
+
+	if _, err := Load[reloadConfig](filepath.Join(t.TempDir(), "yok.toml")); err == nil {
+		t.Error("olmayan dosya yüklendi")
+	}
+}
+``
+/*
+Çıktı:
+
+``bash
+$ go test -race -v ./config
+=== RUN   TestParse
+--- PASS: TestParse (0.00s)
+=== RUN   TestDecode
+--- PASS: TestDecode (0.00s)
+=== RUN   TestDecodeErrors
+--- PASS: TestDecodeErrors (0.00s)
+=== RUN   TestReload
+--- PASS: TestReload (0.00s)
+PASS
+ok  	configdemo/config	1.019s
+``
+
+---
+
+✅ Artık config; tipli, doğrulanmış ve formattan bağımsız. Hatalı bir değişiklik canlı sistemi bozamıyor, port değişikliği de kesinti olmadan uygulanıyor.
+*/